@@ -21,10 +21,12 @@ type AuditEvent struct {
 
 	// User identification
 	UserID    string `json:"userId"`
+	TenantID  string `json:"tenantId"`
 	UserAgent string `json:"userAgent"`
 
 	// Request information
 	RequestID     string         `json:"requestId"`
+	TraceID       string         `json:"traceId"`
 	RequestIP     string         `json:"requestIp"`
 	RequestParams map[string]any `json:"requestParams"`
 	RequestMeta   map[string]any `json:"requestMeta"`
@@ -47,10 +49,12 @@ type AuditEventParams struct {
 
 	// User identification
 	UserID    string
+	TenantID  string
 	UserAgent string
 
 	// Request information
 	RequestID     string
+	TraceID       string
 	RequestIP     string
 	RequestParams map[string]any
 	RequestMeta   map[string]any
@@ -65,15 +69,18 @@ type AuditEventParams struct {
 }
 
 // NewAuditEvent creates a new audit event with the given parameters.
-func NewAuditEvent(params AuditEventParams) *AuditEvent {
+// Options are applied to the base event, e.g. event.WithTraceContext to continue the trace of the request.
+func NewAuditEvent(params AuditEventParams, opts ...event.BaseEventOption) *AuditEvent {
 	return &AuditEvent{
-		BaseEvent:     event.NewBaseEvent(eventTypeAudit),
+		BaseEvent:     event.NewBaseEvent(eventTypeAudit, opts...),
 		Resource:      params.Resource,
 		Action:        params.Action,
 		Version:       params.Version,
 		UserID:        params.UserID,
+		TenantID:      params.TenantID,
 		UserAgent:     params.UserAgent,
 		RequestID:     params.RequestID,
+		TraceID:       params.TraceID,
 		RequestIP:     params.RequestIP,
 		RequestParams: params.RequestParams,
 		RequestMeta:   params.RequestMeta,
@@ -31,12 +31,13 @@ const (
 	AuthStrategyNone      = "none"
 	AuthStrategyBearer    = "bearer"
 	AuthStrategySignature = "signature"
+	AuthStrategyAPIKey    = "apikey"
 )
 
 // AuthConfig defines authentication configuration for an operation.
 type AuthConfig struct {
 	// Strategy specifies the auth strategy name. default is "bearer".
-	// Built-in: "none", "bearer", "signature"
+	// Built-in: "none", "bearer", "signature", "apikey"
 	// Custom strategies can be registered via AuthStrategyRegistry.
 	Strategy string
 	// Options holds strategy-specific configuration.
@@ -80,3 +81,10 @@ func SignatureAuth() *AuthConfig {
 		Strategy: AuthStrategySignature,
 	}
 }
+
+// APIKeyAuth creates an AuthConfig for API key authentication.
+func APIKeyAuth() *AuthConfig {
+	return &AuthConfig{
+		Strategy: AuthStrategyAPIKey,
+	}
+}
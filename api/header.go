@@ -6,5 +6,13 @@ const (
 	HeaderXTimestamp  = "X-Timestamp"
 	HeaderXNonce      = "X-Nonce"
 	HeaderXSignature  = "X-Signature"
+	HeaderXAPIKey     = "X-API-Key"
 	HeaderXMetaPrefix = "X-Meta-"
+
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
 )
+
+// MetaKeyIdempotencyKey is the request meta field that carries the idempotency key
+// when the Idempotency-Key header cannot be set.
+const MetaKeyIdempotencyKey = "idempotencyKey"
@@ -1,7 +1,11 @@
 //nolint:revive // package name is intentional
 package api
 
-import "time"
+import (
+	"time"
+
+	"github.com/coldsmirk/vef-framework-go/ratelimit"
+)
 
 // OperationSpec defines the specification for an API endpoint.
 type OperationSpec struct {
@@ -15,8 +19,13 @@ type OperationSpec struct {
 	Public bool
 	// PermToken is the permission token required for access
 	PermToken string
+	// AllowScoped lets scoped principals such as API keys call this endpoint without a PermToken,
+	// which they are otherwise denied as their scopes cannot grant it
+	AllowScoped bool
 	// RateLimit represents the rate limit for an API endpoint
 	RateLimit *RateLimitConfig
+	// Idempotency enables Idempotency-Key handling for this endpoint
+	Idempotency *IdempotencyConfig
 	// Handler is the business logic handler.
 	Handler any
 }
@@ -35,6 +44,8 @@ type Operation struct {
 	Auth *AuthConfig
 	// RateLimit is the final rate limit configuration.
 	RateLimit *RateLimitConfig
+	// Idempotency is the idempotency configuration, nil means disabled.
+	Idempotency *IdempotencyConfig
 	// Handler is the resolved handler (before adaptation).
 	Handler any
 	// Dynamic indicates whether this operation is registered dynamically.
@@ -49,6 +60,11 @@ func (o *Operation) HasRateLimit() bool {
 	return o.RateLimit != nil && o.RateLimit.Max > 0
 }
 
+// IsIdempotent returns true if Idempotency-Key handling is enabled.
+func (o *Operation) IsIdempotent() bool {
+	return o.Idempotency != nil
+}
+
 // RequiresAuth returns true if authentication is required.
 func (o *Operation) RequiresAuth() bool {
 	return o.Auth.Strategy != AuthStrategyNone
@@ -63,6 +79,18 @@ type RateLimitConfig struct {
 	// Key is a custom rate limit key template.
 	// Empty means using the default key.
 	Key string
+	// Algorithm selects the rate limiting algorithm.
+	// Empty means ratelimit.SlidingWindow.
+	Algorithm ratelimit.Algorithm
+}
+
+// IdempotencyConfig defines Idempotency-Key handling configuration.
+// Requests carrying the same key from the same principal to the same operation
+// receive the response of the first request instead of executing the handler again.
+type IdempotencyConfig struct {
+	// TTL is how long a completed response is kept for replay.
+	// Zero means 24 hours.
+	TTL time.Duration
 }
 
 // OperationsProvider provides operation specs.
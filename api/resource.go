@@ -43,6 +43,8 @@ type Engine interface {
 	Register(resources ...Resource) error
 	// Lookup finds an operation by identifier.
 	Lookup(id Identifier) *Operation
+	// Operations returns all registered operations ordered by identifier.
+	Operations() []*Operation
 	// Mount attaches the engine to a Fiber router.
 	Mount(router fiber.Router) error
 }
@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"encoding/json"
+
+	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// Kind identifies the event an audit log was recorded from.
+type Kind string
+
+const (
+	// KindAPI marks a log recorded from an api.AuditEvent.
+	KindAPI Kind = "api"
+	// KindLogin marks a log recorded from a security.LoginEvent.
+	KindLogin Kind = "login"
+)
+
+// Resource and action of the logs recorded from login events, so that they can be searched like API requests.
+const (
+	LoginResource = "security/auth"
+	LoginAction   = "login"
+)
+
+// Payload is a JSON document stored with an audit log, e.g. the parameters of a request.
+type Payload map[string]any
+
+// String returns the JSON encoding of the payload, which is how it is exported.
+func (p Payload) String() string {
+	if p == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// Log is a persisted audit record of an API request or a login attempt.
+// Secrets in the params, meta and result data are redacted before the record is written.
+// Logs carry the tenant of the request they record, so tenant-scoped queries only see their own tenant's logs.
+type Log struct {
+	orm.BaseModel `bun:"table:sys_audit_log,alias:sal"`
+	orm.Model
+	orm.TenantModel
+
+	CreatedAt     timex.DateTime `json:"createdAt" tabular:"Time,width=20" bun:",notnull,type:timestamp,default:CURRENT_TIMESTAMP"`
+	Kind          Kind           `json:"kind" tabular:"Kind,width=8" bun:"kind"`
+	Resource      string         `json:"resource" tabular:"Resource,width=24" bun:"resource"`
+	Action        string         `json:"action" tabular:"Action,width=16" bun:"action"`
+	Version       string         `json:"version" tabular:"Version,width=8" bun:"version"`
+	UserID        string         `json:"userId" tabular:"User ID,width=20" bun:"user_id"`
+	Username      *string        `json:"username" tabular:"Username,width=16" bun:"username,nullzero"`
+	RequestIP     string         `json:"requestIp" tabular:"IP,width=16" bun:"request_ip"`
+	UserAgent     string         `json:"userAgent" tabular:"User Agent,width=30" bun:"user_agent"`
+	RequestID     string         `json:"requestId" tabular:"Request ID,width=24" bun:"request_id"`
+	TraceID       string         `json:"traceId" tabular:"Trace ID,width=32" bun:"trace_id"`
+	RequestParams Payload        `json:"requestParams" tabular:"Params,width=40" bun:"request_params,type:jsonb"`
+	RequestMeta   Payload        `json:"requestMeta" tabular:"Meta,width=30" bun:"request_meta,type:jsonb"`
+	ResultCode    int            `json:"resultCode" tabular:"Result Code,width=12" bun:"result_code"`
+	ResultMessage string         `json:"resultMessage" tabular:"Result Message,width=30" bun:"result_message"`
+	ResultData    any            `json:"resultData" tabular:"-" bun:"result_data,type:jsonb"`
+	ElapsedTime   int64          `json:"elapsedTime" tabular:"Elapsed (ms),width=12" bun:"elapsed_time"` // Elapsed time in milliseconds
+}
@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayload(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		payload := Payload{"name": "alice", "roles": []string{"admin"}}
+
+		assert.JSONEq(t, `{"name":"alice","roles":["admin"]}`, payload.String(), "Payload should be exported as JSON")
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		var payload Payload
+
+		assert.Empty(t, payload.String(), "Nil payload should be exported as an empty cell")
+	})
+}
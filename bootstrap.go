@@ -8,6 +8,7 @@ import (
 
 	"github.com/coldsmirk/vef-framework-go/internal/api"
 	"github.com/coldsmirk/vef-framework-go/internal/app"
+	"github.com/coldsmirk/vef-framework-go/internal/audit"
 	"github.com/coldsmirk/vef-framework-go/internal/config"
 	"github.com/coldsmirk/vef-framework-go/internal/cqrs"
 	"github.com/coldsmirk/vef-framework-go/internal/cron"
@@ -15,9 +16,12 @@ import (
 	"github.com/coldsmirk/vef-framework-go/internal/event"
 	ilogx "github.com/coldsmirk/vef-framework-go/internal/logx"
 	"github.com/coldsmirk/vef-framework-go/internal/mcp"
+	"github.com/coldsmirk/vef-framework-go/internal/metrics"
 	"github.com/coldsmirk/vef-framework-go/internal/middleware"
+	"github.com/coldsmirk/vef-framework-go/internal/migration"
 	"github.com/coldsmirk/vef-framework-go/internal/mold"
 	"github.com/coldsmirk/vef-framework-go/internal/monitor"
+	"github.com/coldsmirk/vef-framework-go/internal/openapi"
 	"github.com/coldsmirk/vef-framework-go/internal/orm"
 	"github.com/coldsmirk/vef-framework-go/internal/redis"
 	"github.com/coldsmirk/vef-framework-go/internal/schema"
@@ -45,6 +49,7 @@ func Run(options ...fx.Option) {
 		config.Module,
 		database.Module,
 		orm.Module,
+		migration.Module,
 		middleware.Module,
 		api.Module,
 		security.Module,
@@ -58,6 +63,9 @@ func Run(options ...fx.Option) {
 		schema.Module,
 		monitor.Module,
 		mcp.Module,
+		openapi.Module,
+		metrics.Module,
+		audit.Module,
 		app.Module,
 	}
 
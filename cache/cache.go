@@ -34,6 +34,15 @@ type Cache[T any] interface {
 	Size(ctx context.Context) (int64, error)
 }
 
+// AtomicSetter is implemented by caches that can store a value unless its key exists in a single atomic step,
+// e.g. to claim a key across instances. The memory and Redis caches implement it, and so do the wrappers of
+// this package over a cache that does; other Cache implementations may not, so callers check for it with a
+// type assertion.
+type AtomicSetter[T any] interface {
+	// SetIfAbsent atomically stores a value unless the key exists, reporting whether it was stored.
+	SetIfAbsent(ctx context.Context, key string, value T, ttl ...time.Duration) (bool, error)
+}
+
 // Store defines the interface for the underlying cache storage implementation.
 // This interface works with raw bytes and can be implemented by different backends
 // like Badger, Redis, etc. It's designed to be injected as a singleton via fx.
@@ -77,6 +86,7 @@ func NewMemory[T any](opts ...MemoryOption) Cache[T] {
 
 // NewRedis constructs a Redis-backed cache with the given namespace.
 // The namespace must be non-empty and is used to isolate keys.
+// Its operations are recorded as spans of traced requests and jobs.
 func NewRedis[T any](client *redis.Client, namespace string, opts ...RedisOption) Cache[T] {
 	if client == nil {
 		panic("redis cache requires a non-nil redis client")
@@ -93,5 +103,5 @@ func NewRedis[T any](client *redis.Client, namespace string, opts ...RedisOption
 
 	prefix := defaultKeyBuilder.Build(cacheKeyPrefix, namespace)
 
-	return newRedisCache[T](client, NewPrefixKeyBuilder(prefix), cfg)
+	return NewInstrumented(newRedisCache[T](client, NewPrefixKeyBuilder(prefix), cfg), "redis", namespace)
 }
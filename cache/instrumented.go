@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/coldsmirk/vef-framework-go/metrics"
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+var lookups = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "cache",
+		Name:      "lookups_total",
+		Help:      "Number of cache lookups by namespace and result, hit or miss.",
+	},
+	"backend", "namespace", "result",
+)
+
+// instrumentedCache records every operation of a traced request or job as a span and counts the hits and misses.
+type instrumentedCache[T any] struct {
+	cache      Cache[T]
+	attributes []attribute.KeyValue
+	hits       prometheus.Counter
+	misses     prometheus.Counter
+}
+
+// NewInstrumented wraps c so that its operations are recorded as child spans of the traced request or job,
+// and its hits and misses are counted in the vef_cache_lookups_total metric under the namespace.
+// Redis caches are instrumented already; in-memory caches are not by default, their operations take
+// less time than recording a span.
+// The result implements AtomicSetter when c does.
+func NewInstrumented[T any](c Cache[T], backend, namespace string) Cache[T] {
+	instrumented := &instrumentedCache[T]{
+		cache: c,
+		attributes: []attribute.KeyValue{
+			attribute.String(tracing.AttrCacheBackend, backend),
+			attribute.String(tracing.AttrCacheNamespace, namespace),
+		},
+		hits:   lookups.WithLabelValues(backend, namespace, "hit"),
+		misses: lookups.WithLabelValues(backend, namespace, "miss"),
+	}
+
+	if setter, ok := c.(AtomicSetter[T]); ok {
+		return &atomicInstrumentedCache[T]{instrumentedCache: instrumented, setter: setter}
+	}
+
+	return instrumented
+}
+
+// atomicInstrumentedCache is an instrumentedCache over a cache that implements AtomicSetter.
+type atomicInstrumentedCache[T any] struct {
+	*instrumentedCache[T]
+
+	setter AtomicSetter[T]
+}
+
+func (c *atomicInstrumentedCache[T]) SetIfAbsent(ctx context.Context, key string, value T, ttl ...time.Duration) (bool, error) {
+	ctx, span := c.start(ctx, "set_if_absent")
+	stored, err := c.setter.SetIfAbsent(ctx, key, value, ttl...)
+	tracing.End(span, err)
+
+	return stored, err
+}
+
+func (c *instrumentedCache[T]) start(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return tracing.StartChild(
+		ctx,
+		"cache "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(c.attributes...),
+	)
+}
+
+// record counts the lookup and annotates its span with the outcome.
+func (c *instrumentedCache[T]) record(span trace.Span, hit bool) {
+	if hit {
+		c.hits.Inc()
+	} else {
+		c.misses.Inc()
+	}
+
+	span.SetAttributes(attribute.Bool(tracing.AttrCacheHit, hit))
+}
+
+func (c *instrumentedCache[T]) Get(ctx context.Context, key string) (T, bool) {
+	ctx, span := c.start(ctx, "get")
+	defer span.End()
+
+	value, found := c.cache.Get(ctx, key)
+	c.record(span, found)
+
+	return value, found
+}
+
+func (c *instrumentedCache[T]) GetOrLoad(ctx context.Context, key string, loader LoaderFunc[T], ttl ...time.Duration) (T, error) {
+	ctx, span := c.start(ctx, "get_or_load")
+
+	loaded := false
+	value, err := c.cache.GetOrLoad(ctx, key, func(ctx context.Context) (T, error) {
+		loaded = true
+
+		return loader(ctx)
+	}, ttl...)
+
+	c.record(span, !loaded)
+	tracing.End(span, err)
+
+	return value, err
+}
+
+func (c *instrumentedCache[T]) Set(ctx context.Context, key string, value T, ttl ...time.Duration) error {
+	ctx, span := c.start(ctx, "set")
+	err := c.cache.Set(ctx, key, value, ttl...)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (c *instrumentedCache[T]) Contains(ctx context.Context, key string) bool {
+	ctx, span := c.start(ctx, "contains")
+	defer span.End()
+
+	found := c.cache.Contains(ctx, key)
+	c.record(span, found)
+
+	return found
+}
+
+func (c *instrumentedCache[T]) Delete(ctx context.Context, key string) error {
+	ctx, span := c.start(ctx, "delete")
+	err := c.cache.Delete(ctx, key)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (c *instrumentedCache[T]) Clear(ctx context.Context) error {
+	ctx, span := c.start(ctx, "clear")
+	err := c.cache.Clear(ctx)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (c *instrumentedCache[T]) Keys(ctx context.Context, prefix ...string) ([]string, error) {
+	ctx, span := c.start(ctx, "keys")
+	keys, err := c.cache.Keys(ctx, prefix...)
+	tracing.End(span, err)
+
+	return keys, err
+}
+
+func (c *instrumentedCache[T]) ForEach(ctx context.Context, callback func(key string, value T) bool, prefix ...string) error {
+	ctx, span := c.start(ctx, "for_each")
+	err := c.cache.ForEach(ctx, callback, prefix...)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (c *instrumentedCache[T]) Size(ctx context.Context) (int64, error) {
+	ctx, span := c.start(ctx, "size")
+	size, err := c.cache.Size(ctx)
+	tracing.End(span, err)
+
+	return size, err
+}
+
+func (c *instrumentedCache[T]) Close() error {
+	return c.cache.Close()
+}
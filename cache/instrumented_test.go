@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+// TestInstrumentedCache tests that cache operations within a traced context are recorded as child spans
+// and that lookups are counted by result.
+func TestInstrumentedCache(t *testing.T) {
+	recorder := testx.RecordSpans(t)
+
+	cache := NewInstrumented(NewMemory[string](), "memory", "users")
+	defer cache.Close()
+
+	t.Run("Untraced", func(t *testing.T) {
+		recorder.Reset()
+
+		require.NoError(t, cache.Set(context.Background(), "a", "alice"), "Should set the value")
+		assert.Empty(t, recorder.Ended(), "Should not start a trace of its own")
+	})
+
+	t.Run("Traced", func(t *testing.T) {
+		recorder.Reset()
+
+		ctx, span := tracing.Tracer().Start(context.Background(), "request")
+		_, found := cache.Get(ctx, "a")
+		_, missing := cache.Get(ctx, "b")
+		value, err := cache.GetOrLoad(ctx, "c", func(context.Context) (string, error) {
+			return "carol", nil
+		})
+		span.End()
+
+		require.True(t, found, "Should find the stored value")
+		require.False(t, missing, "Should miss the unknown key")
+		require.NoError(t, err, "Should load the value")
+		require.Equal(t, "carol", value, "Should return the loaded value")
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 4, "Should record a span per operation")
+
+		hits := make([]bool, 0, 3)
+		for _, span := range spans[:3] {
+			attributes := attribute.NewSet(span.Attributes()...)
+			namespace, _ := attributes.Value(tracing.AttrCacheNamespace)
+			hit, _ := attributes.Value(tracing.AttrCacheHit)
+
+			assert.Equal(t, "users", namespace.AsString(), "Should record the namespace")
+			hits = append(hits, hit.AsBool())
+		}
+
+		assert.Equal(t, []string{"cache get", "cache get", "cache get_or_load"}, []string{spans[0].Name(), spans[1].Name(), spans[2].Name()}, "Should name the spans after the operation")
+		assert.Equal(t, []bool{true, false, false}, hits, "Should record hits and misses")
+	})
+
+	t.Run("Lookups", func(t *testing.T) {
+		hits := testx.MetricValue(t, lookups.WithLabelValues("memory", "orders", "hit"))
+		misses := testx.MetricValue(t, lookups.WithLabelValues("memory", "orders", "miss"))
+
+		orders := NewInstrumented(NewMemory[int](), "memory", "orders")
+		defer orders.Close()
+
+		require.NoError(t, orders.Set(context.Background(), "a", 1), "Should set the value")
+		orders.Get(context.Background(), "a")
+		orders.Get(context.Background(), "b")
+		orders.Contains(context.Background(), "c")
+
+		assert.Equal(t, hits+1, testx.MetricValue(t, lookups.WithLabelValues("memory", "orders", "hit")), "Should count the hit")
+		assert.Equal(t, misses+2, testx.MetricValue(t, lookups.WithLabelValues("memory", "orders", "miss")), "Should count the misses")
+	})
+}
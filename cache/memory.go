@@ -149,6 +149,31 @@ func (m *memoryCache[T]) Set(_ context.Context, key string, value T, ttl ...time
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.set(key, value, ttl)
+}
+
+// SetIfAbsent stores a value unless the key exists and has not expired.
+func (m *memoryCache[T]) SetIfAbsent(_ context.Context, key string, value T, ttl ...time.Duration) (bool, error) {
+	if m.closed.Load() {
+		return false, ErrCacheClosed
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if entry, exists := m.data.Load(key); exists && !m.checkExpired(key, entry) {
+		return false, nil
+	}
+
+	if err := m.set(key, value, ttl); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// set stores a value, evicting entries when the cache is full. The caller must hold m.mu.
+func (m *memoryCache[T]) set(key string, value T, ttl []time.Duration) error {
 	// Check if key already exists (update case)
 	_, exists := m.data.Load(key)
 
@@ -149,6 +149,58 @@ func TestMemoryCacheBasicOperations(t *testing.T) {
 		assert.Equal(t, int32(1), loaderCalls.Load(), "Should equal expected value")
 	})
 
+	t.Run("SetIfAbsent", func(t *testing.T) {
+		cache := newTestCache[string](0, 0, EvictionPolicyLRU, 5*time.Minute)
+		defer cache.Close()
+
+		stored, err := cache.(AtomicSetter[string]).SetIfAbsent(ctx, "key1", "value1")
+		require.NoError(t, err, "Should not return error")
+		assert.True(t, stored, "Should store an absent key")
+
+		stored, err = cache.(AtomicSetter[string]).SetIfAbsent(ctx, "key1", "value2")
+		require.NoError(t, err, "Should not return error")
+		assert.False(t, stored, "Should not overwrite an existing key")
+
+		value, _ := cache.Get(ctx, "key1")
+		assert.Equal(t, "value1", value, "Should keep the first value")
+	})
+
+	t.Run("SetIfAbsentReplacesExpiredKey", func(t *testing.T) {
+		cache := newTestCache[string](0, 0, EvictionPolicyLRU, 5*time.Minute)
+		defer cache.Close()
+
+		_ = cache.Set(ctx, "key1", "value1", 10*time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+
+		stored, err := cache.(AtomicSetter[string]).SetIfAbsent(ctx, "key1", "value2")
+		require.NoError(t, err, "Should not return error")
+		assert.True(t, stored, "Should store over an expired key")
+
+		size, _ := cache.Size(ctx)
+		assert.Equal(t, int64(1), size, "Should count the key once")
+	})
+
+	t.Run("SetIfAbsentConcurrent", func(t *testing.T) {
+		cache := newTestCache[int](0, 0, EvictionPolicyLRU, 5*time.Minute)
+		defer cache.Close()
+
+		var (
+			wg     sync.WaitGroup
+			stored atomic.Int32
+		)
+
+		for i := range 50 {
+			wg.Go(func() {
+				if ok, err := cache.(AtomicSetter[int]).SetIfAbsent(ctx, "key1", i); err == nil && ok {
+					stored.Add(1)
+				}
+			})
+		}
+
+		wg.Wait()
+		assert.Equal(t, int32(1), stored.Load(), "Exactly one concurrent call should store the key")
+	})
+
 	t.Run("GetOrLoadRequiresLoader", func(t *testing.T) {
 		cache := newTestCache[string](0, 0, EvictionPolicyLRU, 5*time.Minute)
 		defer cache.Close()
@@ -163,6 +163,27 @@ func (c *redisCache[T]) Set(ctx context.Context, key string, value T, ttl ...tim
 	return c.setByCacheKey(ctx, cacheKey, value, ttl...)
 }
 
+// SetIfAbsent stores a value unless the key exists.
+func (c *redisCache[T]) SetIfAbsent(ctx context.Context, key string, value T, ttl ...time.Duration) (bool, error) {
+	if c.closed.Load() {
+		return false, ErrCacheClosed
+	}
+
+	payload, err := c.serializer.Serialize(value)
+	if err != nil {
+		return false, err
+	}
+
+	cacheKey := c.keyBuilder.Build(key)
+
+	stored, err := c.client.SetNX(ctx, cacheKey, payload, c.getExpiration(ttl)).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis cache set if absent failed for key %s: %w", cacheKey, err)
+	}
+
+	return stored, nil
+}
+
 // Contains checks if a key exists in the cache.
 func (c *redisCache[T]) Contains(ctx context.Context, key string) bool {
 	if c.closed.Load() {
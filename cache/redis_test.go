@@ -131,6 +131,21 @@ func (suite *RedisCacheTestSuite) TestRedisCacheBasicOperations() {
 		suite.True(found, "Should find updated user")
 		suite.Equal(updatedUser, result, "Should return updated user")
 	})
+
+	suite.Run("SetIfAbsent", func() {
+		user := TestUser{ID: 5, Name: "Eve", Age: 28}
+
+		stored, err := userCache.(AtomicSetter[TestUser]).SetIfAbsent(suite.ctx, "user5", user)
+		suite.Require().NoError(err, "Should not return error")
+		suite.True(stored, "Should store an absent key")
+
+		stored, err = userCache.(AtomicSetter[TestUser]).SetIfAbsent(suite.ctx, "user5", TestUser{ID: 5, Name: "Mallory"})
+		suite.Require().NoError(err, "Should not return error")
+		suite.False(stored, "Should not overwrite an existing key")
+
+		result, _ := userCache.Get(suite.ctx, "user5")
+		suite.Equal(user, result, "Should keep the first value")
+	})
 }
 
 func (suite *RedisCacheTestSuite) TestRedisCacheTtl() {
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScopeFunc returns the scope a cache operation runs in, e.g. the tenant of the request.
+// An empty scope leaves keys unchanged.
+type ScopeFunc func(ctx context.Context) string
+
+// scopedCache prefixes every key with the scope taken from the operation context.
+type scopedCache[T any] struct {
+	cache Cache[T]
+	scope ScopeFunc
+}
+
+// NewScoped wraps c so that keys are isolated per scope, e.g. per tenant with
+// cache.NewScoped(c, contextx.TenantID). Keys are stored as "<length of scope>:scope:key", so that a scope
+// containing ":" cannot collide with another scope; Keys and ForEach return them without the scope,
+// and Clear and Size only cover the current scope. Operations without a scope see the whole underlying cache.
+//
+// Scoping is opt-in. Within the framework the cached role permissions loader scopes its entries by tenant,
+// CachedDepartmentHierarchyLoader does when created with security.WithCacheScope, and idempotency records
+// carry the tenant in their keys. Other caches, including the ones applications create, are shared across
+// tenants unless they are wrapped.
+//
+// The result implements AtomicSetter when c does.
+func NewScoped[T any](c Cache[T], scope ScopeFunc) Cache[T] {
+	scoped := &scopedCache[T]{
+		cache: c,
+		scope: scope,
+	}
+
+	if setter, ok := c.(AtomicSetter[T]); ok {
+		return &atomicScopedCache[T]{scopedCache: scoped, setter: setter}
+	}
+
+	return scoped
+}
+
+// atomicScopedCache is a scopedCache over a cache that implements AtomicSetter.
+type atomicScopedCache[T any] struct {
+	*scopedCache[T]
+
+	setter AtomicSetter[T]
+}
+
+func (s *atomicScopedCache[T]) SetIfAbsent(ctx context.Context, key string, value T, ttl ...time.Duration) (bool, error) {
+	return s.setter.SetIfAbsent(ctx, s.prefix(ctx)+key, value, ttl...)
+}
+
+// prefix returns the key prefix of the current scope, or an empty string when unscoped.
+func (s *scopedCache[T]) prefix(ctx context.Context) string {
+	scope := s.scope(ctx)
+	if scope == "" {
+		return ""
+	}
+
+	return Key(strconv.Itoa(len(scope)), scope, "")
+}
+
+func (s *scopedCache[T]) Get(ctx context.Context, key string) (T, bool) {
+	return s.cache.Get(ctx, s.prefix(ctx)+key)
+}
+
+func (s *scopedCache[T]) GetOrLoad(ctx context.Context, key string, loader LoaderFunc[T], ttl ...time.Duration) (T, error) {
+	return s.cache.GetOrLoad(ctx, s.prefix(ctx)+key, loader, ttl...)
+}
+
+func (s *scopedCache[T]) Set(ctx context.Context, key string, value T, ttl ...time.Duration) error {
+	return s.cache.Set(ctx, s.prefix(ctx)+key, value, ttl...)
+}
+
+func (s *scopedCache[T]) Contains(ctx context.Context, key string) bool {
+	return s.cache.Contains(ctx, s.prefix(ctx)+key)
+}
+
+func (s *scopedCache[T]) Delete(ctx context.Context, key string) error {
+	return s.cache.Delete(ctx, s.prefix(ctx)+key)
+}
+
+func (s *scopedCache[T]) Clear(ctx context.Context) error {
+	prefix := s.prefix(ctx)
+	if prefix == "" {
+		return s.cache.Clear(ctx)
+	}
+
+	keys, err := s.cache.Keys(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := s.cache.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *scopedCache[T]) Keys(ctx context.Context, prefix ...string) ([]string, error) {
+	scopePrefix := s.prefix(ctx)
+	if scopePrefix == "" {
+		return s.cache.Keys(ctx, prefix...)
+	}
+
+	keys, err := s.cache.Keys(ctx, scopePrefix+firstPrefix(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		keys[i] = strings.TrimPrefix(key, scopePrefix)
+	}
+
+	return keys, nil
+}
+
+func (s *scopedCache[T]) ForEach(ctx context.Context, callback func(key string, value T) bool, prefix ...string) error {
+	scopePrefix := s.prefix(ctx)
+	if scopePrefix == "" {
+		return s.cache.ForEach(ctx, callback, prefix...)
+	}
+
+	return s.cache.ForEach(ctx, func(key string, value T) bool {
+		return callback(strings.TrimPrefix(key, scopePrefix), value)
+	}, scopePrefix+firstPrefix(prefix))
+}
+
+func (s *scopedCache[T]) Size(ctx context.Context) (int64, error) {
+	prefix := s.prefix(ctx)
+	if prefix == "" {
+		return s.cache.Size(ctx)
+	}
+
+	keys, err := s.cache.Keys(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(keys)), nil
+}
+
+func (s *scopedCache[T]) Close() error {
+	return s.cache.Close()
+}
+
+// firstPrefix returns the optional prefix argument of Keys and ForEach.
+func firstPrefix(prefix []string) string {
+	if len(prefix) > 0 {
+		return prefix[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scopeKey struct{}
+
+func withScope(ctx context.Context, scope string) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+func scopeOf(ctx context.Context) string {
+	scope, _ := ctx.Value(scopeKey{}).(string)
+
+	return scope
+}
+
+// TestScopedCache tests key isolation between scopes.
+func TestScopedCache(t *testing.T) {
+	inner := NewMemory[string]()
+	defer inner.Close()
+
+	scoped := NewScoped(inner, scopeOf)
+	tenantA := withScope(context.Background(), "a")
+	tenantB := withScope(context.Background(), "b")
+
+	require.NoError(t, scoped.Set(tenantA, "user:1", "alice"), "Should set value in scope a")
+	require.NoError(t, scoped.Set(tenantA, "role:1", "admin"), "Should set value in scope a")
+	require.NoError(t, scoped.Set(tenantB, "user:1", "bob"), "Should set value in scope b")
+
+	t.Run("IsolatesValues", func(t *testing.T) {
+		value, ok := scoped.Get(tenantA, "user:1")
+		assert.True(t, ok, "Should find value in scope a")
+		assert.Equal(t, "alice", value, "Should return scope a value")
+
+		value, ok = scoped.Get(tenantB, "user:1")
+		assert.True(t, ok, "Should find value in scope b")
+		assert.Equal(t, "bob", value, "Should return scope b value")
+
+		assert.False(t, scoped.Contains(tenantB, "role:1"), "Should not see keys of another scope")
+		assert.True(t, inner.Contains(context.Background(), "1:a:user:1"), "Should store the key with the scope prefix")
+	})
+
+	t.Run("SeparatorInScope", func(t *testing.T) {
+		require.NoError(t, scoped.Set(withScope(context.Background(), "a:b"), "c", "x"), "Should set value in scope a:b")
+
+		assert.False(t, scoped.Contains(tenantA, "b:c"), "A scope containing the separator should not collide with another scope")
+		require.NoError(t, scoped.Delete(withScope(context.Background(), "a:b"), "c"), "Should clean up scope a:b")
+	})
+
+	t.Run("SetIfAbsentIsScoped", func(t *testing.T) {
+		stored, err := scoped.(AtomicSetter[string]).SetIfAbsent(tenantB, "role:1", "viewer")
+		require.NoError(t, err, "Should set value in scope b")
+		assert.True(t, stored, "Should not see the key of another scope")
+
+		stored, err = scoped.(AtomicSetter[string]).SetIfAbsent(tenantA, "role:1", "viewer")
+		require.NoError(t, err, "Should not fail in scope a")
+		assert.False(t, stored, "Should see the key of its own scope")
+
+		require.NoError(t, scoped.Delete(tenantB, "role:1"), "Should clean up scope b")
+	})
+
+	t.Run("AtomicSetterFollowsInnerCache", func(t *testing.T) {
+		_, ok := scoped.(AtomicSetter[string])
+		assert.True(t, ok, "Should set atomically over a cache that does")
+
+		_, ok = NewScoped[string](plainCache[string]{}, scopeOf).(AtomicSetter[string])
+		assert.False(t, ok, "Should not claim atomic sets over a cache without them")
+	})
+
+	t.Run("KeysStripScope", func(t *testing.T) {
+		keys, err := scoped.Keys(tenantA)
+		require.NoError(t, err, "Should list keys")
+		slices.Sort(keys)
+		assert.Equal(t, []string{"role:1", "user:1"}, keys, "Should return scope a keys without prefix")
+
+		keys, err = scoped.Keys(tenantA, "user:")
+		require.NoError(t, err, "Should list keys by prefix")
+		assert.Equal(t, []string{"user:1"}, keys, "Should filter within the scope")
+	})
+
+	t.Run("ForEachStripsScope", func(t *testing.T) {
+		var keys []string
+
+		require.NoError(t, scoped.ForEach(tenantB, func(key, _ string) bool {
+			keys = append(keys, key)
+
+			return true
+		}), "Should iterate scope b")
+		assert.Equal(t, []string{"user:1"}, keys, "Should iterate only scope b keys")
+	})
+
+	t.Run("SizeAndClearAreScoped", func(t *testing.T) {
+		size, err := scoped.Size(tenantA)
+		require.NoError(t, err, "Should count scope a")
+		assert.Equal(t, int64(2), size, "Should count only scope a entries")
+
+		require.NoError(t, scoped.Clear(tenantA), "Should clear scope a")
+
+		size, err = scoped.Size(tenantA)
+		require.NoError(t, err, "Should count scope a")
+		assert.Zero(t, size, "Scope a should be empty")
+		assert.True(t, scoped.Contains(tenantB, "user:1"), "Clearing scope a should keep scope b")
+	})
+
+	t.Run("UnscopedPassesThrough", func(t *testing.T) {
+		require.NoError(t, scoped.Set(context.Background(), "global", "value"), "Should set unscoped value")
+		assert.True(t, inner.Contains(context.Background(), "global"), "Should store unscoped keys unchanged")
+	})
+}
+
+// plainCache is a Cache without AtomicSetter; the tests only check which interfaces wrappers of it implement.
+type plainCache[T any] struct {
+	Cache[T]
+}
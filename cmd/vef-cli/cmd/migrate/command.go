@@ -0,0 +1,189 @@
+package migrate
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+
+	"github.com/coldsmirk/vef-framework-go/migration"
+)
+
+// Command returns the migrate cobra command with its up, down, status and create subcommands.
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage versioned database migrations",
+		Long: `Manage the versioned SQL migrations of a VEF application.
+
+Migrations live in a directory as pairs of files named by version and name:
+
+  20260101120000_create_order.up.sql
+  20260101120000_create_order.down.sql
+
+The application embeds the directory and registers it with vef.SupplyMigrations;
+setting auto_migrate applies pending migrations on startup:
+
+  [vef.migration]
+  auto_migrate = true
+
+The up, down and status subcommands connect to the data source in the application
+config file and hold the same database lock as the application, so they never race
+a starting replica. Migrations implemented in Go only run inside the application.
+
+Example usage:
+  vef-cli migrate create add_order_remark -d migrations
+  vef-cli migrate up -c configs/application.toml -d migrations
+  vef-cli migrate down -n 2
+  vef-cli migrate status
+`,
+	}
+
+	cmd.PersistentFlags().StringP("config", "c", "configs/application.toml", "Application config file")
+	cmd.PersistentFlags().StringP("dir", "d", "migrations", "Migration directory")
+
+	cmd.AddCommand(upCommand(), downCommand(), statusCommand(), createCommand())
+
+	return cmd
+}
+
+func upCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "Apply all pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			configPath, dir := pathFlags(cmd)
+			output := termenv.DefaultOutput()
+
+			printLabeledLine(output, "Applying migrations...", "", termenv.ANSICyan)
+			printLabeledLine(output, "  Directory: ", dir, termenv.ANSIBrightBlack)
+
+			return withMigrator(configPath, dir, func(migrator migration.Migrator) error {
+				applied, err := migrator.Up(cmd.Context())
+				printMigrations(output, "  Applied: ", applied)
+
+				if err != nil {
+					return fmt.Errorf("failed to apply migrations: %w", err)
+				}
+
+				_, _ = fmt.Println(output.String(fmt.Sprintf("✓ Applied %d migration(s)", len(applied))).Foreground(termenv.ANSIGreen))
+
+				return nil
+			})
+		},
+	}
+}
+
+func downCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Revert the latest applied migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			configPath, dir := pathFlags(cmd)
+			steps, _ := cmd.Flags().GetInt("steps")
+			output := termenv.DefaultOutput()
+
+			printLabeledLine(output, "Reverting migrations...", "", termenv.ANSICyan)
+			printLabeledLine(output, "  Directory: ", dir, termenv.ANSIBrightBlack)
+			printLabeledLine(output, "  Steps: ", strconv.Itoa(steps), termenv.ANSIBrightBlack)
+
+			return withMigrator(configPath, dir, func(migrator migration.Migrator) error {
+				reverted, err := migrator.Down(cmd.Context(), steps)
+				printMigrations(output, "  Reverted: ", reverted)
+
+				if err != nil {
+					return fmt.Errorf("failed to revert migrations: %w", err)
+				}
+
+				_, _ = fmt.Println(output.String(fmt.Sprintf("✓ Reverted %d migration(s)", len(reverted))).Foreground(termenv.ANSIGreen))
+
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().IntP("steps", "n", 1, "Number of migrations to revert")
+
+	return cmd
+}
+
+func statusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show applied and pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			configPath, dir := pathFlags(cmd)
+			output := termenv.DefaultOutput()
+
+			return withMigrator(configPath, dir, func(migrator migration.Migrator) error {
+				statuses, err := migrator.Status(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("failed to load migration status: %w", err)
+				}
+
+				printLabeledLine(output, "Migration status:", "", termenv.ANSICyan)
+
+				for _, status := range statuses {
+					name := fmt.Sprintf("%d_%s", status.Version, status.Name)
+					if status.Applied {
+						printLabeledLine(output, "  ✓ applied  ", name+" ("+status.AppliedAt.Format(time.DateTime)+")", termenv.ANSIGreen)
+					} else {
+						printLabeledLine(output, "  • pending  ", name, termenv.ANSIYellow)
+					}
+				}
+
+				return nil
+			})
+		},
+	}
+}
+
+func createCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an empty up/down migration pair",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, dir := pathFlags(cmd)
+			output := termenv.DefaultOutput()
+
+			upPath, downPath, err := migration.Create(dir, args[0], time.Now(), nil)
+			if err != nil {
+				return fmt.Errorf("failed to create migration: %w", err)
+			}
+
+			printLabeledLine(output, "  Up: ", upPath, termenv.ANSIBrightBlack)
+			printLabeledLine(output, "  Down: ", downPath, termenv.ANSIBrightBlack)
+			_, _ = fmt.Println(output.String("✓ Successfully created migration files").Foreground(termenv.ANSIGreen))
+
+			return nil
+		},
+	}
+}
+
+func pathFlags(cmd *cobra.Command) (configPath, dir string) {
+	configPath, _ = cmd.Flags().GetString("config")
+	dir, _ = cmd.Flags().GetString("dir")
+
+	return configPath, dir
+}
+
+func printMigrations(output *termenv.Output, label string, migrations []*migration.Migration) {
+	for _, mig := range migrations {
+		printLabeledLine(output, label, fmt.Sprintf("%d_%s", mig.Version, mig.Name), termenv.ANSIBrightBlack)
+	}
+}
+
+func printLabeledLine(output *termenv.Output, label, value string, color termenv.Color) {
+	if value == "" {
+		_, _ = fmt.Println(output.String(label).Foreground(color))
+	} else {
+		_, _ = fmt.Print(output.String(label).Foreground(color))
+		_, _ = fmt.Println(value)
+	}
+}
@@ -0,0 +1,79 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/database"
+	imigration "github.com/coldsmirk/vef-framework-go/internal/migration"
+	iorm "github.com/coldsmirk/vef-framework-go/internal/orm"
+	"github.com/coldsmirk/vef-framework-go/mapx"
+	"github.com/coldsmirk/vef-framework-go/migration"
+)
+
+// loadConfig reads the data source and migration settings from the application config file.
+// Environment variables with the VEF_ prefix override file values, as they do in the application.
+func loadConfig(path string) (*config.DataSourceConfig, *config.MigrationConfig, error) {
+	v := viper.NewWithOptions(
+		viper.EnvKeyReplacer(strings.NewReplacer(".", "_")),
+		viper.KeyDelimiter("."),
+	)
+	v.SetEnvPrefix(config.EnvKeyPrefix)
+	v.AutomaticEnv()
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoderOption := func(c *mapstructure.DecoderConfig) {
+		c.TagName = "config"
+		c.IgnoreUntaggedFields = true
+		c.DecodeHook = mapx.DecoderHook
+	}
+
+	var (
+		ds  config.DataSourceConfig
+		cfg config.MigrationConfig
+	)
+
+	if err := v.UnmarshalKey("vef.data_source", &ds, decoderOption); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode data source config: %w", err)
+	}
+
+	if err := v.UnmarshalKey("vef.migration", &cfg, decoderOption); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode migration config: %w", err)
+	}
+
+	return &ds, &cfg, nil
+}
+
+// withMigrator connects to the configured database and runs fn with a migrator for the SQL migrations in dir.
+// Migrations implemented in Go are only known to the application and cannot run from the command line.
+func withMigrator(configPath, dir string, fn func(migrator migration.Migrator) error) error {
+	ds, cfg, err := loadConfig(configPath)
+	if err != nil {
+		return err
+	}
+
+	db, err := database.New(ds, database.DisableQueryHook())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	migrator := imigration.NewMigrator(
+		iorm.New(db),
+		db,
+		ds,
+		cfg,
+		[]migration.Source{migration.FS(os.DirFS(dir), ".")},
+	)
+
+	return fn(migrator)
+}
@@ -0,0 +1,69 @@
+package openapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+)
+
+// Command returns the export-openapi cobra command.
+func Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-openapi",
+		Short: "Export the OpenAPI document of a running application",
+		Long: `Export the OpenAPI 3.1 document generated by a running VEF application.
+
+The application must enable the document endpoint in its configuration:
+
+  [vef.openapi]
+  enabled = true
+  path = "/openapi.json"
+
+The document describes every registered operation: REST operations on their own
+paths and RPC operations as alternatives of the single /api request body, together
+with authentication, permission tokens and rate limits.
+
+Example usage:
+  vef-cli export-openapi -u http://localhost:8080/openapi.json -o docs/openapi.json
+
+  // Using full GitHub path (no installation required)
+  go run github.com/coldsmirk/vef-framework-go/cmd/vef-cli@latest export-openapi -o docs/openapi.json
+`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			url, _ := cmd.Flags().GetString("url")
+			outputFile, _ := cmd.Flags().GetString("output")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+
+			output := termenv.DefaultOutput()
+
+			printLabeledLine(output, "Exporting OpenAPI document...", "", termenv.ANSICyan)
+			printLabeledLine(output, "  URL: ", url, termenv.ANSIBrightBlack)
+			printLabeledLine(output, "  Output file: ", outputFile, termenv.ANSIBrightBlack)
+
+			if err := Export(cmd.Context(), url, outputFile, timeout); err != nil {
+				return fmt.Errorf("failed to export openapi document: %w", err)
+			}
+
+			_, _ = fmt.Println(output.String("✓ Successfully exported OpenAPI document").Foreground(termenv.ANSIGreen))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("url", "u", "http://localhost:8080/openapi.json", "URL of the OpenAPI document endpoint")
+	cmd.Flags().StringP("output", "o", "openapi.json", "Output file path")
+	cmd.Flags().Duration("timeout", 30*time.Second, "Request timeout")
+
+	return cmd
+}
+
+func printLabeledLine(output *termenv.Output, label, value string, color termenv.Color) {
+	if value == "" {
+		_, _ = fmt.Println(output.String(label).Foreground(color))
+	} else {
+		_, _ = fmt.Print(output.String(label).Foreground(color))
+		_, _ = fmt.Println(value)
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/coldsmirk/vef-framework-go/cmd/vef-cli/cmd/buildinfo"
 	"github.com/coldsmirk/vef-framework-go/cmd/vef-cli/cmd/create"
+	"github.com/coldsmirk/vef-framework-go/cmd/vef-cli/cmd/migrate"
 	"github.com/coldsmirk/vef-framework-go/cmd/vef-cli/cmd/modelschema"
+	"github.com/coldsmirk/vef-framework-go/cmd/vef-cli/cmd/openapi"
 )
 
 var (
@@ -48,6 +50,8 @@ func init() {
 		create.Command(),
 		buildinfo.Command(),
 		modelschema.Command(),
+		openapi.Command(),
+		migrate.Command(),
 	}
 
 	setupHelpColors(rootCmd)
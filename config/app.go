@@ -11,4 +11,7 @@ type AppConfig struct {
 	Name      string `config:"name"`
 	Port      uint16 `config:"port"`
 	BodyLimit string `config:"body_limit"`
+	// Secret signs framework issued opaque tokens such as pagination cursors.
+	// It must be shared by all instances; a random secret is used when empty.
+	Secret string `config:"secret"`
 }
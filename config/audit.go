@@ -0,0 +1,41 @@
+package config
+
+import "time"
+
+// AuditConfig defines the persistent audit log sink settings.
+type AuditConfig struct {
+	Enabled       bool          `config:"enabled"`
+	AutoMigrate   bool          `config:"auto_migrate"`   // Creates the audit log table on startup
+	BatchSize     int           `config:"batch_size"`     // Max records written per insert (default: 100)
+	FlushInterval time.Duration `config:"flush_interval"` // Max time a record waits before being written (default: 2s)
+	QueueSize     int           `config:"queue_size"`     // Max records waiting to be written, newer ones are dropped (default: 10000)
+	RetentionDays int           `config:"retention_days"` // Days records are kept, 0 keeps them forever
+	RedactKeys    []string      `config:"redact_keys"`    // Additional param/result keys to redact, e.g. idCard or *cardNo
+}
+
+// BatchSizeOrDefault returns the batch size, defaulting to 100.
+func (c *AuditConfig) BatchSizeOrDefault() int {
+	if c.BatchSize <= 0 {
+		return 100
+	}
+
+	return c.BatchSize
+}
+
+// FlushIntervalOrDefault returns the flush interval, defaulting to 2 seconds.
+func (c *AuditConfig) FlushIntervalOrDefault() time.Duration {
+	if c.FlushInterval <= 0 {
+		return 2 * time.Second
+	}
+
+	return c.FlushInterval
+}
+
+// QueueSizeOrDefault returns the queue size, defaulting to 10000.
+func (c *AuditConfig) QueueSizeOrDefault() int {
+	if c.QueueSize <= 0 {
+		return 10000
+	}
+
+	return c.QueueSize
+}
@@ -1,5 +1,7 @@
 package config
 
+import "cmp"
+
 // DBKind represents supported database kinds.
 type DBKind string
 
@@ -23,4 +25,30 @@ type DataSourceConfig struct {
 	Schema         string `config:"schema"`
 	Path           string `config:"path"`
 	EnableSQLGuard bool   `config:"enable_sql_guard"`
+	// Replicas are read replicas that serve selects outside transactions.
+	// Empty replica fields inherit the value of the primary.
+	Replicas []DataSourceConfig `config:"replicas"`
 }
+
+// ReplicaConfigs returns the replica configurations with empty fields filled from the primary.
+func (c *DataSourceConfig) ReplicaConfigs() []*DataSourceConfig {
+	configs := make([]*DataSourceConfig, len(c.Replicas))
+	for i, replica := range c.Replicas {
+		configs[i] = &DataSourceConfig{
+			Kind:           cmp.Or(replica.Kind, c.Kind),
+			Host:           cmp.Or(replica.Host, c.Host),
+			Port:           cmp.Or(replica.Port, c.Port),
+			User:           cmp.Or(replica.User, c.User),
+			Password:       cmp.Or(replica.Password, c.Password),
+			Database:       cmp.Or(replica.Database, c.Database),
+			Schema:         cmp.Or(replica.Schema, c.Schema),
+			Path:           cmp.Or(replica.Path, c.Path),
+			EnableSQLGuard: replica.EnableSQLGuard || c.EnableSQLGuard,
+		}
+	}
+
+	return configs
+}
+
+// DataSourcesConfig defines additional named data sources, keyed by name (vef.data_sources.<name>).
+type DataSourcesConfig map[string]DataSourceConfig
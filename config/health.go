@@ -0,0 +1,34 @@
+package config
+
+import "time"
+
+// HealthConfig defines the liveness and readiness endpoint settings.
+type HealthConfig struct {
+	Path          string                   `config:"path"`           // Base path of the endpoints (default: /health)
+	Timeout       time.Duration            `config:"timeout"`        // Timeout of each check (default: 5s)
+	Timeouts      map[string]time.Duration `config:"timeouts"`       // Timeout overrides by indicator name, e.g. database: 2s
+	ShutdownDelay time.Duration            `config:"shutdown_delay"` // Time the application keeps serving after readiness flipped on shutdown
+	ShowDetails   bool                     `config:"show_details"`   // Include the checks and their errors in readiness responses (default: false)
+}
+
+// PathOrDefault returns the base path of the endpoints, defaulting to /health.
+func (c *HealthConfig) PathOrDefault() string {
+	if c.Path == "" {
+		return "/health"
+	}
+
+	return c.Path
+}
+
+// TimeoutOf returns the timeout of the named indicator, defaulting to 5 seconds.
+func (c *HealthConfig) TimeoutOf(name string) time.Duration {
+	if timeout := c.Timeouts[name]; timeout > 0 {
+		return timeout
+	}
+
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+
+	return 5 * time.Second
+}
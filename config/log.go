@@ -0,0 +1,20 @@
+package config
+
+import "time"
+
+// LogConfig defines logging settings.
+type LogConfig struct {
+	Level    string            `config:"level"`  // Root log level (debug|info|warn|error, default: $VEF_LOG_LEVEL or info)
+	Format   string            `config:"format"` // Encoder format (console|json, default: console)
+	Output   string            `config:"output"` // stdout, stderr or a file path (default: stdout)
+	Levels   map[string]string `config:"levels"` // Level overrides by logger name, also applied to its child loggers
+	Rotation LogRotationConfig `config:"rotation"`
+}
+
+// LogRotationConfig defines rotation settings of file output.
+type LogRotationConfig struct {
+	MaxSize    int           `config:"max_size"`    // Size in megabytes a file is rotated at (0: no size rotation)
+	Interval   time.Duration `config:"interval"`    // Rotates the file once per interval, e.g. 24h (0: no time rotation)
+	MaxAge     time.Duration `config:"max_age"`     // Removes rotated files older than this (0: keep)
+	MaxBackups int           `config:"max_backups"` // Number of rotated files to keep (0: keep all)
+}
@@ -0,0 +1,16 @@
+package config
+
+// MetricsConfig defines the Prometheus metrics endpoint settings.
+type MetricsConfig struct {
+	Enabled bool   `config:"enabled"`
+	Path    string `config:"path"` // Endpoint path serving the metrics (default: /metrics)
+}
+
+// PathOrDefault returns the endpoint path, defaulting to /metrics.
+func (c *MetricsConfig) PathOrDefault() string {
+	if c.Path == "" {
+		return "/metrics"
+	}
+
+	return c.Path
+}
@@ -0,0 +1,28 @@
+package config
+
+import "time"
+
+// MigrationConfig defines versioned schema migration settings.
+type MigrationConfig struct {
+	AutoMigrate bool          `config:"auto_migrate"` // Apply pending migrations on startup
+	LockTimeout time.Duration `config:"lock_timeout"` // Time to wait for another instance to finish migrating (default: 60s)
+	LockExpiry  time.Duration `config:"lock_expiry"`  // Age after which an abandoned migration lock is broken (default: 10m)
+}
+
+// LockTimeoutOrDefault returns the lock wait timeout, defaulting to 60 seconds.
+func (c *MigrationConfig) LockTimeoutOrDefault() time.Duration {
+	if c.LockTimeout <= 0 {
+		return 60 * time.Second
+	}
+
+	return c.LockTimeout
+}
+
+// LockExpiryOrDefault returns the age after which a lock is considered abandoned, defaulting to 10 minutes.
+func (c *MigrationConfig) LockExpiryOrDefault() time.Duration {
+	if c.LockExpiry <= 0 {
+		return 10 * time.Minute
+	}
+
+	return c.LockExpiry
+}
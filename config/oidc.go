@@ -0,0 +1,17 @@
+package config
+
+// OIDCConfig defines the OpenID Connect providers users can sign in with.
+type OIDCConfig struct {
+	Providers []OIDCProviderConfig `config:"providers"`
+}
+
+// OIDCProviderConfig defines an OpenID Connect identity provider.
+type OIDCProviderConfig struct {
+	Name          string   `config:"name"`           // Provider name clients select at login, e.g. "azure"
+	Issuer        string   `config:"issuer"`         // Issuer URL; the discovery document is served under it at /.well-known/openid-configuration
+	ClientID      string   `config:"client_id"`      // Client ID registered at the provider
+	ClientSecret  string   `config:"client_secret"`  // Client secret; empty for public clients relying on PKCE only
+	RedirectURL   string   `config:"redirect_url"`   // Redirect URL registered at the provider
+	Scopes        []string `config:"scopes"`         // Requested scopes (default: openid, profile, email)
+	UsernameClaim string   `config:"username_claim"` // Claim holding the local username (default: preferred_username)
+}
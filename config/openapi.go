@@ -0,0 +1,19 @@
+package config
+
+// OpenAPIConfig defines OpenAPI document generation settings.
+type OpenAPIConfig struct {
+	Enabled     bool   `config:"enabled"`
+	Path        string `config:"path"`    // Endpoint path serving the document (default: /openapi.json)
+	Title       string `config:"title"`   // Document title (default: application name)
+	Version     string `config:"version"` // Document version (default: framework version)
+	Description string `config:"description"`
+}
+
+// PathOrDefault returns the endpoint path, defaulting to /openapi.json.
+func (c *OpenAPIConfig) PathOrDefault() string {
+	if c.Path == "" {
+		return "/openapi.json"
+	}
+
+	return c.Path
+}
@@ -8,4 +8,18 @@ type SecurityConfig struct {
 	RefreshNotBefore time.Duration `config:"refresh_not_before"`
 	LoginRateLimit   int           `config:"login_rate_limit"`
 	RefreshRateLimit int           `config:"refresh_rate_limit"`
+	Lockout          LockoutConfig `config:"lockout"`
+}
+
+// LockoutConfig defines the brute-force protection of password logins.
+// Failed logins are counted per username and per client IP; reaching a limit locks the username or blocks the IP,
+// and each consecutive lockout lasts twice as long as the previous one.
+type LockoutConfig struct {
+	Enabled         bool          `config:"enabled"`
+	MaxAttempts     int           `config:"max_attempts"`     // Failures of a username within Window that lock it (default: 5)
+	IPMaxAttempts   int           `config:"ip_max_attempts"`  // Failures from an IP within Window that block it (default: 50); negative disables IP blocking
+	CaptchaAttempts int           `config:"captcha_attempts"` // Failures of a username within Window after which a captcha challenge is required; 0 disables
+	Window          time.Duration `config:"window"`           // Period failures are counted in, starting at the first failure (default: 15m)
+	Duration        time.Duration `config:"duration"`         // Duration of the first lockout (default: 15m)
+	MaxDuration     time.Duration `config:"max_duration"`     // Upper bound of progressive lockouts, also how long past lockouts are remembered (default: 24h)
 }
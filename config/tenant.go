@@ -0,0 +1,16 @@
+package config
+
+// TenantConfig defines multi-tenancy settings.
+type TenantConfig struct {
+	Enabled bool   `config:"enabled"`
+	Header  string `config:"header"` // Request header passed to the tenant resolver, ignored by the default one (default: X-Tenant-ID)
+}
+
+// HeaderOrDefault returns the tenant header name, defaulting to X-Tenant-ID.
+func (c *TenantConfig) HeaderOrDefault() string {
+	if c.Header == "" {
+		return "X-Tenant-ID"
+	}
+
+	return c.Header
+}
@@ -21,6 +21,7 @@ const (
 	KeyLogger
 	KeyDB
 	KeyDataPermApplier
+	KeyTenantID
 )
 
 // setValue stores a value in the context, handling both fiber.Ctx and standard context.Context.
@@ -109,3 +110,14 @@ func RequestIP(ctx context.Context) string {
 func SetRequestIP(ctx context.Context, ip string) context.Context {
 	return setValue(ctx, KeyRequestIP, ip)
 }
+
+// TenantID returns the tenant of the current request, or an empty string when the request is not tenant-scoped.
+func TenantID(ctx context.Context) string {
+	tenantID, _ := ctx.Value(KeyTenantID).(string)
+
+	return tenantID
+}
+
+func SetTenantID(ctx context.Context, tenantID string) context.Context {
+	return setValue(ctx, KeyTenantID, tenantID)
+}
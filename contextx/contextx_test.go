@@ -267,3 +267,23 @@ func TestRequestIP(t *testing.T) {
 		})
 	})
 }
+
+// TestTenantID tests TenantID and SetTenantID for standard and fiber contexts.
+func TestTenantID(t *testing.T) {
+	t.Run("ReturnsEmptyStringFromEmptyContext", func(t *testing.T) {
+		ctx := context.Background()
+		assert.Equal(t, "", TenantID(ctx), "Should return empty string when no tenant is stored")
+	})
+
+	t.Run("ReturnsStoredValue", func(t *testing.T) {
+		ctx := SetTenantID(context.Background(), "tenant-a")
+		assert.Equal(t, "tenant-a", TenantID(ctx), "Should return the stored tenant")
+	})
+
+	t.Run("WorksWithFiberContext", func(t *testing.T) {
+		RunInFiber(t, func(t *testing.T, ctx fiber.Ctx) {
+			SetTenantID(ctx, "tenant-b")
+			assert.Equal(t, "tenant-b", TenantID(ctx), "Should return stored tenant from fiber context")
+		})
+	})
+}
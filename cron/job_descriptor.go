@@ -74,7 +74,7 @@ type jobTask struct {
 	params  []any
 }
 
-func (t *jobTask) buildTask() (gocron.Task, error) {
+func (t *jobTask) buildTask(name string) (gocron.Task, error) {
 	if t.handler == nil {
 		return nil, ErrJobTaskHandlerRequired
 	}
@@ -83,7 +83,7 @@ func (t *jobTask) buildTask() (gocron.Task, error) {
 		return nil, ErrJobTaskHandlerMustFunc
 	}
 
-	return gocron.NewTask(t.handler, t.params...), nil
+	return gocron.NewTask(traceHandler(name, t.handler), t.params...), nil
 }
 
 // jobDescriptor combines job metadata and task information.
@@ -94,7 +94,7 @@ type jobDescriptor struct {
 }
 
 func (d *jobDescriptor) buildDescriptor() (gocron.Task, []gocron.JobOption, error) {
-	task, err := d.buildTask()
+	task, err := d.buildTask(d.name)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to build job task: %w", err)
 	}
@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"context"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+var (
+	contextType = reflect.TypeFor[context.Context]()
+	errorType   = reflect.TypeFor[error]()
+)
+
+// traceHandler wraps a job handler so that each run is recorded as a root span named after the job.
+// The wrapper has the signature of the handler, so a handler taking a context.Context as its first
+// parameter still receives the job context, carrying the span to the work it does.
+func traceHandler(name string, handler any) any {
+	handlerValue := reflect.ValueOf(handler)
+	handlerType := handlerValue.Type()
+
+	takesContext := handlerType.NumIn() > 0 && handlerType.In(0) == contextType
+	returnsError := handlerType.NumOut() > 0 && handlerType.Out(handlerType.NumOut()-1) == errorType
+
+	return reflect.MakeFunc(handlerType, func(args []reflect.Value) []reflect.Value {
+		ctx := context.Background()
+		if takesContext && !args[0].IsNil() {
+			ctx = args[0].Interface().(context.Context)
+		}
+
+		ctx, span := tracing.Tracer().Start(
+			ctx,
+			"cron "+name,
+			trace.WithNewRoot(),
+			trace.WithAttributes(attribute.String(tracing.AttrCronJob, name)),
+		)
+		defer span.End()
+
+		if takesContext {
+			args[0] = reflect.ValueOf(ctx)
+		}
+
+		var results []reflect.Value
+		if handlerType.IsVariadic() {
+			results = handlerValue.CallSlice(args)
+		} else {
+			results = handlerValue.Call(args)
+		}
+
+		if returnsError {
+			if err, ok := results[len(results)-1].Interface().(error); ok {
+				tracing.RecordError(span, err)
+			}
+		}
+
+		return results
+	}).Interface()
+}
@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+// TestTraceHandler tests that job runs are recorded as root spans carried to the handler.
+func TestTraceHandler(t *testing.T) {
+	recorder := testx.RecordSpans(t)
+
+	t.Run("ContextHandler", func(t *testing.T) {
+		recorder.Reset()
+
+		var traceID string
+
+		handler := traceHandler("cleanup", func(ctx context.Context, days int) error {
+			traceID = tracing.TraceID(ctx)
+
+			return errors.New("disk full")
+		}).(func(context.Context, int) error)
+
+		require.Error(t, handler(context.Background(), 7), "Should return the handler error")
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1, "Should record the run")
+		assert.Equal(t, "cron cleanup", spans[0].Name(), "Should name the span after the job")
+		assert.Equal(t, spans[0].SpanContext().TraceID().String(), traceID, "Should pass the span to the handler")
+		assert.Equal(t, codes.Error, spans[0].Status().Code, "Should mark the failed run")
+	})
+
+	t.Run("PlainHandler", func(t *testing.T) {
+		recorder.Reset()
+
+		var received []string
+
+		handler := traceHandler("report", func(names ...string) {
+			received = names
+		}).(func(...string))
+
+		handler("a", "b")
+
+		assert.Equal(t, []string{"a", "b"}, received, "Should pass variadic arguments")
+		assert.Len(t, recorder.Ended(), 1, "Should record the run")
+	})
+}
@@ -22,6 +22,8 @@ type Builder[T any] interface {
 	PermToken(token string) T
 	// RateLimit configures rate limiting for this endpoint.
 	RateLimit(maxRequests int, period time.Duration) T
+	// Idempotent enables Idempotency-Key handling for this endpoint, optionally with a custom replay TTL.
+	Idempotent(ttl ...time.Duration) T
 	// Build creates an OperationSpec with the configured settings and the given handler.
 	Build(handler any) api.OperationSpec
 }
@@ -34,6 +36,7 @@ type baseBuilder[T any] struct {
 	public      bool
 	permToken   string
 	rateLimit   *api.RateLimitConfig
+	idempotency *api.IdempotencyConfig
 
 	self T
 }
@@ -87,6 +90,15 @@ func (b *baseBuilder[T]) RateLimit(maxRequests int, period time.Duration) T {
 	return b.self
 }
 
+func (b *baseBuilder[T]) Idempotent(ttl ...time.Duration) T {
+	b.idempotency = &api.IdempotencyConfig{}
+	if len(ttl) > 0 {
+		b.idempotency.TTL = ttl[0]
+	}
+
+	return b.self
+}
+
 func (b *baseBuilder[T]) Build(handler any) api.OperationSpec {
 	return api.OperationSpec{
 		Action:      b.action,
@@ -95,6 +107,7 @@ func (b *baseBuilder[T]) Build(handler any) api.OperationSpec {
 		Public:      b.public,
 		PermToken:   b.permToken,
 		RateLimit:   b.rateLimit,
+		Idempotency: b.idempotency,
 		Handler:     handler,
 	}
 }
@@ -41,6 +41,17 @@ func TestBuilderMethods(t *testing.T) {
 		assert.Equal(t, 1*time.Minute, specs[0].RateLimit.Period, "RateLimit period should be set")
 	})
 
+	t.Run("Idempotent", func(t *testing.T) {
+		c := crud.NewCreate[orm.FullAuditedModel, orm.FullAuditedModel]().Idempotent()
+		specs := c.Provide()
+		assert.NotNil(t, specs[0].Idempotency, "Idempotency should be set")
+		assert.Zero(t, specs[0].Idempotency.TTL, "Idempotency TTL should default to zero")
+
+		c = crud.NewCreate[orm.FullAuditedModel, orm.FullAuditedModel]().Idempotent(time.Hour)
+		specs = c.Provide()
+		assert.Equal(t, time.Hour, specs[0].Idempotency.TTL, "Idempotency TTL should be set")
+	})
+
 	t.Run("ResourceKind", func(t *testing.T) {
 		c := crud.NewCreate[orm.FullAuditedModel, orm.FullAuditedModel]().ResourceKind(api.KindREST)
 		assert.NotNil(t, c, "ResourceKind should return the builder")
@@ -15,6 +15,8 @@ const (
 const (
 	ErrMessageProcessorMustReturnSlice = "processor_must_return_slice"
 	ErrCodeProcessorInvalidReturn      = 2400
+	ErrMessageInvalidCursor            = "invalid_cursor"
+	ErrCodeInvalidCursor               = 2401
 )
 
 // RPC action names (snake_case identifiers).
@@ -25,9 +27,12 @@ const (
 	RPCActionCreateMany      = "create_many"
 	RPCActionUpdateMany      = "update_many"
 	RPCActionDeleteMany      = "delete_many"
+	RPCActionRestore         = "restore"
+	RPCActionRestoreMany     = "restore_many"
 	RPCActionFindOne         = "find_one"
 	RPCActionFindAll         = "find_all"
 	RPCActionFindPage        = "find_page"
+	RPCActionFindCursor      = "find_cursor"
 	RPCActionFindOptions     = "find_options"
 	RPCActionFindTree        = "find_tree"
 	RPCActionFindTreeOptions = "find_tree_options"
@@ -43,9 +48,12 @@ const (
 	RESTActionCreateMany      = "post /many"
 	RESTActionUpdateMany      = "put /many"
 	RESTActionDeleteMany      = "delete /many"
+	RESTActionRestore         = "put /:" + IDColumn + "/restore"
+	RESTActionRestoreMany     = "put /many/restore"
 	RESTActionFindOne         = "get /:" + IDColumn
 	RESTActionFindAll         = "get /"
 	RESTActionFindPage        = "get /page"
+	RESTActionFindCursor      = "get /cursor"
 	RESTActionFindOptions     = "get /options"
 	RESTActionFindTree        = "get /tree"
 	RESTActionFindTreeOptions = "get /tree/options"
@@ -68,6 +68,22 @@ func NewDeleteMany[TModel any](kind ...api.Kind) DeleteMany[TModel] {
 	return api.Action(getAction(RPCActionDeleteMany, RESTActionDeleteMany, kind...))
 }
 
+// NewRestore creates a new Restore instance for undoing a single soft delete.
+func NewRestore[TModel any](kind ...api.Kind) Restore[TModel] {
+	api := new(restoreOperation[TModel])
+	api.Builder = NewBuilder[Restore[TModel]](api, kind...)
+
+	return api.Action(getAction(RPCActionRestore, RESTActionRestore, kind...))
+}
+
+// NewRestoreMany creates a new RestoreMany instance for undoing soft deletes in batch.
+func NewRestoreMany[TModel any](kind ...api.Kind) RestoreMany[TModel] {
+	api := new(restoreManyOperation[TModel])
+	api.Builder = NewBuilder[RestoreMany[TModel]](api, kind...)
+
+	return api.Action(getAction(RPCActionRestoreMany, RESTActionRestoreMany, kind...))
+}
+
 // NewFind creates the base Find instance used by all find-type endpoints.
 func NewFind[TModel, TSearch, TProcessor, TOperation any](self TOperation, kind ...api.Kind) Find[TModel, TSearch, TProcessor, TOperation] {
 	return &baseFindOperation[TModel, TSearch, TProcessor, TOperation]{
@@ -110,6 +126,17 @@ func NewFindPage[TModel, TSearch any](kind ...api.Kind) FindPage[TModel, TSearch
 	return api.Action(getAction(RPCActionFindPage, RESTActionFindPage, kind...))
 }
 
+// NewFindCursor creates a new FindCursor instance for keyset pagination.
+func NewFindCursor[TModel, TSearch any](kind ...api.Kind) FindCursor[TModel, TSearch] {
+	api := new(findCursorOperation[TModel, TSearch])
+	api.Find = NewFind[TModel, TSearch, []TModel, FindCursor[TModel, TSearch]](
+		api,
+		kind...,
+	)
+
+	return api.Action(getAction(RPCActionFindCursor, RESTActionFindCursor, kind...))
+}
+
 // NewFindOptions creates a new FindOptions instance.
 func NewFindOptions[TModel, TSearch any](kind ...api.Kind) FindOptions[TModel, TSearch] {
 	api := new(findOptionsOperation[TModel, TSearch])
@@ -27,6 +27,8 @@ type Delete[TModel any] interface {
 	WithPostDelete(processor PostDeleteProcessor[TModel]) Delete[TModel]
 	// DisableDataPerm disables automatic data permission filtering for delete queries.
 	DisableDataPerm() Delete[TModel]
+	// ForceDelete permanently removes soft deletable models, including ones that are already soft-deleted.
+	ForceDelete() Delete[TModel]
 }
 
 type deleteOperation[TModel any] struct {
@@ -35,6 +37,7 @@ type deleteOperation[TModel any] struct {
 	preDelete        PreDeleteProcessor[TModel]
 	postDelete       PostDeleteProcessor[TModel]
 	dataPermDisabled bool
+	forceDelete      bool
 }
 
 func (d *deleteOperation[TModel]) Provide() []api.OperationSpec {
@@ -59,6 +62,12 @@ func (d *deleteOperation[TModel]) DisableDataPerm() Delete[TModel] {
 	return d
 }
 
+func (d *deleteOperation[TModel]) ForceDelete() Delete[TModel] {
+	d.forceDelete = true
+
+	return d
+}
+
 func (d *deleteOperation[TModel]) delete(db orm.DB, sc storage.Service, publisher event.Publisher) (func(ctx fiber.Ctx, db orm.DB, params api.Params) error, error) {
 	promoter := storage.NewPromoter[TModel](sc, publisher)
 	schema := db.TableOf((*TModel)(nil))
@@ -68,6 +77,10 @@ func (d *deleteOperation[TModel]) delete(db orm.DB, sc storage.Service, publishe
 		return nil, fmt.Errorf("%w: %s", ErrModelNoPrimaryKey, schema.Name)
 	}
 
+	// Soft-deleted rows keep their files so that they can be restored later.
+	softDelete := schema.SoftDeleteField != nil && !d.forceDelete
+	includeDeleted := schema.SoftDeleteField != nil && d.forceDelete
+
 	return func(ctx fiber.Ctx, db orm.DB, params api.Params) error {
 		var (
 			model      TModel
@@ -85,7 +98,10 @@ func (d *deleteOperation[TModel]) delete(db orm.DB, sc storage.Service, publishe
 			}
 		}
 
-		query := db.NewSelect().Model(&model).WherePK()
+		// The row is loaded from the primary so that a lagging replica cannot hide or resurrect it.
+		query := db.Primary().NewSelect().Model(&model).WherePK().ApplyIf(includeDeleted, func(query orm.SelectQuery) {
+			query.WithDeleted()
+		})
 		if !d.dataPermDisabled {
 			if err := ApplyDataPermission(query, ctx); err != nil {
 				return err
@@ -97,7 +113,9 @@ func (d *deleteOperation[TModel]) delete(db orm.DB, sc storage.Service, publishe
 		}
 
 		return db.RunInTX(ctx.Context(), func(txCtx context.Context, tx orm.DB) error {
-			query := tx.NewDelete().Model(&model)
+			query := tx.NewDelete().Model(&model).ApplyIf(d.forceDelete, func(query orm.DeleteQuery) {
+				query.ForceDelete()
+			})
 			if d.preDelete != nil {
 				if err := d.preDelete(&model, query, ctx, tx); err != nil {
 					return err
@@ -114,8 +132,10 @@ func (d *deleteOperation[TModel]) delete(db orm.DB, sc storage.Service, publishe
 				}
 			}
 
-			if err := promoter.Promote(txCtx, nil, &model); err != nil {
-				return fmt.Errorf("delete succeeded but cleanup files failed: %w", err)
+			if !softDelete {
+				if err := promoter.Promote(txCtx, nil, &model); err != nil {
+					return fmt.Errorf("delete succeeded but cleanup files failed: %w", err)
+				}
 			}
 
 			return result.Ok().Response(ctx)
@@ -27,6 +27,8 @@ type DeleteMany[TModel any] interface {
 	WithPostDeleteMany(processor PostDeleteManyProcessor[TModel]) DeleteMany[TModel]
 	// DisableDataPerm disables automatic data permission filtering for batch delete queries.
 	DisableDataPerm() DeleteMany[TModel]
+	// ForceDelete permanently removes soft deletable models, including ones that are already soft-deleted.
+	ForceDelete() DeleteMany[TModel]
 }
 
 type deleteManyOperation[TModel any] struct {
@@ -35,6 +37,7 @@ type deleteManyOperation[TModel any] struct {
 	preDeleteMany    PreDeleteManyProcessor[TModel]
 	postDeleteMany   PostDeleteManyProcessor[TModel]
 	dataPermDisabled bool
+	forceDelete      bool
 }
 
 func (d *deleteManyOperation[TModel]) Provide() []api.OperationSpec {
@@ -59,6 +62,12 @@ func (d *deleteManyOperation[TModel]) DisableDataPerm() DeleteMany[TModel] {
 	return d
 }
 
+func (d *deleteManyOperation[TModel]) ForceDelete() DeleteMany[TModel] {
+	d.forceDelete = true
+
+	return d
+}
+
 func (d *deleteManyOperation[TModel]) deleteMany(db orm.DB, sc storage.Service, publisher event.Publisher) (func(ctx fiber.Ctx, db orm.DB, params DeleteManyParams) error, error) {
 	promoter := storage.NewPromoter[TModel](sc, publisher)
 	schema := db.TableOf((*TModel)(nil))
@@ -68,6 +77,10 @@ func (d *deleteManyOperation[TModel]) deleteMany(db orm.DB, sc storage.Service,
 		return nil, fmt.Errorf("%w: %s", ErrModelNoPrimaryKey, schema.Name)
 	}
 
+	// Soft-deleted rows keep their files so that they can be restored later.
+	softDelete := schema.SoftDeleteField != nil && !d.forceDelete
+	includeDeleted := schema.SoftDeleteField != nil && d.forceDelete
+
 	return func(ctx fiber.Ctx, db orm.DB, params DeleteManyParams) error {
 		if len(params.PKs) == 0 {
 			return result.Ok().Response(ctx)
@@ -99,7 +112,9 @@ func (d *deleteManyOperation[TModel]) deleteMany(db orm.DB, sc storage.Service,
 				}
 			}
 
-			query := db.NewSelect().Model(&models[i]).WherePK()
+			query := db.Primary().NewSelect().Model(&models[i]).WherePK().ApplyIf(includeDeleted, func(query orm.SelectQuery) {
+				query.WithDeleted()
+			})
 			if !d.dataPermDisabled {
 				if err := ApplyDataPermission(query, ctx); err != nil {
 					return err
@@ -112,14 +127,27 @@ func (d *deleteManyOperation[TModel]) deleteMany(db orm.DB, sc storage.Service,
 		}
 
 		return db.RunInTX(ctx.Context(), func(txCtx context.Context, tx orm.DB) error {
-			query := tx.NewDelete().Model(&models)
+			query := tx.NewDelete().Model(&models).ApplyIf(d.forceDelete, func(query orm.DeleteQuery) {
+				query.ForceDelete()
+			})
+			if !d.dataPermDisabled {
+				if err := ApplyDeleteDataPermission(query, ctx); err != nil {
+					return err
+				}
+			}
+
 			if d.preDeleteMany != nil {
 				if err := d.preDeleteMany(models, query, ctx, tx); err != nil {
 					return err
 				}
 			}
 
-			if _, err := query.WherePK().Exec(txCtx); err != nil {
+			res, err := query.WherePK().Exec(txCtx)
+			if err != nil {
+				return err
+			}
+
+			if err := checkRowsAffected(res, len(models), nil); err != nil {
 				return err
 			}
 
@@ -129,8 +157,10 @@ func (d *deleteManyOperation[TModel]) deleteMany(db orm.DB, sc storage.Service,
 				}
 			}
 
-			if cleanupErr := batchCleanup(txCtx, promoter, models); cleanupErr != nil {
-				return fmt.Errorf("delete succeeded but cleanup files failed: %w", cleanupErr)
+			if !softDelete {
+				if cleanupErr := batchCleanup(txCtx, promoter, models); cleanupErr != nil {
+					return fmt.Errorf("delete succeeded but cleanup files failed: %w", cleanupErr)
+				}
 			}
 
 			return result.Ok().Response(ctx)
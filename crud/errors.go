@@ -5,6 +5,9 @@ import "errors"
 // ErrModelNoPrimaryKey indicates the model schema has no primary key.
 var ErrModelNoPrimaryKey = errors.New("model has no primary key")
 
+// ErrModelNotSoftDeletable indicates the model has no soft delete field, so it cannot be restored.
+var ErrModelNotSoftDeletable = errors.New("model is not soft deletable")
+
 // ErrAuditUserCompositePK indicates the audit user model has a composite primary key which is not supported.
 var ErrAuditUserCompositePK = errors.New("audit user model has composite primary key, only single primary key is supported")
 
@@ -13,3 +16,6 @@ var ErrSearchTypeMismatch = errors.New("search type mismatch")
 
 // ErrColumnNotFound indicates a column does not exist in the model.
 var ErrColumnNotFound = errors.New("column does not exist in model")
+
+// ErrCursorColumnNullable indicates a cursor sort column may hold NULL, i.e. it is not notnull, or it is nullzero or a pointer.
+var ErrCursorColumnNullable = errors.New("cursor sort column is nullable")
@@ -123,7 +123,9 @@ func (a *baseFindOperation[TModel, TSearch, TProcessorIn, TOperation]) setupQuer
 		}
 	}
 
-	a.setupDefaultSort(table, sortParts)
+	if len(sortParts) > 0 {
+		a.setupDefaultSort(table, sortParts)
+	}
 
 	return nil
 }
@@ -0,0 +1,356 @@
+package crud
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/coldsmirk/go-streams"
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/mold"
+	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/page"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/sortx"
+)
+
+// FindCursor provides a fluent interface for building keyset (cursor) pagination endpoints.
+// Pages are addressed by opaque, signed cursors built from the default sort columns plus the
+// primary key, so no OFFSET is used and the total count is skipped unless enabled.
+// Request sorting is ignored because cursors are only valid for a fixed ordering.
+type FindCursor[TModel, TSearch any] interface {
+	api.OperationsProvider
+	Find[TModel, TSearch, []TModel, FindCursor[TModel, TSearch]]
+
+	// WithDefaultPageSize sets the fallback page size when the request's page size is zero or invalid.
+	WithDefaultPageSize(size int) FindCursor[TModel, TSearch]
+	// WithTotal enables counting the total number of matching records, which costs a COUNT(*) per request.
+	WithTotal() FindCursor[TModel, TSearch]
+}
+
+// cursorFindConfig disables framework sorting since the cursor operation orders the query itself.
+var cursorFindConfig = &FindOperationConfig{
+	QueryParts: &QueryPartsConfig{
+		Condition:         []QueryPart{QueryRoot},
+		Sort:              []QueryPart{},
+		AuditUserRelation: []QueryPart{QueryRoot},
+	},
+}
+
+// cursorColumn is a sort column of the keyset together with the model field holding its value.
+type cursorColumn struct {
+	spec  sortx.OrderSpec
+	field *orm.Field
+}
+
+type findCursorOperation[TModel, TSearch any] struct {
+	Find[TModel, TSearch, []TModel, FindCursor[TModel, TSearch]]
+
+	defaultPageSize int
+	defaultSort     []*sortx.OrderSpec
+	withTotal       bool
+}
+
+func (a *findCursorOperation[TModel, TSearch]) Provide() []api.OperationSpec {
+	return []api.OperationSpec{a.Build(a.findCursor)}
+}
+
+// This value is used when the request's page size is zero or invalid.
+func (a *findCursorOperation[TModel, TSearch]) WithDefaultPageSize(size int) FindCursor[TModel, TSearch] {
+	a.defaultPageSize = size
+
+	return a
+}
+
+func (a *findCursorOperation[TModel, TSearch]) WithTotal() FindCursor[TModel, TSearch] {
+	a.withTotal = true
+
+	return a
+}
+
+// WithDefaultSort sets the columns the keyset is built from.
+// The primary key is appended automatically to make the ordering unique.
+// The columns must be NOT NULL, as rows holding NULL would be skipped by the keyset comparison.
+func (a *findCursorOperation[TModel, TSearch]) WithDefaultSort(orderSpecs ...*sortx.OrderSpec) FindCursor[TModel, TSearch] {
+	a.defaultSort = slices.Clone(orderSpecs)
+
+	return a.Find.WithDefaultSort(orderSpecs...)
+}
+
+func (a *findCursorOperation[TModel, TSearch]) findCursor(db orm.DB, codec page.CursorCodec) (func(ctx fiber.Ctx, db orm.DB, transformer mold.Transformer, pageable page.CursorPageable, search TSearch, meta api.Meta) error, error) {
+	if err := a.Setup(db, cursorFindConfig); err != nil {
+		return nil, err
+	}
+
+	columns, err := a.setupCursorColumns(db.TableOf((*TModel)(nil)))
+	if err != nil {
+		return nil, err
+	}
+
+	key := cursorKey(columns)
+
+	return func(ctx fiber.Ctx, db orm.DB, transformer mold.Transformer, pageable page.CursorPageable, search TSearch, meta api.Meta) error {
+		pageable.Normalize(a.defaultPageSize)
+
+		var (
+			cursor *page.Cursor
+			err    error
+		)
+
+		if pageable.Cursor != "" {
+			if cursor, err = codec.Decode(pageable.Cursor); err != nil || cursor.Key != key || len(cursor.Values) != len(columns) {
+				return errInvalidCursor()
+			}
+		}
+
+		var (
+			models   []TModel
+			query    = db.NewSelect().Model(&models).SelectModelColumns()
+			backward = cursor != nil && cursor.Backward
+			cp       = page.NewCursorPage(pageable, []any{})
+		)
+
+		if err := a.ConfigureQuery(query, search, meta, ctx, QueryRoot); err != nil {
+			return err
+		}
+
+		if a.withTotal {
+			countQuery := db.NewSelect().Model((*TModel)(nil))
+			if err := a.ConfigureQuery(countQuery, search, meta, ctx, QueryRoot); err != nil {
+				return err
+			}
+
+			total, err := countQuery.Count(ctx.Context())
+			if err != nil {
+				return err
+			}
+
+			cp.Total = &total
+		}
+
+		if cursor != nil {
+			values, err := decodeCursorValues(columns, cursor.Values)
+			if err != nil {
+				return errInvalidCursor()
+			}
+
+			applyKeysetCondition(query, columns, values, backward)
+		}
+
+		for _, column := range columns {
+			spec := column.spec
+			if backward {
+				spec.Direction = reverseDirection(spec.Direction)
+			}
+
+			applyOrderSpec(query, spec)
+		}
+
+		// Fetch one extra row to detect whether another page exists in the scan direction.
+		if err := query.Limit(pageable.Size + 1).Scan(ctx.Context()); err != nil {
+			return err
+		}
+
+		hasMore := len(models) > pageable.Size
+		if hasMore {
+			models = models[:pageable.Size]
+		}
+
+		if backward {
+			slices.Reverse(models)
+		}
+
+		if len(models) > 0 {
+			// Boundary cursors are built before transformation so that they hold raw column values.
+			hasNext, hasPrev := hasMore, cursor != nil
+			if backward {
+				hasNext, hasPrev = true, hasMore
+			}
+
+			if hasNext {
+				if cp.NextCursor, err = encodeCursor(codec, key, columns, &models[len(models)-1], false); err != nil {
+					return err
+				}
+			}
+
+			if hasPrev {
+				if cp.PrevCursor, err = encodeCursor(codec, key, columns, &models[0], true); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := streams.Range(0, len(models)).ForEachErr(func(i int) error {
+			return transformer.Struct(ctx.Context(), &models[i])
+		}); err != nil {
+			return err
+		}
+
+		if models == nil {
+			models = []TModel{}
+		}
+
+		processed := a.Process(models, search, ctx)
+
+		rv := reflect.Indirect(reflect.ValueOf(processed))
+		if rv.Kind() != reflect.Slice {
+			return result.Err(
+				i18n.T(ErrMessageProcessorMustReturnSlice, map[string]any{"type": reflect.TypeOf(processed).String()}),
+				result.WithCode(ErrCodeProcessorInvalidReturn),
+				result.WithStatus(fiber.StatusInternalServerError),
+			)
+		}
+
+		cp.Items = make([]any, rv.Len())
+		for i := range cp.Items {
+			cp.Items[i] = rv.Index(i).Interface()
+		}
+
+		return result.Ok(cp).Response(ctx)
+	}, nil
+}
+
+// setupCursorColumns resolves the keyset columns: the default sort (or primary key descending)
+// followed by any primary key columns not already included.
+// Columns that may hold NULL are rejected, since NULL never satisfies the keyset comparison
+// and the rows holding it would silently drop out of the pages.
+func (a *findCursorOperation[TModel, TSearch]) setupCursorColumns(table *orm.Table) ([]cursorColumn, error) {
+	if len(table.PKs) == 0 {
+		return nil, ErrModelNoPrimaryKey
+	}
+
+	specs := make([]sortx.OrderSpec, 0, len(a.defaultSort)+len(table.PKs))
+	for _, spec := range a.defaultSort {
+		if spec.IsValid() {
+			specs = append(specs, *spec)
+		}
+	}
+
+	direction := sortx.OrderDesc
+	if len(specs) > 0 {
+		direction = specs[len(specs)-1].Direction
+	}
+
+	for _, pk := range table.PKs {
+		if !slices.ContainsFunc(specs, func(spec sortx.OrderSpec) bool { return columnName(spec.Column) == pk.Name }) {
+			specs = append(specs, sortx.OrderSpec{Column: pk.Name, Direction: direction})
+		}
+	}
+
+	columns := make([]cursorColumn, len(specs))
+	for i, spec := range specs {
+		field, ok := table.FieldMap[columnName(spec.Column)]
+		if !ok {
+			return nil, fmt.Errorf("%w: cursor sort column %q of %s", ErrColumnNotFound, spec.Column, table.TypeName)
+		}
+
+		if !field.NotNull || field.NullZero || field.IsPtr {
+			return nil, fmt.Errorf("%w: cursor sort column %q of %s", ErrCursorColumnNullable, spec.Column, table.TypeName)
+		}
+
+		columns[i] = cursorColumn{spec: spec, field: field}
+	}
+
+	return columns, nil
+}
+
+// applyKeysetCondition restricts the query to rows after (or before, when backward) the cursor row:
+// (c1 > v1) OR (c1 = v1 AND c2 > v2) OR ..., with the comparison flipped for descending columns.
+// The disjunction is grouped so that it is ANDed with the query's other conditions.
+func applyKeysetCondition(query orm.SelectQuery, columns []cursorColumn, values []any, backward bool) {
+	query.Where(func(cb orm.ConditionBuilder) {
+		cb.Group(func(keyset orm.ConditionBuilder) {
+			for i := range columns {
+				keyset.OrGroup(func(group orm.ConditionBuilder) {
+					for j := range i {
+						group.Equals(columns[j].spec.Column, values[j])
+					}
+
+					if (columns[i].spec.Direction == sortx.OrderAsc) != backward {
+						group.GreaterThan(columns[i].spec.Column, values[i])
+					} else {
+						group.LessThan(columns[i].spec.Column, values[i])
+					}
+				})
+			}
+		})
+	})
+}
+
+func encodeCursor(codec page.CursorCodec, key string, columns []cursorColumn, model any, backward bool) (string, error) {
+	strct := reflect.Indirect(reflect.ValueOf(model))
+	values := make([]json.RawMessage, len(columns))
+
+	for i, column := range columns {
+		value, err := json.Marshal(column.field.Value(strct).Interface())
+		if err != nil {
+			return "", fmt.Errorf("failed to encode cursor column %q: %w", column.spec.Column, err)
+		}
+
+		values[i] = value
+	}
+
+	return codec.Encode(&page.Cursor{Key: key, Values: values, Backward: backward})
+}
+
+// decodeCursorValues converts cursor values back into the Go types of their model fields.
+func decodeCursorValues(columns []cursorColumn, raw []json.RawMessage) ([]any, error) {
+	values := make([]any, len(columns))
+
+	for i, column := range columns {
+		value := reflect.New(column.field.StructField.Type)
+		if err := json.Unmarshal(raw[i], value.Interface()); err != nil {
+			return nil, err
+		}
+
+		values[i] = value.Elem().Interface()
+	}
+
+	return values, nil
+}
+
+// cursorKey identifies the ordering so that cursors are rejected after the sort definition changes.
+func cursorKey(columns []cursorColumn) string {
+	var sb strings.Builder
+	for i, column := range columns {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+
+		sb.WriteString(column.spec.Column)
+		sb.WriteByte(' ')
+		sb.WriteString(column.spec.Direction.String())
+	}
+
+	return sb.String()
+}
+
+// columnName strips an optional table alias from a column reference.
+func columnName(column string) string {
+	if idx := strings.LastIndexByte(column, '.'); idx >= 0 {
+		return column[idx+1:]
+	}
+
+	return column
+}
+
+func reverseDirection(direction sortx.OrderDirection) sortx.OrderDirection {
+	if direction == sortx.OrderAsc {
+		return sortx.OrderDesc
+	}
+
+	return sortx.OrderAsc
+}
+
+func errInvalidCursor() error {
+	return result.Err(
+		i18n.T(ErrMessageInvalidCursor),
+		result.WithCode(ErrCodeInvalidCursor),
+		result.WithStatus(fiber.StatusBadRequest),
+	)
+}
@@ -0,0 +1,293 @@
+package crud_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/crud"
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/page"
+	"github.com/coldsmirk/vef-framework-go/sortx"
+)
+
+func init() {
+	registry.Add(func(env *testx.DBEnv) suite.TestingSuite {
+		return &FindCursorTestSuite{
+			BaseTestSuite: BaseTestSuite{
+				ctx:   env.Ctx,
+				db:    env.DB,
+				bunDB: env.BunDB,
+				ds:    env.DS,
+			},
+		}
+	})
+}
+
+// Test Resources.
+type EmployeeFindCursorResource struct {
+	api.Resource
+	crud.FindCursor[Employee, EmployeeSearch]
+}
+
+func NewEmployeeFindCursorResource() api.Resource {
+	return &EmployeeFindCursorResource{
+		Resource:   api.NewRPCResource("test/employee_cursor"),
+		FindCursor: crud.NewFindCursor[Employee, EmployeeSearch]().WithCondition(fixtureScope).WithTotal().Public(),
+	}
+}
+
+// SortedEmployeeFindCursorResource - keyset built from a non-unique default sort column.
+type SortedEmployeeFindCursorResource struct {
+	api.Resource
+	crud.FindCursor[Employee, EmployeeSearch]
+}
+
+func NewSortedEmployeeFindCursorResource() api.Resource {
+	return &SortedEmployeeFindCursorResource{
+		Resource: api.NewRPCResource("test/employee_cursor_sorted"),
+		FindCursor: crud.NewFindCursor[Employee, EmployeeSearch]().
+			WithCondition(fixtureScope).
+			WithDefaultSort(&sortx.OrderSpec{Column: "age", Direction: sortx.OrderAsc}).
+			WithDefaultPageSize(4).
+			Public(),
+	}
+}
+
+// FindCursorTestSuite tests the FindCursor API functionality
+// including forward and backward traversal, non-unique sort columns, total counting and invalid cursors.
+type FindCursorTestSuite struct {
+	BaseTestSuite
+}
+
+// SetupSuite runs once before all tests in the suite.
+func (suite *FindCursorTestSuite) SetupSuite() {
+	suite.setupBaseSuite(
+		NewEmployeeFindCursorResource,
+		NewSortedEmployeeFindCursorResource,
+	)
+}
+
+// TearDownSuite runs once after all tests in the suite.
+func (suite *FindCursorTestSuite) TearDownSuite() {
+	suite.tearDownBaseSuite()
+}
+
+// fetchCursorPage requests one cursor page and returns the decoded page.
+func (suite *FindCursorTestSuite) fetchCursorPage(resource string, meta, params map[string]any) map[string]any {
+	resp := suite.MakeRPCRequest(api.Request{
+		Identifier: api.Identifier{
+			Resource: resource,
+			Action:   "find_cursor",
+			Version:  "v1",
+		},
+		Meta:   meta,
+		Params: params,
+	})
+
+	suite.Require().Equal(200, resp.StatusCode, "Should return 200 status code")
+	body := suite.ReadResult(resp)
+	suite.Require().True(body.IsOk(), "Should return successful response: %s", body.Message)
+
+	return suite.ReadDataAsMap(body.Data)
+}
+
+// collectIDs returns the ids of the page items in order.
+func (suite *FindCursorTestSuite) collectIDs(page map[string]any) []string {
+	items := suite.ReadDataAsSlice(page["items"])
+	ids := make([]string, len(items))
+
+	for i, item := range items {
+		ids[i] = suite.ReadDataAsMap(item)["id"].(string)
+	}
+
+	return ids
+}
+
+// TestFindCursorBasic tests the first page of a cursor query.
+func (suite *FindCursorTestSuite) TestFindCursorBasic() {
+	suite.T().Logf("Testing FindCursor API basic functionality for %s", suite.ds.Kind)
+
+	page := suite.fetchCursorPage("test/employee_cursor", map[string]any{"size": 5}, nil)
+
+	suite.Equal(float64(25), page["total"], "Total should be 25 when counting is enabled")
+	suite.Equal(float64(5), page["size"], "Size should be 5")
+	suite.Len(suite.ReadDataAsSlice(page["items"]), 5, "Should return 5 items on first page")
+	suite.NotEmpty(page["nextCursor"], "First page should have a next cursor")
+	suite.Nil(page["prevCursor"], "First page should not have a previous cursor")
+}
+
+// TestFindCursorTraversal tests walking all pages forward and then backward.
+func (suite *FindCursorTestSuite) TestFindCursorTraversal() {
+	suite.T().Logf("Testing FindCursor API traversal for %s", suite.ds.Kind)
+
+	var (
+		pages  [][]string
+		cursor string
+		seen   = make(map[string]bool)
+		last   map[string]any
+	)
+
+	for {
+		meta := map[string]any{"size": 7}
+		if cursor != "" {
+			meta["cursor"] = cursor
+		}
+
+		last = suite.fetchCursorPage("test/employee_cursor", meta, nil)
+		ids := suite.collectIDs(last)
+		pages = append(pages, ids)
+
+		for _, id := range ids {
+			suite.False(seen[id], "Item %s should not appear on more than one page", id)
+			seen[id] = true
+		}
+
+		next, ok := last["nextCursor"].(string)
+		if !ok {
+			break
+		}
+
+		suite.Require().Less(len(pages), 10, "Traversal should terminate")
+		cursor = next
+	}
+
+	suite.Len(pages, 4, "25 items with size 7 should span 4 pages")
+	suite.Len(seen, 25, "Forward traversal should visit every item exactly once")
+	suite.Len(pages[3], 4, "Last page should contain the remaining 4 items")
+
+	suite.Run("Backward", func() {
+		cursor := last["prevCursor"].(string)
+		for i := len(pages) - 2; i >= 0; i-- {
+			page := suite.fetchCursorPage("test/employee_cursor", map[string]any{"size": 7, "cursor": cursor}, nil)
+			suite.Equal(pages[i], suite.collectIDs(page), "Backward page %d should match the forward page", i)
+			suite.NotEmpty(page["nextCursor"], "Backward pages should always have a next cursor")
+
+			prev, ok := page["prevCursor"].(string)
+			if i == 0 {
+				suite.False(ok, "First page reached backward should not have a previous cursor")
+			} else {
+				suite.Require().True(ok, "Intermediate page should have a previous cursor")
+				cursor = prev
+			}
+		}
+	})
+}
+
+// TestFindCursorNonUniqueSort tests that ties on the sort column are broken by the primary key.
+func (suite *FindCursorTestSuite) TestFindCursorNonUniqueSort() {
+	suite.T().Logf("Testing FindCursor API with non-unique sort for %s", suite.ds.Kind)
+
+	var (
+		ages   []float64
+		cursor string
+		seen   = make(map[string]bool)
+	)
+
+	for range 10 {
+		meta := map[string]any{}
+		if cursor != "" {
+			meta["cursor"] = cursor
+		}
+
+		page := suite.fetchCursorPage("test/employee_cursor_sorted", meta, map[string]any{"status": "active"})
+		suite.Nil(page["total"], "Total should be omitted when counting is disabled")
+
+		for _, item := range suite.ReadDataAsSlice(page["items"]) {
+			employee := suite.ReadDataAsMap(item)
+			id := employee["id"].(string)
+			suite.False(seen[id], "Item %s should not appear on more than one page", id)
+			seen[id] = true
+			ages = append(ages, employee["age"].(float64))
+			suite.Equal("active", employee["status"], "Keyset condition should not bypass the search filter")
+		}
+
+		next, ok := page["nextCursor"].(string)
+		if !ok {
+			break
+		}
+
+		cursor = next
+	}
+
+	suite.Len(seen, 18, "Should visit all 18 active employees")
+	suite.IsNonDecreasing(ages, "Items should be ordered by age ascending across pages")
+}
+
+// TestFindCursorInvalid tests that malformed, tampered and foreign cursors are rejected.
+func (suite *FindCursorTestSuite) TestFindCursorInvalid() {
+	suite.T().Logf("Testing FindCursor API with invalid cursors for %s", suite.ds.Kind)
+
+	first := suite.fetchCursorPage("test/employee_cursor", map[string]any{"size": 5}, nil)
+	valid := first["nextCursor"].(string)
+
+	cases := []struct {
+		name     string
+		resource string
+		cursor   string
+	}{
+		{"Malformed", "test/employee_cursor", "not-a-cursor"},
+		{"Tampered", "test/employee_cursor", valid[:len(valid)-2] + "xx"},
+		{"DifferentOrdering", "test/employee_cursor_sorted", valid},
+	}
+
+	for _, tc := range cases {
+		suite.Run(tc.name, func() {
+			resp := suite.MakeRPCRequest(api.Request{
+				Identifier: api.Identifier{
+					Resource: tc.resource,
+					Action:   "find_cursor",
+					Version:  "v1",
+				},
+				Meta: map[string]any{"cursor": tc.cursor},
+			})
+
+			suite.Equal(400, resp.StatusCode, "Should return 400 status code")
+			body := suite.ReadResult(resp)
+			suite.False(body.IsOk(), "Should return error response")
+			suite.Equal(crud.ErrCodeInvalidCursor, body.Code, "Should return invalid cursor error code")
+		})
+	}
+}
+
+// TestSetupErrFindCursor covers FindCursor factory errors for invalid keyset definitions.
+func TestSetupErrFindCursor(t *testing.T) {
+	db := testx.NewTestDB(t)
+	codec := page.NewCursorCodec([]byte("secret"))
+
+	callFactory := func(specs []api.OperationSpec) error {
+		require.Len(t, specs, 1, "Should return exactly 1 operation spec")
+
+		results := reflect.ValueOf(specs[0].Handler).Call([]reflect.Value{reflect.ValueOf(db), reflect.ValueOf(codec)})
+		if err, ok := results[1].Interface().(error); ok {
+			return err
+		}
+
+		return nil
+	}
+
+	t.Run("NoPrimaryKey", func(t *testing.T) {
+		err := callFactory(crud.NewFindCursor[NoPKModel, struct{}]().Public().Provide())
+		assert.ErrorIs(t, err, crud.ErrModelNoPrimaryKey, "Should return ErrModelNoPrimaryKey")
+	})
+
+	t.Run("UnknownSortColumn", func(t *testing.T) {
+		err := callFactory(crud.NewFindCursor[Employee, EmployeeSearch]().
+			WithDefaultSort(&sortx.OrderSpec{Column: "missing", Direction: sortx.OrderAsc}).
+			Public().
+			Provide())
+		assert.ErrorIs(t, err, crud.ErrColumnNotFound, "Should return ErrColumnNotFound")
+	})
+
+	t.Run("NullableSortColumn", func(t *testing.T) {
+		err := callFactory(crud.NewFindCursor[Employee, EmployeeSearch]().
+			WithDefaultSort(&sortx.OrderSpec{Column: "description", Direction: sortx.OrderAsc}).
+			Public().
+			Provide())
+		assert.ErrorIs(t, err, crud.ErrCursorColumnNullable, "Should return ErrCursorColumnNullable")
+	})
+}
@@ -21,7 +21,7 @@ type FindOperationConfig struct {
 type QueryPartsConfig struct {
 	// Condition specifies which queries apply WHERE clause filtering
 	Condition []QueryPart
-	// Sort specifies which queries apply ORDER BY sorting
+	// Sort specifies which queries apply ORDER BY sorting, an empty non-nil slice disables it
 	Sort []QueryPart
 	// AuditUserRelation specifies which queries auto-join audit user relations (created_by, updated_by)
 	AuditUserRelation []QueryPart
@@ -114,39 +114,13 @@ func withSort(specs []*sortx.OrderSpec, parts ...QueryPart) *FindOperationOption
 				return err
 			}
 
-			applyOrderSpec := func(spec sortx.OrderSpec) {
-				if !spec.IsValid() {
-					return
-				}
-
-				query.OrderByExpr(func(eb orm.ExprBuilder) any {
-					return eb.Order(func(ob orm.OrderBuilder) {
-						ob.Column(spec.Column)
-
-						switch spec.Direction {
-						case sortx.OrderAsc:
-							ob.Asc()
-						case sortx.OrderDesc:
-							ob.Desc()
-						}
-
-						switch spec.NullsOrder {
-						case sortx.NullsFirst:
-							ob.NullsFirst()
-						case sortx.NullsLast:
-							ob.NullsLast()
-						}
-					})
-				})
-			}
-
 			if len(sortable.Sort) > 0 {
 				for _, spec := range sortable.Sort {
-					applyOrderSpec(spec)
+					applyOrderSpec(query, spec)
 				}
 			} else {
 				for _, spec := range specs {
-					applyOrderSpec(*spec)
+					applyOrderSpec(query, *spec)
 				}
 			}
 
@@ -155,6 +129,33 @@ func withSort(specs []*sortx.OrderSpec, parts ...QueryPart) *FindOperationOption
 	}
 }
 
+// applyOrderSpec appends a single ORDER BY item, skipping invalid specs.
+func applyOrderSpec(query orm.SelectQuery, spec sortx.OrderSpec) {
+	if !spec.IsValid() {
+		return
+	}
+
+	query.OrderByExpr(func(eb orm.ExprBuilder) any {
+		return eb.Order(func(ob orm.OrderBuilder) {
+			ob.Column(spec.Column)
+
+			switch spec.Direction {
+			case sortx.OrderAsc:
+				ob.Asc()
+			case sortx.OrderDesc:
+				ob.Desc()
+			}
+
+			switch spec.NullsOrder {
+			case sortx.NullsFirst:
+				ob.NullsFirst()
+			case sortx.NullsLast:
+				ob.NullsLast()
+			}
+		})
+	})
+}
+
 // withCondition adds a WHERE condition using ConditionBuilder.
 // Applies to root query only by default (QueryRoot).
 // This is useful for adding simple filtering conditions.
@@ -2,8 +2,10 @@ package crud
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/uptrace/bun/schema"
 
 	"github.com/coldsmirk/vef-framework-go/contextx"
+	"github.com/coldsmirk/vef-framework-go/copier"
 	"github.com/coldsmirk/vef-framework-go/i18n"
 	"github.com/coldsmirk/vef-framework-go/orm"
 	"github.com/coldsmirk/vef-framework-go/result"
@@ -85,6 +88,28 @@ func ApplyDataPermission(query orm.SelectQuery, ctx fiber.Ctx) error {
 	return nil
 }
 
+// ApplyUpdateDataPermission applies data permission filtering to an UpdateQuery.
+func ApplyUpdateDataPermission(query orm.UpdateQuery, ctx fiber.Ctx) error {
+	if applier := contextx.DataPermApplier(ctx); applier != nil {
+		if err := applier.ApplyUpdate(query); err != nil {
+			return fmt.Errorf("failed to apply data permission: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ApplyDeleteDataPermission applies data permission filtering to a DeleteQuery.
+func ApplyDeleteDataPermission(query orm.DeleteQuery, ctx fiber.Ctx) error {
+	if applier := contextx.DataPermApplier(ctx); applier != nil {
+		if err := applier.ApplyDelete(query); err != nil {
+			return fmt.Errorf("failed to apply data permission: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // GetAuditUserNameRelations returns RelationSpecs for creator and updater joins.
 func GetAuditUserNameRelations(userModel any, nameColumn ...string) []*orm.RelationSpec {
 	nc := defaultAuditUserNameColumn
@@ -172,6 +197,61 @@ func buildMetaJSONExpr(eb orm.ExprBuilder, metaColumns []orm.ColumnInfo) schema.
 	return eb.JSONObject(jsonArgs...)
 }
 
+// translateUpdateError maps an optimistic locking conflict reported by the ORM to its result error.
+func translateUpdateError(err error) error {
+	if errors.Is(err, orm.ErrVersionConflict) {
+		return result.ErrVersionConflict
+	}
+
+	return err
+}
+
+// mergeModel copies the non-empty fields of model onto oldModel. A version the params did not carry keeps the
+// version loaded into oldModel, so the update is checked against the stored row instead of version 0.
+func mergeModel[TModel any](model, oldModel *TModel, version *schema.Field) error {
+	var loaded reflect.Value
+	if version != nil {
+		loaded = reflect.ValueOf(version.Value(reflect.ValueOf(oldModel).Elem()).Interface())
+	}
+
+	if err := copier.Copy(model, oldModel, copier.WithIgnoreEmpty()); err != nil {
+		return err
+	}
+
+	if version != nil && version.Value(reflect.ValueOf(model).Elem()).IsZero() {
+		version.Value(reflect.ValueOf(oldModel).Elem()).Set(loaded)
+	}
+
+	return nil
+}
+
+// checkRowsAffected reports result.ErrRecordNotFound when a write statement matched fewer rows than it targeted,
+// which happens when the data scope pushed into the statement excludes some of the records.
+// Some drivers (MySQL) only report changed rows, so when fewer rows were affected and countMatched is set,
+// it is asked how many of the targeted records the scope matches, telling unchanged rows from excluded ones.
+func checkRowsAffected(res sql.Result, expected int, countMatched func() (int64, error)) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected >= int64(expected) {
+		return nil
+	}
+
+	if countMatched != nil {
+		if affected, err = countMatched(); err != nil {
+			return err
+		}
+
+		if affected >= int64(expected) {
+			return nil
+		}
+	}
+
+	return result.ErrRecordNotFound
+}
+
 // withCleanup attempts a cleanup when err is non-nil.
 // Returns the original error, optionally wrapped with the cleanup error.
 func withCleanup(err error, cleanup func() error) error {
@@ -16,6 +16,7 @@ import (
 
 	"github.com/coldsmirk/vef-framework-go/contextx"
 	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/result"
 )
 
 // MockDataPermApplier implements security.DataPermissionApplier for testing.
@@ -27,6 +28,14 @@ func (m *MockDataPermApplier) Apply(orm.SelectQuery) error {
 	return m.err
 }
 
+func (m *MockDataPermApplier) ApplyUpdate(orm.UpdateQuery) error {
+	return m.err
+}
+
+func (m *MockDataPermApplier) ApplyDelete(orm.DeleteQuery) error {
+	return m.err
+}
+
 // TestApplyDataPermissionError covers helpers.go:88-91 - DataPermApplier returns error.
 func TestApplyDataPermissionError(t *testing.T) {
 	app := fiber.New()
@@ -410,3 +419,49 @@ func TestBatchRollbackZeroCount(t *testing.T) {
 	err := batchRollback(context.Background(), promoter, nil, nil, 0)
 	assert.NoError(t, err, "Should succeed with zero count (no rollback needed)")
 }
+
+// rowsAffectedResult implements sql.Result with a fixed affected row count.
+type rowsAffectedResult struct {
+	affected int64
+	err      error
+}
+
+func (rowsAffectedResult) LastInsertId() (int64, error)   { return 0, nil }
+func (r rowsAffectedResult) RowsAffected() (int64, error) { return r.affected, r.err }
+
+// TestCheckRowsAffected covers checkRowsAffected - rows excluded by the data scope.
+func TestCheckRowsAffected(t *testing.T) {
+	countMatched := func(matched int64, err error) func() (int64, error) {
+		return func() (int64, error) { return matched, err }
+	}
+
+	t.Run("AllRowsAffected", func(t *testing.T) {
+		assert.NoError(t, checkRowsAffected(rowsAffectedResult{affected: 2}, 2, nil), "Should succeed when all rows were written")
+	})
+
+	t.Run("RowsOutOfScope", func(t *testing.T) {
+		err := checkRowsAffected(rowsAffectedResult{affected: 1}, 2, nil)
+		assert.ErrorIs(t, err, result.ErrRecordNotFound, "Should report rows the statement did not reach")
+	})
+
+	t.Run("RowsUnchanged", func(t *testing.T) {
+		err := checkRowsAffected(rowsAffectedResult{affected: 1}, 2, countMatched(2, nil))
+		assert.NoError(t, err, "Should succeed when the unchanged rows are matched by the scope")
+	})
+
+	t.Run("RowsUnchangedOutOfScope", func(t *testing.T) {
+		err := checkRowsAffected(rowsAffectedResult{affected: 0}, 2, countMatched(1, nil))
+		assert.ErrorIs(t, err, result.ErrRecordNotFound, "Should report rows the scope does not match")
+	})
+
+	t.Run("DriverError", func(t *testing.T) {
+		expectedErr := errors.New("rows affected unsupported")
+		assert.ErrorIs(t, checkRowsAffected(rowsAffectedResult{err: expectedErr}, 2, nil), expectedErr, "Should return the driver error")
+	})
+
+	t.Run("CountError", func(t *testing.T) {
+		expectedErr := errors.New("count failed")
+		err := checkRowsAffected(rowsAffectedResult{affected: 1}, 2, countMatched(0, expectedErr))
+		assert.ErrorIs(t, err, expectedErr, "Should return the count error")
+	})
+}
@@ -28,6 +28,14 @@ type DeleteManyParams struct {
 	PKs []any `json:"pks" validate:"required,min=1" label_i18n:"batch_delete_pks"`
 }
 
+// RestoreManyParams is a wrapper type for batch restore parameters.
+// PKs follow the same format as DeleteManyParams.
+type RestoreManyParams struct {
+	api.P
+
+	PKs []any `json:"pks" validate:"required,min=1" label_i18n:"batch_restore_pks"`
+}
+
 // Sortable provides sorting capability for API search parameters.
 type Sortable struct {
 	api.M
@@ -0,0 +1,97 @@
+package crud
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/coldsmirk/vef-framework-go/orm"
+)
+
+// patchPlan maps request param keys to the model columns they update.
+// It is resolved once per operation so that each request only needs key lookups.
+type patchPlan struct {
+	fields map[string]*orm.Field
+	// auditColumns are the update audit and version columns of the model, written along with every patch.
+	auditColumns []string
+}
+
+// newPatchPlan matches the params fields of paramsType to the updatable model fields of table by Go field name.
+// Request keys follow the params decoder: the camel-cased json tag, or the field name when untagged.
+// Primary keys, skipupdate and scanonly fields are never patched.
+func newPatchPlan(table *orm.Table, paramsType reflect.Type) *patchPlan {
+	modelFields := make(map[string]*orm.Field, len(table.Fields))
+	for _, field := range table.Fields {
+		if !field.IsPK && !field.SkipUpdate() {
+			modelFields[field.GoName] = field
+		}
+	}
+
+	plan := &patchPlan{
+		fields: make(map[string]*orm.Field),
+		auditColumns: lo.Filter([]string{orm.ColumnUpdatedAt, orm.ColumnUpdatedBy, orm.ColumnVersion}, func(column string, _ int) bool {
+			return table.HasField(column)
+		}),
+	}
+
+	for _, sf := range reflect.VisibleFields(paramsType) {
+		if sf.Anonymous || !sf.IsExported() {
+			continue
+		}
+
+		field, ok := modelFields[sf.Name]
+		if !ok {
+			continue
+		}
+
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+
+		plan.fields[lo.CamelCase(name)] = field
+	}
+
+	return plan
+}
+
+// resolve returns the model fields whose keys are present in the raw request params, including explicit nulls.
+func (p *patchPlan) resolve(raw map[string]any) []*orm.Field {
+	fields := make([]*orm.Field, 0, len(raw))
+	for key := range raw {
+		if field, ok := p.fields[key]; ok {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// apply copies exactly the given fields from src to dst, including zero values.
+func (*patchPlan) apply(fields []*orm.Field, src, dst reflect.Value) {
+	for _, field := range fields {
+		field.Value(dst).Set(field.Value(src))
+	}
+}
+
+// columns returns the columns a patch of the given fields writes: the fields themselves and the update audit columns.
+// Bulk updates fix their SET list from this selection, so the version column is carried along as well.
+func (p *patchPlan) columns(fields []*orm.Field) []string {
+	return append(patchColumns(fields), p.auditColumns...)
+}
+
+// patchColumns returns the sorted column names of the given fields without duplicates.
+func patchColumns(fields []*orm.Field) []string {
+	columns := lo.Uniq(lo.Map(fields, func(field *orm.Field, _ int) string {
+		return field.Name
+	}))
+	slices.Sort(columns)
+
+	return columns
+}
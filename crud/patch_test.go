@@ -0,0 +1,84 @@
+package crud
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/orm"
+)
+
+// PatchTestModel is a model with primary key, audit and scan-only fields.
+type PatchTestModel struct {
+	bun.BaseModel `bun:"table:patch_test_models"`
+	orm.FullAuditedModel
+
+	Title   string  `bun:"title"`
+	Note    *string `bun:"note"`
+	Counter int     `bun:"counter"`
+	Label   string  `bun:",scanonly"`
+}
+
+// PatchTestParams covers tagged, untagged, ignored and non-model fields.
+type PatchTestParams struct {
+	api.P
+
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	Note      *string `json:"note"`
+	Counter   int
+	Label     string `json:"label"`
+	CreatedBy string `json:"-"`
+	Extra     string `json:"extra"`
+}
+
+func patchTestPlan() *patchPlan {
+	db := bun.NewDB(nil, sqlitedialect.New())
+
+	return newPatchPlan(db.Table(reflect.TypeFor[PatchTestModel]()), reflect.TypeFor[PatchTestParams]())
+}
+
+// TestNewPatchPlan tests which request keys map to updatable columns.
+func TestNewPatchPlan(t *testing.T) {
+	plan := patchTestPlan()
+
+	assert.ElementsMatch(t, []string{"title", "note", "counter"}, lo.Keys(plan.fields), "Should only map updatable model fields")
+	assert.Equal(t, "counter", plan.fields["counter"].Name, "Untagged field should use the camel-cased field name")
+}
+
+// TestPatchPlanResolve tests that only present keys are resolved, including explicit nulls.
+func TestPatchPlanResolve(t *testing.T) {
+	plan := patchTestPlan()
+
+	fields := plan.resolve(map[string]any{"id": "1", "note": nil, "counter": 0, "extra": "x"})
+	assert.Equal(t, []string{"counter", "note"}, patchColumns(fields), "Should resolve present updatable keys only")
+	assert.Empty(t, plan.resolve(map[string]any{"id": "1"}), "Should resolve nothing without updatable keys")
+}
+
+// TestPatchPlanApply tests that present fields are copied including zero values and nulls.
+func TestPatchPlanApply(t *testing.T) {
+	plan := patchTestPlan()
+	note := "old"
+
+	src := PatchTestModel{Title: "new"}
+	dst := PatchTestModel{Title: "old", Note: &note, Counter: 5}
+
+	plan.apply(plan.resolve(map[string]any{"note": nil, "counter": 0}), reflect.ValueOf(&src).Elem(), reflect.ValueOf(&dst).Elem())
+
+	assert.Nil(t, dst.Note, "Explicit null should clear the field")
+	assert.Equal(t, 0, dst.Counter, "Zero value should be applied")
+	assert.Equal(t, "old", dst.Title, "Absent field should keep its value")
+}
+
+// TestPatchPlanColumns tests that a patch writes the present columns along with the update audit columns.
+func TestPatchPlanColumns(t *testing.T) {
+	plan := patchTestPlan()
+
+	columns := plan.columns(plan.resolve(map[string]any{"note": nil, "title": "x"}))
+	assert.Equal(t, []string{"note", "title", orm.ColumnUpdatedAt, orm.ColumnUpdatedBy}, columns, "Should append the update audit columns")
+}
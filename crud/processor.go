@@ -27,13 +27,21 @@ type PreUpdateProcessor[TModel, TParams any] func(oldModel, model *TModel, param
 type PostUpdateProcessor[TModel, TParams any] func(oldModel, model *TModel, params *TParams, ctx fiber.Ctx, tx orm.DB) error
 
 // PreDeleteProcessor handles validation and checks before model deletion.
-// Runs within the same transaction. Common uses: referential integrity checks, soft delete logic.
+// Runs within the same transaction. Common uses: referential integrity checks, cascading soft deletes.
 type PreDeleteProcessor[TModel any] func(model *TModel, query orm.DeleteQuery, ctx fiber.Ctx, tx orm.DB) error
 
 // PostDeleteProcessor handles cleanup tasks after successful deletion.
 // Runs within the same transaction. Uses: cascade operations, audit logging.
 type PostDeleteProcessor[TModel any] func(model *TModel, ctx fiber.Ctx, tx orm.DB) error
 
+// PreRestoreProcessor handles validation and checks before a soft-deleted model is restored.
+// Runs within the same transaction. Common uses: uniqueness checks against live rows.
+type PreRestoreProcessor[TModel any] func(model *TModel, query orm.UpdateQuery, ctx fiber.Ctx, tx orm.DB) error
+
+// PostRestoreProcessor handles side effects after successful restoration.
+// Runs within the same transaction. Uses: restoring dependent rows, audit logging.
+type PostRestoreProcessor[TModel any] func(model *TModel, ctx fiber.Ctx, tx orm.DB) error
+
 // PreCreateManyProcessor handles business logic before batch model creation.
 // Runs within the same transaction. Common uses: batch validation, default values, related data setup.
 type PreCreateManyProcessor[TModel, TParams any] func(models []TModel, paramsList []TParams, query orm.InsertQuery, ctx fiber.Ctx, tx orm.DB) error
@@ -51,13 +59,21 @@ type PreUpdateManyProcessor[TModel, TParams any] func(oldModels, models []TModel
 type PostUpdateManyProcessor[TModel, TParams any] func(oldModels, models []TModel, paramsList []TParams, ctx fiber.Ctx, tx orm.DB) error
 
 // PreDeleteManyProcessor handles validation and checks before batch model deletion.
-// Runs within the same transaction. Common uses: referential integrity checks, soft delete logic.
+// Runs within the same transaction. Common uses: referential integrity checks, cascading soft deletes.
 type PreDeleteManyProcessor[TModel any] func(models []TModel, query orm.DeleteQuery, ctx fiber.Ctx, tx orm.DB) error
 
 // PostDeleteManyProcessor handles cleanup tasks after successful batch deletion.
 // Runs within the same transaction. Uses: cascade operations, audit logging.
 type PostDeleteManyProcessor[TModel any] func(models []TModel, ctx fiber.Ctx, tx orm.DB) error
 
+// PreRestoreManyProcessor handles validation and checks before soft-deleted models are restored in batch.
+// Runs within the same transaction. Common uses: uniqueness checks against live rows.
+type PreRestoreManyProcessor[TModel any] func(models []TModel, query orm.UpdateQuery, ctx fiber.Ctx, tx orm.DB) error
+
+// PostRestoreManyProcessor handles side effects after successful batch restoration.
+// Runs within the same transaction. Uses: restoring dependent rows, audit logging.
+type PostRestoreManyProcessor[TModel any] func(models []TModel, ctx fiber.Ctx, tx orm.DB) error
+
 // PreExportProcessor handles data modification before exporting to Excel.
 // Common uses: data formatting, field filtering, additional data loading.
 type PreExportProcessor[TModel, TSearch any] func(models []TModel, search TSearch, ctx fiber.Ctx, db orm.DB) error
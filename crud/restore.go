@@ -0,0 +1,162 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+// Restore provides a fluent interface for building endpoints that undo a soft delete.
+// The model must embed orm.SoftDeletableModel; only soft-deleted rows can be restored.
+type Restore[TModel any] interface {
+	api.OperationsProvider
+	Builder[Restore[TModel]]
+
+	// WithPreRestore registers a processor that is called before the model is restored.
+	WithPreRestore(processor PreRestoreProcessor[TModel]) Restore[TModel]
+	// WithPostRestore registers a processor that is called after the model is restored within the same transaction.
+	WithPostRestore(processor PostRestoreProcessor[TModel]) Restore[TModel]
+	// DisableDataPerm disables automatic data permission filtering for restore queries.
+	DisableDataPerm() Restore[TModel]
+}
+
+type restoreOperation[TModel any] struct {
+	Builder[Restore[TModel]]
+
+	preRestore       PreRestoreProcessor[TModel]
+	postRestore      PostRestoreProcessor[TModel]
+	dataPermDisabled bool
+}
+
+func (r *restoreOperation[TModel]) Provide() []api.OperationSpec {
+	return []api.OperationSpec{r.Build(r.restore)}
+}
+
+func (r *restoreOperation[TModel]) WithPreRestore(processor PreRestoreProcessor[TModel]) Restore[TModel] {
+	r.preRestore = processor
+
+	return r
+}
+
+func (r *restoreOperation[TModel]) WithPostRestore(processor PostRestoreProcessor[TModel]) Restore[TModel] {
+	r.postRestore = processor
+
+	return r
+}
+
+func (r *restoreOperation[TModel]) DisableDataPerm() Restore[TModel] {
+	r.dataPermDisabled = true
+
+	return r
+}
+
+func (r *restoreOperation[TModel]) restore(db orm.DB) (func(ctx fiber.Ctx, db orm.DB, params api.Params) error, error) {
+	schema := db.TableOf((*TModel)(nil))
+	pks := db.ModelPKFields((*TModel)(nil))
+
+	if len(pks) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrModelNoPrimaryKey, schema.Name)
+	}
+
+	if schema.SoftDeleteField == nil {
+		return nil, fmt.Errorf("%w: %s", ErrModelNotSoftDeletable, schema.Name)
+	}
+
+	return func(ctx fiber.Ctx, db orm.DB, params api.Params) error {
+		var (
+			model      TModel
+			modelValue = reflect.ValueOf(&model).Elem()
+		)
+
+		for _, pk := range pks {
+			value, ok := params[pk.Name]
+			if !ok {
+				return result.Err(i18n.T("primary_key_required", map[string]any{"field": pk.Name}))
+			}
+
+			if err := pk.Set(modelValue, value); err != nil {
+				return err
+			}
+		}
+
+		query := db.Primary().NewSelect().Model(&model).OnlyDeleted().WherePK()
+		if !r.dataPermDisabled {
+			if err := ApplyDataPermission(query, ctx); err != nil {
+				return err
+			}
+		}
+
+		if err := query.Scan(ctx.Context(), &model); err != nil {
+			return err
+		}
+
+		pkValue, err := modelPKValue(pks, &model)
+		if err != nil {
+			return err
+		}
+
+		return db.RunInTX(ctx.Context(), func(txCtx context.Context, tx orm.DB) error {
+			query := newRestoreQuery(tx, schema, (*TModel)(nil))
+			if r.preRestore != nil {
+				if err := r.preRestore(&model, query, ctx, tx); err != nil {
+					return err
+				}
+			}
+
+			if _, err := query.Where(func(cb orm.ConditionBuilder) {
+				cb.PKEquals(pkValue)
+			}).Exec(txCtx); err != nil {
+				return err
+			}
+
+			if r.postRestore != nil {
+				if err := r.postRestore(&model, ctx, tx); err != nil {
+					return err
+				}
+			}
+
+			return result.Ok().Response(ctx)
+		})
+	}, nil
+}
+
+// newRestoreQuery builds an UPDATE that clears the soft delete columns of soft-deleted rows.
+func newRestoreQuery(tx orm.DB, schema *orm.Table, model any) orm.UpdateQuery {
+	query := tx.NewUpdate().
+		Model(model).
+		Set(schema.SoftDeleteField.Name, nil).
+		OnlyDeleted()
+
+	if schema.HasField(orm.ColumnDeletedBy) {
+		query.Set(orm.ColumnDeletedBy, nil)
+	}
+
+	return query
+}
+
+// modelPKValue returns the primary key of a model in the form accepted by PK conditions:
+// the plain value for single primary keys and a slice of values for composite primary keys.
+func modelPKValue(pks []*orm.PKField, model any) (any, error) {
+	if len(pks) == 1 {
+		return pks[0].Value(model)
+	}
+
+	values := make([]any, len(pks))
+	for i, pk := range pks {
+		value, err := pk.Value(model)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,148 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+// RestoreMany provides a fluent interface for building batch endpoints that undo a soft delete.
+// Restores multiple soft-deleted models atomically with validation and pre/post hooks.
+type RestoreMany[TModel any] interface {
+	api.OperationsProvider
+	Builder[RestoreMany[TModel]]
+
+	// WithPreRestoreMany registers a processor that is called before the models are restored.
+	WithPreRestoreMany(processor PreRestoreManyProcessor[TModel]) RestoreMany[TModel]
+	// WithPostRestoreMany registers a processor that is called after the models are restored within the same transaction.
+	WithPostRestoreMany(processor PostRestoreManyProcessor[TModel]) RestoreMany[TModel]
+	// DisableDataPerm disables automatic data permission filtering for batch restore queries.
+	DisableDataPerm() RestoreMany[TModel]
+}
+
+type restoreManyOperation[TModel any] struct {
+	Builder[RestoreMany[TModel]]
+
+	preRestoreMany   PreRestoreManyProcessor[TModel]
+	postRestoreMany  PostRestoreManyProcessor[TModel]
+	dataPermDisabled bool
+}
+
+func (r *restoreManyOperation[TModel]) Provide() []api.OperationSpec {
+	return []api.OperationSpec{r.Build(r.restoreMany)}
+}
+
+func (r *restoreManyOperation[TModel]) WithPreRestoreMany(processor PreRestoreManyProcessor[TModel]) RestoreMany[TModel] {
+	r.preRestoreMany = processor
+
+	return r
+}
+
+func (r *restoreManyOperation[TModel]) WithPostRestoreMany(processor PostRestoreManyProcessor[TModel]) RestoreMany[TModel] {
+	r.postRestoreMany = processor
+
+	return r
+}
+
+func (r *restoreManyOperation[TModel]) DisableDataPerm() RestoreMany[TModel] {
+	r.dataPermDisabled = true
+
+	return r
+}
+
+func (r *restoreManyOperation[TModel]) restoreMany(db orm.DB) (func(ctx fiber.Ctx, db orm.DB, params RestoreManyParams) error, error) {
+	schema := db.TableOf((*TModel)(nil))
+	pks := db.ModelPKFields((*TModel)(nil))
+
+	if len(pks) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrModelNoPrimaryKey, schema.Name)
+	}
+
+	if schema.SoftDeleteField == nil {
+		return nil, fmt.Errorf("%w: %s", ErrModelNotSoftDeletable, schema.Name)
+	}
+
+	return func(ctx fiber.Ctx, db orm.DB, params RestoreManyParams) error {
+		if len(params.PKs) == 0 {
+			return result.Ok().Response(ctx)
+		}
+
+		var (
+			models   = make([]TModel, len(params.PKs))
+			pkValues = make([]any, len(params.PKs))
+		)
+
+		for i, pkValue := range params.PKs {
+			modelValue := reflect.ValueOf(&models[i]).Elem()
+
+			if pkMap, ok := pkValue.(map[string]any); ok {
+				for _, pk := range pks {
+					value, ok := pkMap[pk.Name]
+					if !ok {
+						return result.Err(i18n.T("primary_key_required", map[string]any{"field": pk.Name}))
+					}
+
+					if err := pk.Set(modelValue, value); err != nil {
+						return err
+					}
+				}
+			} else {
+				if len(pks) != 1 {
+					return result.Err(i18n.T("composite_primary_key_requires_map"))
+				}
+
+				if err := pks[0].Set(modelValue, pkValue); err != nil {
+					return err
+				}
+			}
+
+			query := db.Primary().NewSelect().Model(&models[i]).OnlyDeleted().WherePK()
+			if !r.dataPermDisabled {
+				if err := ApplyDataPermission(query, ctx); err != nil {
+					return err
+				}
+			}
+
+			if err := query.Scan(ctx.Context(), &models[i]); err != nil {
+				return err
+			}
+
+			value, err := modelPKValue(pks, &models[i])
+			if err != nil {
+				return err
+			}
+
+			pkValues[i] = value
+		}
+
+		return db.RunInTX(ctx.Context(), func(txCtx context.Context, tx orm.DB) error {
+			query := newRestoreQuery(tx, schema, (*TModel)(nil))
+			if r.preRestoreMany != nil {
+				if err := r.preRestoreMany(models, query, ctx, tx); err != nil {
+					return err
+				}
+			}
+
+			if _, err := query.Where(func(cb orm.ConditionBuilder) {
+				cb.PKIn(pkValues)
+			}).Exec(txCtx); err != nil {
+				return err
+			}
+
+			if r.postRestoreMany != nil {
+				if err := r.postRestoreMany(models, ctx, tx); err != nil {
+					return err
+				}
+			}
+
+			return result.Ok().Response(ctx)
+		})
+	}, nil
+}
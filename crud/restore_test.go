@@ -0,0 +1,224 @@
+package crud_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/crud"
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/internal/orm"
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+func init() {
+	registry.Add(func(env *testx.DBEnv) suite.TestingSuite {
+		return &RestoreTestSuite{
+			BaseTestSuite: BaseTestSuite{
+				ctx:   env.Ctx,
+				db:    env.DB,
+				bunDB: env.BunDB,
+				ds:    env.DS,
+			},
+		}
+	})
+}
+
+// Memo is a soft deletable test model.
+type Memo struct {
+	bun.BaseModel `bun:"table:test_memo,alias:tm"`
+	orm.FullAuditedModel
+	orm.SoftDeletableModel
+
+	Title string `json:"title" bun:",notnull"`
+}
+
+// MemoSearch is the search parameters for Memo.
+type MemoSearch struct {
+	api.P
+}
+
+// MemoResource exposes delete, restore and find operations for Memo.
+type MemoResource struct {
+	api.Resource
+	crud.Delete[Memo]
+	crud.DeleteMany[Memo]
+	crud.Restore[Memo]
+	crud.RestoreMany[Memo]
+	crud.FindAll[Memo, MemoSearch]
+}
+
+func NewMemoResource() api.Resource {
+	return &MemoResource{
+		Resource:    api.NewRPCResource("test/memo"),
+		Delete:      crud.NewDelete[Memo]().Public(),
+		DeleteMany:  crud.NewDeleteMany[Memo]().Public(),
+		Restore:     crud.NewRestore[Memo]().Public(),
+		RestoreMany: crud.NewRestoreMany[Memo]().Public(),
+		FindAll:     crud.NewFindAll[Memo, MemoSearch]().Public(),
+	}
+}
+
+// PurgeMemoResource permanently deletes memos.
+type PurgeMemoResource struct {
+	api.Resource
+	crud.Delete[Memo]
+}
+
+func NewPurgeMemoResource() api.Resource {
+	return &PurgeMemoResource{
+		Resource: api.NewRPCResource("test/memo_purge"),
+		Delete:   crud.NewDelete[Memo]().ForceDelete().Public(),
+	}
+}
+
+// RestoreTestSuite tests soft delete through the Delete API and the Restore/RestoreMany APIs.
+type RestoreTestSuite struct {
+	BaseTestSuite
+}
+
+// SetupSuite runs once before all tests in the suite.
+func (suite *RestoreTestSuite) SetupSuite() {
+	suite.db.RegisterModel((*Memo)(nil))
+	suite.Require().NoError(suite.db.ResetModel(suite.ctx, (*Memo)(nil)), "Should create memo table")
+
+	suite.setupBaseSuite(
+		NewMemoResource,
+		NewPurgeMemoResource,
+	)
+}
+
+// TearDownSuite runs once after all tests in the suite.
+func (suite *RestoreTestSuite) TearDownSuite() {
+	suite.tearDownBaseSuite()
+
+	_, _ = suite.db.NewDropTable().Model((*Memo)(nil)).IfExists().Exec(suite.ctx)
+}
+
+// SetupTest inserts isolated memos before each test method.
+func (suite *RestoreTestSuite) SetupTest() {
+	memos := make([]Memo, 3)
+	for i := range memos {
+		memos[i].ID = fmt.Sprintf("memo%03d", i+1)
+		memos[i].Title = fmt.Sprintf("Memo %d", i+1)
+	}
+
+	_, err := suite.db.NewInsert().Model(&memos).Exec(suite.ctx)
+	suite.Require().NoError(err, "Failed to insert test memos")
+}
+
+// TearDownTest purges all memos after each test method.
+func (suite *RestoreTestSuite) TearDownTest() {
+	_, _ = suite.db.NewDelete().Model((*Memo)(nil)).ForceDelete().Where(func(cb orm.ConditionBuilder) {
+		cb.IsNotNull("id")
+	}).Exec(suite.ctx)
+}
+
+// call invokes an action on a memo resource and returns the decoded result.
+func (suite *RestoreTestSuite) call(resource, action string, params map[string]any) result.Result {
+	resp := suite.MakeRPCRequest(api.Request{
+		Identifier: api.Identifier{
+			Resource: resource,
+			Action:   action,
+			Version:  "v1",
+		},
+		Params: params,
+	})
+
+	suite.Require().Equal(200, resp.StatusCode, "Should return 200 status code")
+
+	return suite.ReadResult(resp)
+}
+
+// visibleMemos returns the number of memos returned by find_all.
+func (suite *RestoreTestSuite) visibleMemos() int {
+	body := suite.call("test/memo", "find_all", nil)
+	suite.Require().True(body.IsOk(), "find_all should succeed")
+
+	return len(suite.ReadDataAsSlice(body.Data))
+}
+
+// TestDeleteAndRestore tests that deleted memos are hidden and can be restored.
+func (suite *RestoreTestSuite) TestDeleteAndRestore() {
+	suite.T().Logf("Testing soft delete and restore for %s", suite.ds.Kind)
+
+	body := suite.call("test/memo", "delete", map[string]any{"id": "memo001"})
+	suite.True(body.IsOk(), "Delete should succeed")
+	suite.Equal(2, suite.visibleMemos(), "Soft-deleted memo should be hidden from find_all")
+
+	count, err := suite.db.NewSelect().Model((*Memo)(nil)).OnlyDeleted().Count(suite.ctx)
+	suite.NoError(err, "Should count soft-deleted memos")
+	suite.Equal(int64(1), count, "Deleted memo should still exist as soft-deleted")
+
+	body = suite.call("test/memo", "delete", map[string]any{"id": "memo001"})
+	suite.False(body.IsOk(), "Deleting an already deleted memo should fail")
+	suite.Equal(i18n.T(result.ErrMessageRecordNotFound), body.Message, "Should return record not found message")
+
+	body = suite.call("test/memo", "restore", map[string]any{"id": "memo001"})
+	suite.True(body.IsOk(), "Restore should succeed")
+	suite.Equal(3, suite.visibleMemos(), "Restored memo should be visible again")
+
+	var memo Memo
+	suite.Require().NoError(suite.db.NewSelect().Model(&memo).Where(func(cb orm.ConditionBuilder) {
+		cb.PKEquals("memo001")
+	}).Scan(suite.ctx), "Should load the restored memo")
+	suite.Nil(memo.DeletedAt, "deleted_at should be cleared")
+	suite.Nil(memo.DeletedBy, "deleted_by should be cleared")
+}
+
+// TestRestoreNotDeleted tests that restoring a live memo is rejected.
+func (suite *RestoreTestSuite) TestRestoreNotDeleted() {
+	suite.T().Logf("Testing restore of a live memo for %s", suite.ds.Kind)
+
+	body := suite.call("test/memo", "restore", map[string]any{"id": "memo002"})
+	suite.False(body.IsOk(), "Restoring a live memo should fail")
+	suite.Equal(i18n.T(result.ErrMessageRecordNotFound), body.Message, "Should return record not found message")
+}
+
+// TestDeleteManyAndRestoreMany tests batch soft delete and restore.
+func (suite *RestoreTestSuite) TestDeleteManyAndRestoreMany() {
+	suite.T().Logf("Testing batch soft delete and restore for %s", suite.ds.Kind)
+
+	body := suite.call("test/memo", "delete_many", map[string]any{"pks": []any{"memo001", "memo002"}})
+	suite.True(body.IsOk(), "DeleteMany should succeed")
+	suite.Equal(1, suite.visibleMemos(), "Soft-deleted memos should be hidden")
+
+	body = suite.call("test/memo", "restore_many", map[string]any{"pks": []any{"memo001", "memo002"}})
+	suite.True(body.IsOk(), "RestoreMany should succeed")
+	suite.Equal(3, suite.visibleMemos(), "Restored memos should be visible again")
+}
+
+// TestForceDelete tests that ForceDelete purges live and soft-deleted memos.
+func (suite *RestoreTestSuite) TestForceDelete() {
+	suite.T().Logf("Testing force delete for %s", suite.ds.Kind)
+
+	body := suite.call("test/memo", "delete", map[string]any{"id": "memo001"})
+	suite.True(body.IsOk(), "Soft delete should succeed")
+
+	for _, id := range []string{"memo001", "memo002"} {
+		body = suite.call("test/memo_purge", "delete", map[string]any{"id": id})
+		suite.True(body.IsOk(), "Force delete of %s should succeed", id)
+	}
+
+	count, err := suite.db.NewSelect().Model((*Memo)(nil)).WithDeleted().Count(suite.ctx)
+	suite.NoError(err, "Should count all memos")
+	suite.Equal(int64(1), count, "Force-deleted memos should be removed permanently")
+}
+
+// TestSetupErrRestoreNotSoftDeletable tests that Restore requires a soft deletable model.
+func TestSetupErrRestoreNotSoftDeletable(t *testing.T) {
+	db := testx.NewTestDB(t)
+
+	for _, specs := range [][]api.OperationSpec{
+		crud.NewRestore[Employee]().Public().Provide(),
+		crud.NewRestoreMany[Employee]().Public().Provide(),
+	} {
+		err := callHandlerFactory(t, specs[0].Handler, db)
+		assert.ErrorIs(t, err, crud.ErrModelNotSoftDeletable, "Should return ErrModelNotSoftDeletable")
+	}
+}
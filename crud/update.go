@@ -28,6 +28,9 @@ type Update[TModel, TParams any] interface {
 	WithPostUpdate(processor PostUpdateProcessor[TModel, TParams]) Update[TModel, TParams]
 	// DisableDataPerm disables automatic data permission filtering for update queries.
 	DisableDataPerm() Update[TModel, TParams]
+	// EnablePatch switches to JSON Merge Patch semantics: only the params present in the request are applied,
+	// including zero values and explicit nulls, and the UPDATE only sets those columns.
+	EnablePatch() Update[TModel, TParams]
 }
 
 type updateOperation[TModel, TParams any] struct {
@@ -36,6 +39,7 @@ type updateOperation[TModel, TParams any] struct {
 	preUpdate        PreUpdateProcessor[TModel, TParams]
 	postUpdate       PostUpdateProcessor[TModel, TParams]
 	dataPermDisabled bool
+	patch            bool
 }
 
 func (u *updateOperation[TModel, TParams]) Provide() []api.OperationSpec {
@@ -60,7 +64,13 @@ func (u *updateOperation[TModel, TParams]) DisableDataPerm() Update[TModel, TPar
 	return u
 }
 
-func (u *updateOperation[TModel, TParams]) update(db orm.DB, sc storage.Service, publisher event.Publisher) (func(ctx fiber.Ctx, db orm.DB, params TParams) error, error) {
+func (u *updateOperation[TModel, TParams]) EnablePatch() Update[TModel, TParams] {
+	u.patch = true
+
+	return u
+}
+
+func (u *updateOperation[TModel, TParams]) update(db orm.DB, sc storage.Service, publisher event.Publisher) (func(ctx fiber.Ctx, db orm.DB, params TParams, rawParams api.Params) error, error) {
 	promoter := storage.NewPromoter[TModel](sc, publisher)
 	schema := db.TableOf((*TModel)(nil))
 	pks := db.ModelPKFields((*TModel)(nil))
@@ -69,7 +79,14 @@ func (u *updateOperation[TModel, TParams]) update(db orm.DB, sc storage.Service,
 		return nil, fmt.Errorf("%w: %s", ErrModelNoPrimaryKey, schema.Name)
 	}
 
-	return func(ctx fiber.Ctx, db orm.DB, params TParams) error {
+	version := schema.LookupField(orm.ColumnVersion)
+
+	var plan *patchPlan
+	if u.patch {
+		plan = newPatchPlan(schema, reflect.TypeFor[TParams]())
+	}
+
+	return func(ctx fiber.Ctx, db orm.DB, params TParams, rawParams api.Params) error {
 		var (
 			oldModel   TModel
 			model      TModel
@@ -91,7 +108,8 @@ func (u *updateOperation[TModel, TParams]) update(db orm.DB, sc storage.Service,
 			}
 		}
 
-		query := db.NewSelect().Model(&model).WherePK()
+		// The row is loaded from the primary so that a lagging replica cannot hand back a stale version.
+		query := db.Primary().NewSelect().Model(&model).WherePK()
 		if !u.dataPermDisabled {
 			if err := ApplyDataPermission(query, ctx); err != nil {
 				return err
@@ -102,6 +120,13 @@ func (u *updateOperation[TModel, TParams]) update(db orm.DB, sc storage.Service,
 			return err
 		}
 
+		var patchFields []*orm.Field
+		if plan != nil {
+			if patchFields = plan.resolve(rawParams); len(patchFields) == 0 {
+				return result.Ok().Response(ctx)
+			}
+		}
+
 		return db.RunInTX(ctx.Context(), func(txCtx context.Context, tx orm.DB) error {
 			rollback := func() error { return promoter.Promote(txCtx, &model, &oldModel) }
 
@@ -112,7 +137,10 @@ func (u *updateOperation[TModel, TParams]) update(db orm.DB, sc storage.Service,
 				}
 			}
 
-			if err := copier.Copy(&model, &oldModel, copier.WithIgnoreEmpty()); err != nil {
+			if plan != nil {
+				plan.apply(patchFields, modelValue, reflect.ValueOf(&oldModel).Elem())
+				query.Select(plan.columns(patchFields)...)
+			} else if err := mergeModel(&model, &oldModel, version); err != nil {
 				return err
 			}
 
@@ -121,7 +149,7 @@ func (u *updateOperation[TModel, TParams]) update(db orm.DB, sc storage.Service,
 			}
 
 			if _, err := query.WherePK().Exec(txCtx); err != nil {
-				return withCleanup(err, rollback)
+				return withCleanup(translateUpdateError(err), rollback)
 			}
 
 			if u.postUpdate != nil {
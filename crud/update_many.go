@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	"github.com/gofiber/fiber/v3"
+	"github.com/samber/lo"
 
 	"github.com/coldsmirk/vef-framework-go/api"
 	"github.com/coldsmirk/vef-framework-go/copier"
@@ -28,6 +29,9 @@ type UpdateMany[TModel, TParams any] interface {
 	WithPostUpdateMany(processor PostUpdateManyProcessor[TModel, TParams]) UpdateMany[TModel, TParams]
 	// DisableDataPerm disables automatic data permission filtering for batch update queries.
 	DisableDataPerm() UpdateMany[TModel, TParams]
+	// EnablePatch switches to JSON Merge Patch semantics: each item only applies the params present in the request,
+	// including zero values and explicit nulls, and the UPDATE only sets columns present in at least one item.
+	EnablePatch() UpdateMany[TModel, TParams]
 }
 
 type updateManyOperation[TModel, TParams any] struct {
@@ -36,6 +40,7 @@ type updateManyOperation[TModel, TParams any] struct {
 	preUpdateMany    PreUpdateManyProcessor[TModel, TParams]
 	postUpdateMany   PostUpdateManyProcessor[TModel, TParams]
 	dataPermDisabled bool
+	patch            bool
 }
 
 func (u *updateManyOperation[TModel, TParams]) Provide() []api.OperationSpec {
@@ -60,7 +65,13 @@ func (u *updateManyOperation[TModel, TParams]) DisableDataPerm() UpdateMany[TMod
 	return u
 }
 
-func (u *updateManyOperation[TModel, TParams]) updateMany(db orm.DB, sc storage.Service, publisher event.Publisher) (func(ctx fiber.Ctx, db orm.DB, params UpdateManyParams[TParams]) error, error) {
+func (u *updateManyOperation[TModel, TParams]) EnablePatch() UpdateMany[TModel, TParams] {
+	u.patch = true
+
+	return u
+}
+
+func (u *updateManyOperation[TModel, TParams]) updateMany(db orm.DB, sc storage.Service, publisher event.Publisher) (func(ctx fiber.Ctx, db orm.DB, params UpdateManyParams[TParams], rawParams api.Params) error, error) {
 	promoter := storage.NewPromoter[TModel](sc, publisher)
 	schema := db.TableOf((*TModel)(nil))
 	pks := db.ModelPKFields((*TModel)(nil))
@@ -69,11 +80,35 @@ func (u *updateManyOperation[TModel, TParams]) updateMany(db orm.DB, sc storage.
 		return nil, fmt.Errorf("%w: %s", ErrModelNoPrimaryKey, schema.Name)
 	}
 
-	return func(ctx fiber.Ctx, db orm.DB, params UpdateManyParams[TParams]) error {
+	version := schema.LookupField(orm.ColumnVersion)
+
+	var plan *patchPlan
+	if u.patch {
+		plan = newPatchPlan(schema, reflect.TypeFor[TParams]())
+	}
+
+	return func(ctx fiber.Ctx, db orm.DB, params UpdateManyParams[TParams], rawParams api.Params) error {
 		if len(params.List) == 0 {
 			return result.Ok().Response(ctx)
 		}
 
+		var patchFields [][]*orm.Field
+		if plan != nil {
+			items, _ := rawParams["list"].([]any)
+			patchFields = make([][]*orm.Field, len(params.List))
+
+			for i := range patchFields {
+				if i < len(items) {
+					item, _ := items[i].(map[string]any)
+					patchFields[i] = plan.resolve(item)
+				}
+			}
+
+			if len(lo.Flatten(patchFields)) == 0 {
+				return result.Ok().Response(ctx)
+			}
+		}
+
 		oldModels := make([]TModel, len(params.List))
 		models := make([]TModel, len(params.List))
 
@@ -94,7 +129,7 @@ func (u *updateManyOperation[TModel, TParams]) updateMany(db orm.DB, sc storage.
 				}
 			}
 
-			query := db.NewSelect().Model(&models[i]).WherePK()
+			query := db.Primary().NewSelect().Model(&models[i]).WherePK()
 			if !u.dataPermDisabled {
 				if err := ApplyDataPermission(query, ctx); err != nil {
 					return err
@@ -111,6 +146,11 @@ func (u *updateManyOperation[TModel, TParams]) updateMany(db orm.DB, sc storage.
 			rollback := func() error { return batchRollback(txCtx, promoter, oldModels, models, n) }
 
 			query := tx.NewUpdate().Model(&oldModels)
+			if !u.dataPermDisabled {
+				if err := ApplyUpdateDataPermission(query, ctx); err != nil {
+					return err
+				}
+			}
 
 			if u.preUpdateMany != nil {
 				if err := u.preUpdateMany(oldModels, models, params.List, query, ctx, tx); err != nil {
@@ -118,9 +158,25 @@ func (u *updateManyOperation[TModel, TParams]) updateMany(db orm.DB, sc storage.
 				}
 			}
 
-			for i := range models {
-				if err := copier.Copy(&models[i], &oldModels[i], copier.WithIgnoreEmpty()); err != nil {
-					return err
+			if plan != nil {
+				for i := range models {
+					plan.apply(patchFields[i], reflect.ValueOf(&models[i]).Elem(), reflect.ValueOf(&oldModels[i]).Elem())
+				}
+
+				// Items that omit a column still write their current value, so the shared SET list stays correct.
+				query.Select(plan.columns(lo.Flatten(patchFields))...)
+
+				// Bulk rows are written from the model values, so the operator is bound before Bulk builds them.
+				if schema.HasField(orm.ColumnUpdatedBy) {
+					query.ColumnExpr(orm.ColumnUpdatedBy, func(eb orm.ExprBuilder) any {
+						return eb.Expr(orm.ExprOperator)
+					})
+				}
+			} else {
+				for i := range models {
+					if err := mergeModel(&models[i], &oldModels[i], version); err != nil {
+						return err
+					}
 				}
 			}
 
@@ -132,7 +188,23 @@ func (u *updateManyOperation[TModel, TParams]) updateMany(db orm.DB, sc storage.
 				}
 			}
 
-			if _, err := query.Bulk().Exec(txCtx); err != nil {
+			res, err := query.Bulk().Exec(txCtx)
+			if err != nil {
+				return withCleanup(translateUpdateError(err), rollback)
+			}
+
+			countMatched := func() (int64, error) {
+				query := tx.NewSelect().Model(&oldModels).WherePK()
+				if !u.dataPermDisabled {
+					if err := ApplyDataPermission(query, ctx); err != nil {
+						return 0, err
+					}
+				}
+
+				return query.Count(txCtx)
+			}
+
+			if err := checkRowsAffected(res, n, countMatched); err != nil {
 				return withCleanup(err, rollback)
 			}
 
@@ -133,6 +133,19 @@ func NewEmployeeUpdateManyPostHookErrorResource() api.Resource {
 	}
 }
 
+// Resource with EnablePatch.
+type EmployeeUpdateManyPatchResource struct {
+	api.Resource
+	crud.UpdateMany[Employee, EmployeePatchParams]
+}
+
+func NewEmployeeUpdateManyPatchResource() api.Resource {
+	return &EmployeeUpdateManyPatchResource{
+		Resource:   api.NewRPCResource("test/employee_update_many_patch"),
+		UpdateMany: crud.NewUpdateMany[Employee, EmployeePatchParams]().EnablePatch().Public(),
+	}
+}
+
 // UpdateManyTestSuite tests the UpdateMany API functionality
 // including basic batch update, PreUpdateMany/PostUpdateMany hooks, negative cases, transaction rollback, and partial updates.
 type UpdateManyTestSuite struct {
@@ -150,6 +163,7 @@ func (suite *UpdateManyTestSuite) SetupSuite() {
 		NewEmployeeUpdateManyNoPermResource,
 		NewEmployeeUpdateManyPreHookErrorResource,
 		NewEmployeeUpdateManyPostHookErrorResource,
+		NewEmployeeUpdateManyPatchResource,
 	)
 }
 
@@ -712,3 +726,37 @@ func (suite *UpdateManyTestSuite) TestUpdateManyPostHookError() {
 
 	suite.T().Logf("UpdateMany failed as expected due to post-hook error")
 }
+
+// TestUpdateManyPatch tests that EnablePatch applies only the params present in each item.
+func (suite *UpdateManyTestSuite) TestUpdateManyPatch() {
+	suite.T().Logf("Testing UpdateMany API patch semantics for %s", suite.ds.Kind)
+
+	resp := suite.MakeRPCRequest(api.Request{
+		Identifier: api.Identifier{
+			Resource: "test/employee_update_many_patch",
+			Action:   "update_many",
+			Version:  "v1",
+		},
+		Params: map[string]any{
+			"list": []any{
+				map[string]any{"id": "um_emp001", "age": 0},
+				map[string]any{"id": "um_emp002", "name": "UM Bob Patched"},
+			},
+		},
+	})
+
+	suite.Equal(200, resp.StatusCode, "Should return 200 status code")
+	body := suite.ReadResult(resp)
+	suite.True(body.IsOk(), "Should return successful response")
+
+	var employees []Employee
+	suite.Require().NoError(suite.db.NewSelect().Model(&employees).Where(func(cb orm.ConditionBuilder) {
+		cb.PKIn([]string{"um_emp001", "um_emp002"})
+	}).OrderBy("id").Scan(suite.ctx), "Should load the patched employees")
+	suite.Require().Len(employees, 2, "Should load both patched employees")
+
+	suite.Equal(0, employees[0].Age, "Present zero value should be applied")
+	suite.Equal("UM Alice", employees[0].Name, "Column patched only in another item should keep its value")
+	suite.Equal("UM Bob Patched", employees[1].Name, "Present field should be applied")
+	suite.Equal(25, employees[1].Age, "Column patched only in another item should keep its value")
+}
@@ -136,6 +136,29 @@ func NewEmployeeUpdatePostHookErrorResource() api.Resource {
 	}
 }
 
+// EmployeePatchParams is the patch parameters for Employee; every field except the ID is optional.
+type EmployeePatchParams struct {
+	api.P
+
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Age         int    `json:"age"`
+}
+
+// Resource with EnablePatch.
+type EmployeeUpdatePatchResource struct {
+	api.Resource
+	crud.Update[Employee, EmployeePatchParams]
+}
+
+func NewEmployeeUpdatePatchResource() api.Resource {
+	return &EmployeeUpdatePatchResource{
+		Resource: api.NewRPCResource("test/employee_update_patch"),
+		Update:   crud.NewUpdate[Employee, EmployeePatchParams]().EnablePatch().Public(),
+	}
+}
+
 // UpdateTestSuite tests the Update API functionality.
 type UpdateTestSuite struct {
 	BaseTestSuite
@@ -151,6 +174,7 @@ func (suite *UpdateTestSuite) SetupSuite() {
 		NewEmployeeUpdateNoPermResource,
 		NewEmployeeUpdatePreHookErrorResource,
 		NewEmployeeUpdatePostHookErrorResource,
+		NewEmployeeUpdatePatchResource,
 	)
 }
 
@@ -493,3 +517,51 @@ func (suite *UpdateTestSuite) TestUpdatePostHookError() {
 
 	suite.T().Logf("Update failed as expected due to post-hook error")
 }
+
+// TestUpdatePatch tests that EnablePatch applies exactly the params present in the request, including zero values.
+func (suite *UpdateTestSuite) TestUpdatePatch() {
+	suite.T().Logf("Testing Update API patch semantics for %s", suite.ds.Kind)
+
+	patch := func(params map[string]any) {
+		resp := suite.MakeRPCRequest(api.Request{
+			Identifier: api.Identifier{
+				Resource: "test/employee_update_patch",
+				Action:   "update",
+				Version:  "v1",
+			},
+			Params: params,
+		})
+
+		suite.Equal(200, resp.StatusCode, "Should return 200 status code")
+		body := suite.ReadResult(resp)
+		suite.True(body.IsOk(), "Should return successful response")
+	}
+
+	load := func() Employee {
+		var employee Employee
+		suite.Require().NoError(suite.db.NewSelect().Model(&employee).Where(func(cb orm.ConditionBuilder) {
+			cb.PKEquals("ut_emp001")
+		}).Scan(suite.ctx), "Should load the patched employee")
+
+		return employee
+	}
+
+	patch(map[string]any{"id": "ut_emp001", "description": "Temporary note"})
+
+	employee := load()
+	suite.Equal("Temporary note", employee.Description, "Present field should be applied")
+	suite.Equal("UT Alice", employee.Name, "Absent field should keep its value")
+	suite.Equal(30, employee.Age, "Absent field should keep its value")
+
+	patch(map[string]any{"id": "ut_emp001", "description": "", "age": 0})
+
+	employee = load()
+	suite.Empty(employee.Description, "Present empty string should clear the field")
+	suite.Equal(0, employee.Age, "Present zero value should be applied")
+	suite.Equal("ut_alice@test.com", employee.Email, "Fields outside the params should keep their value")
+
+	suite.Run("NoPatchableFields", func() {
+		patch(map[string]any{"id": "ut_emp001"})
+		suite.Equal(0, load().Age, "Request without patchable fields should not change the record")
+	})
+}
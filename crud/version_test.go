@@ -0,0 +1,166 @@
+package crud_test
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/crud"
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/internal/orm"
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+func init() {
+	registry.Add(func(env *testx.DBEnv) suite.TestingSuite {
+		return &VersionTestSuite{
+			BaseTestSuite: BaseTestSuite{
+				ctx:   env.Ctx,
+				db:    env.DB,
+				bunDB: env.BunDB,
+				ds:    env.DS,
+			},
+		}
+	})
+}
+
+// Article is a versioned test model.
+type Article struct {
+	bun.BaseModel `bun:"table:test_article,alias:ta"`
+	orm.FullAuditedModel
+	orm.VersionedModel
+
+	Title string `json:"title" bun:",notnull"`
+}
+
+// ArticleParams is the update parameters for Article.
+type ArticleParams struct {
+	api.P
+
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Version int64  `json:"version"`
+}
+
+// ArticleResource exposes update operations for Article.
+type ArticleResource struct {
+	api.Resource
+	crud.Update[Article, ArticleParams]
+	crud.UpdateMany[Article, ArticleParams]
+}
+
+func NewArticleResource() api.Resource {
+	return &ArticleResource{
+		Resource:   api.NewRPCResource("test/article"),
+		Update:     crud.NewUpdate[Article, ArticleParams]().Public(),
+		UpdateMany: crud.NewUpdateMany[Article, ArticleParams]().Public(),
+	}
+}
+
+// VersionTestSuite tests optimistic locking through the Update and UpdateMany APIs.
+type VersionTestSuite struct {
+	BaseTestSuite
+}
+
+// SetupSuite runs once before all tests in the suite.
+func (suite *VersionTestSuite) SetupSuite() {
+	suite.db.RegisterModel((*Article)(nil))
+	suite.Require().NoError(suite.db.ResetModel(suite.ctx, (*Article)(nil)), "Should create article table")
+
+	suite.setupBaseSuite(NewArticleResource)
+}
+
+// TearDownSuite runs once after all tests in the suite.
+func (suite *VersionTestSuite) TearDownSuite() {
+	suite.tearDownBaseSuite()
+
+	_, _ = suite.db.NewDropTable().Model((*Article)(nil)).IfExists().Exec(suite.ctx)
+}
+
+// SetupTest inserts isolated articles before each test method.
+func (suite *VersionTestSuite) SetupTest() {
+	articles := []Article{
+		{FullAuditedModel: orm.FullAuditedModel{ID: "article001"}, Title: "Article 1"},
+		{FullAuditedModel: orm.FullAuditedModel{ID: "article002"}, Title: "Article 2"},
+	}
+
+	_, err := suite.db.NewInsert().Model(&articles).Exec(suite.ctx)
+	suite.Require().NoError(err, "Failed to insert test articles")
+}
+
+// TearDownTest removes all articles after each test method.
+func (suite *VersionTestSuite) TearDownTest() {
+	_, _ = suite.db.NewDelete().Model((*Article)(nil)).Where(func(cb orm.ConditionBuilder) {
+		cb.IsNotNull("id")
+	}).Exec(suite.ctx)
+}
+
+// call invokes an action on the article resource and returns the decoded result.
+func (suite *VersionTestSuite) call(action string, params map[string]any) result.Result {
+	resp := suite.MakeRPCRequest(api.Request{
+		Identifier: api.Identifier{
+			Resource: "test/article",
+			Action:   action,
+			Version:  "v1",
+		},
+		Params: params,
+	})
+
+	suite.Require().Equal(200, resp.StatusCode, "Should return 200 status code")
+
+	return suite.ReadResult(resp)
+}
+
+// loadArticle reloads an article by primary key.
+func (suite *VersionTestSuite) loadArticle(id string) Article {
+	var article Article
+	suite.Require().NoError(suite.db.NewSelect().Model(&article).Where(func(cb orm.ConditionBuilder) {
+		cb.PKEquals(id)
+	}).Scan(suite.ctx), "Should load the article")
+
+	return article
+}
+
+// TestUpdateVersion tests that Update increments the version and rejects stale versions.
+func (suite *VersionTestSuite) TestUpdateVersion() {
+	suite.T().Logf("Testing update optimistic locking for %s", suite.ds.Kind)
+
+	body := suite.call("update", map[string]any{"id": "article001", "title": "Edited", "version": 1})
+	suite.True(body.IsOk(), "Update with the current version should succeed")
+	suite.Equal(int64(2), suite.loadArticle("article001").Version, "Version should be incremented")
+
+	body = suite.call("update", map[string]any{"id": "article001", "title": "Overwrite", "version": 1})
+	suite.False(body.IsOk(), "Update with an outdated version should fail")
+	suite.Equal(result.ErrCodeVersionConflict, body.Code, "Should return version conflict code")
+	suite.Equal(i18n.T(result.ErrMessageVersionConflict), body.Message, "Should return version conflict message")
+	suite.Equal("Edited", suite.loadArticle("article001").Title, "Stale update should not overwrite the article")
+
+	suite.Run("WithoutVersion", func() {
+		body := suite.call("update", map[string]any{"id": "article002", "title": "Edited"})
+		suite.True(body.IsOk(), "Update without a version should use the loaded version")
+		suite.Equal(int64(2), suite.loadArticle("article002").Version, "Version should be incremented")
+	})
+}
+
+// TestUpdateManyVersion tests that UpdateMany rejects the whole batch when any version is stale.
+func (suite *VersionTestSuite) TestUpdateManyVersion() {
+	suite.T().Logf("Testing update_many optimistic locking for %s", suite.ds.Kind)
+
+	body := suite.call("update_many", map[string]any{"list": []map[string]any{
+		{"id": "article001", "title": "Batch 1", "version": 1},
+		{"id": "article002", "title": "Batch 2", "version": 1},
+	}})
+	suite.True(body.IsOk(), "UpdateMany with current versions should succeed")
+
+	body = suite.call("update_many", map[string]any{"list": []map[string]any{
+		{"id": "article001", "title": "Stale 1", "version": 2},
+		{"id": "article002", "title": "Stale 2", "version": 1},
+	}})
+	suite.False(body.IsOk(), "UpdateMany with an outdated version should fail")
+	suite.Equal(result.ErrCodeVersionConflict, body.Code, "Should return version conflict code")
+
+	first := suite.loadArticle("article001")
+	suite.Equal("Batch 1", first.Title, "Conflicting batch should be rolled back")
+	suite.Equal(int64(2), first.Version, "Rolled back batch should keep the version")
+}
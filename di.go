@@ -1,11 +1,18 @@
 package vef
 
 import (
+	"io/fs"
+
 	"github.com/coldsmirk/go-streams"
 	"go.uber.org/fx"
 
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/database"
+	iorm "github.com/coldsmirk/vef-framework-go/internal/orm"
 	"github.com/coldsmirk/vef-framework-go/mcp"
 	"github.com/coldsmirk/vef-framework-go/middleware"
+	"github.com/coldsmirk/vef-framework-go/migration"
+	"github.com/coldsmirk/vef-framework-go/orm"
 )
 
 var (
@@ -133,6 +140,32 @@ func ProvideCQRSBehavior(constructor any, paramTags ...string) fx.Option {
 	)
 }
 
+// ProvideMetricsCollector provides a Prometheus collector to the dependency injection container.
+// The collector will be registered in the "vef:metrics:collectors" group and exposed at the metrics endpoint.
+// The constructor must return prometheus.Collector (not a concrete type).
+func ProvideMetricsCollector(constructor any, paramTags ...string) fx.Option {
+	return fx.Provide(
+		fx.Annotate(
+			constructor,
+			fx.ParamTags(paramTags...),
+			fx.ResultTags(`group:"vef:metrics:collectors"`),
+		),
+	)
+}
+
+// ProvideHealthIndicator provides a health indicator to the dependency injection container.
+// The indicator will be registered in the "vef:health:indicators" group and checked by the readiness endpoint.
+// The constructor must return monitor.HealthIndicator (not a concrete type).
+func ProvideHealthIndicator(constructor any, paramTags ...string) fx.Option {
+	return fx.Provide(
+		fx.Annotate(
+			constructor,
+			fx.ParamTags(paramTags...),
+			fx.ResultTags(`group:"vef:health:indicators"`),
+		),
+	)
+}
+
 // ProvideChallengeProvider provides a login challenge provider to the dependency injection container.
 // The provider will be registered in the "vef:security:challenge_providers" group.
 // The constructor must return security.ChallengeProvider (not a concrete type).
@@ -146,6 +179,18 @@ func ProvideChallengeProvider(constructor any, paramTags ...string) fx.Option {
 	)
 }
 
+// ProvideAPIKeyEncoder provides the encoder API key secrets are hashed with, instead of the default SHA-256.
+// The constructor must return password.Encoder (not a concrete type).
+func ProvideAPIKeyEncoder(constructor any, paramTags ...string) fx.Option {
+	return fx.Provide(
+		fx.Annotate(
+			constructor,
+			fx.ParamTags(paramTags...),
+			fx.ResultTags(`name:"vef:security:api_key_encoder"`),
+		),
+	)
+}
+
 // ProvideMCPTools provides an MCP tool provider.
 // The constructor must return mcp.ToolProvider (not a concrete type).
 func ProvideMCPTools(constructor any, paramTags ...string) fx.Option {
@@ -198,3 +243,47 @@ func ProvideMCPPrompts(constructor any, paramTags ...string) fx.Option {
 func SupplyMCPServerInfo(info *mcp.ServerInfo) fx.Option {
 	return fx.Supply(info)
 }
+
+// ProvideDataSource provides the named data source configured under vef.data_sources.<name>
+// as an orm.DB, including read replica routing when replicas are configured.
+// Inject it with the tag returned by DataSourceTag, e.g. vef.ParamTags(vef.DataSourceTag("report")).
+func ProvideDataSource(name string) fx.Option {
+	return fx.Provide(
+		fx.Annotate(
+			func(lc fx.Lifecycle, cfgs *config.DataSourcesConfig) (orm.DB, error) {
+				db, replicas, err := database.OpenNamed(lc, cfgs, name)
+				if err != nil {
+					return nil, err
+				}
+
+				return iorm.NewReplicated(db, replicas), nil
+			},
+			fx.ResultTags(DataSourceTag(name)),
+		),
+	)
+}
+
+// ProvideMigrationSource provides a migration source to the dependency injection container.
+// The source will be registered in the "vef:migration:sources" group.
+// The constructor must return migration.Source (not a concrete type).
+func ProvideMigrationSource(constructor any, paramTags ...string) fx.Option {
+	return fx.Provide(
+		fx.Annotate(
+			constructor,
+			fx.ParamTags(paramTags...),
+			fx.ResultTags(`group:"vef:migration:sources"`),
+		),
+	)
+}
+
+// SupplyMigrations registers the SQL migrations in dir of fsys, typically an embed.FS, as a migration source.
+func SupplyMigrations(fsys fs.FS, dir string) fx.Option {
+	return ProvideMigrationSource(func() migration.Source {
+		return migration.FS(fsys, dir)
+	})
+}
+
+// DataSourceTag returns the FX tag of the named data source provided by ProvideDataSource.
+func DataSourceTag(name string) string {
+	return `name:"vef:data_source:` + name + `"`
+}
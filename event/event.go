@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/coldsmirk/vef-framework-go/id"
+	"github.com/coldsmirk/vef-framework-go/tracing"
 )
 
 // Event represents the base interface for all events in the system.
@@ -117,6 +118,13 @@ func WithMeta(key, value string) BaseEventOption {
 	}
 }
 
+// WithTraceContext adds the trace context of ctx to the metadata, so the handlers of the event continue the trace.
+func WithTraceContext(ctx context.Context) BaseEventOption {
+	return func(e *BaseEvent) {
+		tracing.Inject(ctx, e.meta)
+	}
+}
+
 // MarshalJSON implements custom JSON marshaling for BaseEvent.
 func (e BaseEvent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
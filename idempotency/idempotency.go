@@ -0,0 +1,17 @@
+package idempotency
+
+// Record is the state stored for an idempotency key.
+// A record is pending while the original request is in flight and completed once
+// its response has been captured for replay.
+type Record struct {
+	// Fingerprint identifies the request payload the key was first used with.
+	Fingerprint string `json:"fingerprint"`
+	// Completed reports whether the original request has finished.
+	Completed bool `json:"completed"`
+	// Status is the HTTP status code of the original response.
+	Status int `json:"status,omitempty"`
+	// ContentType is the content type of the original response.
+	ContentType string `json:"contentType,omitempty"`
+	// Body is the original response body.
+	Body []byte `json:"body,omitempty"`
+}
@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/contextx"
+	"github.com/coldsmirk/vef-framework-go/httpx"
+	isecurity "github.com/coldsmirk/vef-framework-go/internal/security"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// APIKeyStrategy implements api.AuthStrategy for API key authentication.
+// It reads the key from the X-API-Key header and delegates authentication
+// to the security.AuthManager, which resolves the user or external app owning the key.
+type APIKeyStrategy struct {
+	authManager security.AuthManager
+}
+
+// NewAPIKey creates a new API key authentication strategy.
+func NewAPIKey(authManager security.AuthManager) api.AuthStrategy {
+	return &APIKeyStrategy{
+		authManager: authManager,
+	}
+}
+
+// Name returns the strategy name.
+func (*APIKeyStrategy) Name() string {
+	return api.AuthStrategyAPIKey
+}
+
+// Authenticate extracts the API key from the request header and delegates authentication to the AuthManager.
+func (s *APIKeyStrategy) Authenticate(ctx fiber.Ctx, _ map[string]any) (*security.Principal, error) {
+	key := ctx.Get(api.HeaderXAPIKey)
+	if key == "" {
+		return nil, result.ErrAPIKeyRequired
+	}
+
+	// The authenticator checks the key's IP whitelist against the request IP.
+	return s.authManager.Authenticate(contextx.SetRequestIP(ctx.Context(), httpx.GetIP(ctx)), security.Authentication{
+		Type:      isecurity.AuthTypeAPIKey,
+		Principal: key,
+	})
+}
@@ -3,6 +3,8 @@ package api
 import (
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/coldsmirk/go-collections"
@@ -148,6 +150,16 @@ func (e *engine) Lookup(identifier api.Identifier) *api.Operation {
 	return op
 }
 
+// Operations returns all registered operations ordered by identifier.
+func (e *engine) Operations() []*api.Operation {
+	ops := e.operations.Values()
+	slices.SortFunc(ops, func(a, b *api.Operation) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return ops
+}
+
 // registerResource registers a single resource.
 func (e *engine) registerResource(res api.Resource) error {
 	if res == nil {
@@ -225,6 +237,14 @@ func (e *engine) buildOperation(res api.Resource, spec api.OperationSpec, handle
 		ac.Options[shared.AuthOptionPermToken] = spec.PermToken
 	}
 
+	if spec.AllowScoped {
+		if ac.Options == nil {
+			ac.Options = make(map[string]any)
+		}
+
+		ac.Options[shared.AuthOptionAllowScoped] = true
+	}
+
 	return &api.Operation{
 		Identifier: api.Identifier{
 			Resource: res.Name(),
@@ -234,6 +254,7 @@ func (e *engine) buildOperation(res api.Resource, spec api.OperationSpec, handle
 		Auth:        ac,
 		Timeout:     e.resolveTimeout(spec.Timeout),
 		RateLimit:   e.resolveRateLimit(spec.RateLimit),
+		Idempotency: spec.Idempotency,
 		EnableAudit: spec.EnableAudit,
 		Meta: map[string]any{
 			shared.MetaKeyResource: res,
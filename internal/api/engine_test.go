@@ -384,6 +384,18 @@ func TestEngineRegister(t *testing.T) {
 		assert.Equal(t, "sys:user:delete", op.Auth.Options[shared.AuthOptionPermToken], "PermToken should be stored correctly")
 	})
 
+	t.Run("OperationAllowScoped", func(t *testing.T) {
+		e, _ := newRegistrationEngine(t, []api.OperationSpec{{Action: "get_info", AllowScoped: true}})
+		res := &MockResource{kind: api.KindRPC, name: "sys/user"}
+
+		err := e.Register(res)
+		require.NoError(t, err, "Registration with allow scoped should succeed")
+
+		op := e.Lookup(api.Identifier{Resource: "sys/user", Action: "get_info", Version: api.VersionV1})
+		require.NotNil(t, op, "Operation should be found")
+		assert.Equal(t, true, op.Auth.Options[shared.AuthOptionAllowScoped], "AllowScoped should be stored correctly")
+	})
+
 	t.Run("OperationWithCustomTimeout", func(t *testing.T) {
 		e, _ := newRegistrationEngine(t, []api.OperationSpec{{Action: "export", Timeout: 2 * time.Minute}})
 		res := &MockResource{kind: api.KindRPC, name: "test/resource"}
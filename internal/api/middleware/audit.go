@@ -18,6 +18,7 @@ import (
 	"github.com/coldsmirk/vef-framework-go/internal/app"
 	"github.com/coldsmirk/vef-framework-go/result"
 	"github.com/coldsmirk/vef-framework-go/security"
+	"github.com/coldsmirk/vef-framework-go/tracing"
 )
 
 // Audit handles audit logging.
@@ -112,8 +113,10 @@ func buildAuditEvent(ctx fiber.Ctx, elapsed int64, err error) (*api.AuditEvent,
 		Action:        req.Action,
 		Version:       req.Version,
 		UserID:        principal.ID,
+		TenantID:      contextx.TenantID(ctx),
 		UserAgent:     utils.CopyString(ctx.Get(fiber.HeaderUserAgent)),
 		RequestID:     contextx.RequestID(ctx),
+		TraceID:       tracing.TraceID(ctx.Context()),
 		RequestIP:     httpx.GetIP(ctx),
 		RequestParams: req.Params,
 		RequestMeta:   req.Meta,
@@ -121,7 +124,7 @@ func buildAuditEvent(ctx fiber.Ctx, elapsed int64, err error) (*api.AuditEvent,
 		ResultMessage: resultMsg,
 		ResultData:    resultData,
 		ElapsedTime:   elapsed,
-	}), nil
+	}, event.WithTraceContext(ctx.Context())), nil
 }
 
 func extractErrorInfo(err error) (code int, message string) {
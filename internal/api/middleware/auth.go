@@ -13,15 +13,19 @@ import (
 )
 
 type Auth struct {
-	registry api.AuthStrategyRegistry
-	checker  security.PermissionChecker
+	registry  api.AuthStrategyRegistry
+	checker   security.PermissionChecker
+	evaluator security.PolicyEvaluator
 }
 
 // NewAuth creates a new authentication middleware.
-func NewAuth(registry api.AuthStrategyRegistry, checker security.PermissionChecker) api.Middleware {
+// Permissions granted by the checker are limited to the scopes of API key principals
+// and further restricted by the access policies of the evaluator, if any.
+func NewAuth(registry api.AuthStrategyRegistry, checker security.PermissionChecker, evaluator security.PolicyEvaluator) api.Middleware {
 	return &Auth{
-		registry: registry,
-		checker:  checker,
+		registry:  registry,
+		checker:   checker,
+		evaluator: evaluator,
 	}
 }
 
@@ -68,16 +72,39 @@ func (m *Auth) checkPermission(ctx fiber.Ctx, op *api.Operation, principal *secu
 		return ctx.Next()
 	}
 
-	if permToken := permTokenFromOperation(op); permToken != "" {
-		if err := m.doCheck(ctx.Context(), principal, permToken); err != nil {
-			return err
+	permToken := permTokenFromOperation(op)
+	if permToken == "" {
+		// Scopes only grant permission tokens, so operations without one are out of scope unless allowed explicitly.
+		if principal.Scopes != nil && !allowsScoped(op) {
+			return fmt.Errorf(
+				"%w: %w, principal=%q (type=%s), operation=%s",
+				fiber.ErrForbidden, ErrOutOfScope, principal.ID, principal.Type, op.Identifier,
+			)
 		}
+
+		return ctx.Next()
+	}
+
+	if err := m.doCheck(ctx.Context(), principal, permToken); err != nil {
+		return err
+	}
+
+	if err := m.checkPolicies(ctx, op, principal, permToken); err != nil {
+		return err
 	}
 
 	return ctx.Next()
 }
 
 func (m *Auth) doCheck(ctx context.Context, principal *security.Principal, permToken string) error {
+	// Scopes narrow what the roles grant, whichever checker is in use.
+	if !principal.InScope(permToken) {
+		return fmt.Errorf(
+			"%w: %w, principal=%q (type=%s), permission=%q",
+			fiber.ErrForbidden, ErrOutOfScope, principal.ID, principal.Type, permToken,
+		)
+	}
+
 	if m.checker == nil {
 		return fmt.Errorf(
 			"%w: %w, permission=%q",
@@ -103,6 +130,39 @@ func (m *Auth) doCheck(ctx context.Context, principal *security.Principal, permT
 	return nil
 }
 
+// checkPolicies evaluates the access policies of the permission token against the request.
+func (m *Auth) checkPolicies(ctx fiber.Ctx, op *api.Operation, principal *security.Principal, permToken string) error {
+	if m.evaluator == nil {
+		return nil
+	}
+
+	request := &security.PolicyRequest{
+		Principal: principal,
+		PermToken: permToken,
+		Operation: security.PolicyOperation(op.Identifier),
+	}
+	if req := shared.Request(ctx); req != nil {
+		request.Params = req.Params
+	}
+
+	decision, err := m.evaluator.Evaluate(ctx.Context(), request)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: %w, principal=%q, permission=%q: %w",
+			fiber.ErrForbidden, ErrPolicyEvaluationFailed, principal.ID, permToken, err,
+		)
+	}
+
+	if !decision.Allowed {
+		return fmt.Errorf(
+			"%w: %w, principal=%q (type=%s), permission=%q",
+			fiber.ErrForbidden, ErrPolicyDenied, principal.ID, principal.Type, permToken,
+		)
+	}
+
+	return nil
+}
+
 // permTokenFromOperation extracts the permission token from an operation's auth options.
 func permTokenFromOperation(op *api.Operation) string {
 	if token, ok := op.Auth.Options[shared.AuthOptionPermToken].(string); ok {
@@ -111,3 +171,10 @@ func permTokenFromOperation(op *api.Operation) string {
 
 	return ""
 }
+
+// allowsScoped reports whether scoped principals may call an operation that has no permission token.
+func allowsScoped(op *api.Operation) bool {
+	allowed, _ := op.Auth.Options[shared.AuthOptionAllowScoped].(bool)
+
+	return allowed
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// grantAllChecker grants every permission token.
+type grantAllChecker struct{}
+
+func (grantAllChecker) HasPermission(context.Context, *security.Principal, string) (bool, error) {
+	return true, nil
+}
+
+// recordingEvaluator allows every request and keeps the last one.
+type recordingEvaluator struct {
+	request *security.PolicyRequest
+}
+
+func (e *recordingEvaluator) Evaluate(_ context.Context, request *security.PolicyRequest) (*security.PolicyDecision, error) {
+	e.request = request
+
+	return &security.PolicyDecision{Allowed: true}, nil
+}
+
+// checkScopedPermission runs the permission check of op for principal and returns the resulting status.
+func checkScopedPermission(t *testing.T, op *api.Operation, principal *security.Principal) int {
+	t.Helper()
+
+	return checkPermissionWith(t, &Auth{}, op, principal)
+}
+
+// checkPermissionWith runs the permission check of op for principal through auth and returns the resulting status.
+func checkPermissionWith(t *testing.T, auth *Auth, op *api.Operation, principal *security.Principal) int {
+	t.Helper()
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(ctx fiber.Ctx, err error) error {
+			if errors.Is(err, fiber.ErrForbidden) {
+				return ctx.SendStatus(fiber.StatusForbidden)
+			}
+
+			return ctx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		},
+	})
+
+	app.Get("/", func(ctx fiber.Ctx) error {
+		return auth.checkPermission(ctx, op, principal)
+	}, func(ctx fiber.Ctx) error {
+		return ctx.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	require.NoError(t, err, "Request should not fail")
+	require.NoError(t, resp.Body.Close(), "Body should close")
+
+	return resp.StatusCode
+}
+
+// TestCheckPermissionWithoutPermToken tests how operations without a permission token treat scoped principals.
+func TestCheckPermissionWithoutPermToken(t *testing.T) {
+	op := &api.Operation{
+		Identifier: api.Identifier{Resource: "security/auth", Action: "logout_all", Version: api.VersionV1},
+		Auth:       &api.AuthConfig{},
+	}
+	scoped := security.NewUser("u1", "Alice")
+	scoped.Scopes = []string{"sys:user:query"}
+
+	t.Run("UnscopedPrincipal", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusOK, checkScopedPermission(t, op, security.NewUser("u1", "Alice")), "Unscoped principals should pass")
+	})
+
+	t.Run("ScopedPrincipal", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusForbidden, checkScopedPermission(t, op, scoped), "Scoped principals should be denied")
+	})
+
+	t.Run("EmptyScopes", func(t *testing.T) {
+		principal := security.NewUser("u1", "Alice")
+		principal.Scopes = []string{}
+
+		assert.Equal(t, fiber.StatusForbidden, checkScopedPermission(t, op, principal), "An empty scope list should grant nothing")
+	})
+
+	t.Run("AllowScoped", func(t *testing.T) {
+		allowed := &api.Operation{
+			Identifier: op.Identifier,
+			Auth:       &api.AuthConfig{Options: map[string]any{shared.AuthOptionAllowScoped: true}},
+		}
+
+		assert.Equal(t, fiber.StatusOK, checkScopedPermission(t, allowed, scoped), "Allow-listed operations should pass")
+	})
+}
+
+// TestCheckPoliciesPassesOperation tests that policies are evaluated with the identifier of the invoked operation.
+func TestCheckPoliciesPassesOperation(t *testing.T) {
+	evaluator := &recordingEvaluator{}
+	auth := &Auth{checker: grantAllChecker{}, evaluator: evaluator}
+	op := &api.Operation{
+		Identifier: api.Identifier{Resource: "expense", Action: "approve", Version: api.VersionV1},
+		Auth:       &api.AuthConfig{Options: map[string]any{shared.AuthOptionPermToken: "expense:approve"}},
+	}
+
+	status := checkPermissionWith(t, auth, op, security.NewUser("u1", "Alice"))
+	assert.Equal(t, fiber.StatusOK, status, "Allowed request should pass")
+	require.NotNil(t, evaluator.request, "Policies should be evaluated")
+	assert.Equal(t, "expense:approve", evaluator.request.PermToken, "Should pass the permission token")
+	assert.Equal(t, security.PolicyOperation{Resource: "expense", Action: "approve", Version: api.VersionV1}, evaluator.request.Operation, "Should pass the operation identifier")
+}
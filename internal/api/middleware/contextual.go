@@ -4,23 +4,36 @@ import (
 	"github.com/gofiber/fiber/v3"
 
 	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/config"
 	"github.com/coldsmirk/vef-framework-go/contextx"
 	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
 	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/result"
 	"github.com/coldsmirk/vef-framework-go/security"
 )
 
 // Contextual injects DB and Logger into the request context.
 // It sets up a contextual database with the operator ID and a scoped logger
-// with request identification information.
+// with request identification information. When multi-tenancy is enabled,
+// the database is also scoped to the tenant resolved for the request, and
+// requests of authenticated principals without a tenant are denied.
 type Contextual struct {
-	db orm.DB
+	db             orm.DB
+	tenantConfig   *config.TenantConfig
+	tenantResolver security.TenantResolver
 }
 
 // NewContextual creates a new context middleware.
-func NewContextual(db orm.DB) api.Middleware {
+// The tenant resolver defaults to security.DefaultTenantResolver.
+func NewContextual(db orm.DB, tenantConfig *config.TenantConfig, tenantResolver security.TenantResolver) api.Middleware {
+	if tenantResolver == nil {
+		tenantResolver = security.NewDefaultTenantResolver()
+	}
+
 	return &Contextual{
-		db: db,
+		db:             db,
+		tenantConfig:   tenantConfig,
+		tenantResolver: tenantResolver,
 	}
 }
 
@@ -42,7 +55,27 @@ func (m *Contextual) Process(ctx fiber.Ctx) error {
 		principal = security.PrincipalAnonymous
 	}
 
-	db := m.db.WithNamedArg(orm.PlaceholderKeyOperator, principal.ID)
+	// Reads of a request go to the primary once the request has written, so it reads its own writes.
+	db := m.db.WithNamedArg(orm.PlaceholderKeyOperator, principal.ID).WithStickyPrimary()
+
+	if m.tenantConfig != nil && m.tenantConfig.Enabled {
+		tenantID, err := m.tenantResolver.Resolve(ctx.Context(), principal, ctx.Get(m.tenantConfig.HeaderOrDefault()))
+		if err != nil {
+			return err
+		}
+
+		// Only anonymous principals, which can reach public operations alone, may run without a tenant.
+		if tenantID == "" && principal.ID != security.PrincipalAnonymous.ID {
+			return result.ErrAccessDenied
+		}
+
+		if tenantID != "" {
+			db = db.WithTenant(tenantID)
+			contextx.SetTenantID(ctx, tenantID)
+			ctx.SetContext(contextx.SetTenantID(ctx.Context(), tenantID))
+		}
+	}
+
 	contextx.SetDB(ctx, db)
 	ctx.SetContext(contextx.SetDB(ctx.Context(), db))
 
@@ -50,9 +83,10 @@ func (m *Contextual) Process(ctx fiber.Ctx) error {
 
 	lgr := contextx.Logger(ctx)
 	if req != nil && lgr != nil {
-		scopedLogger := lgr.
-			Named(buildLoggerName(req.Resource, req.Action, req.Version)).
-			Named(buildLoggerName(string(principal.Type), principal.ID, principal.Name))
+		scopedLogger := lgr.With(
+			"operation", buildLoggerName(req.Resource, req.Action, req.Version),
+			"principal", buildLoggerName(string(principal.Type), principal.ID, principal.Name),
+		)
 		contextx.SetLogger(ctx, scopedLogger)
 		ctx.SetContext(contextx.SetLogger(ctx.Context(), scopedLogger))
 	}
@@ -60,7 +94,7 @@ func (m *Contextual) Process(ctx fiber.Ctx) error {
 	return ctx.Next()
 }
 
-// buildLoggerName joins three parts into "a:b@c" format.
+// buildLoggerName joins three parts into "a:b@c" format, the value of the operation and principal log fields.
 func buildLoggerName(a, b, c string) string {
 	return a + ":" + b + "@" + c
 }
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/contextx"
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// sendContextual runs the contextual middleware with tenancy enabled for principal and returns
+// the response status and the tenant the request DB was scoped to.
+func sendContextual(t *testing.T, principal *security.Principal, requestedTenant string) (int, string) {
+	t.Helper()
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(ctx fiber.Ctx, err error) error {
+			if resultErr, ok := result.AsErr(err); ok {
+				return ctx.Status(resultErr.Status).JSON(result.Result{Code: resultErr.Code, Message: resultErr.Message})
+			}
+
+			return ctx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		},
+	})
+
+	var tenantID string
+
+	contextual := NewContextual(testx.NewTestDB(t), &config.TenantConfig{Enabled: true}, nil)
+	app.Get("/", func(ctx fiber.Ctx) error {
+		contextx.SetPrincipal(ctx, principal)
+
+		return ctx.Next()
+	}, contextual.Process, func(ctx fiber.Ctx) error {
+		tenantID = contextx.DB(ctx).TenantID()
+
+		return ctx.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	if requestedTenant != "" {
+		req.Header.Set("X-Tenant-ID", requestedTenant)
+	}
+
+	resp, err := app.Test(req)
+	require.NoError(t, err, "Request should not fail")
+	require.NoError(t, resp.Body.Close(), "Body should close")
+
+	return resp.StatusCode, tenantID
+}
+
+// TestContextualTenant tests tenant scoping and denial of the contextual middleware.
+func TestContextualTenant(t *testing.T) {
+	t.Run("PrincipalTenant", func(t *testing.T) {
+		status, tenantID := sendContextual(t, security.NewUser("u1", "Alice").WithTenantID("tenant-a"), "tenant-b")
+
+		assert.Equal(t, fiber.StatusOK, status, "Request should pass")
+		assert.Equal(t, "tenant-a", tenantID, "DB should be scoped to the principal's tenant")
+	})
+
+	t.Run("PrincipalWithoutTenant", func(t *testing.T) {
+		status, _ := sendContextual(t, security.NewUser("u1", "Alice"), "")
+
+		assert.Equal(t, fiber.StatusForbidden, status, "Requests without a tenant should be denied")
+	})
+
+	t.Run("HeaderTenant", func(t *testing.T) {
+		status, _ := sendContextual(t, security.NewUser("u1", "Alice"), "tenant-b")
+
+		assert.Equal(t, fiber.StatusForbidden, status, "The header should not choose the tenant")
+	})
+
+	t.Run("Anonymous", func(t *testing.T) {
+		status, tenantID := sendContextual(t, security.PrincipalAnonymous, "")
+
+		assert.Equal(t, fiber.StatusOK, status, "Public requests should pass")
+		assert.Empty(t, tenantID, "Public requests should run unscoped")
+	})
+}
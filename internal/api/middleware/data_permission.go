@@ -79,6 +79,15 @@ func (m *DataPermission) resolveDataScope(ctx fiber.Ctx, principal *security.Pri
 		)
 	}
 
+	if bindable, ok := ds.(security.BindableDataScope); ok {
+		if ds, err = bindable.Bind(ctx.Context(), principal); err != nil {
+			return fmt.Errorf(
+				"%w: %w, principal=%q, permission=%q: %w",
+				fiber.ErrForbidden, ErrDataScopeResolutionFailed, principal.ID, permToken, err,
+			)
+		}
+	}
+
 	lgr := contextx.Logger(ctx)
 	if ds != nil {
 		lgr.Debugf("Resolved data scope: scope=%q, principal=%q", ds.Key(), principal.ID)
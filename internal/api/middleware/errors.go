@@ -24,6 +24,15 @@ var (
 	// ErrPermissionDenied indicates the principal does not have the required permission.
 	ErrPermissionDenied = errors.New("permission denied")
 
+	// ErrOutOfScope indicates the permission lies outside the scopes of the principal's API key.
+	ErrOutOfScope = errors.New("permission outside the scopes of the api key")
+
+	// ErrPolicyDenied indicates an access policy denied the request.
+	ErrPolicyDenied = errors.New("access denied by policy")
+
+	// ErrPolicyEvaluationFailed indicates an error occurred during policy evaluation.
+	ErrPolicyEvaluationFailed = errors.New("policy evaluation failed")
+
 	// ErrPermissionCheckFailed indicates an error occurred during permission check.
 	ErrPermissionCheckFailed = errors.New("permission check failed")
 
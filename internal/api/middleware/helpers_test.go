@@ -9,6 +9,7 @@ import (
 
 	"github.com/coldsmirk/vef-framework-go/api"
 	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	"github.com/coldsmirk/vef-framework-go/ratelimit"
 	"github.com/coldsmirk/vef-framework-go/result"
 )
 
@@ -113,16 +114,17 @@ func TestExtractErrorInfo(t *testing.T) {
 
 // TestNewRateLimit tests NewRateLimit constructor and its methods.
 func TestNewRateLimit(t *testing.T) {
-	rl := NewRateLimit()
+	rl := NewRateLimit(nil, nil)
 
 	assert.NotNil(t, rl, "RateLimit should not be nil")
 	assert.Equal(t, "ratelimit", rl.Name(), "Name should be 'ratelimit'")
 	assert.Equal(t, -70, rl.Order(), "Order should be -70")
+	assert.IsType(t, ratelimit.NewMemoryStore(), rl.(*RateLimit).store, "Should keep quotas in memory without Redis")
 }
 
 // TestNewContextual tests NewContextual constructor and its methods.
 func TestNewContextual(t *testing.T) {
-	ctx := NewContextual(nil)
+	ctx := NewContextual(nil, nil, nil)
 
 	assert.NotNil(t, ctx, "Contextual should not be nil")
 	assert.Equal(t, "contextual", ctx.Name(), "Name should be 'contextual'")
@@ -131,7 +133,7 @@ func TestNewContextual(t *testing.T) {
 
 // TestNewAuth tests NewAuth constructor and its methods.
 func TestNewAuth(t *testing.T) {
-	auth := NewAuth(nil, nil)
+	auth := NewAuth(nil, nil, nil)
 
 	assert.NotNil(t, auth, "Auth should not be nil")
 	assert.Equal(t, "auth", auth.Name(), "Name should be 'auth'")
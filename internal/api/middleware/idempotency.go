@@ -0,0 +1,247 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/utils/v2"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/cache"
+	"github.com/coldsmirk/vef-framework-go/contextx"
+	"github.com/coldsmirk/vef-framework-go/httpx"
+	"github.com/coldsmirk/vef-framework-go/idempotency"
+	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	"github.com/coldsmirk/vef-framework-go/internal/redis"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+const (
+	defaultIdempotencyTTL = 24 * time.Hour
+	// defaultIdempotencyLockTTL bounds how long a crashed request can block its key
+	// when the operation has no timeout.
+	defaultIdempotencyLockTTL = time.Minute
+)
+
+// idempotencyCacheNamespace is the Redis cache namespace idempotency records are kept in.
+const idempotencyCacheNamespace = "idempotency"
+
+// Idempotency replays the first response of requests that carry the same Idempotency-Key.
+// Records are kept in a Redis cache when Redis is configured, so that duplicates hitting
+// different instances are detected, and in memory otherwise.
+type Idempotency struct {
+	records idempotencyRecords
+}
+
+// idempotencyRecords is the cache of idempotency records, which claims keys atomically.
+type idempotencyRecords interface {
+	cache.Cache[idempotency.Record]
+	cache.AtomicSetter[idempotency.Record]
+}
+
+// NewIdempotency creates a new idempotency middleware.
+func NewIdempotency(redisProvider *redis.ClientProvider) api.Middleware {
+	return &Idempotency{records: newIdempotencyCache(redisProvider)}
+}
+
+// newIdempotencyCache returns a Redis cache when Redis is configured, and an in-memory cache otherwise.
+// Both implement cache.AtomicSetter.
+func newIdempotencyCache(redisProvider *redis.ClientProvider) idempotencyRecords {
+	if redisProvider != nil {
+		if client := redisProvider.ConfiguredClient(); client != nil {
+			return cache.NewRedis[idempotency.Record](client, idempotencyCacheNamespace).(idempotencyRecords)
+		}
+	}
+
+	return cache.NewMemory[idempotency.Record]().(idempotencyRecords)
+}
+
+// Name returns the middleware name.
+func (*Idempotency) Name() string {
+	return "idempotency"
+}
+
+// Order returns the middleware order.
+func (*Idempotency) Order() int {
+	return -50
+}
+
+// Process handles the idempotency key.
+func (m *Idempotency) Process(ctx fiber.Ctx) error {
+	op := shared.Operation(ctx)
+	if op == nil || !op.IsIdempotent() {
+		return ctx.Next()
+	}
+
+	req := shared.Request(ctx)
+
+	idempotencyKey := extractIdempotencyKey(ctx, req)
+	if idempotencyKey == "" {
+		return ctx.Next()
+	}
+
+	var (
+		key         = m.buildKey(ctx, op, idempotencyKey)
+		fingerprint = fingerprintRequest(req)
+		pending     = idempotency.Record{Fingerprint: fingerprint}
+	)
+
+	acquired, err := m.records.SetIfAbsent(ctx.Context(), key, pending, lockTTL(op))
+	if err != nil {
+		return fmt.Errorf("failed to acquire idempotency key for %s: %w", op.Identifier, err)
+	}
+
+	if !acquired {
+		existing, ok := m.records.Get(ctx.Context(), key)
+		if !ok {
+			// The original request released or outlived its key in the meantime.
+			return result.ErrIdempotencyKeyInProgress
+		}
+
+		return replay(ctx, &existing, fingerprint)
+	}
+
+	if err := ctx.Next(); err != nil {
+		// Failed requests are not recorded so that the client can retry with the same key.
+		m.release(ctx, key)
+
+		return err
+	}
+
+	res := ctx.Response()
+	if res.IsBodyStream() {
+		m.release(ctx, key)
+
+		return nil
+	}
+
+	record := idempotency.Record{
+		Fingerprint: fingerprint,
+		Completed:   true,
+		Status:      res.StatusCode(),
+		ContentType: utils.CopyString(string(res.Header.ContentType())),
+		Body:        utils.CopyBytes(res.Body()),
+	}
+
+	ttl := op.Idempotency.TTL
+	if ttl <= 0 {
+		ttl = defaultIdempotencyTTL
+	}
+
+	if err := m.records.Set(ctx.Context(), key, record, ttl); err != nil {
+		contextx.Logger(ctx).Errorf("Failed to record idempotent response for %s: %v", op.Identifier, err)
+		m.release(ctx, key)
+	}
+
+	return nil
+}
+
+func (m *Idempotency) release(ctx fiber.Ctx, key string) {
+	if err := m.records.Delete(ctx.Context(), key); err != nil {
+		contextx.Logger(ctx).Errorf("Failed to release idempotency key: %v", err)
+	}
+}
+
+// buildKey scopes the idempotency key to the tenant, the caller and the operation, so that neither another tenant
+// nor another caller can replay the recorded response. Anonymous callers share a principal and are told apart
+// by their IP instead.
+func (*Idempotency) buildKey(ctx fiber.Ctx, op *api.Operation, idempotencyKey string) string {
+	principal := contextx.Principal(ctx)
+	if principal == nil {
+		principal = security.PrincipalAnonymous
+	}
+
+	caller := principal.ID
+	if caller == security.PrincipalAnonymous.ID {
+		caller += "@" + httpx.GetIP(ctx)
+	}
+
+	var sb strings.Builder
+	writeKeySegment(&sb, contextx.TenantID(ctx))
+	writeKeySegment(&sb, caller)
+	sb.WriteString(op.Identifier.String())
+	sb.WriteByte(':')
+	sb.WriteString(idempotencyKey)
+
+	return sb.String()
+}
+
+// writeKeySegment writes a length-prefixed key segment, so that segments containing ":" cannot collide.
+func writeKeySegment(sb *strings.Builder, segment string) {
+	sb.WriteString(strconv.Itoa(len(segment)))
+	sb.WriteByte(':')
+	sb.WriteString(segment)
+	sb.WriteByte(':')
+}
+
+// replay writes the recorded response, or rejects the request if the original is still
+// in flight or the key was used with a different payload.
+func replay(ctx fiber.Ctx, record *idempotency.Record, fingerprint string) error {
+	if record.Fingerprint != fingerprint {
+		return result.ErrIdempotencyKeyReused
+	}
+
+	if !record.Completed {
+		return result.ErrIdempotencyKeyInProgress
+	}
+
+	ctx.Set(api.HeaderIdempotentReplayed, "true")
+
+	if record.ContentType != "" {
+		ctx.Set(fiber.HeaderContentType, record.ContentType)
+	}
+
+	return ctx.Status(record.Status).Send(record.Body)
+}
+
+// extractIdempotencyKey reads the key from the Idempotency-Key header, falling back to request meta.
+func extractIdempotencyKey(ctx fiber.Ctx, req *api.Request) string {
+	if key := strings.TrimSpace(ctx.Get(api.HeaderIdempotencyKey)); key != "" {
+		return utils.CopyString(key)
+	}
+
+	if req == nil {
+		return ""
+	}
+
+	if value, ok := req.GetMeta(api.MetaKeyIdempotencyKey); ok {
+		if key, ok := value.(string); ok {
+			return strings.TrimSpace(key)
+		}
+	}
+
+	return ""
+}
+
+// fingerprintRequest hashes the request params so that a key reused with a different payload is detected.
+func fingerprintRequest(req *api.Request) string {
+	if req == nil {
+		return ""
+	}
+
+	// Map keys are marshaled in sorted order, so equal params yield equal fingerprints.
+	data, err := json.Marshal(req.Params)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// lockTTL bounds the in-flight reservation so that a crashed request does not block its key forever.
+func lockTTL(op *api.Operation) time.Duration {
+	if op.Timeout > 0 {
+		return op.Timeout + defaultIdempotencyLockTTL
+	}
+
+	return defaultIdempotencyLockTTL
+}
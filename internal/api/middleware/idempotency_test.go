@@ -0,0 +1,228 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/contextx"
+	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+const (
+	testHeaderTenant = "X-Test-Tenant"
+	testHeaderUser   = "X-Test-User"
+)
+
+// newIdempotencyTestApp mounts the idempotency middleware in front of handler.
+// The request params are read from the "params" query parameter, the tenant and the user
+// from the test tenant and user headers; requests without a user are anonymous.
+func newIdempotencyTestApp(handler fiber.Handler) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(ctx fiber.Ctx, err error) error {
+			if resultErr, ok := result.AsErr(err); ok {
+				return ctx.Status(resultErr.Status).JSON(result.Result{Code: resultErr.Code, Message: resultErr.Message})
+			}
+
+			return ctx.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		},
+	})
+
+	op := &api.Operation{
+		Identifier:  api.Identifier{Resource: "test", Action: "create", Version: api.VersionV1},
+		Idempotency: &api.IdempotencyConfig{},
+	}
+
+	app.Post("/", func(ctx fiber.Ctx) error {
+		shared.SetOperation(ctx, op)
+		shared.SetRequest(ctx, &api.Request{
+			Identifier: op.Identifier,
+			Params:     api.Params{"value": ctx.Query("params")},
+		})
+
+		if tenant := ctx.Get(testHeaderTenant); tenant != "" {
+			contextx.SetTenantID(ctx, tenant)
+		}
+
+		if user := ctx.Get(testHeaderUser); user != "" {
+			contextx.SetPrincipal(ctx, security.NewUser(user, user))
+		}
+
+		return ctx.Next()
+	}, NewIdempotency(nil).Process, handler)
+
+	return app
+}
+
+func sendIdempotent(t *testing.T, app *fiber.App, key, params string) (int, string, string) {
+	t.Helper()
+
+	return sendIdempotentWithHeaders(t, app, key, params, nil)
+}
+
+func sendIdempotentWithHeaders(t *testing.T, app *fiber.App, key, params string, headers map[string]string) (int, string, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/?params="+params, nil)
+	if key != "" {
+		req.Header.Set(api.HeaderIdempotencyKey, key)
+	}
+
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := app.Test(req)
+	require.NoError(t, err, "Request should not fail")
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "Body should be readable")
+
+	return resp.StatusCode, string(body), resp.Header.Get(api.HeaderIdempotentReplayed)
+}
+
+// TestIdempotency tests replay, conflict and retry behavior of the idempotency middleware.
+func TestIdempotency(t *testing.T) {
+	t.Run("ReplaysFirstResponse", func(t *testing.T) {
+		var calls atomic.Int32
+
+		app := newIdempotencyTestApp(func(ctx fiber.Ctx) error {
+			return result.Ok(calls.Add(1)).Response(ctx)
+		})
+
+		status, first, replayed := sendIdempotent(t, app, "key-1", "a")
+		assert.Equal(t, fiber.StatusOK, status, "First request should succeed")
+		assert.Empty(t, replayed, "First request should not be marked as replayed")
+
+		status, second, replayed := sendIdempotent(t, app, "key-1", "a")
+		assert.Equal(t, fiber.StatusOK, status, "Duplicate should replay the original status")
+		assert.Equal(t, first, second, "Duplicate should replay the original body")
+		assert.Equal(t, "true", replayed, "Duplicate should be marked as replayed")
+		assert.Equal(t, int32(1), calls.Load(), "Handler should run only once")
+
+		sendIdempotent(t, app, "key-2", "a")
+		assert.Equal(t, int32(2), calls.Load(), "Different keys should run the handler")
+	})
+
+	t.Run("WithoutKey", func(t *testing.T) {
+		var calls atomic.Int32
+
+		app := newIdempotencyTestApp(func(ctx fiber.Ctx) error {
+			return result.Ok(calls.Add(1)).Response(ctx)
+		})
+
+		sendIdempotent(t, app, "", "a")
+		sendIdempotent(t, app, "", "a")
+		assert.Equal(t, int32(2), calls.Load(), "Requests without key should not be deduplicated")
+	})
+
+	t.Run("KeyReusedWithDifferentParams", func(t *testing.T) {
+		app := newIdempotencyTestApp(func(ctx fiber.Ctx) error {
+			return result.Ok().Response(ctx)
+		})
+
+		sendIdempotent(t, app, "key", "a")
+
+		status, body, _ := sendIdempotent(t, app, "key", "b")
+		assert.Equal(t, fiber.StatusUnprocessableEntity, status, "Reused key should be rejected")
+
+		var res result.Result
+		require.NoError(t, json.Unmarshal([]byte(body), &res), "Body should be a result")
+		assert.Equal(t, result.ErrCodeIdempotencyKeyReused, res.Code, "Should return key reused code")
+	})
+
+	t.Run("ConflictWhileInFlight", func(t *testing.T) {
+		var (
+			started = make(chan struct{})
+			release = make(chan struct{})
+		)
+
+		app := newIdempotencyTestApp(func(ctx fiber.Ctx) error {
+			close(started)
+			<-release
+
+			return result.Ok().Response(ctx)
+		})
+
+		done := make(chan int)
+		go func() {
+			status, _, _ := sendIdempotent(t, app, "key", "a")
+			done <- status
+		}()
+
+		<-started
+
+		status, body, _ := sendIdempotent(t, app, "key", "a")
+		assert.Equal(t, fiber.StatusConflict, status, "Duplicate in flight should conflict")
+		assert.Contains(t, body, `"code":1403`, "Should return in progress code")
+
+		close(release)
+
+		select {
+		case status := <-done:
+			assert.Equal(t, fiber.StatusOK, status, "Original request should complete")
+		case <-time.After(5 * time.Second):
+			t.Fatal("Original request did not complete")
+		}
+	})
+
+	t.Run("FailureReleasesKey", func(t *testing.T) {
+		var calls atomic.Int32
+
+		app := newIdempotencyTestApp(func(ctx fiber.Ctx) error {
+			if calls.Add(1) == 1 {
+				return result.ErrUnknown
+			}
+
+			return result.Ok().Response(ctx)
+		})
+
+		status, _, _ := sendIdempotent(t, app, "key", "a")
+		assert.Equal(t, fiber.StatusInternalServerError, status, "First attempt should fail")
+
+		status, _, replayed := sendIdempotent(t, app, "key", "a")
+		assert.Equal(t, fiber.StatusOK, status, "Retry should run the handler again")
+		assert.Empty(t, replayed, "Retry should not be a replay")
+		assert.Equal(t, int32(2), calls.Load(), "Handler should run for the retry")
+	})
+
+	t.Run("KeysScopedByTenantAndCaller", func(t *testing.T) {
+		var calls atomic.Int32
+
+		app := newIdempotencyTestApp(func(ctx fiber.Ctx) error {
+			return result.Ok(calls.Add(1)).Response(ctx)
+		})
+
+		callers := []map[string]string{
+			{testHeaderTenant: "t1", testHeaderUser: "alice"},
+			{testHeaderTenant: "t2", testHeaderUser: "alice"},
+			{testHeaderTenant: "t1", testHeaderUser: "bob"},
+			{fiber.HeaderXForwardedFor: "10.0.0.1"},
+			{fiber.HeaderXForwardedFor: "10.0.0.2"},
+		}
+		for _, headers := range callers {
+			_, _, replayed := sendIdempotentWithHeaders(t, app, "key", "a", headers)
+			assert.Empty(t, replayed, "Another tenant or caller should not replay the recorded response: %v", headers)
+		}
+
+		assert.Equal(t, int32(len(callers)), calls.Load(), "Handler should run once per tenant and caller")
+
+		_, _, replayed := sendIdempotentWithHeaders(t, app, "key", "a", callers[0])
+		assert.Equal(t, "true", replayed, "Same tenant and caller should replay the recorded response")
+
+		_, _, replayed = sendIdempotentWithHeaders(t, app, "key", "a", callers[3])
+		assert.Equal(t, "true", replayed, "Same anonymous client should replay the recorded response")
+	})
+}
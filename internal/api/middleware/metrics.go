@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	"github.com/coldsmirk/vef-framework-go/metrics"
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+var (
+	apiRequests = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "api",
+			Name:      "requests_total",
+			Help:      "Number of API requests by operation and result code.",
+		},
+		"resource", "action", "version", "code",
+	)
+	apiRequestDuration = metrics.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "api",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of API requests by operation.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		"resource", "action", "version",
+	)
+)
+
+// Metrics counts the requests of every API operation by result code and observes their latency.
+type Metrics struct{}
+
+// NewMetrics creates a new metrics middleware.
+func NewMetrics() api.Middleware {
+	return &Metrics{}
+}
+
+// Name returns the middleware name.
+func (*Metrics) Name() string {
+	return "metrics"
+}
+
+// Order returns the middleware order.
+// Runs first, so requests rejected by authentication or rate limiting are counted too.
+func (*Metrics) Order() int {
+	return -120
+}
+
+// Process records the request of the operation.
+func (*Metrics) Process(ctx fiber.Ctx) error {
+	req := shared.Request(ctx)
+	if req == nil {
+		return ctx.Next()
+	}
+
+	start := time.Now()
+	err := ctx.Next()
+
+	code := result.OkCode
+	if err != nil {
+		code, _ = extractErrorInfo(err)
+	}
+
+	apiRequests.WithLabelValues(req.Resource, req.Action, req.Version, strconv.Itoa(code)).Inc()
+	apiRequestDuration.WithLabelValues(req.Resource, req.Action, req.Version).Observe(time.Since(start).Seconds())
+
+	return err
+}
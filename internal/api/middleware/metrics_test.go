@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+// TestMetrics tests that API requests are counted by operation and result code.
+func TestMetrics(t *testing.T) {
+	app := fiber.New()
+	app.Post("/:code", func(ctx fiber.Ctx) error {
+		shared.SetRequest(ctx, &api.Request{
+			Identifier: api.Identifier{Resource: "test/metrics", Action: "create", Version: api.VersionV1},
+		})
+
+		return ctx.Next()
+	}, NewMetrics().Process, func(ctx fiber.Ctx) error {
+		if ctx.Params("code") == "ok" {
+			return ctx.SendStatus(fiber.StatusOK)
+		}
+
+		return result.Err("conflict", result.WithCode(result.ErrCodeBadRequest))
+	})
+
+	send := func(code string) {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/"+code, nil))
+		require.NoError(t, err, "Request should not fail")
+		require.NoError(t, resp.Body.Close(), "Body should be closed")
+	}
+
+	send("ok")
+	send("ok")
+	send("error")
+
+	assert.Equal(t, 2.0, testx.MetricValue(t, apiRequests.WithLabelValues("test/metrics", "create", api.VersionV1, "0")), "Should count the successful requests")
+	assert.Equal(t, 1.0, testx.MetricValue(t, apiRequests.WithLabelValues("test/metrics", "create", api.VersionV1, "1400")), "Should count the failed request by its code")
+	assert.Equal(t, 3.0, testx.MetricValue(t, apiRequestDuration.WithLabelValues("test/metrics", "create", api.VersionV1).(prometheus.Histogram)), "Should observe the latency of every request")
+}
@@ -12,18 +12,34 @@ var Module = fx.Module(
 		),
 		fx.Annotate(
 			NewAuth,
+			fx.ParamTags(``, ``, `optional:"true"`),
 			fx.ResultTags(`group:"vef:api:middlewares"`),
 		),
 		fx.Annotate(
 			NewContextual,
+			fx.ParamTags(``, ``, `optional:"true"`),
 			fx.ResultTags(`group:"vef:api:middlewares"`),
 		),
 		fx.Annotate(
 			NewDataPermission,
 			fx.ResultTags(`group:"vef:api:middlewares"`),
 		),
+		fx.Annotate(
+			NewIdempotency,
+			fx.ParamTags(`optional:"true"`),
+			fx.ResultTags(`group:"vef:api:middlewares"`),
+		),
+		fx.Annotate(
+			NewTracing,
+			fx.ResultTags(`group:"vef:api:middlewares"`),
+		),
+		fx.Annotate(
+			NewMetrics,
+			fx.ResultTags(`group:"vef:api:middlewares"`),
+		),
 		fx.Annotate(
 			NewRateLimit,
+			fx.ParamTags(`optional:"true"`, `optional:"true"`),
 			fx.ResultTags(`group:"vef:api:middlewares"`),
 		),
 	),
@@ -1,77 +1,56 @@
 package middleware
 
 import (
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v3"
-	"github.com/gofiber/fiber/v3/middleware/limiter"
 
 	"github.com/coldsmirk/vef-framework-go/api"
 	"github.com/coldsmirk/vef-framework-go/contextx"
 	"github.com/coldsmirk/vef-framework-go/httpx"
 	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	"github.com/coldsmirk/vef-framework-go/internal/redis"
+	"github.com/coldsmirk/vef-framework-go/ratelimit"
 	"github.com/coldsmirk/vef-framework-go/result"
 	"github.com/coldsmirk/vef-framework-go/security"
 )
 
 const (
-	defaultRateLimitMax        = 100
-	defaultRateLimitExpiration = 5 * time.Minute
+	defaultRateLimitPeriod = time.Minute
+
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
 )
 
 // RateLimit handles rate limiting based on operation config.
-// It uses a shared in-memory store to maintain state across requests.
+// State is kept in a ratelimit.Store. Unless one is provided, quotas are shared across instances
+// through a ratelimit.RedisStore when Redis is configured, and kept in memory otherwise.
 type RateLimit struct {
-	h fiber.Handler
+	store ratelimit.Store
 }
 
-// NewRateLimit creates a new rate limit middleware with shared state.
-func NewRateLimit() api.Middleware {
-	return &RateLimit{
-		h: limiter.New(limiter.Config{
-			LimiterMiddleware: limiter.SlidingWindow{},
-			MaxFunc: func(ctx fiber.Ctx) int {
-				if op := shared.Operation(ctx); op != nil {
-					return op.RateLimit.Max
-				}
-
-				return defaultRateLimitMax
-			},
-			ExpirationFunc: func(c fiber.Ctx) time.Duration {
-				if op := shared.Operation(c); op != nil {
-					return op.RateLimit.Period
-				}
-
-				return defaultRateLimitExpiration
-			},
-			KeyGenerator: func(ctx fiber.Ctx) string {
-				var sb strings.Builder
-				if req := shared.Request(ctx); req != nil {
-					sb.WriteString(req.Resource)
-					sb.WriteByte(':')
-					sb.WriteString(req.Version)
-					sb.WriteByte(':')
-					sb.WriteString(req.Action)
-					sb.WriteByte(':')
-					sb.WriteString(httpx.GetIP(ctx))
-					sb.WriteByte(':')
-				}
-
-				principal := contextx.Principal(ctx)
-				if principal == nil {
-					principal = security.PrincipalAnonymous
-				}
-
-				sb.WriteString(principal.ID)
-
-				return sb.String()
-			},
-			LimitReached: func(fiber.Ctx) error {
-				return result.ErrTooManyRequests
-			},
-		}),
+// NewRateLimit creates a new rate limit middleware backed by the given store, or by a default store when it is nil.
+func NewRateLimit(store ratelimit.Store, redisProvider *redis.ClientProvider) api.Middleware {
+	if store == nil {
+		store = newDefaultRateLimitStore(redisProvider)
 	}
+
+	return &RateLimit{store: store}
+}
+
+// newDefaultRateLimitStore returns a Redis store when Redis is configured, and an in-memory store otherwise.
+func newDefaultRateLimitStore(redisProvider *redis.ClientProvider) ratelimit.Store {
+	if redisProvider != nil {
+		if client := redisProvider.ConfiguredClient(); client != nil {
+			return ratelimit.NewRedisStore(client)
+		}
+	}
+
+	return ratelimit.NewMemoryStore()
 }
 
 // Name returns the middleware name.
@@ -86,5 +65,66 @@ func (*RateLimit) Order() int {
 
 // Process handles the rate limiting.
 func (m *RateLimit) Process(ctx fiber.Ctx) error {
-	return m.h(ctx)
+	op := shared.Operation(ctx)
+	if op == nil || !op.HasRateLimit() {
+		return ctx.Next()
+	}
+
+	limit := ratelimit.Limit{
+		Algorithm: op.RateLimit.Algorithm,
+		Max:       op.RateLimit.Max,
+		Period:    op.RateLimit.Period,
+	}
+	if limit.Period <= 0 {
+		limit.Period = defaultRateLimitPeriod
+	}
+
+	res, err := m.store.Allow(ctx.Context(), m.buildKey(ctx), limit)
+	if err != nil {
+		// Fail open so that an unavailable store does not take the whole API down.
+		contextx.Logger(ctx).Errorf("Failed to check rate limit for %s: %v", op.Identifier, err)
+
+		return ctx.Next()
+	}
+
+	ctx.Set(headerRateLimitLimit, strconv.Itoa(res.Limit))
+	ctx.Set(headerRateLimitRemaining, strconv.Itoa(res.Remaining))
+	ctx.Set(headerRateLimitReset, formatSeconds(res.ResetAfter))
+
+	if !res.Allowed {
+		ctx.Set(fiber.HeaderRetryAfter, formatSeconds(res.RetryAfter))
+
+		return result.ErrTooManyRequests
+	}
+
+	return ctx.Next()
+}
+
+// buildKey scopes the quota to the operation, client IP and principal.
+func (*RateLimit) buildKey(ctx fiber.Ctx) string {
+	var sb strings.Builder
+	if req := shared.Request(ctx); req != nil {
+		sb.WriteString(req.Resource)
+		sb.WriteByte(':')
+		sb.WriteString(req.Version)
+		sb.WriteByte(':')
+		sb.WriteString(req.Action)
+		sb.WriteByte(':')
+		sb.WriteString(httpx.GetIP(ctx))
+		sb.WriteByte(':')
+	}
+
+	principal := contextx.Principal(ctx)
+	if principal == nil {
+		principal = security.PrincipalAnonymous
+	}
+
+	sb.WriteString(principal.ID)
+
+	return sb.String()
+}
+
+// formatSeconds rounds a duration up to whole seconds as used by rate limit headers.
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
 }
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/contextx"
+	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+// Tracing names the server span of the request after the API operation
+// and records the principal and the result code of the operation.
+type Tracing struct{}
+
+// NewTracing creates a new tracing middleware.
+func NewTracing() api.Middleware {
+	return &Tracing{}
+}
+
+// Name returns the middleware name.
+func (*Tracing) Name() string {
+	return "tracing"
+}
+
+// Order returns the middleware order.
+// Runs before authentication (-100), so rejected requests are named after their operation too.
+func (*Tracing) Order() int {
+	return -110
+}
+
+// Process annotates the server span with the operation.
+func (*Tracing) Process(ctx fiber.Ctx) error {
+	span := trace.SpanFromContext(ctx.Context())
+	if !span.IsRecording() {
+		return ctx.Next()
+	}
+
+	if req := shared.Request(ctx); req != nil {
+		span.SetName(req.Identifier.String())
+		span.SetAttributes(
+			attribute.String(tracing.AttrAPIResource, req.Resource),
+			attribute.String(tracing.AttrAPIAction, req.Action),
+			attribute.String(tracing.AttrAPIVersion, req.Version),
+		)
+	}
+
+	err := ctx.Next()
+
+	if principal := contextx.Principal(ctx); principal != nil {
+		span.SetAttributes(
+			attribute.String("enduser.id", principal.ID),
+			attribute.String("enduser.type", string(principal.Type)),
+		)
+	}
+
+	code := result.OkCode
+	if resultErr, ok := result.AsErr(err); ok {
+		code = resultErr.Code
+	} else if err != nil {
+		code = result.ErrCodeUnknown
+	}
+
+	span.SetAttributes(attribute.Int(tracing.AttrResultCode, code))
+
+	return err
+}
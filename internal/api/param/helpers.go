@@ -20,6 +20,7 @@ var (
 	// BuiltinMetaTypes contains framework built-in types that should be resolved from meta.
 	builtinMetaTypes = []reflect.Type{
 		reflect.TypeFor[page.Pageable](),
+		reflect.TypeFor[page.CursorPageable](),
 	}
 )
 
@@ -112,6 +113,16 @@ func searchEmbeddedFields(target reflect.Value, paramType reflect.Type) reflect.
 	return foundField
 }
 
+// IsParamsType reports whether the handler parameter type is resolved from request params.
+func IsParamsType(t reflect.Type) bool {
+	return embedsAPIParams(t) || isBuiltinParamsType(t)
+}
+
+// IsMetaType reports whether the handler parameter type is resolved from request meta.
+func IsMetaType(t reflect.Type) bool {
+	return embedsAPIMeta(t) || isBuiltinMetaType(t)
+}
+
 func embedsAPIParams(targetType reflect.Type) bool {
 	return embedsSentinelType(targetType, apiParamsType)
 }
@@ -1,21 +1,29 @@
 package param
 
 import (
+	"crypto/rand"
+	"fmt"
+
 	"github.com/gofiber/fiber/v3"
 	"go.uber.org/fx"
 
 	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/config"
 	"github.com/coldsmirk/vef-framework-go/contextx"
 	"github.com/coldsmirk/vef-framework-go/cron"
 	"github.com/coldsmirk/vef-framework-go/event"
 	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	ilogx "github.com/coldsmirk/vef-framework-go/internal/logx"
 	"github.com/coldsmirk/vef-framework-go/logx"
 	"github.com/coldsmirk/vef-framework-go/mold"
 	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/page"
 	"github.com/coldsmirk/vef-framework-go/security"
 	"github.com/coldsmirk/vef-framework-go/storage"
 )
 
+var logger = ilogx.Named("api.param")
+
 // Handler param resolver constructors
 
 func NewCtxResolver() api.HandlerParamResolver {
@@ -92,6 +100,20 @@ func NewStorageFactoryResolver(service storage.Service) api.FactoryParamResolver
 	return newFactoryValueResolver(service)
 }
 
+func NewCursorCodecFactoryResolver(cfg *config.AppConfig) (api.FactoryParamResolver, error) {
+	secret := []byte(cfg.Secret)
+	if len(secret) == 0 {
+		logger.Warn("No application secret configured, pagination cursors will not survive restarts or work across instances")
+
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate cursor secret: %w", err)
+		}
+	}
+
+	return newFactoryValueResolver(page.NewCursorCodec(secret)), nil
+}
+
 var Module = fx.Module(
 	"vef:api:param",
 	fx.Provide(
@@ -158,6 +180,10 @@ var Module = fx.Module(
 			NewStorageFactoryResolver,
 			fx.ResultTags(`group:"vef:api:factory_param_resolvers"`),
 		),
+		fx.Annotate(
+			NewCursorCodecFactoryResolver,
+			fx.ResultTags(`group:"vef:api:factory_param_resolvers"`),
+		),
 	),
 	fx.Provide(
 		fx.Annotate(
@@ -5,5 +5,6 @@ const (
 	MetaKeyRESTHTTPMethod = "__http_method"
 	MetaKeyRESTHTTPPath   = "__http_path"
 
-	AuthOptionPermToken = "__perm_token"
+	AuthOptionPermToken   = "__perm_token"
+	AuthOptionAllowScoped = "__allow_scoped"
 )
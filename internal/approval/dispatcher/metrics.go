@@ -0,0 +1,19 @@
+package dispatcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/coldsmirk/vef-framework-go/metrics"
+)
+
+// dispatchedEvents counts the domain events relayed from the outbox, e.g. approval.instance.created
+// or approval.task.approved, which makes it the counter of instances and tasks going through the engine.
+var dispatchedEvents = metrics.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "approval",
+		Name:      "events_dispatched_total",
+		Help:      "Number of approval domain events dispatched from the outbox by event and result, success or failure.",
+	},
+	"event", "result",
+)
@@ -157,10 +157,13 @@ func (r *Relay) dispatchOne(ctx context.Context, record *approval.EventOutbox) e
 
 	if err := r.dispatcher.Dispatch(ctx, *record); err != nil {
 		logger.Errorf("Dispatch failed for event %s: %v", record.EventID, err)
+		dispatchedEvents.WithLabelValues(record.EventType, "failure").Inc()
 
 		return r.markFailed(ctx, record, err, now)
 	}
 
+	dispatchedEvents.WithLabelValues(record.EventType, "success").Inc()
+
 	return r.markCompleted(ctx, record, now)
 }
 
@@ -120,6 +120,8 @@ func (s *RelayTestSuite) TestNoPendingEvents() {
 }
 
 func (s *RelayTestSuite) TestDispatchesPendingSuccessfully() {
+	dispatched := testx.MetricValue(s.T(), dispatchedEvents.WithLabelValues("approval.instance.created", "success"))
+
 	s.insertRecord(&approval.EventOutbox{
 		EventID:   "evt-1",
 		EventType: "approval.instance.created",
@@ -135,6 +137,7 @@ func (s *RelayTestSuite) TestDispatchesPendingSuccessfully() {
 	record := s.getRecord(s.dispatcher.Dispatched[0].ID)
 	s.Assert().Equal(approval.EventOutboxCompleted, record.Status, "Should mark as completed")
 	s.Assert().NotNil(record.ProcessedAt, "Should set ProcessedAt")
+	s.Assert().Equal(dispatched+1, testx.MetricValue(s.T(), dispatchedEvents.WithLabelValues("approval.instance.created", "success")), "Should count the dispatched event")
 }
 
 func (s *RelayTestSuite) TestClaimsEventAsProcessingBeforeDispatch() {
@@ -162,6 +165,7 @@ func (s *RelayTestSuite) TestClaimsEventAsProcessingBeforeDispatch() {
 
 func (s *RelayTestSuite) TestDispatchFailureMarksRecordFailed() {
 	s.dispatcher.Err = errors.New("connection refused")
+	failed := testx.MetricValue(s.T(), dispatchedEvents.WithLabelValues("approval.test", "failure"))
 
 	rec := &approval.EventOutbox{
 		EventID:   "evt-fail",
@@ -180,6 +184,7 @@ func (s *RelayTestSuite) TestDispatchFailureMarksRecordFailed() {
 	s.Require().NotNil(record.LastError, "Should set last error")
 	s.Assert().Equal("connection refused", *record.LastError, "Should record error message")
 	s.Require().NotNil(record.RetryAfter, "Should schedule retry")
+	s.Assert().Equal(failed+1, testx.MetricValue(s.T(), dispatchedEvents.WithLabelValues("approval.test", "failure")), "Should count the failed dispatch")
 }
 
 func (s *RelayTestSuite) TestExponentialBackoff() {
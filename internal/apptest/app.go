@@ -11,15 +11,18 @@ import (
 	"github.com/coldsmirk/vef-framework-go/config"
 	"github.com/coldsmirk/vef-framework-go/internal/api"
 	"github.com/coldsmirk/vef-framework-go/internal/app"
+	"github.com/coldsmirk/vef-framework-go/internal/audit"
 	iconfig "github.com/coldsmirk/vef-framework-go/internal/config"
 	"github.com/coldsmirk/vef-framework-go/internal/cqrs"
 	"github.com/coldsmirk/vef-framework-go/internal/cron"
 	"github.com/coldsmirk/vef-framework-go/internal/database"
 	"github.com/coldsmirk/vef-framework-go/internal/event"
 	"github.com/coldsmirk/vef-framework-go/internal/mcp"
+	"github.com/coldsmirk/vef-framework-go/internal/metrics"
 	"github.com/coldsmirk/vef-framework-go/internal/middleware"
 	"github.com/coldsmirk/vef-framework-go/internal/mold"
 	"github.com/coldsmirk/vef-framework-go/internal/monitor"
+	"github.com/coldsmirk/vef-framework-go/internal/openapi"
 	"github.com/coldsmirk/vef-framework-go/internal/orm"
 	"github.com/coldsmirk/vef-framework-go/internal/redis"
 	"github.com/coldsmirk/vef-framework-go/internal/schema"
@@ -82,6 +85,9 @@ func coreOptions() []fx.Option {
 		monitor.Module,
 		schema.Module,
 		mcp.Module,
+		openapi.Module,
+		metrics.Module,
+		audit.Module,
 		app.Module,
 	}
 }
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/orm"
+)
+
+var errUnsupportedDBKind = errors.New("unsupported database kind")
+
+//go:embed scripts/*.sql
+var scripts embed.FS
+
+// tableName is the table audit logs are written to.
+const tableName = "sys_audit_log"
+
+// Migrate creates the audit log table for the given database kind, unless it exists already.
+func Migrate(ctx context.Context, db orm.DB, kind config.DBKind) error {
+	query := buildTableExistsQuery(kind)
+	if query == "" {
+		return fmt.Errorf("%w %q", errUnsupportedDBKind, kind)
+	}
+
+	var count int
+	if err := db.NewRaw(query, tableName).Scan(ctx, &count); err != nil {
+		return fmt.Errorf("check audit log table: %w", err)
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	sql, err := GetMigrationSQL(kind)
+	if err != nil {
+		return err
+	}
+
+	if _, err = db.NewRaw(sql).Exec(ctx); err != nil {
+		return fmt.Errorf("execute audit migration: %w", err)
+	}
+
+	return nil
+}
+
+// GetMigrationSQL returns the migration SQL script for the given database kind.
+func GetMigrationSQL(kind config.DBKind) (string, error) {
+	data, err := scripts.ReadFile("scripts/" + string(kind) + ".sql")
+	if err != nil {
+		return "", fmt.Errorf("%w %q for audit migration", errUnsupportedDBKind, kind)
+	}
+
+	return string(data), nil
+}
+
+// buildTableExistsQuery builds a SQL query that counts the tables of the given name.
+func buildTableExistsQuery(kind config.DBKind) string {
+	switch kind {
+	case config.Postgres:
+		return "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = 'public' AND table_name = ?"
+	case config.MySQL:
+		return "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?"
+	case config.SQLite:
+		return "SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name = ?"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+)
+
+func TestGetMigrationSQL(t *testing.T) {
+	for _, kind := range []config.DBKind{config.Postgres, config.MySQL, config.SQLite} {
+		t.Run(string(kind), func(t *testing.T) {
+			sql, err := GetMigrationSQL(kind)
+			require.NoError(t, err, "Should load migration SQL")
+			assert.Contains(t, sql, "CREATE TABLE IF NOT EXISTS sys_audit_log", "Should contain audit log table DDL")
+		})
+	}
+
+	t.Run("UnsupportedKind", func(t *testing.T) {
+		_, err := GetMigrationSQL("unknown")
+		require.ErrorIs(t, err, errUnsupportedDBKind, "Should error for unsupported database kind")
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	db := newMigratedDB(t)
+
+	require.NoError(t, Migrate(t.Context(), db, config.SQLite), "Migrating an existing table should be a no-op")
+}
@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/fx"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/cron"
+	"github.com/coldsmirk/vef-framework-go/event"
+	"github.com/coldsmirk/vef-framework-go/internal/logx"
+	"github.com/coldsmirk/vef-framework-go/orm"
+)
+
+// purgeInterval is the interval expired audit logs are purged at.
+const purgeInterval = time.Hour
+
+var (
+	logger = logx.Named("audit")
+
+	// Module provides the persistent audit log sink and its admin resource, both only if enabled.
+	Module = fx.Module(
+		"vef:audit",
+
+		fx.Provide(provideResource),
+		fx.Invoke(setupSink),
+	)
+)
+
+type resourceResult struct {
+	fx.Out
+
+	Resources []api.Resource `group:"vef:api:resources,flatten"`
+}
+
+func provideResource(cfg *config.AuditConfig) resourceResult {
+	if !cfg.Enabled {
+		return resourceResult{}
+	}
+
+	return resourceResult{Resources: []api.Resource{NewResource()}}
+}
+
+func setupSink(
+	lc fx.Lifecycle,
+	cfg *config.AuditConfig,
+	ds *config.DataSourceConfig,
+	db orm.DB,
+	subscriber event.Subscriber,
+	scheduler cron.Scheduler,
+) error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	if cfg.AutoMigrate {
+		lc.Append(fx.StartHook(func(ctx context.Context) error {
+			return Migrate(ctx, db, ds.Kind)
+		}))
+	}
+
+	sink := NewSink(cfg, db)
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			sink.Start(subscriber)
+			logger.Infof("Audit log sink started (batch=%d, interval=%s)", sink.batchSize, sink.flushInterval)
+
+			return nil
+		},
+		OnStop: sink.Stop,
+	})
+
+	if cfg.RetentionDays <= 0 {
+		return nil
+	}
+
+	job, err := scheduler.NewJob(cron.NewDurationJob(
+		purgeInterval,
+		cron.WithName("audit:retention"),
+		cron.WithTags("audit"),
+		cron.WithTask(NewPurger(db, cfg.RetentionDays).Purge),
+	))
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Audit log retention job [%s] registered, keeping logs of the last %d days", job.Name(), cfg.RetentionDays)
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// redactedValue replaces the values of redacted keys.
+const redactedValue = "******"
+
+var keySeparatorRemover = strings.NewReplacer("_", "", "-", "")
+
+// defaultRedactKeys lists the keys holding secrets the framework itself sends or returns.
+// Keys are matched case-insensitively, ignoring underscores and hyphens, and may contain path.Match wildcards.
+var defaultRedactKeys = []string{
+	"*password*",
+	"*passwd*",
+	"*secret*",
+	"token",
+	"*accesstoken",
+	"*refreshtoken",
+	"idtoken",
+	"*challengetoken",
+	"*ceremonytoken",
+	"*apikey",
+	"*privatekey",
+	"authorization",
+	"credential",
+	"credentials",
+}
+
+// redactor replaces the values of secret keys in the params, meta and result data of audit logs.
+type redactor struct {
+	patterns []string
+}
+
+// newRedactor creates a redactor of the default keys and the additional ones.
+func newRedactor(keys []string) *redactor {
+	patterns := make([]string, 0, len(defaultRedactKeys)+len(keys))
+	for _, key := range slices.Concat(defaultRedactKeys, keys) {
+		if key = normalizeKey(key); key != "" {
+			patterns = append(patterns, key)
+		}
+	}
+
+	return &redactor{patterns: patterns}
+}
+
+// Map returns a copy of the map with the values of secret keys redacted, at any depth.
+func (r *redactor) Map(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+
+	redacted := make(map[string]any, len(m))
+	for key, value := range m {
+		if r.matches(key) {
+			redacted[key] = redactedValue
+		} else {
+			redacted[key] = r.Value(value)
+		}
+	}
+
+	return redacted
+}
+
+// Value returns a copy of the decoded JSON value with the values of secret keys redacted, at any depth.
+func (r *redactor) Value(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return r.Map(v)
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = r.Value(item)
+		}
+
+		return redacted
+	default:
+		return value
+	}
+}
+
+func (r *redactor) matches(key string) bool {
+	key = normalizeKey(key)
+	for _, pattern := range r.patterns {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeKey makes camelCase, snake_case and kebab-case spellings of a key match the same pattern.
+func normalizeKey(key string) string {
+	return keySeparatorRemover.Replace(strings.ToLower(key))
+}
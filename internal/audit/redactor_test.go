@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactor(t *testing.T) {
+	t.Run("DefaultKeys", func(t *testing.T) {
+		r := newRedactor(nil)
+
+		redacted := r.Map(map[string]any{
+			"username":     "alice",
+			"password":     "p@ss",
+			"new_password": "n3w",
+			"clientSecret": "s3cret",
+			"refreshToken": "rt",
+			"permToken":    "sys:user:query",
+			"X-Api-Key":    "vef_123",
+		})
+
+		assert.Equal(t, "alice", redacted["username"], "Plain values should be kept")
+		assert.Equal(t, redactedValue, redacted["password"], "Passwords should be redacted")
+		assert.Equal(t, redactedValue, redacted["new_password"], "Snake case keys should be matched")
+		assert.Equal(t, redactedValue, redacted["clientSecret"], "Secrets should be redacted")
+		assert.Equal(t, redactedValue, redacted["refreshToken"], "Tokens should be redacted")
+		assert.Equal(t, "sys:user:query", redacted["permToken"], "Permission tokens are no secrets")
+		assert.Equal(t, redactedValue, redacted["X-Api-Key"], "Kebab case keys should be matched")
+	})
+
+	t.Run("AdditionalKeys", func(t *testing.T) {
+		r := newRedactor([]string{"idCard", "*_card_no"})
+
+		redacted := r.Map(map[string]any{
+			"id_card":    "110101199003070000",
+			"bankCardNo": "6222000000000000",
+			"cardType":   "debit",
+		})
+
+		assert.Equal(t, redactedValue, redacted["id_card"], "Configured keys should be redacted")
+		assert.Equal(t, redactedValue, redacted["bankCardNo"], "Configured patterns should be redacted")
+		assert.Equal(t, "debit", redacted["cardType"], "Other keys should be kept")
+	})
+
+	t.Run("NestedValues", func(t *testing.T) {
+		r := newRedactor(nil)
+		original := map[string]any{
+			"user": map[string]any{"name": "alice", "password": "p@ss"},
+			"keys": []any{map[string]any{"name": "ci", "apiKey": "vef_123"}},
+		}
+
+		redacted := r.Value(original)
+
+		assert.Equal(t, map[string]any{
+			"user": map[string]any{"name": "alice", "password": redactedValue},
+			"keys": []any{map[string]any{"name": "ci", "apiKey": redactedValue}},
+		}, redacted, "Secrets should be redacted at any depth")
+		assert.Equal(t, "p@ss", original["user"].(map[string]any)["password"], "The original value should not be modified")
+	})
+
+	t.Run("ScalarsAndNil", func(t *testing.T) {
+		r := newRedactor(nil)
+
+		assert.Equal(t, "ok", r.Value("ok"), "Scalars should be returned as is")
+		assert.Nil(t, r.Value(nil), "Nil should be returned as is")
+		assert.Nil(t, r.Map(nil), "Nil maps should stay nil")
+	})
+}
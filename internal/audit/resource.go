@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/audit"
+	"github.com/coldsmirk/vef-framework-go/crud"
+	"github.com/coldsmirk/vef-framework-go/sortx"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// LogSearch contains the search parameters for audit logs.
+type LogSearch struct {
+	crud.Sortable
+
+	Kind       *string         `json:"kind" search:"eq,column=kind"`
+	UserID     *string         `json:"userId" search:"eq,column=user_id"`
+	Username   *string         `json:"username" search:"contains,column=username"`
+	Resource   *string         `json:"resource" search:"eq,column=resource"`
+	Action     *string         `json:"action" search:"eq,column=action"`
+	ResultCode *int            `json:"resultCode" search:"eq,column=result_code"`
+	RequestID  *string         `json:"requestId" search:"eq,column=request_id"`
+	TraceID    *string         `json:"traceId" search:"eq,column=trace_id"`
+	StartTime  *timex.DateTime `json:"startTime" search:"gte,column=created_at"`
+	EndTime    *timex.DateTime `json:"endTime" search:"lte,column=created_at"`
+}
+
+// Resource lets admins search and export the audit logs, newest first.
+type Resource struct {
+	api.Resource
+
+	crud.FindPage[audit.Log, LogSearch]
+	crud.Export[audit.Log, LogSearch]
+}
+
+// NewResource creates the audit log resource.
+func NewResource() api.Resource {
+	newestFirst := &sortx.OrderSpec{Column: "created_at", Direction: sortx.OrderDesc}
+
+	return &Resource{
+		Resource: api.NewRPCResource("sys/audit_log"),
+		FindPage: crud.NewFindPage[audit.Log, LogSearch]().
+			WithDefaultSort(newestFirst).
+			PermToken("sys:audit_log:query"),
+		Export: crud.NewExport[audit.Log, LogSearch]().
+			WithDefaultSort(newestFirst).
+			PermToken("sys:audit_log:export"),
+	}
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/coldsmirk/vef-framework-go/audit"
+	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// Purger deletes the audit logs older than the retention period.
+type Purger struct {
+	db            orm.DB
+	retentionDays int
+}
+
+// NewPurger creates a purger keeping the audit logs of the last retentionDays days.
+func NewPurger(db orm.DB, retentionDays int) *Purger {
+	return &Purger{db: db, retentionDays: retentionDays}
+}
+
+// Purge deletes the expired audit logs.
+func (p *Purger) Purge(ctx context.Context) {
+	cutoff := timex.Now().AddDays(-p.retentionDays)
+
+	res, err := p.db.NewDelete().
+		Model((*audit.Log)(nil)).
+		Where(func(cb orm.ConditionBuilder) {
+			cb.LessThan("created_at", cutoff)
+		}).
+		Exec(ctx)
+	if err != nil {
+		logger.Errorf("Failed to purge audit logs created before %s: %v", cutoff, err)
+
+		return
+	}
+
+	if purged, err := res.RowsAffected(); err == nil && purged > 0 {
+		logger.Infof("Purged %d audit logs created before %s", purged, cutoff)
+	}
+}
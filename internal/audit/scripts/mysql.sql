@@ -0,0 +1,28 @@
+-- Audit log
+CREATE TABLE IF NOT EXISTS sys_audit_log (
+    id VARCHAR(32) NOT NULL COMMENT '主键',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '发生时间',
+    tenant_id VARCHAR(64) NOT NULL DEFAULT '' COMMENT '租户ID',
+    kind VARCHAR(16) NOT NULL COMMENT '日志类型',
+    resource VARCHAR(128) NOT NULL COMMENT '资源',
+    action VARCHAR(128) NOT NULL COMMENT '动作',
+    version VARCHAR(16) NOT NULL DEFAULT '' COMMENT '版本',
+    user_id VARCHAR(64) NOT NULL DEFAULT '' COMMENT '用户ID',
+    username VARCHAR(128) NULL COMMENT '登录用户名',
+    request_ip VARCHAR(64) NOT NULL DEFAULT '' COMMENT '请求IP',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '' COMMENT '用户代理',
+    request_id VARCHAR(64) NOT NULL DEFAULT '' COMMENT '请求ID',
+    trace_id VARCHAR(32) NOT NULL DEFAULT '' COMMENT '链路追踪ID',
+    request_params JSON NULL COMMENT '请求参数',
+    request_meta JSON NULL COMMENT '请求元数据',
+    result_code INTEGER NOT NULL DEFAULT 0 COMMENT '结果码',
+    result_message TEXT NOT NULL COMMENT '结果消息',
+    result_data JSON NULL COMMENT '结果数据',
+    elapsed_time BIGINT NOT NULL DEFAULT 0 COMMENT '耗时（毫秒）',
+    CONSTRAINT pk_sys_audit_log PRIMARY KEY (id)
+) COMMENT '审计日志';
+
+CREATE INDEX idx_sys_audit_log__created_at ON sys_audit_log(created_at);
+CREATE INDEX idx_sys_audit_log__tenant_id__created_at ON sys_audit_log(tenant_id, created_at);
+CREATE INDEX idx_sys_audit_log__user_id__created_at ON sys_audit_log(user_id, created_at);
+CREATE INDEX idx_sys_audit_log__resource__action ON sys_audit_log(resource, action, created_at);
@@ -0,0 +1,48 @@
+-- Audit log
+CREATE TABLE IF NOT EXISTS sys_audit_log (
+    id VARCHAR(32) CONSTRAINT pk_sys_audit_log PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT LOCALTIMESTAMP,
+    tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+    kind VARCHAR(16) NOT NULL,
+    resource VARCHAR(128) NOT NULL,
+    action VARCHAR(128) NOT NULL,
+    version VARCHAR(16) NOT NULL DEFAULT '',
+    user_id VARCHAR(64) NOT NULL DEFAULT '',
+    username VARCHAR(128),
+    request_ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    trace_id VARCHAR(32) NOT NULL DEFAULT '',
+    request_params JSONB,
+    request_meta JSONB,
+    result_code INTEGER NOT NULL DEFAULT 0,
+    result_message TEXT NOT NULL DEFAULT '',
+    result_data JSONB,
+    elapsed_time BIGINT NOT NULL DEFAULT 0
+);
+
+COMMENT ON TABLE sys_audit_log IS '审计日志';
+COMMENT ON COLUMN sys_audit_log.id IS '主键';
+COMMENT ON COLUMN sys_audit_log.created_at IS '发生时间';
+COMMENT ON COLUMN sys_audit_log.tenant_id IS '租户ID';
+COMMENT ON COLUMN sys_audit_log.kind IS '日志类型';
+COMMENT ON COLUMN sys_audit_log.resource IS '资源';
+COMMENT ON COLUMN sys_audit_log.action IS '动作';
+COMMENT ON COLUMN sys_audit_log.version IS '版本';
+COMMENT ON COLUMN sys_audit_log.user_id IS '用户ID';
+COMMENT ON COLUMN sys_audit_log.username IS '登录用户名';
+COMMENT ON COLUMN sys_audit_log.request_ip IS '请求IP';
+COMMENT ON COLUMN sys_audit_log.user_agent IS '用户代理';
+COMMENT ON COLUMN sys_audit_log.request_id IS '请求ID';
+COMMENT ON COLUMN sys_audit_log.trace_id IS '链路追踪ID';
+COMMENT ON COLUMN sys_audit_log.request_params IS '请求参数';
+COMMENT ON COLUMN sys_audit_log.request_meta IS '请求元数据';
+COMMENT ON COLUMN sys_audit_log.result_code IS '结果码';
+COMMENT ON COLUMN sys_audit_log.result_message IS '结果消息';
+COMMENT ON COLUMN sys_audit_log.result_data IS '结果数据';
+COMMENT ON COLUMN sys_audit_log.elapsed_time IS '耗时（毫秒）';
+
+CREATE INDEX idx_sys_audit_log__created_at ON sys_audit_log(created_at);
+CREATE INDEX idx_sys_audit_log__tenant_id__created_at ON sys_audit_log(tenant_id, created_at);
+CREATE INDEX idx_sys_audit_log__user_id__created_at ON sys_audit_log(user_id, created_at);
+CREATE INDEX idx_sys_audit_log__resource__action ON sys_audit_log(resource, action, created_at);
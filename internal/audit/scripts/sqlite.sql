@@ -0,0 +1,27 @@
+-- Audit log
+CREATE TABLE IF NOT EXISTS sys_audit_log (
+    id VARCHAR(32) CONSTRAINT pk_sys_audit_log PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT (datetime('now', 'localtime')),
+    tenant_id VARCHAR(64) NOT NULL DEFAULT '',
+    kind VARCHAR(16) NOT NULL,
+    resource VARCHAR(128) NOT NULL,
+    action VARCHAR(128) NOT NULL,
+    version VARCHAR(16) NOT NULL DEFAULT '',
+    user_id VARCHAR(64) NOT NULL DEFAULT '',
+    username VARCHAR(128),
+    request_ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    trace_id VARCHAR(32) NOT NULL DEFAULT '',
+    request_params TEXT,
+    request_meta TEXT,
+    result_code INTEGER NOT NULL DEFAULT 0,
+    result_message TEXT NOT NULL DEFAULT '',
+    result_data TEXT,
+    elapsed_time BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_sys_audit_log__created_at ON sys_audit_log(created_at);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_log__tenant_id__created_at ON sys_audit_log(tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_log__user_id__created_at ON sys_audit_log(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_sys_audit_log__resource__action ON sys_audit_log(resource, action, created_at);
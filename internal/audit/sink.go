@@ -0,0 +1,173 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/audit"
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/event"
+	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/security"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// Sink records API audit and login events as audit logs and writes them to the database in batches.
+// Writing is best effort: logs are dropped when the queue is full or a batch fails to be written,
+// so that auditing never slows down or fails the requests it records.
+type Sink struct {
+	db            orm.DB
+	redactor      *redactor
+	batchSize     int
+	flushInterval time.Duration
+	queue         chan *audit.Log
+	stop          chan struct{}
+	done          chan struct{}
+	unsubscribers []event.UnsubscribeFunc
+}
+
+// NewSink creates a sink writing to the audit log table of the database.
+func NewSink(cfg *config.AuditConfig, db orm.DB) *Sink {
+	return &Sink{
+		db:            db,
+		redactor:      newRedactor(cfg.RedactKeys),
+		batchSize:     cfg.BatchSizeOrDefault(),
+		flushInterval: cfg.FlushIntervalOrDefault(),
+		queue:         make(chan *audit.Log, cfg.QueueSizeOrDefault()),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start subscribes the sink to the API audit and login events and starts writing their logs.
+func (s *Sink) Start(subscriber event.Subscriber) {
+	s.unsubscribers = []event.UnsubscribeFunc{
+		api.SubscribeAuditEvent(subscriber, s.recordAuditEvent),
+		security.SubscribeLoginEvent(subscriber, s.recordLoginEvent),
+	}
+
+	go s.run()
+}
+
+// Stop unsubscribes the sink and writes the logs still queued, waiting until they are written or ctx is done.
+func (s *Sink) Stop(ctx context.Context) error {
+	for _, unsubscribe := range s.unsubscribers {
+		unsubscribe()
+	}
+
+	close(s.stop)
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Sink) recordAuditEvent(_ context.Context, evt *api.AuditEvent) {
+	s.enqueue(&audit.Log{
+		CreatedAt:     timex.DateTime(evt.Time()),
+		Kind:          audit.KindAPI,
+		Resource:      evt.Resource,
+		Action:        evt.Action,
+		Version:       evt.Version,
+		TenantModel:   orm.TenantModel{TenantID: evt.TenantID},
+		UserID:        evt.UserID,
+		RequestIP:     evt.RequestIP,
+		UserAgent:     evt.UserAgent,
+		RequestID:     evt.RequestID,
+		TraceID:       evt.TraceID,
+		RequestParams: s.redactor.Map(evt.RequestParams),
+		RequestMeta:   s.redactor.Map(evt.RequestMeta),
+		ResultCode:    evt.ResultCode,
+		ResultMessage: evt.ResultMessage,
+		ResultData:    s.redactor.Value(evt.ResultData),
+		ElapsedTime:   evt.ElapsedTime,
+	})
+}
+
+func (s *Sink) recordLoginEvent(_ context.Context, evt *security.LoginEvent) {
+	meta := audit.Payload{"authType": evt.AuthType}
+	if evt.LockoutReason != "" {
+		meta["lockoutReason"] = evt.LockoutReason
+	}
+
+	s.enqueue(&audit.Log{
+		CreatedAt:     timex.DateTime(evt.Time()),
+		Kind:          audit.KindLogin,
+		Resource:      audit.LoginResource,
+		Action:        audit.LoginAction,
+		TenantModel:   orm.TenantModel{TenantID: evt.TenantID},
+		UserID:        lo.FromPtr(evt.UserID),
+		Username:      lo.EmptyableToPtr(evt.Username),
+		RequestIP:     evt.LoginIP,
+		UserAgent:     evt.UserAgent,
+		TraceID:       evt.TraceID,
+		RequestMeta:   meta,
+		ResultCode:    evt.ErrorCode,
+		ResultMessage: evt.FailReason,
+	})
+}
+
+// enqueue queues the log without blocking the event handler, dropping it if the queue is full.
+func (s *Sink) enqueue(log *audit.Log) {
+	select {
+	case s.queue <- log:
+	default:
+		logger.Warnf("Audit log queue is full, dropping log of %s:%s", log.Resource, log.Action)
+	}
+}
+
+// run writes the queued logs whenever a batch is full or the flush interval elapsed, until the sink is stopped.
+func (s *Sink) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*audit.Log, 0, s.batchSize)
+
+	for {
+		select {
+		case log := <-s.queue:
+			if batch = append(batch, log); len(batch) >= s.batchSize {
+				batch = s.write(batch)
+			}
+
+		case <-ticker.C:
+			batch = s.write(batch)
+
+		case <-s.stop:
+			for {
+				select {
+				case log := <-s.queue:
+					if batch = append(batch, log); len(batch) >= s.batchSize {
+						batch = s.write(batch)
+					}
+
+				default:
+					s.write(batch)
+
+					return
+				}
+			}
+		}
+	}
+}
+
+// write inserts the batch, returning an empty batch to collect the next logs in.
+func (s *Sink) write(batch []*audit.Log) []*audit.Log {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	if _, err := s.db.NewInsert().Model(&batch).Exec(context.Background()); err != nil {
+		logger.Errorf("Failed to write %d audit logs: %v", len(batch), err)
+	}
+
+	return make([]*audit.Log, 0, s.batchSize)
+}
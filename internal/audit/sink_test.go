@@ -0,0 +1,178 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/audit"
+	"github.com/coldsmirk/vef-framework-go/config"
+	ievent "github.com/coldsmirk/vef-framework-go/internal/event"
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/security"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+func newMigratedDB(t *testing.T) orm.DB {
+	t.Helper()
+
+	db := testx.NewTestDB(t)
+	require.NoError(t, Migrate(t.Context(), db, config.SQLite), "Migration should succeed")
+
+	return db
+}
+
+func findLogs(t *testing.T, db orm.DB) []audit.Log {
+	t.Helper()
+
+	var logs []audit.Log
+	require.NoError(t, db.NewSelect().Model(&logs).OrderBy("kind").Scan(t.Context()), "Logs should be queried")
+
+	return logs
+}
+
+func TestSink(t *testing.T) {
+	t.Run("RecordsEvents", func(t *testing.T) {
+		db := newMigratedDB(t)
+		bus := ievent.NewMemoryBus(nil)
+		require.NoError(t, bus.(*ievent.MemoryBus).Start(), "Bus should start")
+		t.Cleanup(func() {
+			require.NoError(t, bus.(*ievent.MemoryBus).Shutdown(context.Background()), "Bus should stop")
+		})
+
+		sink := NewSink(&config.AuditConfig{FlushInterval: 10 * time.Millisecond}, db)
+		sink.Start(bus)
+
+		bus.Publish(api.NewAuditEvent(api.AuditEventParams{
+			Resource:      "sys/user",
+			Action:        "create",
+			Version:       "v1",
+			UserID:        "u1",
+			TenantID:      "t1",
+			RequestIP:     "10.0.0.1",
+			RequestParams: map[string]any{"username": "bob", "password": "p@ss"},
+			ResultCode:    0,
+			ResultMessage: "ok",
+			ResultData:    map[string]any{"id": "u2"},
+			ElapsedTime:   12,
+		}))
+		bus.Publish(security.NewLoginEvent(security.LoginEventParams{
+			AuthType:   "password",
+			Username:   "bob",
+			TenantID:   "t2",
+			LoginIP:    "10.0.0.2",
+			IsOk:       false,
+			FailReason: "bad credentials",
+			ErrorCode:  1401,
+		}))
+
+		require.Eventually(t, func() bool {
+			count, err := db.NewSelect().Model((*audit.Log)(nil)).Count(context.Background())
+
+			return err == nil && count == 2
+		}, 5*time.Second, 10*time.Millisecond, "Both events should be written once the flush interval elapsed")
+		require.NoError(t, sink.Stop(t.Context()), "Sink should stop")
+
+		logs := findLogs(t, db)
+		require.Len(t, logs, 2, "Both events should be recorded")
+
+		apiLog, loginLog := logs[0], logs[1]
+		assert.Equal(t, audit.KindAPI, apiLog.Kind, "First log should be the API request")
+		assert.Equal(t, "sys/user", apiLog.Resource, "Resource should be recorded")
+		assert.Equal(t, "u1", apiLog.UserID, "User should be recorded")
+		assert.Equal(t, "t1", apiLog.TenantID, "Tenant should be recorded")
+		assert.Equal(t, redactedValue, apiLog.RequestParams["password"], "Secret params should be redacted")
+		assert.Equal(t, "bob", apiLog.RequestParams["username"], "Other params should be kept")
+		assert.Equal(t, map[string]any{"id": "u2"}, apiLog.ResultData, "Result data should be recorded")
+		assert.Equal(t, int64(12), apiLog.ElapsedTime, "Elapsed time should be recorded")
+
+		assert.Equal(t, audit.KindLogin, loginLog.Kind, "Second log should be the login")
+		assert.Equal(t, audit.LoginResource, loginLog.Resource, "Logins should be recorded under the auth resource")
+		assert.Equal(t, audit.LoginAction, loginLog.Action, "Logins should be recorded as login action")
+		assert.Equal(t, "bob", lo.FromPtr(loginLog.Username), "Username should be recorded")
+		assert.Empty(t, loginLog.UserID, "Failed logins have no user")
+		assert.Equal(t, "t2", loginLog.TenantID, "Tenant should be recorded")
+		assert.Equal(t, 1401, loginLog.ResultCode, "Error code should be recorded as result code")
+		assert.Equal(t, "bad credentials", loginLog.ResultMessage, "Fail reason should be recorded as result message")
+		assert.Equal(t, "password", loginLog.RequestMeta["authType"], "Auth type should be recorded as meta")
+	})
+
+	t.Run("ScopedByTenant", func(t *testing.T) {
+		db := newMigratedDB(t)
+		sink := NewSink(&config.AuditConfig{FlushInterval: time.Hour}, db)
+
+		sink.recordAuditEvent(t.Context(), api.NewAuditEvent(api.AuditEventParams{Resource: "sys/user", Action: "create", TenantID: "t1"}))
+		sink.recordAuditEvent(t.Context(), api.NewAuditEvent(api.AuditEventParams{Resource: "sys/user", Action: "create", TenantID: "t2"}))
+		go sink.run()
+		require.NoError(t, sink.Stop(t.Context()), "Sink should write the queued logs on stop")
+
+		logs := findLogs(t, db.WithTenant("t1"))
+		require.Len(t, logs, 1, "Tenant-scoped queries should only see the logs of the tenant")
+		assert.Equal(t, "t1", logs[0].TenantID, "Log of the tenant should be returned")
+	})
+
+	t.Run("WritesQueuedLogsOnStop", func(t *testing.T) {
+		db := newMigratedDB(t)
+		sink := NewSink(&config.AuditConfig{FlushInterval: time.Hour}, db)
+
+		sink.recordAuditEvent(t.Context(), api.NewAuditEvent(api.AuditEventParams{Resource: "sys/user", Action: "find_page"}))
+		go sink.run()
+		require.NoError(t, sink.Stop(t.Context()), "Sink should write the queued logs on stop")
+
+		assert.Len(t, findLogs(t, db), 1, "The queued log should be written")
+	})
+
+	t.Run("WritesFullBatches", func(t *testing.T) {
+		db := newMigratedDB(t)
+		sink := NewSink(&config.AuditConfig{BatchSize: 2, FlushInterval: time.Hour}, db)
+
+		go sink.run()
+		t.Cleanup(func() {
+			close(sink.stop)
+			<-sink.done
+		})
+
+		for range 2 {
+			sink.recordAuditEvent(t.Context(), api.NewAuditEvent(api.AuditEventParams{Resource: "sys/user", Action: "find_page"}))
+		}
+
+		assert.Eventually(t, func() bool {
+			count, err := db.NewSelect().Model((*audit.Log)(nil)).Count(context.Background())
+
+			return err == nil && count == 2
+		}, 5*time.Second, 10*time.Millisecond, "A full batch should be written without waiting for the flush interval")
+	})
+
+	t.Run("DropsLogsWhenQueueIsFull", func(t *testing.T) {
+		sink := NewSink(&config.AuditConfig{QueueSize: 1}, nil)
+
+		sink.recordLoginEvent(t.Context(), security.NewLoginEvent(security.LoginEventParams{Username: "a"}))
+		sink.recordLoginEvent(t.Context(), security.NewLoginEvent(security.LoginEventParams{Username: "b"}))
+
+		assert.Len(t, sink.queue, 1, "Logs beyond the queue size should be dropped")
+	})
+}
+
+func TestPurger(t *testing.T) {
+	db := newMigratedDB(t)
+	now := timex.Now()
+
+	logs := []*audit.Log{
+		{CreatedAt: now.AddDays(-31), Kind: audit.KindAPI, Resource: "sys/user", Action: "old"},
+		{CreatedAt: now.AddDays(-1), Kind: audit.KindAPI, Resource: "sys/user", Action: "recent"},
+	}
+	_, err := db.NewInsert().Model(&logs).Exec(t.Context())
+	require.NoError(t, err, "Logs should be inserted")
+
+	NewPurger(db, 30).Purge(t.Context())
+
+	remaining := findLogs(t, db)
+	require.Len(t, remaining, 1, "Expired logs should be purged")
+	assert.Equal(t, "recent", remaining[0].Action, "Logs within the retention period should be kept")
+}
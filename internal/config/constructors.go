@@ -1,10 +1,14 @@
 package config
 
 import (
+	"cmp"
 	"fmt"
 
+	"github.com/samber/lo"
+
 	"github.com/coldsmirk/vef-framework-go/config"
 	"github.com/coldsmirk/vef-framework-go/internal/monitor"
+	"github.com/coldsmirk/vef-framework-go/security"
 )
 
 // unmarshalConfig is a generic helper that unmarshals configuration from a given key.
@@ -20,6 +24,10 @@ func newAppConfig(cfg config.Config) (*config.AppConfig, error) {
 	return unmarshalConfig(cfg, "vef.app", new(config.AppConfig))
 }
 
+func newLogConfig(cfg config.Config) (*config.LogConfig, error) {
+	return unmarshalConfig(cfg, "vef.log", new(config.LogConfig))
+}
+
 func newDataSourceConfig(cfg config.Config) (*config.DataSourceConfig, error) {
 	return unmarshalConfig(cfg, "vef.data_source", new(config.DataSourceConfig))
 }
@@ -32,6 +40,30 @@ func newSecurityConfig(cfg config.Config) (*config.SecurityConfig, error) {
 	return unmarshalConfig(cfg, "vef.security", new(config.SecurityConfig))
 }
 
+// newJWTConfig reads the JWT settings, defaulting the audience to the snake-cased application name.
+func newJWTConfig(cfg config.Config, appConfig *config.AppConfig) (*security.JWTConfig, error) {
+	jwtConfig, err := unmarshalConfig(cfg, "vef.security.jwt", new(security.JWTConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	jwtConfig.Audience = cmp.Or(jwtConfig.Audience, lo.SnakeCase(appConfig.Name))
+
+	return jwtConfig, nil
+}
+
+func newOIDCConfig(cfg config.Config) (*config.OIDCConfig, error) {
+	return unmarshalConfig(cfg, "vef.security.oidc", new(config.OIDCConfig))
+}
+
+func newWebAuthnConfig(cfg config.Config) (*security.WebAuthnConfig, error) {
+	return unmarshalConfig(cfg, "vef.security.webauthn", new(security.WebAuthnConfig))
+}
+
+func newPasswordPolicyConfig(cfg config.Config) (*security.PasswordPolicyConfig, error) {
+	return unmarshalConfig(cfg, "vef.security.password_policy", new(security.PasswordPolicyConfig))
+}
+
 func newRedisConfig(cfg config.Config) (*config.RedisConfig, error) {
 	return unmarshalConfig(cfg, "vef.redis", new(config.RedisConfig))
 }
@@ -50,6 +82,34 @@ func newMCPConfig(cfg config.Config) (*config.MCPConfig, error) {
 	return unmarshalConfig(cfg, "vef.mcp", new(config.MCPConfig))
 }
 
+func newOpenAPIConfig(cfg config.Config) (*config.OpenAPIConfig, error) {
+	return unmarshalConfig(cfg, "vef.openapi", new(config.OpenAPIConfig))
+}
+
+func newMetricsConfig(cfg config.Config) (*config.MetricsConfig, error) {
+	return unmarshalConfig(cfg, "vef.metrics", new(config.MetricsConfig))
+}
+
+func newHealthConfig(cfg config.Config) (*config.HealthConfig, error) {
+	return unmarshalConfig(cfg, "vef.health", new(config.HealthConfig))
+}
+
+func newAuditConfig(cfg config.Config) (*config.AuditConfig, error) {
+	return unmarshalConfig(cfg, "vef.audit", new(config.AuditConfig))
+}
+
 func newApprovalConfig(cfg config.Config) (*config.ApprovalConfig, error) {
 	return unmarshalConfig(cfg, "vef.approval", new(config.ApprovalConfig))
 }
+
+func newTenantConfig(cfg config.Config) (*config.TenantConfig, error) {
+	return unmarshalConfig(cfg, "vef.tenant", new(config.TenantConfig))
+}
+
+func newMigrationConfig(cfg config.Config) (*config.MigrationConfig, error) {
+	return unmarshalConfig(cfg, "vef.migration", new(config.MigrationConfig))
+}
+
+func newDataSourcesConfig(cfg config.Config) (*config.DataSourcesConfig, error) {
+	return unmarshalConfig(cfg, "vef.data_sources", new(config.DataSourcesConfig))
+}
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"context"
+
 	"go.uber.org/fx"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	ilogx "github.com/coldsmirk/vef-framework-go/internal/logx"
 )
 
 var Module = fx.Module(
@@ -9,13 +14,35 @@ var Module = fx.Module(
 	fx.Provide(
 		newConfig,
 		newAppConfig,
+		newLogConfig,
 		newDataSourceConfig,
+		newDataSourcesConfig,
 		newCorsConfig,
 		newSecurityConfig,
+		newJWTConfig,
+		newOIDCConfig,
+		newWebAuthnConfig,
+		newPasswordPolicyConfig,
 		newRedisConfig,
 		newStorageConfig,
 		newMonitorConfig,
 		newMCPConfig,
+		newOpenAPIConfig,
+		newMetricsConfig,
+		newHealthConfig,
+		newAuditConfig,
 		newApprovalConfig,
+		newTenantConfig,
+		newMigrationConfig,
 	),
+	fx.Invoke(configureLogger),
 )
+
+// configureLogger applies the logging configuration as early as possible and flushes the logs on shutdown.
+func configureLogger(lc fx.Lifecycle, logConfig *config.LogConfig) error {
+	lc.Append(fx.StopHook(func(context.Context) {
+		_ = ilogx.Sync()
+	}))
+
+	return ilogx.Configure(logConfig)
+}
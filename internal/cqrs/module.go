@@ -1,13 +1,19 @@
 package cqrs
 
-import "go.uber.org/fx"
+import (
+	"slices"
+
+	"go.uber.org/fx"
+)
 
 // Module provides the CQRS Bus to the DI container.
 var Module = fx.Module(
 	"vef:cqrs",
 	fx.Provide(
 		fx.Annotate(
-			NewBus,
+			func(behaviors []Behavior) Bus {
+				return NewBus(slices.Concat([]Behavior{NewTracingBehavior()}, behaviors))
+			},
 			fx.ParamTags(`group:"vef:cqrs:behaviors"`),
 		),
 	),
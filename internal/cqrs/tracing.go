@@ -0,0 +1,44 @@
+package cqrs
+
+import (
+	"context"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+// TracingBehavior runs every command and query in a span named after the action type.
+// It is the outermost behavior of the bus, so the span covers the other behaviors too.
+type TracingBehavior struct{}
+
+// NewTracingBehavior creates a new TracingBehavior.
+func NewTracingBehavior() Behavior {
+	return &TracingBehavior{}
+}
+
+// Handle dispatches the action in a child span of the caller.
+func (*TracingBehavior) Handle(ctx context.Context, action Action, next func(context.Context) (any, error)) (any, error) {
+	kind := "command"
+	if action.Kind() == Query {
+		kind = "query"
+	}
+
+	name := reflect.TypeOf(action).String()
+
+	ctx, span := tracing.StartChild(
+		ctx,
+		"cqrs "+name,
+		trace.WithAttributes(
+			attribute.String(tracing.AttrCQRSAction, name),
+			attribute.String(tracing.AttrCQRSKind, kind),
+		),
+	)
+
+	result, err := next(ctx)
+	tracing.End(span, err)
+
+	return result, err
+}
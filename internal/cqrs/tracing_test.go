@@ -0,0 +1,54 @@
+package cqrs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+// TestTracingBehavior tests that actions sent within a traced context are recorded as child spans.
+func TestTracingBehavior(t *testing.T) {
+	recorder := testx.RecordSpans(t)
+
+	bus := NewBus([]Behavior{NewTracingBehavior()})
+	Register(bus, HandlerFunc[GetUserQuery, GetUserResult](func(context.Context, GetUserQuery) (GetUserResult, error) {
+		return GetUserResult{Name: "alice"}, nil
+	}))
+	Register(bus, HandlerFunc[DeleteUserCmd, Unit](func(context.Context, DeleteUserCmd) (Unit, error) {
+		return Unit{}, errors.New("user is referenced")
+	}))
+
+	t.Run("Untraced", func(t *testing.T) {
+		recorder.Reset()
+
+		_, err := Send[GetUserQuery, GetUserResult](context.Background(), bus, GetUserQuery{ID: "1"})
+		require.NoError(t, err, "Should send the query")
+		assert.Empty(t, recorder.Ended(), "Should not start a trace of its own")
+	})
+
+	t.Run("Traced", func(t *testing.T) {
+		recorder.Reset()
+
+		ctx, span := tracing.Tracer().Start(context.Background(), "request")
+		_, queryErr := Send[GetUserQuery, GetUserResult](ctx, bus, GetUserQuery{ID: "1"})
+		_, commandErr := Send[DeleteUserCmd, Unit](ctx, bus, DeleteUserCmd{ID: "1"})
+		span.End()
+
+		require.NoError(t, queryErr, "Should send the query")
+		require.Error(t, commandErr, "Should return the handler error")
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 3, "Should record a span per action")
+		assert.Equal(t, "cqrs cqrs.GetUserQuery", spans[0].Name(), "Should name the span after the action type")
+		assert.Equal(t, codes.Unset, spans[0].Status().Code, "Should leave successful actions unset")
+		assert.Equal(t, "cqrs cqrs.DeleteUserCmd", spans[1].Name(), "Should name the span after the action type")
+		assert.Equal(t, codes.Error, spans[1].Status().Code, "Should mark failed actions")
+	})
+}
@@ -0,0 +1,10 @@
+package cron
+
+import "errors"
+
+var (
+	// ErrSchedulerNotRunning indicates scheduler not started or already shut down.
+	ErrSchedulerNotRunning = errors.New("cron scheduler not running")
+	// ErrJobQueueFull indicates all job slots busy with runs waiting, jobs run late.
+	ErrJobQueueFull = errors.New("cron job queue full")
+)
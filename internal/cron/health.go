@@ -0,0 +1,24 @@
+package cron
+
+import (
+	"context"
+
+	"github.com/go-co-op/gocron/v2"
+
+	"github.com/coldsmirk/vef-framework-go/monitor"
+)
+
+// newHealthIndicator checks that the scheduler is running and keeps up with its jobs.
+func newHealthIndicator(scheduler gocron.Scheduler, status *schedulerStatus) monitor.HealthIndicator {
+	return monitor.NewHealthIndicator("cron", func(context.Context) error {
+		if !status.running.Load() {
+			return ErrSchedulerNotRunning
+		}
+
+		if scheduler.JobsWaitingInQueue() >= maxConcurrentJobs {
+			return ErrJobQueueFull
+		}
+
+		return nil
+	})
+}
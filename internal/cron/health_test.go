@@ -0,0 +1,28 @@
+package cron
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
+)
+
+// TestHealthIndicator tests that the health indicator reports whether the scheduler is running.
+func TestHealthIndicator(t *testing.T) {
+	lc := fxtest.NewLifecycle(t)
+
+	scheduler, status, err := newScheduler(lc)
+	require.NoError(t, err, "Should create the scheduler")
+
+	indicator := newHealthIndicator(scheduler, status)
+
+	assert.ErrorIs(t, indicator.Check(context.Background()), ErrSchedulerNotRunning, "Should fail before the scheduler is started")
+
+	lc.RequireStart()
+	assert.NoError(t, indicator.Check(context.Background()), "Should pass while the scheduler is running")
+
+	lc.RequireStop()
+	assert.ErrorIs(t, indicator.Check(context.Background()), ErrSchedulerNotRunning, "Should fail after the scheduler is stopped")
+}
@@ -10,5 +10,11 @@ import (
 var Module = fx.Module(
 	"vef:cron",
 	fx.Provide(newScheduler, fx.Private),
-	fx.Provide(cron.NewScheduler),
+	fx.Provide(
+		cron.NewScheduler,
+		fx.Annotate(
+			newHealthIndicator,
+			fx.ResultTags(`group:"vef:health:indicators"`),
+		),
+	),
 )
@@ -6,12 +6,41 @@ import (
 
 	"github.com/go-co-op/gocron/v2"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/coldsmirk/vef-framework-go/metrics"
+)
+
+var (
+	jobRuns = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "cron",
+			Name:      "job_runs_total",
+			Help:      "Number of cron job runs by job and status, e.g. success or fail.",
+		},
+		"job", "status",
+	)
+	jobDuration = metrics.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "cron",
+			Name:      "job_duration_seconds",
+			Help:      "Duration of cron job runs by job.",
+			Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 900},
+		},
+		"job",
+	)
 )
 
-// jobMonitor implements gocron.Monitor interface to track job execution metrics. It provides detailed logging for job lifecycle events including timing and status.
+// jobMonitor implements gocron.Monitor interface to track job execution metrics. It provides detailed logging for job lifecycle events including timing and status,
+// and counts and times the job runs in the vef_cron_job_runs_total and vef_cron_job_duration_seconds metrics.
 type jobMonitor struct{}
 
 func (*jobMonitor) RecordJobTimingWithStatus(startTime, endTime time.Time, id uuid.UUID, name string, tags []string, status gocron.JobStatus, err error) {
+	jobRuns.WithLabelValues(name, string(status)).Inc()
+	jobDuration.WithLabelValues(name).Observe(endTime.Sub(startTime).Seconds())
+
 	switch status {
 	case gocron.Success:
 		logger.Infof(
@@ -0,0 +1,27 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+)
+
+// TestJobMonitorMetrics tests that job runs are counted by status and timed.
+func TestJobMonitorMetrics(t *testing.T) {
+	monitor := newJobMonitor()
+	start := time.Now()
+
+	monitor.RecordJobTimingWithStatus(start, start.Add(2*time.Second), uuid.New(), "cleanup", nil, gocron.Success, nil)
+	monitor.RecordJobTimingWithStatus(start, start.Add(time.Second), uuid.New(), "cleanup", nil, gocron.Fail, errors.New("disk full"))
+
+	assert.Equal(t, 1.0, testx.MetricValue(t, jobRuns.WithLabelValues("cleanup", "success")), "Should count the successful run")
+	assert.Equal(t, 1.0, testx.MetricValue(t, jobRuns.WithLabelValues("cleanup", "fail")), "Should count the failed run")
+	assert.Equal(t, 2.0, testx.MetricValue(t, jobDuration.WithLabelValues("cleanup").(prometheus.Histogram)), "Should time both runs")
+}
@@ -2,6 +2,7 @@ package cron
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-co-op/gocron/v2"
@@ -12,28 +13,41 @@ import (
 
 var logger = logx.Named("cron")
 
+// maxConcurrentJobs is the number of jobs running at the same time, further runs wait in the queue.
+const maxConcurrentJobs = 1000
+
+// schedulerStatus tracks whether the scheduler is running, which gocron does not expose.
+type schedulerStatus struct {
+	running atomic.Bool
+}
+
 // newScheduler creates a new gocron scheduler with optimal configuration for production use.
-func newScheduler(lc fx.Lifecycle) (gocron.Scheduler, error) {
+func newScheduler(lc fx.Lifecycle) (gocron.Scheduler, *schedulerStatus, error) {
 	scheduler, err := gocron.NewScheduler(
 		gocron.WithLocation(time.Local),
 		gocron.WithStopTimeout(30*time.Second),
 		gocron.WithLogger(newCronLogger()),
 		gocron.WithMonitorStatus(newJobMonitor()),
-		gocron.WithLimitConcurrentJobs(1000, gocron.LimitModeWait),
+		gocron.WithLimitConcurrentJobs(maxConcurrentJobs, gocron.LimitModeWait),
 		// gocron.WithGlobalJobOptions(
 		// 	gocron.WithSingletonMode(gocron.LimitModeWait),
 		// ),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create cron scheduler: %w", err)
+		return nil, nil, fmt.Errorf("failed to create cron scheduler: %w", err)
 	}
 
+	status := new(schedulerStatus)
+
 	lc.Append(fx.StartStopHook(
 		func() {
 			scheduler.Start()
+			status.running.Store(true)
 			logger.Info("Cron scheduler started")
 		},
 		func() error {
+			status.running.Store(false)
+
 			if err := scheduler.Shutdown(); err != nil {
 				return fmt.Errorf("failed to stop scheduler: %w", err)
 			}
@@ -44,5 +58,5 @@ func newScheduler(lc fx.Lifecycle) (gocron.Scheduler, error) {
 		},
 	))
 
-	return scheduler, nil
+	return scheduler, status, nil
 }
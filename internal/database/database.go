@@ -23,6 +23,8 @@ func logDBVersion(provider DatabaseProvider, db *bun.DB, logger logx.Logger) err
 
 func setupBunDB(sqlDB *sql.DB, dialect schema.Dialect, opts *databaseOptions) *bun.DB {
 	db := bun.NewDB(sqlDB, dialect, opts.BunOptions...)
+	db.AddQueryHook(&tracingQueryHook{system: dialect.Name().String()})
+	db.AddQueryHook(&metricsQueryHook{system: dialect.Name().String()})
 
 	if opts.EnableQueryHook {
 		addQueryHook(db, opts.Logger, opts.SQLGuardConfig)
@@ -9,6 +9,7 @@ import (
 
 var (
 	ErrUnsupportedDBKind  = errors.New("unsupported database type")
+	ErrDataSourceNotFound = errors.New("data source not configured")
 	errPingFailed         = errors.New("database ping failed")
 	errVersionQueryFailed = errors.New("database version query failed")
 )
@@ -0,0 +1,12 @@
+package database
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/coldsmirk/vef-framework-go/monitor"
+)
+
+// newHealthIndicator checks that the primary data source accepts connections.
+func newHealthIndicator(db *bun.DB) monitor.HealthIndicator {
+	return monitor.NewHealthIndicator("database", db.PingContext)
+}
@@ -3,12 +3,15 @@ package database
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/uptrace/bun"
 	"go.uber.org/fx"
 
 	"github.com/coldsmirk/vef-framework-go/config"
 	"github.com/coldsmirk/vef-framework-go/internal/logx"
+	"github.com/coldsmirk/vef-framework-go/metrics"
 )
 
 var (
@@ -17,47 +20,112 @@ var (
 		"vef:database",
 		fx.Provide(
 			fx.Annotate(
-				func(lc fx.Lifecycle, cfg *config.DataSourceConfig) (db *bun.DB, err error) {
-					if db, err = New(cfg); err != nil {
-						return db, err
-					}
-
-					provider, exists := registry.provider(cfg.Kind)
-					if !exists {
-						return nil, newUnsupportedDBKindError(cfg.Kind)
-					}
-
-					lc.Append(
-						fx.StartStopHook(
-							func(ctx context.Context) error {
-								if err := db.PingContext(ctx); err != nil {
-									return wrapPingError(provider.Kind(), err)
-								}
-
-								if err := logDBVersion(provider, db, logger); err != nil {
-									return err
-								}
-
-								logger.Infof("Database client started successfully: %s", provider.Kind())
-
-								return nil
-							},
-							func() error {
-								logger.Info("Closing database connection...")
-
-								return db.Close()
-							},
-						),
-					)
-
-					return db, err
+				func(lc fx.Lifecycle, cfg *config.DataSourceConfig) (*bun.DB, error) {
+					return Open(lc, cfg, "primary")
 				},
 				fx.As(new(bun.IDB)),
 				fx.As(fx.Self()),
 			),
+			fx.Annotate(
+				OpenReplicas,
+				fx.ResultTags(`name:"vef:database:replicas"`),
+			),
 			func(db *bun.DB) *sql.DB {
 				return db.DB
 			},
+			fx.Annotate(
+				newHealthIndicator,
+				fx.ResultTags(`group:"vef:health:indicators"`),
+			),
 		),
 	)
 )
+
+// Open connects to the data source and ties the connection to the application lifecycle:
+// it is pinged on start and closed on stop. The label identifies the data source in logs
+// and in the connection pool metrics.
+func Open(lc fx.Lifecycle, cfg *config.DataSourceConfig, label string) (*bun.DB, error) {
+	db, err := New(cfg)
+	if err != nil {
+		return db, err
+	}
+
+	provider, exists := registry.provider(cfg.Kind)
+	if !exists {
+		return nil, newUnsupportedDBKindError(cfg.Kind)
+	}
+
+	poolStats := collectors.NewDBStatsCollector(db.DB, label)
+
+	lc.Append(
+		fx.StartStopHook(
+			func(ctx context.Context) error {
+				if err := db.PingContext(ctx); err != nil {
+					return wrapPingError(provider.Kind(), err)
+				}
+
+				if err := logDBVersion(provider, db, logger); err != nil {
+					return err
+				}
+
+				if err := metrics.Register(poolStats); err != nil {
+					return fmt.Errorf("failed to register connection pool metrics: %w", err)
+				}
+
+				logger.Infof("Database client started successfully: %s (%s)", provider.Kind(), label)
+
+				return nil
+			},
+			func() error {
+				logger.Infof("Closing database connection (%s)...", label)
+
+				metrics.Unregister(poolStats)
+
+				return db.Close()
+			},
+		),
+	)
+
+	return db, nil
+}
+
+// OpenReplicas connects to the read replicas of the primary data source.
+func OpenReplicas(lc fx.Lifecycle, cfg *config.DataSourceConfig) ([]bun.IDB, error) {
+	return openReplicas(lc, cfg, "replica")
+}
+
+// OpenNamed connects to the named data source configured under vef.data_sources.<name> and its replicas.
+func OpenNamed(lc fx.Lifecycle, cfgs *config.DataSourcesConfig, name string) (*bun.DB, []bun.IDB, error) {
+	cfg, ok := (*cfgs)[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrDataSourceNotFound, name)
+	}
+
+	db, err := Open(lc, &cfg, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	replicas, err := openReplicas(lc, &cfg, name+" replica")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db, replicas, nil
+}
+
+func openReplicas(lc fx.Lifecycle, cfg *config.DataSourceConfig, label string) ([]bun.IDB, error) {
+	replicaConfigs := cfg.ReplicaConfigs()
+	replicas := make([]bun.IDB, 0, len(replicaConfigs))
+
+	for i, replicaConfig := range replicaConfigs {
+		replica, err := Open(lc, replicaConfig, fmt.Sprintf("%s %d", label, i+1))
+		if err != nil {
+			return nil, err
+		}
+
+		replicas = append(replicas, replica)
+	}
+
+	return replicas, nil
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+// maxTracedQueryLength caps the query text recorded on spans, bulk inserts can be megabytes long.
+const maxTracedQueryLength = 4096
+
+// tracingQueryHook records every query of a traced request or job as a client span.
+type tracingQueryHook struct {
+	system string
+}
+
+func (h *tracingQueryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	operation := event.Operation()
+
+	ctx, _ = tracing.StartChild(
+		ctx,
+		operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", h.system),
+			attribute.String("db.operation.name", operation),
+			attribute.String("db.query.text", truncateQuery(event.Query)),
+		),
+	)
+
+	return ctx
+}
+
+func (*tracingQueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	if event.Result != nil {
+		if rows, err := event.Result.RowsAffected(); err == nil {
+			span.SetAttributes(attribute.Int64("db.response.returned_rows", rows))
+		}
+	}
+
+	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) {
+		tracing.RecordError(span, event.Err)
+	}
+
+	span.End()
+}
+
+func truncateQuery(query string) string {
+	query = strings.TrimSpace(whitespaceRegex.ReplaceAllString(query, " "))
+	if len(query) > maxTracedQueryLength {
+		return query[:maxTracedQueryLength] + "..."
+	}
+
+	return query
+}
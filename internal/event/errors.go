@@ -7,4 +7,8 @@ var (
 	ErrEventBusAlreadyStarted = errors.New("event bus already started")
 	// ErrShutdownTimeoutExceeded indicates shutdown wait timeout.
 	ErrShutdownTimeoutExceeded = errors.New("shutdown timeout exceeded")
+	// ErrEventBusNotRunning indicates event bus not started or already shut down.
+	ErrEventBusNotRunning = errors.New("event bus not running")
+	// ErrEventQueueFull indicates event bus queue full, publishers block until events are delivered.
+	ErrEventQueueFull = errors.New("event queue full")
 )
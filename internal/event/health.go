@@ -0,0 +1,35 @@
+package event
+
+import (
+	"context"
+
+	"github.com/coldsmirk/vef-framework-go/event"
+	"github.com/coldsmirk/vef-framework-go/monitor"
+)
+
+// newHealthIndicator checks that the event bus delivers events.
+func newHealthIndicator(bus event.Bus) monitor.HealthIndicator {
+	memoryBus, ok := bus.(*MemoryBus)
+	if !ok {
+		return nil
+	}
+
+	return monitor.NewHealthIndicator("event_bus", memoryBus.check)
+}
+
+// check reports whether the bus is running and has room in its queue.
+func (b *MemoryBus) check(context.Context) error {
+	b.mu.RLock()
+	started := b.started
+	b.mu.RUnlock()
+
+	if !started || b.ctx.Err() != nil {
+		return ErrEventBusNotRunning
+	}
+
+	if len(b.eventCh) == cap(b.eventCh) {
+		return ErrEventQueueFull
+	}
+
+	return nil
+}
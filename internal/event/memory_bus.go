@@ -6,9 +6,12 @@ import (
 	"time"
 
 	"github.com/coldsmirk/go-streams"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/coldsmirk/vef-framework-go/event"
 	"github.com/coldsmirk/vef-framework-go/id"
+	"github.com/coldsmirk/vef-framework-go/tracing"
 )
 
 // MemoryBus is a simple, thread-safe in-memory event bus implementation.
@@ -90,7 +93,21 @@ func (b *MemoryBus) Shutdown(ctx context.Context) error {
 }
 
 // Publish publishes an event asynchronously.
+// Events carrying a trace context, see event.WithTraceContext, are traced from the publisher to the handlers.
 func (b *MemoryBus) Publish(evt event.Event) {
+	if parent := tracing.Extract(context.Background(), evt.Meta()); trace.SpanContextFromContext(parent).IsValid() {
+		_, span := tracing.Tracer().Start(
+			parent,
+			evt.Type()+" publish",
+			trace.WithSpanKind(trace.SpanKindProducer),
+			trace.WithAttributes(messagingAttributes(evt, "publish")...),
+		)
+		defer span.End()
+	}
+
+	publishedEvents.WithLabelValues(evt.Type()).Inc()
+	queueDepth.Inc()
+
 	b.eventCh <- evt
 }
 
@@ -134,6 +151,8 @@ func (b *MemoryBus) processEvents() {
 				return
 			}
 
+			queueDepth.Dec()
+
 			go b.deliverEvent(evt)
 
 		case <-b.ctx.Done():
@@ -161,8 +180,36 @@ func (b *MemoryBus) deliverEvent(evt event.Event) {
 	}
 
 	if subs, exists := b.subscribers[evt.Type()]; exists {
+		parent := tracing.Extract(b.ctx, processedEvent.Meta())
 		for _, sub := range subs {
-			sub.handler(b.ctx, processedEvent)
+			b.handleEvent(parent, sub, processedEvent)
 		}
 	}
 }
+
+// handleEvent runs a subscriber in a consumer span, which continues the trace of the publisher if any, and times it.
+func (*MemoryBus) handleEvent(ctx context.Context, sub *subscription, evt event.Event) {
+	ctx, span := tracing.Tracer().Start(
+		ctx,
+		evt.Type()+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(messagingAttributes(evt, "process")...),
+	)
+	defer span.End()
+
+	start := time.Now()
+	defer func() {
+		handlerDuration.WithLabelValues(evt.Type()).Observe(time.Since(start).Seconds())
+	}()
+
+	sub.handler(ctx, evt)
+}
+
+func messagingAttributes(evt event.Event, operation string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("messaging.system", "vef"),
+		attribute.String("messaging.operation.type", operation),
+		attribute.String("messaging.destination.name", evt.Type()),
+		attribute.String("messaging.message.id", evt.ID()),
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/coldsmirk/vef-framework-go/event"
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/tracing"
 )
 
 // TestMemoryEventBusBasicPublishSubscribe tests MemoryEventBus basic publish subscribe scenarios.
@@ -704,3 +707,82 @@ func createTestEventBusWithMiddleware(t *testing.T, middlewares []event.Middlewa
 
 	return bus
 }
+
+// TestMemoryEventBusTracing tests that handlers continue the trace of the publisher.
+func TestMemoryEventBusTracing(t *testing.T) {
+	recorder := testx.RecordSpans(t)
+	bus := createTestEventBus(t)
+
+	handled := make(chan context.Context, 1)
+	unsubscribe := bus.Subscribe("order.placed", func(ctx context.Context, _ event.Event) {
+		handled <- ctx
+	})
+	defer unsubscribe()
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "request")
+	bus.Publish(event.NewBaseEvent("order.placed", event.WithTraceContext(ctx)))
+	span.End()
+
+	select {
+	case handlerCtx := <-handled:
+		assert.Equal(t, tracing.TraceID(ctx), tracing.TraceID(handlerCtx), "Should run the handler in the trace of the publisher")
+
+	case <-time.After(100 * time.Millisecond):
+		require.Fail(t, "Timeout waiting for event delivery")
+	}
+
+	require.Eventually(t, func() bool {
+		return len(recorder.Ended()) == 3
+	}, time.Second, 10*time.Millisecond, "Should record the request, publish and process spans")
+
+	names := make([]string, 0, 3)
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+	}
+
+	assert.ElementsMatch(t, []string{"request", "order.placed publish", "order.placed process"}, names, "Should name the spans after the event type")
+}
+
+// TestMemoryEventBusMetrics tests that published and handled events are recorded.
+func TestMemoryEventBusMetrics(t *testing.T) {
+	bus := createTestEventBus(t)
+
+	depth := testx.MetricValue(t, queueDepth)
+	published := testx.MetricValue(t, publishedEvents.WithLabelValues("invoice.paid"))
+	handledCount := testx.MetricValue(t, handlerDuration.WithLabelValues("invoice.paid").(prometheus.Histogram))
+
+	handled := make(chan struct{}, 1)
+	unsubscribe := bus.Subscribe("invoice.paid", func(context.Context, event.Event) {
+		handled <- struct{}{}
+	})
+	defer unsubscribe()
+
+	bus.Publish(event.NewBaseEvent("invoice.paid"))
+
+	select {
+	case <-handled:
+	case <-time.After(100 * time.Millisecond):
+		require.Fail(t, "Timeout waiting for event delivery")
+	}
+
+	assert.Equal(t, published+1, testx.MetricValue(t, publishedEvents.WithLabelValues("invoice.paid")), "Should count the published event")
+	assert.Eventually(t, func() bool {
+		return testx.MetricValue(t, handlerDuration.WithLabelValues("invoice.paid").(prometheus.Histogram)) == handledCount+1
+	}, time.Second, 10*time.Millisecond, "Should time the handler")
+	assert.Equal(t, depth, testx.MetricValue(t, queueDepth), "Should have drained the queue")
+}
+
+// TestMemoryEventBusHealth tests that the health indicator reports whether the bus is running.
+func TestMemoryEventBusHealth(t *testing.T) {
+	bus := NewMemoryBus(nil)
+	indicator := newHealthIndicator(bus)
+
+	assert.Equal(t, "event_bus", indicator.Name(), "Should name the indicator")
+	assert.ErrorIs(t, indicator.Check(context.Background()), ErrEventBusNotRunning, "Should fail before the bus is started")
+
+	require.NoError(t, bus.Start(), "Should start the bus")
+	assert.NoError(t, indicator.Check(context.Background()), "Should pass while the bus is running")
+
+	require.NoError(t, bus.Shutdown(context.Background()), "Should shut down the bus")
+	assert.ErrorIs(t, indicator.Check(context.Background()), ErrEventBusNotRunning, "Should fail after the bus is shut down")
+}
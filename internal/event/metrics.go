@@ -0,0 +1,35 @@
+package event
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/coldsmirk/vef-framework-go/metrics"
+)
+
+var (
+	queueDepth = metrics.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "event",
+		Name:      "queue_depth",
+		Help:      "Number of published events waiting to be delivered.",
+	})
+	publishedEvents = metrics.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "event",
+			Name:      "published_total",
+			Help:      "Number of published events by type.",
+		},
+		"type",
+	)
+	handlerDuration = metrics.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "event",
+			Name:      "handler_duration_seconds",
+			Help:      "Duration of event handlers by event type.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		"type",
+	)
+)
@@ -22,6 +22,10 @@ var (
 				fx.As(new(event.Subscriber)),
 				fx.As(new(event.Publisher)),
 			),
+			fx.Annotate(
+				newHealthIndicator,
+				fx.ResultTags(`group:"vef:health:indicators"`),
+			),
 		),
 	)
 )
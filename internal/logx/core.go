@@ -0,0 +1,75 @@
+package logx
+
+import (
+	"io"
+	"slices"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// sink is where all loggers write to. Loggers are created at package initialization, long before the
+// configuration is loaded, so the encoder, the output and the levels are swapped in place instead.
+type sink struct {
+	levels *levelRegistry
+	output atomic.Pointer[output]
+}
+
+// output is an encoder bound to a writer, and the closer of the writer if it was opened by Configure.
+type output struct {
+	core   zapcore.Core
+	closer io.Closer
+}
+
+func newSink(level zapcore.Level, core zapcore.Core) *sink {
+	s := &sink{levels: newLevelRegistry(level)}
+	s.output.Store(&output{core: core})
+
+	return s
+}
+
+// swap replaces the output and closes the previous one.
+func (s *sink) swap(out *output) error {
+	previous := s.output.Swap(out)
+	if previous == nil || previous.closer == nil {
+		return nil
+	}
+
+	_ = previous.core.Sync()
+
+	return previous.closer.Close()
+}
+
+// dynamicCore is a zapcore.Core that filters entries by the level of their logger name
+// and writes them to the current output of the sink.
+type dynamicCore struct {
+	sink   *sink
+	fields []zapcore.Field
+}
+
+func (c *dynamicCore) Enabled(level zapcore.Level) bool {
+	return c.sink.levels.anyEnabled(level)
+}
+
+func (c *dynamicCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dynamicCore{
+		sink:   c.sink,
+		fields: slices.Concat(c.fields, fields),
+	}
+}
+
+func (c *dynamicCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.sink.levels.enabled(entry.LoggerName, entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+func (c *dynamicCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.sink.output.Load().core.Write(entry, slices.Concat(c.fields, fields))
+}
+
+func (c *dynamicCore) Sync() error {
+	return c.sink.output.Load().core.Sync()
+}
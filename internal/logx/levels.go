@@ -0,0 +1,124 @@
+package logx
+
+import (
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// levelRegistry holds the root level and the level overrides by logger name, which can be changed at runtime.
+// An override applies to the named logger and its children, the longest matching name wins.
+type levelRegistry struct {
+	mu        sync.RWMutex
+	root      zapcore.Level
+	overrides map[string]zapcore.Level
+	// lowest is the lowest level of the root level and all overrides, so most disabled entries are dropped
+	// without looking up their logger name.
+	lowest atomic.Int32
+}
+
+func newLevelRegistry(root zapcore.Level) *levelRegistry {
+	registry := &levelRegistry{
+		root:      root,
+		overrides: make(map[string]zapcore.Level),
+	}
+	registry.lowest.Store(int32(root))
+
+	return registry
+}
+
+// anyEnabled reports whether the level is enabled for any logger.
+func (r *levelRegistry) anyEnabled(level zapcore.Level) bool {
+	return level >= zapcore.Level(r.lowest.Load())
+}
+
+// enabled reports whether the level is enabled for the named logger.
+func (r *levelRegistry) enabled(name string, level zapcore.Level) bool {
+	if !r.anyEnabled(level) {
+		return false
+	}
+
+	return level >= r.levelOf(name)
+}
+
+// levelOf returns the effective level of the named logger.
+func (r *levelRegistry) levelOf(name string) zapcore.Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	level, matched := r.root, -1
+	for prefix, override := range r.overrides {
+		if len(prefix) > matched && matchesLoggerName(name, prefix) {
+			level, matched = override, len(prefix)
+		}
+	}
+
+	return level
+}
+
+// matchesLoggerName reports whether the logger name is the prefix itself or one of its children,
+// separated by "." as zap joins names or by ":" as the framework names sub-components.
+func matchesLoggerName(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+
+	return len(name) == len(prefix) || name[len(prefix)] == '.' || name[len(prefix)] == ':'
+}
+
+// set changes the level of the named logger and its children, or the root level if the name is empty.
+func (r *levelRegistry) set(name string, level zapcore.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if name == "" {
+		r.root = level
+	} else {
+		r.overrides[name] = level
+	}
+
+	r.updateLowest()
+}
+
+// reset removes the level override of the named logger, which falls back to the level of its parents.
+func (r *levelRegistry) reset(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.overrides, name)
+	r.updateLowest()
+}
+
+// replace replaces the root level and all overrides.
+func (r *levelRegistry) replace(root zapcore.Level, overrides map[string]zapcore.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.root = root
+	r.overrides = maps.Clone(overrides)
+	if r.overrides == nil {
+		r.overrides = make(map[string]zapcore.Level)
+	}
+
+	r.updateLowest()
+}
+
+// snapshot returns the root level and a copy of the overrides.
+func (r *levelRegistry) snapshot() (zapcore.Level, map[string]zapcore.Level) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.root, maps.Clone(r.overrides)
+}
+
+func (r *levelRegistry) updateLowest() {
+	lowest := r.root
+	for _, level := range r.overrides {
+		lowest = min(lowest, level)
+	}
+
+	r.lowest.Store(int32(lowest))
+}
@@ -1,22 +1,28 @@
 package logx
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/coldsmirk/vef-framework-go/config"
 	"github.com/coldsmirk/vef-framework-go/logx"
 )
 
-var rootLogger = newLogger()
+var (
+	rootSink   = newDefaultSink()
+	rootLogger = newLogger(rootSink)
+)
 
 func Named(name string) logx.Logger {
 	return rootLogger.Named(name)
 }
 
-func newLogger() *zapLogger {
+// newDefaultSink writes to the colored console at the level of the environment until Configure is called.
+func newDefaultSink() *sink {
 	level, levelString := zap.InfoLevel, strings.ToLower(os.Getenv(config.EnvLogLevel))
 
 	switch levelString {
@@ -30,7 +36,89 @@ func newLogger() *zapLogger {
 		level = zap.ErrorLevel
 	}
 
+	return newSink(level, zapcore.NewCore(newConsoleEncoder(true), zapcore.Lock(os.Stdout), zapcore.DebugLevel))
+}
+
+func newLogger(s *sink) *zapLogger {
 	return &zapLogger{
-		logger: newZapLogger(level).WithOptions(zap.AddCallerSkip(1)),
+		logger: zap.New(
+			&dynamicCore{sink: s},
+			zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+			zap.AddCallerSkip(1),
+		).Sugar(),
+		sink: s,
+	}
+}
+
+// Configure applies the logging configuration to all loggers, including the ones created before.
+// The root level is left unchanged if the configuration has none.
+func Configure(cfg *config.LogConfig) error {
+	return rootSink.configure(cfg)
+}
+
+func (s *sink) configure(cfg *config.LogConfig) error {
+	root, _ := s.levels.snapshot()
+	if cfg.Level != "" {
+		level, err := parseLevel(cfg.Level)
+		if err != nil {
+			return err
+		}
+
+		root = level
+	}
+
+	overrides := make(map[string]zapcore.Level, len(cfg.Levels))
+	for name, value := range cfg.Levels {
+		level, err := parseLevel(value)
+		if err != nil {
+			return fmt.Errorf("invalid level of logger %q: %w", name, err)
+		}
+
+		overrides[name] = level
 	}
+
+	out, err := newOutput(cfg)
+	if err != nil {
+		return err
+	}
+
+	s.levels.replace(root, overrides)
+
+	return s.swap(out)
+}
+
+// Sync flushes the buffered entries of the output.
+func Sync() error {
+	return rootSink.output.Load().core.Sync()
+}
+
+// Levels returns the root level and the level overrides by logger name.
+func Levels() (logx.Level, map[string]logx.Level) {
+	root, overrides := rootSink.levels.snapshot()
+
+	levels := make(map[string]logx.Level, len(overrides))
+	for name, level := range overrides {
+		levels[name] = fromZapLevel(level)
+	}
+
+	return fromZapLevel(root), levels
+}
+
+// SetLevel changes the level of the named logger and its children at runtime, or the root level if the name is empty.
+func SetLevel(name string, level logx.Level) {
+	rootSink.levels.set(name, toZapLevel(level))
+}
+
+// ResetLevel removes the level override of the named logger, which falls back to the level of its parents.
+func ResetLevel(name string) {
+	rootSink.levels.reset(name)
+}
+
+func parseLevel(value string) (zapcore.Level, error) {
+	level, err := logx.ParseLevel(value)
+	if err != nil {
+		return zapcore.InfoLevel, err
+	}
+
+	return toZapLevel(level), nil
 }
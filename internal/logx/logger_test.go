@@ -0,0 +1,152 @@
+package logx
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/logx"
+)
+
+// newBufferLogger creates a root logger writing JSON entries to a buffer.
+func newBufferLogger(level zapcore.Level) (*zapLogger, *bytes.Buffer) {
+	var buffer bytes.Buffer
+
+	s := newSink(level, zapcore.NewCore(newJSONEncoder(), zapcore.AddSync(&buffer), zapcore.DebugLevel))
+
+	return newLogger(s), &buffer
+}
+
+// decodeEntries decodes the JSON entries written to the buffer.
+func decodeEntries(t *testing.T, buffer *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var entries []map[string]any
+	for line := range strings.Lines(buffer.String()) {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), "Should write one JSON object per line")
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// TestLoggerWith tests that structured fields are written with every entry of the child logger.
+func TestLoggerWith(t *testing.T) {
+	logger, buffer := newBufferLogger(zapcore.InfoLevel)
+
+	child := logger.Named("request").With("request_id", "r1").With("operation", "sys/log:set_level@v1")
+	child.Info("handled")
+	logger.Info("unrelated")
+
+	entries := decodeEntries(t, buffer)
+	require.Len(t, entries, 2, "Should write both entries")
+
+	assert.Equal(t, "handled", entries[0]["message"], "Should write the message")
+	assert.Equal(t, "info", entries[0]["level"], "Should write the lowercase level")
+	assert.Equal(t, "request", entries[0]["logger"], "Should write the logger name")
+	assert.Equal(t, "r1", entries[0]["request_id"], "Should write the fields of the parent")
+	assert.Equal(t, "sys/log:set_level@v1", entries[0]["operation"], "Should write the fields of the child")
+	assert.NotContains(t, entries[1], "request_id", "Should not leak fields to the parent logger")
+}
+
+// TestLoggerLevels tests level overrides by logger name and their changes at runtime.
+func TestLoggerLevels(t *testing.T) {
+	t.Run("Overrides", func(t *testing.T) {
+		logger, buffer := newBufferLogger(zapcore.InfoLevel)
+		logger.sink.levels.set("orm", zapcore.DebugLevel)
+		logger.sink.levels.set("api", zapcore.ErrorLevel)
+
+		logger.Named("orm").Named("query").Debug("orm child")
+		logger.Named("ormx").Debug("similar name")
+		logger.Named("api").Warn("api warn")
+		logger.Named("api.collector").Error("api error")
+		logger.Named("security").Debug("root debug")
+
+		var messages []string
+		for _, entry := range decodeEntries(t, buffer) {
+			messages = append(messages, entry["message"].(string))
+		}
+
+		assert.Equal(t, []string{"orm child", "api error"}, messages, "Should apply the override of the longest matching logger name")
+	})
+
+	t.Run("FrameworkSeparator", func(t *testing.T) {
+		logger, _ := newBufferLogger(zapcore.InfoLevel)
+		logger.sink.levels.set("security", zapcore.DebugLevel)
+
+		assert.True(t, logger.Named("security:cached_role_permissions_loader").Enabled(logx.LevelDebug), "Should treat colon-separated names as children")
+	})
+
+	t.Run("Runtime", func(t *testing.T) {
+		logger, _ := newBufferLogger(zapcore.InfoLevel)
+		child := logger.Named("cron")
+
+		assert.False(t, child.Enabled(logx.LevelDebug), "Should disable debug at the root level")
+
+		logger.sink.levels.set("cron", zapcore.DebugLevel)
+		assert.True(t, child.Enabled(logx.LevelDebug), "Should apply overrides to existing loggers")
+
+		logger.sink.levels.set("", zapcore.ErrorLevel)
+		assert.False(t, logger.Enabled(logx.LevelWarn), "Should change the root level")
+		assert.True(t, child.Enabled(logx.LevelDebug), "Should keep overrides when the root level changes")
+
+		logger.sink.levels.reset("cron")
+		assert.False(t, child.Enabled(logx.LevelWarn), "Should fall back to the root level after a reset")
+	})
+}
+
+// TestSinkConfigure tests applying the logging configuration.
+func TestSinkConfigure(t *testing.T) {
+	t.Run("FileOutput", func(t *testing.T) {
+		logger, _ := newBufferLogger(zapcore.InfoLevel)
+		path := filepath.Join(t.TempDir(), "logs", "app.log")
+
+		err := logger.sink.configure(&config.LogConfig{
+			Level:  "warn",
+			Format: "json",
+			Output: path,
+			Levels: map[string]string{"orm": "debug"},
+		})
+		require.NoError(t, err, "Should apply the configuration")
+
+		t.Cleanup(func() {
+			_ = logger.sink.swap(&output{core: zapcore.NewNopCore()})
+		})
+
+		logger.Info("dropped")
+		logger.Named("orm").With("rows", 3).Debug("kept")
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err, "Should create the log file")
+
+		entries := decodeEntries(t, bytes.NewBuffer(content))
+		require.Len(t, entries, 1, "Should write only the enabled entries")
+		assert.Equal(t, "kept", entries[0]["message"], "Should write the entry of the overridden logger")
+		assert.InDelta(t, 3, entries[0]["rows"], 0, "Should write the structured field as JSON")
+	})
+
+	t.Run("KeepsLevelWithoutConfig", func(t *testing.T) {
+		logger, _ := newBufferLogger(zapcore.DebugLevel)
+
+		require.NoError(t, logger.sink.configure(&config.LogConfig{Output: "stderr"}), "Should apply an empty configuration")
+		assert.True(t, logger.Enabled(logx.LevelDebug), "Should keep the level of the environment")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		logger, _ := newBufferLogger(zapcore.InfoLevel)
+
+		assert.Error(t, logger.sink.configure(&config.LogConfig{Level: "verbose"}), "Should reject an unknown level")
+		assert.Error(t, logger.sink.configure(&config.LogConfig{Levels: map[string]string{"orm": "loud"}}), "Should reject an unknown override level")
+		assert.Error(t, logger.sink.configure(&config.LogConfig{Format: "xml"}), "Should reject an unknown format")
+	})
+}
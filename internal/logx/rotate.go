@@ -0,0 +1,196 @@
+package logx
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+)
+
+// backupTimeLayout is the timestamp of rotated files, e.g. app-2024-01-02T15-04-05.000.log, which sorts chronologically.
+const backupTimeLayout = "2006-01-02T15-04-05.000"
+
+// rotatingFile is an io.Writer that appends to a file and moves it aside once it grows beyond the maximum size
+// or an interval has passed, removing rotated files beyond the configured age and count.
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	interval   time.Duration
+	maxAge     time.Duration
+	maxBackups int
+	now        func() time.Time
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+func newRotatingFile(path string, rotation config.LogRotationConfig) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    int64(rotation.MaxSize) * 1024 * 1024,
+		interval:   rotation.Interval,
+		maxAge:     rotation.MaxAge,
+		maxBackups: rotation.MaxBackups,
+		now:        time.Now,
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	var rotateErr error
+	if f.shouldRotate(len(p)) {
+		// A failed rotation keeps the current file, so the entry is still written and the error reported with it.
+		rotateErr = f.rotate()
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+
+	return n, errors.Join(rotateErr, err)
+}
+
+func (f *rotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	return f.file.Sync()
+}
+
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+func (f *rotatingFile) shouldRotate(size int) bool {
+	if f.size == 0 {
+		return false
+	}
+
+	if f.maxSize > 0 && f.size+int64(size) > f.maxSize {
+		return true
+	}
+
+	return f.interval > 0 && f.now().Truncate(f.interval).After(f.openedAt.Truncate(f.interval))
+}
+
+func (f *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	f.file, f.size, f.openedAt = file, info.Size(), f.now()
+	// An existing file is rotated by the time it was last written, e.g. when the application restarts the next day.
+	if f.size > 0 {
+		f.openedAt = info.ModTime()
+	}
+
+	return nil
+}
+
+// rotate moves the file aside and opens a new one. The current handle is only replaced once the new file is open,
+// so that a failed rename or open leaves the writer appending to the file it had.
+func (f *rotatingFile) rotate() error {
+	ext := filepath.Ext(f.path)
+	backup := strings.TrimSuffix(f.path, ext) + "-" + f.now().Format(backupTimeLayout) + ext
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	previous := f.file
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if err := previous.Close(); err != nil {
+		return fmt.Errorf("failed to close rotated log file: %w", err)
+	}
+
+	f.removeBackups()
+
+	return nil
+}
+
+// removeBackups removes the rotated files beyond the maximum count and age, on a best-effort basis.
+func (f *rotatingFile) removeBackups() {
+	if f.maxBackups <= 0 && f.maxAge <= 0 {
+		return
+	}
+
+	ext := filepath.Ext(f.path)
+	prefix := strings.TrimSuffix(f.path, ext) + "-"
+
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return
+	}
+
+	type backup struct {
+		path      string
+		rotatedAt time.Time
+	}
+
+	backups := make([]backup, 0, len(matches))
+	for _, match := range matches {
+		// Skips other files sharing the prefix, e.g. app-error.log next to app.log.
+		rotatedAt, err := time.ParseInLocation(backupTimeLayout, strings.TrimSuffix(strings.TrimPrefix(match, prefix), ext), time.Local)
+		if err == nil {
+			backups = append(backups, backup{path: match, rotatedAt: rotatedAt})
+		}
+	}
+
+	slices.SortFunc(backups, func(a, b backup) int {
+		return b.rotatedAt.Compare(a.rotatedAt)
+	})
+
+	cutoff := f.now().Add(-f.maxAge)
+	for i, backup := range backups {
+		if (f.maxBackups > 0 && i >= f.maxBackups) || (f.maxAge > 0 && backup.rotatedAt.Before(cutoff)) {
+			_ = os.Remove(backup.path)
+		}
+	}
+}
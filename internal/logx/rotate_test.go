@@ -0,0 +1,138 @@
+package logx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+)
+
+// TestRotatingFile tests rotation by size and interval and the removal of old rotated files.
+func TestRotatingFile(t *testing.T) {
+	listBackups := func(t *testing.T, path string) []string {
+		t.Helper()
+
+		backups, err := filepath.Glob(filepath.Join(filepath.Dir(path), "app-*.log"))
+		require.NoError(t, err, "Should list rotated files")
+
+		return backups
+	}
+
+	t.Run("BySize", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		file, err := newRotatingFile(path, config.LogRotationConfig{MaxSize: 1})
+		require.NoError(t, err, "Should open the log file")
+
+		t.Cleanup(func() { _ = file.Close() })
+
+		now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.Local)
+		file.now = func() time.Time { return now }
+
+		chunk := make([]byte, 600*1024)
+		_, err = file.Write(chunk)
+		require.NoError(t, err, "Should write the first chunk")
+		assert.Empty(t, listBackups(t, path), "Should not rotate below the maximum size")
+
+		_, err = file.Write(chunk)
+		require.NoError(t, err, "Should write the second chunk")
+		assert.Len(t, listBackups(t, path), 1, "Should rotate when the maximum size is exceeded")
+
+		info, err := os.Stat(path)
+		require.NoError(t, err, "Should reopen the log file")
+		assert.Equal(t, int64(len(chunk)), info.Size(), "Should write the second chunk to the new file")
+	})
+
+	t.Run("ByInterval", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.log")
+		file, err := newRotatingFile(path, config.LogRotationConfig{Interval: time.Hour})
+		require.NoError(t, err, "Should open the log file")
+
+		t.Cleanup(func() { _ = file.Close() })
+
+		now := time.Now()
+		file.now = func() time.Time { return now }
+
+		_, err = file.Write([]byte("first\n"))
+		require.NoError(t, err, "Should write the first entry")
+
+		_, err = file.Write([]byte("second\n"))
+		require.NoError(t, err, "Should write the second entry")
+		assert.Empty(t, listBackups(t, path), "Should not rotate within the interval")
+
+		now = now.Add(time.Hour)
+		_, err = file.Write([]byte("third\n"))
+		require.NoError(t, err, "Should write the third entry")
+		assert.Len(t, listBackups(t, path), 1, "Should rotate once the interval has passed")
+	})
+
+	t.Run("RemovesBackups", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "app.log")
+		file, err := newRotatingFile(path, config.LogRotationConfig{MaxSize: 1, MaxBackups: 2, MaxAge: 48 * time.Hour})
+		require.NoError(t, err, "Should open the log file")
+
+		t.Cleanup(func() { _ = file.Close() })
+
+		now := time.Date(2024, 1, 10, 10, 0, 0, 0, time.Local)
+		file.now = func() time.Time { return now }
+
+		expired := filepath.Join(dir, "app-"+now.Add(-72*time.Hour).Format(backupTimeLayout)+".log")
+		unrelated := filepath.Join(dir, "app-error.log")
+		for _, name := range []string{expired, unrelated} {
+			require.NoError(t, os.WriteFile(name, []byte("old\n"), 0o644), "Should create the existing file")
+		}
+
+		chunk := make([]byte, 700*1024)
+		for range 4 {
+			now = now.Add(time.Minute)
+			_, err = file.Write(chunk)
+			require.NoError(t, err, "Should write the chunk")
+		}
+
+		backups := listBackups(t, path)
+		assert.Len(t, backups, 3, "Should keep the maximum number of rotated files and unrelated files")
+		assert.NotContains(t, backups, expired, "Should remove expired rotated files")
+		assert.Contains(t, backups, unrelated, "Should keep files that are not rotated log files")
+	})
+
+	t.Run("KeepsFileWhenRotationFails", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "app.log")
+		file, err := newRotatingFile(path, config.LogRotationConfig{MaxSize: 1})
+		require.NoError(t, err, "Should open the log file")
+
+		t.Cleanup(func() { _ = file.Close() })
+
+		now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.Local)
+		file.now = func() time.Time { return now }
+
+		// A non-empty directory at the rotated file path makes the rename fail.
+		backup := filepath.Join(dir, "app-"+now.Format(backupTimeLayout)+".log")
+		require.NoError(t, os.MkdirAll(filepath.Join(backup, "occupied"), 0o755), "Should create the blocking directory")
+
+		chunk := make([]byte, 600*1024)
+		_, err = file.Write(chunk)
+		require.NoError(t, err, "Should write the first chunk")
+
+		n, err := file.Write(chunk)
+		assert.Error(t, err, "Should report the failed rotation")
+		assert.Equal(t, len(chunk), n, "Should still write the chunk to the current file")
+
+		now = now.Add(time.Minute)
+		_, err = file.Write(chunk)
+		require.NoError(t, err, "Should rotate once the rename succeeds")
+
+		info, err := os.Stat(path)
+		require.NoError(t, err, "Should reopen the log file")
+		assert.Equal(t, int64(len(chunk)), info.Size(), "Should write to the new file after rotating")
+
+		rotated, err := os.Stat(filepath.Join(dir, "app-"+now.Format(backupTimeLayout)+".log"))
+		require.NoError(t, err, "Should move the file aside")
+		assert.Equal(t, int64(2*len(chunk)), rotated.Size(), "Should keep the entries written while rotation failed")
+	})
+}
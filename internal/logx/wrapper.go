@@ -9,24 +9,42 @@ import (
 
 type zapLogger struct {
 	logger *zap.SugaredLogger
+	sink   *sink
+	// name is the full name of the logger, which is what level overrides are looked up by.
+	name string
 }
 
 func (l *zapLogger) Named(name string) logx.Logger {
+	fullName := name
+	if l.name != "" {
+		fullName = l.name + "." + name
+	}
+
 	return &zapLogger{
 		logger: l.logger.Named(name),
+		sink:   l.sink,
+		name:   fullName,
+	}
+}
+
+func (l *zapLogger) With(keysAndValues ...any) logx.Logger {
+	return &zapLogger{
+		logger: l.logger.With(keysAndValues...),
+		sink:   l.sink,
+		name:   l.name,
 	}
 }
 
 func (l *zapLogger) WithCallerSkip(skip int) logx.Logger {
 	return &zapLogger{
 		logger: l.logger.WithOptions(zap.AddCallerSkip(skip)),
+		sink:   l.sink,
+		name:   l.name,
 	}
 }
 
 func (l *zapLogger) Enabled(level logx.Level) bool {
-	zapLevel := toZapLevel(level)
-
-	return l.logger.Level().Enabled(zapLevel)
+	return l.sink.levels.enabled(l.name, toZapLevel(level))
 }
 
 func toZapLevel(level logx.Level) zapcore.Level {
@@ -46,6 +64,21 @@ func toZapLevel(level logx.Level) zapcore.Level {
 	}
 }
 
+func fromZapLevel(level zapcore.Level) logx.Level {
+	switch {
+	case level <= zap.DebugLevel:
+		return logx.LevelDebug
+	case level == zap.InfoLevel:
+		return logx.LevelInfo
+	case level == zap.WarnLevel:
+		return logx.LevelWarn
+	case level == zap.ErrorLevel:
+		return logx.LevelError
+	default:
+		return logx.LevelPanic
+	}
+}
+
 func (l *zapLogger) Sync() {
 	if err := l.logger.Sync(); err != nil {
 		l.Errorf("error occurred while flushing logger: %v", err)
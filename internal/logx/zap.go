@@ -2,60 +2,129 @@ package logx
 
 import (
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/muesli/termenv"
-	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+)
+
+const (
+	formatConsole = "console"
+	formatJSON    = "json"
+
+	outputStdout = "stdout"
+	outputStderr = "stderr"
 )
 
-func newZapLogger(level zapcore.Level) *zap.SugaredLogger {
-	output := termenv.DefaultOutput()
-	config := zap.Config{
-		Level:       zap.NewAtomicLevelAt(level),
-		Development: false,
-		Encoding:    "console",
-		EncoderConfig: zapcore.EncoderConfig{
-			TimeKey:       "time",
-			LevelKey:      "level",
-			NameKey:       "logger",
-			FunctionKey:   zapcore.OmitKey,
-			MessageKey:    "message",
-			StacktraceKey: zapcore.OmitKey,
-			CallerKey:     zapcore.OmitKey,
-			LineEnding:    zapcore.DefaultLineEnding,
-			EncodeLevel:   zapcore.CapitalColorLevelEncoder,
-			EncodeTime: func() zapcore.TimeEncoder {
-				layout := time.DateOnly + "T" + time.TimeOnly + ".000"
-
-				return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-					enc.AppendString(
-						output.String(t.Format(layout)).
-							Foreground(termenv.ANSIBrightBlack).
-							String(),
-					)
-				}
-			}(),
-			EncodeDuration: zapcore.StringDurationEncoder,
-			EncodeName: func(name string, enc zapcore.PrimitiveArrayEncoder) {
-				enc.AppendString(
-					output.String("[" + name + "]").
-						Foreground(termenv.ANSIBrightMagenta).
-						String(),
-				)
-			},
+// timeLayout is the timestamp layout of console entries.
+const timeLayout = time.DateOnly + "T" + time.TimeOnly + ".000"
+
+// newOutput builds the encoder and the writer of the configured format and output.
+func newOutput(cfg *config.LogConfig) (*output, error) {
+	format := strings.ToLower(cfg.Format)
+	if format == "" {
+		format = formatConsole
+	}
+
+	var (
+		writer   zapcore.WriteSyncer
+		out      = &output{}
+		terminal bool
+	)
+
+	switch target := cfg.Output; strings.ToLower(target) {
+	case "", outputStdout:
+		writer, terminal = zapcore.Lock(os.Stdout), true
+	case outputStderr:
+		writer, terminal = zapcore.Lock(os.Stderr), true
+	default:
+		file, err := newRotatingFile(target, cfg.Rotation)
+		if err != nil {
+			return nil, err
+		}
+
+		writer, out.closer = file, file
+	}
+
+	var encoder zapcore.Encoder
+
+	switch format {
+	case formatConsole:
+		encoder = newConsoleEncoder(terminal)
+	case formatJSON:
+		encoder = newJSONEncoder()
+	default:
+		if out.closer != nil {
+			_ = out.closer.Close()
+		}
+
+		return nil, fmt.Errorf("unknown log format %q, expected console or json", cfg.Format)
+	}
+
+	out.core = zapcore.NewCore(encoder, writer, zapcore.DebugLevel)
+
+	return out, nil
+}
+
+// newConsoleEncoder creates the human-readable encoder, colored when writing to a terminal.
+func newConsoleEncoder(colored bool) zapcore.Encoder {
+	encoderConfig := zapcore.EncoderConfig{
+		TimeKey:        "time",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		FunctionKey:    zapcore.OmitKey,
+		MessageKey:     "message",
+		StacktraceKey:  zapcore.OmitKey,
+		CallerKey:      zapcore.OmitKey,
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.CapitalLevelEncoder,
+		EncodeTime:     zapcore.TimeEncoderOfLayout(timeLayout),
+		EncodeDuration: zapcore.StringDurationEncoder,
+		EncodeName: func(name string, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString("[" + name + "]")
 		},
-		DisableStacktrace: true,
-		OutputPaths:       []string{"stdout"},
-		ErrorOutputPaths:  []string{"stderr"},
 	}
 
-	logger, err := config.Build(zap.WithCaller(false))
-	if err != nil {
-		panic(
-			fmt.Errorf("failed to build zap logger: %w", err),
-		)
+	if colored {
+		output := termenv.DefaultOutput()
+
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		encoderConfig.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString(
+				output.String(t.Format(timeLayout)).
+					Foreground(termenv.ANSIBrightBlack).
+					String(),
+			)
+		}
+		encoderConfig.EncodeName = func(name string, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString(
+				output.String("[" + name + "]").
+					Foreground(termenv.ANSIBrightMagenta).
+					String(),
+			)
+		}
 	}
 
-	return logger.Sugar()
+	return zapcore.NewConsoleEncoder(encoderConfig)
+}
+
+// newJSONEncoder creates the encoder writing one JSON object per entry, for log pipelines such as Loki or ELK.
+func newJSONEncoder() zapcore.Encoder {
+	return zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		TimeKey:        "time",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		FunctionKey:    zapcore.OmitKey,
+		MessageKey:     "message",
+		StacktraceKey:  "stacktrace",
+		CallerKey:      zapcore.OmitKey,
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.RFC3339NanoTimeEncoder,
+		EncodeDuration: zapcore.MillisDurationEncoder,
+	})
 }
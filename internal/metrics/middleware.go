@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/app"
+	"github.com/coldsmirk/vef-framework-go/metrics"
+)
+
+// Middleware serves the metrics in the Prometheus exposition format.
+type Middleware struct {
+	path    string
+	handler fiber.Handler
+}
+
+// MiddlewareParams contains dependencies for creating the middleware.
+type MiddlewareParams struct {
+	fx.In
+
+	Config *config.MetricsConfig
+}
+
+// NewMiddleware creates a new metrics middleware.
+// Returns nil if the metrics endpoint is disabled by configuration.
+func NewMiddleware(params MiddlewareParams) app.Middleware {
+	if !params.Config.Enabled {
+		logger.Info("Metrics endpoint is disabled by configuration")
+
+		return nil
+	}
+
+	return &Middleware{
+		path: params.Config.PathOrDefault(),
+		handler: adaptor.HTTPHandler(promhttp.HandlerFor(metrics.Gatherer(), promhttp.HandlerOpts{
+			ErrorLog: promhttpLogger{},
+		})),
+	}
+}
+
+func (*Middleware) Name() string {
+	return "metrics"
+}
+
+func (*Middleware) Order() int {
+	return 410
+}
+
+func (m *Middleware) Apply(router fiber.Router) {
+	router.Get(m.path, m.handler)
+	logger.Infof("Metrics endpoint registered at GET %s", m.path)
+}
+
+// promhttpLogger reports the metrics failing to be gathered.
+type promhttpLogger struct{}
+
+func (promhttpLogger) Println(v ...any) {
+	logger.Error(fmt.Sprint(v...))
+}
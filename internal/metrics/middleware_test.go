@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/metrics"
+)
+
+// TestMiddleware tests that the registered metrics are exposed at the configured path.
+func TestMiddleware(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		assert.Nil(t, NewMiddleware(MiddlewareParams{Config: &config.MetricsConfig{}}), "Should not serve metrics unless enabled")
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		orders := prometheus.NewCounter(prometheus.CounterOpts{Name: "shop_orders_total", Help: "Number of orders."})
+		require.NoError(t, metrics.Register(orders), "Should register the collector")
+		require.NoError(t, metrics.Register(orders), "Should accept a collector registered already")
+		t.Cleanup(func() { metrics.Unregister(orders) })
+
+		orders.Add(3)
+
+		app := fiber.New()
+		NewMiddleware(MiddlewareParams{Config: &config.MetricsConfig{Enabled: true, Path: "/internal/metrics"}}).Apply(app)
+
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/internal/metrics", nil))
+		require.NoError(t, err, "Request should not fail")
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err, "Body should be readable")
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode, "Should serve the metrics")
+		assert.Contains(t, string(body), "shop_orders_total 3", "Should expose the business metric")
+		assert.Contains(t, string(body), "go_goroutines", "Should expose the runtime metrics")
+	})
+}
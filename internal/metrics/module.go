@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+
+	"github.com/coldsmirk/vef-framework-go/internal/logx"
+	"github.com/coldsmirk/vef-framework-go/metrics"
+)
+
+var logger = logx.Named("metrics")
+
+var Module = fx.Module(
+	"vef:metrics",
+	fx.Provide(
+		fx.Annotate(
+			NewMiddleware,
+			fx.ResultTags(`group:"vef:app:middlewares"`),
+		),
+	),
+	fx.Invoke(
+		fx.Annotate(
+			registerCollectors,
+			fx.ParamTags(``, `group:"vef:metrics:collectors"`),
+		),
+	),
+)
+
+// registerCollectors registers the collectors provided by the application for as long as it runs.
+func registerCollectors(lc fx.Lifecycle, collectors []prometheus.Collector) {
+	lc.Append(fx.StartStopHook(
+		func() error {
+			for _, collector := range collectors {
+				if err := metrics.Register(collector); err != nil {
+					return fmt.Errorf("failed to register metrics collector: %w", err)
+				}
+			}
+
+			return nil
+		},
+		func() {
+			for _, collector := range collectors {
+				metrics.Unregister(collector)
+			}
+		},
+	))
+}
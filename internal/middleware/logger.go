@@ -1,14 +1,13 @@
 package middleware
 
 import (
-	"fmt"
-
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/requestid"
 
 	"github.com/coldsmirk/vef-framework-go/contextx"
 	"github.com/coldsmirk/vef-framework-go/internal/app"
 	"github.com/coldsmirk/vef-framework-go/internal/logx"
+	"github.com/coldsmirk/vef-framework-go/tracing"
 )
 
 // NewLoggerMiddleware creates request-scoped loggers to correlate all log entries within a request.
@@ -16,7 +15,11 @@ func NewLoggerMiddleware() app.Middleware {
 	return &SimpleMiddleware{
 		handler: func(ctx fiber.Ctx) error {
 			requestID := requestid.FromContext(ctx)
-			logger := logx.Named(fmt.Sprintf("request_id:%s", requestID))
+			logger := logx.Named("request").With("request_id", requestID)
+			if traceID := tracing.TraceID(ctx.Context()); traceID != "" {
+				logger = logger.With("trace_id", traceID, "span_id", tracing.SpanID(ctx.Context()))
+			}
+
 			contextx.SetLogger(ctx, logger)
 			contextx.SetRequestID(ctx, requestID)
 
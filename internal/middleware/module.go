@@ -9,6 +9,10 @@ var Module = fx.Module(
 			NewRequestIDMiddleware,
 			fx.ResultTags(`group:"vef:app:middlewares"`),
 		),
+		fx.Annotate(
+			NewTracingMiddleware,
+			fx.ResultTags(`group:"vef:app:middlewares"`),
+		),
 		fx.Annotate(
 			NewLoggerMiddleware,
 			fx.ResultTags(`group:"vef:app:middlewares"`),
@@ -44,4 +48,10 @@ var Module = fx.Module(
 			fx.ResultTags(`group:"vef:app:middlewares"`),
 		),
 	),
+	fx.Invoke(
+		fx.Annotate(
+			setupTracing,
+			fx.ParamTags(`optional:"true"`, `optional:"true"`),
+		),
+	),
 )
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/requestid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/coldsmirk/vef-framework-go/httpx"
+	"github.com/coldsmirk/vef-framework-go/internal/app"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+// setupTracing installs the tracer provider and the propagator provided by the application as the global ones,
+// which the framework instrumentation uses. The propagator defaults to W3C trace context and baggage.
+func setupTracing(provider trace.TracerProvider, propagator propagation.TextMapPropagator) {
+	if provider != nil {
+		otel.SetTracerProvider(provider)
+	}
+
+	if propagator == nil {
+		propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+
+	otel.SetTextMapPropagator(propagator)
+}
+
+// NewTracingMiddleware starts a server span for every request, continuing the trace of the caller
+// from the traceparent header. API operations rename the span after their identifier.
+func NewTracingMiddleware() app.Middleware {
+	return &SimpleMiddleware{
+		handler: func(ctx fiber.Ctx) error {
+			parent := otel.GetTextMapPropagator().Extract(ctx.Context(), headerCarrier{ctx: ctx})
+			spanCtx, span := tracing.Tracer().Start(
+				parent,
+				ctx.Method(),
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", ctx.Method()),
+					attribute.String("url.path", ctx.Path()),
+					attribute.String("client.address", httpx.GetIP(ctx)),
+					attribute.String("user_agent.original", ctx.Get(fiber.HeaderUserAgent)),
+					attribute.String(tracing.AttrRequestID, requestid.FromContext(ctx)),
+				),
+			)
+			defer span.End()
+
+			ctx.SetContext(spanCtx)
+
+			err := ctx.Next()
+
+			// Errors are turned into responses by the error handler after all middlewares returned,
+			// so the status code is derived from the error instead of the response.
+			status := responseStatus(ctx, err)
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+
+			if status >= fiber.StatusInternalServerError {
+				if err != nil {
+					span.RecordError(err)
+				}
+
+				span.SetStatus(codes.Error, "")
+			}
+
+			return err
+		},
+		name:  "tracing",
+		order: -640,
+	}
+}
+
+// responseStatus returns the HTTP status code of the response to the request.
+func responseStatus(ctx fiber.Ctx, err error) int {
+	if err == nil {
+		return ctx.Response().StatusCode()
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+
+	if resultErr, ok := result.AsErr(err); ok {
+		if resultErr.Status == 0 {
+			return fiber.StatusOK
+		}
+
+		return resultErr.Status
+	}
+
+	return fiber.StatusInternalServerError
+}
+
+// headerCarrier adapts the request headers to propagation.TextMapCarrier.
+type headerCarrier struct {
+	ctx fiber.Ctx
+}
+
+func (c headerCarrier) Get(key string) string {
+	return c.ctx.Get(key)
+}
+
+func (c headerCarrier) Set(key, value string) {
+	c.ctx.Request().Header.Set(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, c.ctx.Request().Header.Len())
+	for key := range c.ctx.GetReqHeaders() {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,157 @@
+package migration
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"ariga.io/atlas/sql/migrate"
+	bunschema "github.com/uptrace/bun/schema"
+
+	as "ariga.io/atlas/sql/schema"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/schema"
+	"github.com/coldsmirk/vef-framework-go/migration"
+)
+
+// Diff compares the tables of the given models, or of all models registered with the database when none
+// are given, against the live schema and plans the statements reconciling them.
+// Tables missing from the live schema are created; existing tables are altered column by column.
+// Tables without a model are never dropped, and changes Atlas cannot reverse are left out of Down.
+func (m *Migrator) Diff(ctx context.Context, models ...any) (*migration.Diff, error) {
+	driver, err := schema.OpenDriver(m.bunDB.DB, m.ds.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	inspector, err := schema.NewInspector(m.bunDB.DB, m.ds.Kind, m.ds.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := inspector.InspectSchema(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("inspect live schema: %w", err)
+	}
+
+	var changes []as.Change
+
+	for _, table := range m.modelTables(models) {
+		desired, err := desiredTable(m.ds.Kind, table)
+		if err != nil {
+			return nil, err
+		}
+
+		current, ok := live.Table(table.Name)
+		if !ok {
+			desired.Schema = live
+			changes = append(changes, &as.AddTable{T: desired})
+
+			continue
+		}
+
+		desired.Schema = current.Schema
+
+		tableChanges, err := driver.TableDiff(current, desired)
+		if err != nil {
+			return nil, fmt.Errorf("diff table %s: %w", table.Name, err)
+		}
+
+		if len(tableChanges) > 0 {
+			changes = append(changes, &as.ModifyTable{T: desired, Changes: tableChanges})
+		}
+	}
+
+	if len(changes) == 0 {
+		return new(migration.Diff), nil
+	}
+
+	plan, err := driver.PlanChanges(ctx, "diff", changes)
+	if err != nil {
+		return nil, fmt.Errorf("plan schema changes: %w", err)
+	}
+
+	return planDiff(plan), nil
+}
+
+// modelTables resolves the tables to diff, sorted by name. The migration bookkeeping tables are excluded.
+func (m *Migrator) modelTables(models []any) []*bunschema.Table {
+	var tables []*bunschema.Table
+	if len(models) == 0 {
+		tables = m.bunDB.Dialect().Tables().All()
+	} else {
+		for _, model := range models {
+			tables = append(tables, m.db.TableOf(model))
+		}
+	}
+
+	tables = slices.DeleteFunc(slices.Clone(tables), func(table *bunschema.Table) bool {
+		return table.Name == "" || table.Name == trackingTable || table.Name == lockTable
+	})
+	slices.SortFunc(tables, func(a, b *bunschema.Table) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return slices.CompactFunc(tables, func(a, b *bunschema.Table) bool {
+		return a.Name == b.Name
+	})
+}
+
+// desiredTable converts a bun model table into the Atlas table its CREATE TABLE statement would produce.
+func desiredTable(kind config.DBKind, table *bunschema.Table) (*as.Table, error) {
+	desired := as.NewTable(table.Name)
+
+	for _, field := range table.Fields {
+		typ, err := schema.ParseType(kind, field.CreateTableSQLType)
+		if err != nil {
+			return nil, fmt.Errorf("parse type of %s.%s: %w", table.Name, field.Name, err)
+		}
+
+		column := &as.Column{
+			Name: field.Name,
+			Type: &as.ColumnType{
+				Type: typ,
+				Raw:  field.CreateTableSQLType,
+				Null: !field.NotNull && !field.IsPK,
+			},
+		}
+		if field.SQLDefault != "" {
+			column.Default = &as.RawExpr{X: field.SQLDefault}
+		}
+
+		desired.AddColumns(column)
+	}
+
+	if len(table.PKs) > 0 {
+		primaryKey := &as.Index{Table: desired}
+		for i, field := range table.PKs {
+			column, _ := desired.Column(field.Name)
+			primaryKey.Parts = append(primaryKey.Parts, &as.IndexPart{SeqNo: i, C: column})
+		}
+
+		desired.PrimaryKey = primaryKey
+	}
+
+	return desired, nil
+}
+
+// planDiff extracts the forward statements of plan and its reverse statements in rollback order.
+func planDiff(plan *migrate.Plan) *migration.Diff {
+	diff := new(migration.Diff)
+	for _, change := range plan.Changes {
+		diff.Up = append(diff.Up, change.Cmd)
+	}
+
+	for _, change := range slices.Backward(plan.Changes) {
+		switch reverse := change.Reverse.(type) {
+		case string:
+			diff.Down = append(diff.Down, reverse)
+		case []string:
+			diff.Down = append(diff.Down, reverse...)
+		}
+	}
+
+	return diff
+}
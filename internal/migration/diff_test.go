@@ -0,0 +1,75 @@
+package migration
+
+import (
+	"reflect"
+	"testing"
+
+	"ariga.io/atlas/sql/migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+
+	as "ariga.io/atlas/sql/schema"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+)
+
+// diffOrder is a model used to verify the conversion of bun tables into Atlas tables.
+type diffOrder struct {
+	bun.BaseModel `bun:"table:diff_order"`
+
+	ID     string `bun:"id,pk,type:varchar(32)"`
+	Status string `bun:"status,notnull,type:varchar(16),default:'draft'"`
+	Remark string `bun:"remark,type:text"`
+}
+
+func TestDesiredTable(t *testing.T) {
+	table := sqlitedialect.New().Tables().Get(reflect.TypeFor[diffOrder]())
+
+	desired, err := desiredTable(config.SQLite, table)
+	require.NoError(t, err, "Should convert the model table")
+
+	assert.Equal(t, "diff_order", desired.Name, "Should keep the table name")
+	require.Len(t, desired.Columns, 3, "Should convert every column")
+
+	id, ok := desired.Column("id")
+	require.True(t, ok, "Should contain the primary key column")
+	assert.False(t, id.Type.Null, "Primary key column should not be nullable")
+	assert.Equal(t, "varchar(32)", id.Type.Raw, "Should keep the declared type")
+
+	status, ok := desired.Column("status")
+	require.True(t, ok, "Should contain the status column")
+	assert.False(t, status.Type.Null, "Not null column should not be nullable")
+	assert.Equal(t, &as.RawExpr{X: "'draft'"}, status.Default, "Should carry the SQL default")
+
+	remark, ok := desired.Column("remark")
+	require.True(t, ok, "Should contain the remark column")
+	assert.True(t, remark.Type.Null, "Column without notnull should be nullable")
+	assert.Nil(t, remark.Default, "Column without default should have no default")
+
+	require.NotNil(t, desired.PrimaryKey, "Should declare the primary key")
+	require.Len(t, desired.PrimaryKey.Parts, 1, "Primary key should have one part")
+	assert.Same(t, id, desired.PrimaryKey.Parts[0].C, "Primary key should reference the id column")
+}
+
+func TestPlanDiff(t *testing.T) {
+	diff := planDiff(&migrate.Plan{
+		Changes: []*migrate.Change{
+			{Cmd: "CREATE TABLE a (id int)", Reverse: "DROP TABLE a"},
+			{Cmd: "ALTER TABLE b ADD c int", Reverse: []string{"ALTER TABLE b DROP c", "ANALYZE b"}},
+			{Cmd: "ALTER TABLE b DROP d"},
+		},
+	})
+
+	assert.Equal(t, []string{
+		"CREATE TABLE a (id int)",
+		"ALTER TABLE b ADD c int",
+		"ALTER TABLE b DROP d",
+	}, diff.Up, "Should keep the forward statements in order")
+	assert.Equal(t, []string{
+		"ALTER TABLE b DROP c",
+		"ANALYZE b",
+		"DROP TABLE a",
+	}, diff.Down, "Should reverse changes newest first and skip irreversible ones")
+}
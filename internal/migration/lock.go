@@ -0,0 +1,108 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/coldsmirk/vef-framework-go/migration"
+	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// lockPollInterval is the delay between attempts to acquire a lock held by another instance.
+var lockPollInterval = 500 * time.Millisecond
+
+// lockOwner identifies this process in the lock row.
+func lockOwner() string {
+	hostname, _ := os.Hostname()
+
+	return hostname + ":" + strconv.Itoa(os.Getpid())
+}
+
+// withLock runs fn while holding the migration lock.
+// The lock is a row with a fixed primary key, so inserting it succeeds for exactly one instance;
+// the others poll until it is released, broken after expiring, or the lock timeout elapses.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	if err := m.acquireLock(ctx); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := m.releaseLock(context.WithoutCancel(ctx)); err != nil {
+			logger.Warnf("Failed to release migration lock: %v", err)
+		}
+	}()
+
+	return fn()
+}
+
+func (m *Migrator) acquireLock(ctx context.Context) error {
+	deadline := time.Now().Add(m.cfg.LockTimeoutOrDefault())
+
+	for {
+		lock := &migrationLock{ID: lockID, Owner: m.owner, LockedAt: timex.Now()}
+
+		_, err := m.db.NewInsert().Model(lock).Exec(ctx)
+		if err == nil {
+			return nil
+		}
+
+		// The orm reports the duplicate lock row as an existing record, so that is the lock held by another instance.
+		if !errors.Is(err, result.ErrRecordAlreadyExists) {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+
+		if err := m.breakExpiredLock(ctx); err != nil {
+			return err
+		}
+
+		if time.Now().After(deadline) {
+			return migration.ErrLockTimeout
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// breakExpiredLock removes a lock left behind by an instance that crashed while migrating.
+func (m *Migrator) breakExpiredLock(ctx context.Context) error {
+	expiredBefore := timex.Now().Add(-m.cfg.LockExpiryOrDefault())
+
+	res, err := m.db.NewDelete().
+		Model((*migrationLock)(nil)).
+		Where(func(cb orm.ConditionBuilder) {
+			cb.PKEquals(lockID).
+				LessThan("locked_at", expiredBefore)
+		}).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("break expired migration lock: %w", err)
+	}
+
+	if rows, _ := res.RowsAffected(); rows > 0 {
+		logger.Warnf("Broke expired migration lock acquired before %s", expiredBefore)
+	}
+
+	return nil
+}
+
+func (m *Migrator) releaseLock(ctx context.Context) error {
+	_, err := m.db.NewDelete().
+		Model((*migrationLock)(nil)).
+		Where(func(cb orm.ConditionBuilder) {
+			cb.PKEquals(lockID).
+				Equals("owner", m.owner)
+		}).
+		Exec(ctx)
+
+	return err
+}
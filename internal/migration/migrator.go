@@ -0,0 +1,256 @@
+package migration
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/database/sqlguard"
+	"github.com/coldsmirk/vef-framework-go/internal/logx"
+	"github.com/coldsmirk/vef-framework-go/migration"
+	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+var logger = logx.Named("migration")
+
+// Migrator applies versioned migrations and records them in the tracking table.
+// Migration statements bypass the SQL guard, since schema changes are their purpose.
+type Migrator struct {
+	db      orm.DB
+	bunDB   *bun.DB
+	ds      *config.DataSourceConfig
+	cfg     *config.MigrationConfig
+	sources []migration.Source
+	owner   string
+}
+
+// NewMigrator creates a Migrator for the migrations of the given sources.
+// Migrations always run on the primary database.
+func NewMigrator(
+	db orm.DB,
+	bunDB *bun.DB,
+	ds *config.DataSourceConfig,
+	cfg *config.MigrationConfig,
+	sources []migration.Source,
+) migration.Migrator {
+	return &Migrator{
+		db:      db.Primary(),
+		bunDB:   bunDB,
+		ds:      ds,
+		cfg:     cfg,
+		sources: sources,
+		owner:   lockOwner(),
+	}
+}
+
+// Up applies all pending migrations in version order.
+// A pending migration older than the latest applied one is applied as well, so migrations merged
+// from parallel branches are never skipped.
+func (m *Migrator) Up(ctx context.Context) ([]*migration.Migration, error) {
+	migrations, err := migration.Collect(m.sources...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = sqlguard.WithWhitelist(ctx)
+	if err := m.ensureTables(ctx); err != nil {
+		return nil, err
+	}
+
+	var applied []*migration.Migration
+
+	err = m.withLock(ctx, func() error {
+		records, err := m.appliedRecords(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range migrations {
+			if _, ok := records[mig.Version]; ok {
+				continue
+			}
+
+			if err := m.apply(ctx, mig); err != nil {
+				return fmt.Errorf("apply migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+
+			logger.Infof("Applied migration %d_%s", mig.Version, mig.Name)
+
+			applied = append(applied, mig)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the latest steps applied migrations, newest first. Steps below 1 revert a single migration.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*migration.Migration, error) {
+	migrations, err := migration.Collect(m.sources...)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*migration.Migration, len(migrations))
+	for _, mig := range migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	ctx = sqlguard.WithWhitelist(ctx)
+	if err := m.ensureTables(ctx); err != nil {
+		return nil, err
+	}
+
+	var reverted []*migration.Migration
+
+	err = m.withLock(ctx, func() error {
+		var records []appliedMigration
+		if err := m.db.NewSelect().
+			Model(&records).
+			OrderByDesc("version").
+			Limit(max(steps, 1)).
+			Scan(ctx); err != nil {
+			return fmt.Errorf("load applied migrations: %w", err)
+		}
+
+		for _, record := range records {
+			mig, ok := byVersion[record.Version]
+			if !ok {
+				return fmt.Errorf("%w: %d_%s", migration.ErrUnknownVersion, record.Version, record.Name)
+			}
+
+			if mig.Down == nil {
+				return fmt.Errorf("%w: %d_%s", migration.ErrIrreversible, mig.Version, mig.Name)
+			}
+
+			if err := m.revert(ctx, mig); err != nil {
+				return fmt.Errorf("revert migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+
+			logger.Infof("Reverted migration %d_%s", mig.Version, mig.Name)
+
+			reverted = append(reverted, mig)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status lists every migration known to the sources or the tracking table.
+func (m *Migrator) Status(ctx context.Context) ([]migration.Status, error) {
+	migrations, err := migration.Collect(m.sources...)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = sqlguard.WithWhitelist(ctx)
+	if err := m.ensureTables(ctx); err != nil {
+		return nil, err
+	}
+
+	records, err := m.appliedRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]migration.Status, 0, max(len(migrations), len(records)))
+	for _, mig := range migrations {
+		status := migration.Status{Version: mig.Version, Name: mig.Name}
+		if record, ok := records[mig.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = appliedAt(record)
+
+			delete(records, mig.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	// Applied versions whose source is gone are still reported, so drift is visible.
+	for _, record := range records {
+		statuses = append(statuses, migration.Status{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			AppliedAt: appliedAt(record),
+		})
+	}
+
+	slices.SortFunc(statuses, func(a, b migration.Status) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return statuses, nil
+}
+
+func (m *Migrator) ensureTables(ctx context.Context) error {
+	for _, model := range []any{(*appliedMigration)(nil), (*migrationLock)(nil)} {
+		if _, err := m.db.NewCreateTable().Model(model).IfNotExists().Exec(ctx); err != nil {
+			return fmt.Errorf("create migration tables: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) appliedRecords(ctx context.Context) (map[int64]*appliedMigration, error) {
+	var records []appliedMigration
+	if err := m.db.NewSelect().Model(&records).Scan(ctx); err != nil {
+		return nil, fmt.Errorf("load applied migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*appliedMigration, len(records))
+	for i := range records {
+		byVersion[records[i].Version] = &records[i]
+	}
+
+	return byVersion, nil
+}
+
+// apply runs the up step and records the migration in one transaction.
+func (m *Migrator) apply(ctx context.Context, mig *migration.Migration) error {
+	return m.db.RunInTX(ctx, func(ctx context.Context, tx orm.DB) error {
+		if err := mig.Up(ctx, tx); err != nil {
+			return err
+		}
+
+		_, err := tx.NewInsert().
+			Model(&appliedMigration{Version: mig.Version, Name: mig.Name, AppliedAt: timex.Now()}).
+			Exec(ctx)
+
+		return err
+	})
+}
+
+// revert runs the down step and removes the migration record in one transaction.
+func (m *Migrator) revert(ctx context.Context, mig *migration.Migration) error {
+	return m.db.RunInTX(ctx, func(ctx context.Context, tx orm.DB) error {
+		if err := mig.Down(ctx, tx); err != nil {
+			return err
+		}
+
+		_, err := tx.NewDelete().
+			Model((*appliedMigration)(nil)).
+			Where(func(cb orm.ConditionBuilder) {
+				cb.PKEquals(mig.Version)
+			}).
+			Exec(ctx)
+
+		return err
+	})
+}
+
+func appliedAt(record *appliedMigration) *time.Time {
+	at := record.AppliedAt.Unwrap()
+
+	return &at
+}
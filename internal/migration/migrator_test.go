@@ -0,0 +1,160 @@
+package migration
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/migration"
+	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// widget is the table created and altered by the test migrations.
+type widget struct {
+	bun.BaseModel `bun:"table:mig_widget"`
+
+	ID   string `bun:"id,pk,type:varchar(32)"`
+	Name string `bun:"name,notnull,type:varchar(64)"`
+}
+
+func testSources() []migration.Source {
+	return []migration.Source{
+		migration.Go(
+			&migration.Migration{
+				Version: 1,
+				Name:    "create_widget",
+				Up: func(ctx context.Context, db orm.DB) error {
+					_, err := db.NewCreateTable().Model((*widget)(nil)).Exec(ctx)
+
+					return err
+				},
+				Down: func(ctx context.Context, db orm.DB) error {
+					_, err := db.NewDropTable().Model((*widget)(nil)).IfExists().Exec(ctx)
+
+					return err
+				},
+			},
+			&migration.Migration{
+				Version: 3,
+				Name:    "seed_widget",
+				Up: func(ctx context.Context, db orm.DB) error {
+					_, err := db.NewInsert().Model(&widget{ID: "w1", Name: "Seed"}).Exec(ctx)
+
+					return err
+				},
+			},
+		),
+		migration.FS(fstest.MapFS{
+			"m/2_add_remark.up.sql":   {Data: []byte("ALTER TABLE mig_widget ADD COLUMN remark VARCHAR(64)")},
+			"m/2_add_remark.down.sql": {Data: []byte("ALTER TABLE mig_widget DROP COLUMN remark")},
+		}, "m"),
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	testx.ForEachDB(t, func(t *testing.T, env *testx.DBEnv) {
+		cfg := &config.MigrationConfig{LockTimeout: time.Second}
+		migrator := NewMigrator(env.DB, env.BunDB, env.DS, cfg, testSources())
+
+		t.Cleanup(func() {
+			for _, model := range []any{(*widget)(nil), (*appliedMigration)(nil), (*migrationLock)(nil)} {
+				_, _ = env.DB.NewDropTable().Model(model).IfExists().Exec(env.Ctx)
+			}
+		})
+
+		t.Run("Up", func(t *testing.T) {
+			applied, err := migrator.Up(env.Ctx)
+			require.NoError(t, err, "Should apply all migrations")
+			require.Len(t, applied, 3, "Should apply every pending migration")
+			assert.Equal(t, []int64{1, 2, 3}, []int64{applied[0].Version, applied[1].Version, applied[2].Version}, "Should apply in version order")
+
+			count, err := env.DB.NewSelect().Model((*widget)(nil)).Count(env.Ctx)
+			require.NoError(t, err, "Migrated table should be queryable")
+			assert.Equal(t, int64(1), count, "Seed migration should have run")
+
+			applied, err = migrator.Up(env.Ctx)
+			require.NoError(t, err, "Second run should succeed")
+			assert.Empty(t, applied, "Second run should apply nothing")
+		})
+
+		t.Run("Status", func(t *testing.T) {
+			statuses, err := migrator.Status(env.Ctx)
+			require.NoError(t, err, "Should load status")
+			require.Len(t, statuses, 3, "Should list every migration")
+
+			for _, status := range statuses {
+				assert.True(t, status.Applied, "Migration %d should be applied", status.Version)
+				assert.NotNil(t, status.AppliedAt, "Migration %d should have an applied time", status.Version)
+			}
+		})
+
+		t.Run("DownIrreversible", func(t *testing.T) {
+			reverted, err := migrator.Down(env.Ctx, 1)
+			assert.ErrorIs(t, err, migration.ErrIrreversible, "Should refuse to revert a migration without down step")
+			assert.Empty(t, reverted, "Should revert nothing")
+		})
+
+		t.Run("Down", func(t *testing.T) {
+			_, err := env.DB.NewDelete().
+				Model((*appliedMigration)(nil)).
+				Where(func(cb orm.ConditionBuilder) {
+					cb.PKEquals(3)
+				}).
+				Exec(env.Ctx)
+			require.NoError(t, err, "Should forget the irreversible migration")
+
+			reverted, err := migrator.Down(env.Ctx, 2)
+			require.NoError(t, err, "Should revert the latest migrations")
+			require.Len(t, reverted, 2, "Should revert the requested steps")
+			assert.Equal(t, []int64{2, 1}, []int64{reverted[0].Version, reverted[1].Version}, "Should revert newest first")
+
+			statuses, err := migrator.Status(env.Ctx)
+			require.NoError(t, err, "Should load status")
+
+			for _, status := range statuses {
+				assert.False(t, status.Applied, "Migration %d should be pending after revert", status.Version)
+			}
+		})
+
+		t.Run("LockHeld", func(t *testing.T) {
+			_, err := env.DB.NewInsert().
+				Model(&migrationLock{ID: lockID, Owner: "other", LockedAt: timex.Now()}).
+				Exec(env.Ctx)
+			require.NoError(t, err, "Should simulate another instance holding the lock")
+
+			_, err = migrator.Up(env.Ctx)
+			assert.ErrorIs(t, err, migration.ErrLockTimeout, "Should time out while another instance migrates")
+
+			count, err := env.DB.NewSelect().Model((*appliedMigration)(nil)).Count(env.Ctx)
+			require.NoError(t, err, "Should count applied migrations")
+			assert.Zero(t, count, "Should not migrate without the lock")
+		})
+
+		t.Run("LockExpired", func(t *testing.T) {
+			_, err := env.DB.NewUpdate().
+				Model((*migrationLock)(nil)).
+				Set("locked_at", timex.Now().Add(-time.Hour)).
+				Where(func(cb orm.ConditionBuilder) {
+					cb.PKEquals(lockID)
+				}).
+				Exec(env.Ctx)
+			require.NoError(t, err, "Should age the foreign lock")
+
+			applied, err := migrator.Up(env.Ctx)
+			require.NoError(t, err, "Should break the expired lock and migrate")
+			assert.Len(t, applied, 3, "Should apply every pending migration")
+
+			count, err := env.DB.NewSelect().Model((*migrationLock)(nil)).Count(env.Ctx)
+			require.NoError(t, err, "Should count locks")
+			assert.Zero(t, count, "Should release the lock after migrating")
+		})
+	})
+}
@@ -0,0 +1,34 @@
+package migration
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+const (
+	// trackingTable records the applied migrations.
+	trackingTable = "vef_schema_migration"
+	// lockTable holds at most one row while an instance is migrating.
+	lockTable = "vef_schema_migration_lock"
+	// lockID is the primary key of the single lock row.
+	lockID = 1
+)
+
+// appliedMigration is a row of the tracking table.
+type appliedMigration struct {
+	bun.BaseModel `bun:"table:vef_schema_migration,alias:vsm"`
+
+	Version   int64          `bun:"version,pk"`
+	Name      string         `bun:"name,notnull"`
+	AppliedAt timex.DateTime `bun:"applied_at,notnull,type:timestamp"`
+}
+
+// migrationLock is the row inserted by the instance holding the migration lock.
+type migrationLock struct {
+	bun.BaseModel `bun:"table:vef_schema_migration_lock,alias:vsml"`
+
+	ID       int            `bun:"id,pk"`
+	Owner    string         `bun:"owner,notnull"`
+	LockedAt timex.DateTime `bun:"locked_at,notnull,type:timestamp"`
+}
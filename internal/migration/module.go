@@ -0,0 +1,37 @@
+package migration
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/migration"
+)
+
+// Module provides the versioned schema migrator for the migration sources in the
+// "vef:migration:sources" group and applies pending migrations on startup when enabled.
+var Module = fx.Module(
+	"vef:migration",
+
+	fx.Provide(
+		fx.Annotate(
+			NewMigrator,
+			fx.ParamTags(``, ``, ``, ``, `group:"vef:migration:sources"`),
+		),
+	),
+	fx.Invoke(autoMigrate),
+)
+
+// autoMigrate applies pending migrations once the database client has started.
+func autoMigrate(lc fx.Lifecycle, cfg *config.MigrationConfig, migrator migration.Migrator) {
+	if !cfg.AutoMigrate {
+		return
+	}
+
+	lc.Append(fx.StartHook(func(ctx context.Context) error {
+		_, err := migrator.Up(ctx)
+
+		return err
+	}))
+}
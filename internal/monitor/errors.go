@@ -7,4 +7,6 @@ var (
 	ErrCPUInfoNotReady = errors.New("cpu info not ready")
 	// ErrProcessInfoNotReady indicates process information is not yet available from background sampling.
 	ErrProcessInfoNotReady = errors.New("process info not ready")
+	// ErrHealthCheckPanicked indicates a health indicator panicked instead of reporting the failure.
+	ErrHealthCheckPanicked = errors.New("health check panicked")
 )
@@ -0,0 +1,133 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/monitor"
+)
+
+// HealthChecker runs the health indicators of the application and tracks whether it is shutting down.
+type HealthChecker struct {
+	cfg          *config.HealthConfig
+	indicators   []monitor.HealthIndicator
+	shuttingDown atomic.Bool
+}
+
+// NewHealthChecker creates a health checker of the provided indicators.
+func NewHealthChecker(cfg *config.HealthConfig, indicators []monitor.HealthIndicator) *HealthChecker {
+	return &HealthChecker{
+		cfg: cfg,
+		indicators: lo.Filter(indicators, func(indicator monitor.HealthIndicator, _ int) bool {
+			return indicator != nil
+		}),
+	}
+}
+
+// ShutDown flips the readiness of the application, so that load balancers stop routing requests to it,
+// and waits for the configured shutdown delay, giving them time to notice before connections are drained.
+// The wait ends early once ctx is done, leaving the rest of the stop timeout to draining connections.
+func (h *HealthChecker) ShutDown(ctx context.Context) {
+	if h.shuttingDown.Swap(true) || h.cfg.ShutdownDelay <= 0 {
+		return
+	}
+
+	logger.Infof("Application marked as not ready, waiting %s before shutting down", h.cfg.ShutdownDelay)
+
+	timer := time.NewTimer(h.cfg.ShutdownDelay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		logger.Warnf("Shutdown delay cut short: %v", ctx.Err())
+	}
+}
+
+// Ready runs all indicators concurrently, each within its timeout, and reports the application as up
+// if it is not shutting down and all checks passed.
+func (h *HealthChecker) Ready(ctx context.Context) *monitor.HealthReport {
+	if h.shuttingDown.Load() {
+		return &monitor.HealthReport{Status: monitor.HealthStatusDown}
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		report = &monitor.HealthReport{
+			Status: monitor.HealthStatusUp,
+			Checks: make(map[string]*monitor.HealthCheck, len(h.indicators)),
+		}
+	)
+
+	for _, indicator := range h.indicators {
+		wg.Go(func() {
+			check, ok := h.check(ctx, indicator)
+			if !ok {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[indicator.Name()] = check
+			if check.Status == monitor.HealthStatusDown {
+				report.Status = monitor.HealthStatusDown
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+// check runs the indicator within its timeout, reporting false if the check was skipped.
+func (h *HealthChecker) check(ctx context.Context, indicator monitor.HealthIndicator) (*monitor.HealthCheck, bool) {
+	ctx, cancel := context.WithTimeout(ctx, h.cfg.TimeoutOf(indicator.Name()))
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+
+	// The check runs aside so that an indicator ignoring its context cannot block the probe beyond the timeout.
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- ErrHealthCheckPanicked
+			}
+		}()
+
+		errCh <- indicator.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if errors.Is(err, monitor.ErrHealthCheckSkipped) {
+		return nil, false
+	}
+
+	check := &monitor.HealthCheck{
+		Status:   monitor.HealthStatusUp,
+		Duration: time.Since(start).Milliseconds(),
+	}
+	// The error is logged in full; the readiness endpoint only exposes it when details are enabled.
+	if err != nil {
+		logger.Warnf("Health check %q failed: %v", indicator.Name(), err)
+
+		check.Status, check.Error = monitor.HealthStatusDown, err.Error()
+	}
+
+	return check, true
+}
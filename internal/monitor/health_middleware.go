@@ -0,0 +1,57 @@
+package monitor
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/app"
+	"github.com/coldsmirk/vef-framework-go/monitor"
+)
+
+// HealthMiddleware serves the liveness and readiness endpoints for probes of orchestrators and load balancers.
+// Readiness responses carry only the overall status unless details are enabled, as the endpoints are public
+// and the errors of failing checks may reveal hosts, addresses or credentials of the dependencies.
+type HealthMiddleware struct {
+	path        string
+	showDetails bool
+	checker     *HealthChecker
+}
+
+// NewHealthMiddleware creates a new health middleware.
+func NewHealthMiddleware(cfg *config.HealthConfig, checker *HealthChecker) app.Middleware {
+	return &HealthMiddleware{
+		path:        cfg.PathOrDefault(),
+		showDetails: cfg.ShowDetails,
+		checker:     checker,
+	}
+}
+
+func (*HealthMiddleware) Name() string {
+	return "health"
+}
+
+// Order returns the middleware order.
+// The endpoints are registered before all other middlewares, so probes are neither authenticated nor logged.
+func (*HealthMiddleware) Order() int {
+	return -1100
+}
+
+func (m *HealthMiddleware) Apply(router fiber.Router) {
+	// The process serves requests, so it is alive; restarting it would not fix a failing dependency.
+	router.Get(m.path+"/live", func(ctx fiber.Ctx) error {
+		return ctx.JSON(&monitor.HealthReport{Status: monitor.HealthStatusUp})
+	})
+	router.Get(m.path+"/ready", func(ctx fiber.Ctx) error {
+		report := m.checker.Ready(ctx.Context())
+		if report.Status != monitor.HealthStatusUp {
+			ctx.Status(fiber.StatusServiceUnavailable)
+		}
+
+		if !m.showDetails {
+			report = &monitor.HealthReport{Status: report.Status}
+		}
+
+		return ctx.JSON(report)
+	})
+	logger.Infof("Health endpoints registered at GET %s/live and GET %s/ready", m.path, m.path)
+}
@@ -0,0 +1,213 @@
+package monitor_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	imonitor "github.com/coldsmirk/vef-framework-go/internal/monitor"
+	"github.com/coldsmirk/vef-framework-go/monitor"
+)
+
+func healthy(context.Context) error {
+	return nil
+}
+
+// TestHealthChecker tests the readiness report of the health indicators.
+func TestHealthChecker(t *testing.T) {
+	cfg := &config.HealthConfig{
+		Timeout:  time.Second,
+		Timeouts: map[string]time.Duration{"slow": 20 * time.Millisecond},
+	}
+
+	t.Run("AllUp", func(t *testing.T) {
+		checker := imonitor.NewHealthChecker(cfg, []monitor.HealthIndicator{
+			monitor.NewHealthIndicator("database", healthy),
+			monitor.NewHealthIndicator("storage", healthy),
+			nil,
+		})
+
+		report := checker.Ready(context.Background())
+
+		assert.Equal(t, monitor.HealthStatusUp, report.Status, "Should be up when all checks pass")
+		assert.Len(t, report.Checks, 2, "Should report every indicator")
+		assert.Equal(t, monitor.HealthStatusUp, report.Checks["database"].Status, "Should report the passing check")
+	})
+
+	t.Run("OneDown", func(t *testing.T) {
+		checker := imonitor.NewHealthChecker(cfg, []monitor.HealthIndicator{
+			monitor.NewHealthIndicator("database", healthy),
+			monitor.NewHealthIndicator("redis", func(context.Context) error {
+				return errors.New("connection refused")
+			}),
+		})
+
+		report := checker.Ready(context.Background())
+
+		assert.Equal(t, monitor.HealthStatusDown, report.Status, "Should be down when a check fails")
+		assert.Equal(t, monitor.HealthStatusDown, report.Checks["redis"].Status, "Should report the failing check")
+		assert.Equal(t, "connection refused", report.Checks["redis"].Error, "Should report the error")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+
+		checker := imonitor.NewHealthChecker(cfg, []monitor.HealthIndicator{
+			// Ignores its context, so the checker has to give up on its own.
+			monitor.NewHealthIndicator("slow", func(context.Context) error {
+				<-release
+
+				return nil
+			}),
+		})
+
+		start := time.Now()
+		report := checker.Ready(context.Background())
+
+		assert.Less(t, time.Since(start), 500*time.Millisecond, "Should give up after the timeout of the indicator")
+		assert.Equal(t, monitor.HealthStatusDown, report.Status, "Should be down when a check times out")
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].Error, "Should report the timeout")
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		checker := imonitor.NewHealthChecker(cfg, []monitor.HealthIndicator{
+			monitor.NewHealthIndicator("broken", func(context.Context) error {
+				panic("nil map")
+			}),
+		})
+
+		report := checker.Ready(context.Background())
+
+		assert.Equal(t, imonitor.ErrHealthCheckPanicked.Error(), report.Checks["broken"].Error, "Should report the panic as a failure")
+	})
+
+	t.Run("Skipped", func(t *testing.T) {
+		checker := imonitor.NewHealthChecker(cfg, []monitor.HealthIndicator{
+			monitor.NewHealthIndicator("redis", func(context.Context) error {
+				return monitor.ErrHealthCheckSkipped
+			}),
+		})
+
+		report := checker.Ready(context.Background())
+
+		assert.Equal(t, monitor.HealthStatusUp, report.Status, "Should not fail on a skipped check")
+		assert.Empty(t, report.Checks, "Should leave the skipped check out")
+	})
+
+	t.Run("ShutDown", func(t *testing.T) {
+		checker := imonitor.NewHealthChecker(&config.HealthConfig{ShutdownDelay: 20 * time.Millisecond}, []monitor.HealthIndicator{
+			monitor.NewHealthIndicator("database", healthy),
+		})
+
+		start := time.Now()
+		checker.ShutDown(context.Background())
+
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond, "Should wait for the shutdown delay")
+		assert.Equal(t, monitor.HealthStatusDown, checker.Ready(context.Background()).Status, "Should not be ready once shutting down")
+	})
+
+	t.Run("ShutDownStopsWaitingWhenContextDone", func(t *testing.T) {
+		checker := imonitor.NewHealthChecker(&config.HealthConfig{ShutdownDelay: time.Minute}, nil)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		checker.ShutDown(ctx)
+
+		assert.Less(t, time.Since(start), 5*time.Second, "Should stop waiting once the context is done")
+		assert.Equal(t, monitor.HealthStatusDown, checker.Ready(context.Background()).Status, "Should not be ready once shutting down")
+	})
+}
+
+// TestHealthMiddleware tests the liveness and readiness endpoints.
+func TestHealthMiddleware(t *testing.T) {
+	cfg := &config.HealthConfig{}
+	checker := imonitor.NewHealthChecker(cfg, []monitor.HealthIndicator{
+		monitor.NewHealthIndicator("database", healthy),
+	})
+
+	app := fiber.New()
+	imonitor.NewHealthMiddleware(cfg, checker).Apply(app)
+
+	get := func(path string) (int, monitor.HealthReport) {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		require.NoError(t, err, "Request should not fail")
+
+		defer resp.Body.Close()
+
+		var report monitor.HealthReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&report), "Body should be a health report")
+
+		return resp.StatusCode, report
+	}
+
+	t.Run("Ready", func(t *testing.T) {
+		status, report := get("/health/ready")
+
+		assert.Equal(t, fiber.StatusOK, status, "Should be ready")
+		assert.Equal(t, monitor.HealthStatusUp, report.Status, "Should report the application up")
+		assert.Empty(t, report.Checks, "Should hide the checks by default")
+	})
+
+	t.Run("HidesErrorsByDefault", func(t *testing.T) {
+		failing := imonitor.NewHealthChecker(cfg, []monitor.HealthIndicator{
+			monitor.NewHealthIndicator("redis", func(context.Context) error {
+				return errors.New("dial tcp 10.0.0.5:6379: connection refused")
+			}),
+		})
+
+		app := fiber.New()
+		imonitor.NewHealthMiddleware(cfg, failing).Apply(app)
+
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/health/ready", nil))
+		require.NoError(t, err, "Request should not fail")
+
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err, "Body should be read")
+		assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode, "Should not be ready")
+		assert.JSONEq(t, `{"status":"down"}`, string(body), "Should only report the status")
+	})
+
+	t.Run("ShowsDetails", func(t *testing.T) {
+		detailed := &config.HealthConfig{ShowDetails: true}
+
+		app := fiber.New()
+		imonitor.NewHealthMiddleware(detailed, imonitor.NewHealthChecker(detailed, []monitor.HealthIndicator{
+			monitor.NewHealthIndicator("database", healthy),
+		})).Apply(app)
+
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/health/ready", nil))
+		require.NoError(t, err, "Request should not fail")
+
+		defer resp.Body.Close()
+
+		var report monitor.HealthReport
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&report), "Body should be a health report")
+		assert.Equal(t, monitor.HealthStatusUp, report.Checks["database"].Status, "Should report the checks when details are enabled")
+	})
+
+	t.Run("NotReadyWhenShuttingDown", func(t *testing.T) {
+		checker.ShutDown(context.Background())
+
+		status, report := get("/health/ready")
+		assert.Equal(t, fiber.StatusServiceUnavailable, status, "Should not be ready")
+		assert.Equal(t, monitor.HealthStatusDown, report.Status, "Should report the application down")
+
+		status, report = get("/health/live")
+		assert.Equal(t, fiber.StatusOK, status, "Should still be alive")
+		assert.Equal(t, monitor.HealthStatusUp, report.Status, "Should report the process up")
+	})
+}
@@ -0,0 +1,87 @@
+package monitor
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	ilogx "github.com/coldsmirk/vef-framework-go/internal/logx"
+	"github.com/coldsmirk/vef-framework-go/logx"
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+// NewLogResource creates a new log resource to inspect and change log levels at runtime.
+func NewLogResource() api.Resource {
+	return &LogResource{
+		Resource: api.NewRPCResource(
+			"sys/log",
+			api.WithOperations(
+				api.OperationSpec{Action: "get_levels", PermToken: "sys:log:query"},
+				api.OperationSpec{Action: "set_level", PermToken: "sys:log:manage"},
+				api.OperationSpec{Action: "reset_level", PermToken: "sys:log:manage"},
+			),
+		),
+	}
+}
+
+// LogResource handles the log level API endpoints.
+// Level changes apply to the running instance only and are replaced by the configuration on restart.
+type LogResource struct {
+	api.Resource
+}
+
+// LogLevels represents the root level and the level overrides by logger name.
+type LogLevels struct {
+	Root    string            `json:"root"`
+	Loggers map[string]string `json:"loggers"`
+}
+
+// GetLevels returns the root level and the level overrides by logger name.
+func (*LogResource) GetLevels(ctx fiber.Ctx) error {
+	return result.Ok(currentLogLevels()).Response(ctx)
+}
+
+// SetLogLevelParams represents the request parameters for changing a log level.
+type SetLogLevelParams struct {
+	api.P
+
+	// Logger is the name of the logger whose level changes together with its children, the root level if empty.
+	Logger string `json:"logger" label_i18n:"log_logger"`
+	Level  string `json:"level" validate:"required,oneof=debug info warn error" label_i18n:"log_level"`
+}
+
+// SetLevel changes the level of a logger and its children, or the root level.
+func (*LogResource) SetLevel(ctx fiber.Ctx, params SetLogLevelParams) error {
+	level, err := logx.ParseLevel(params.Level)
+	if err != nil {
+		return result.Err(err.Error(), result.WithCode(result.ErrCodeBadRequest))
+	}
+
+	ilogx.SetLevel(params.Logger, level)
+
+	return result.Ok(currentLogLevels()).Response(ctx)
+}
+
+// ResetLogLevelParams represents the request parameters for removing a log level override.
+type ResetLogLevelParams struct {
+	api.P
+
+	Logger string `json:"logger" validate:"required" label_i18n:"log_logger"`
+}
+
+// ResetLevel removes the level override of a logger, which falls back to the level of its parents.
+func (*LogResource) ResetLevel(ctx fiber.Ctx, params ResetLogLevelParams) error {
+	ilogx.ResetLevel(params.Logger)
+
+	return result.Ok(currentLogLevels()).Response(ctx)
+}
+
+func currentLogLevels() LogLevels {
+	root, overrides := ilogx.Levels()
+
+	loggers := make(map[string]string, len(overrides))
+	for name, level := range overrides {
+		loggers[name] = level.String()
+	}
+
+	return LogLevels{Root: root.String(), Loggers: loggers}
+}
@@ -82,5 +82,19 @@ var Module = fx.Module(
 			NewResource,
 			fx.ResultTags(`group:"vef:api:resources"`),
 		),
+		// Provide log level resource
+		fx.Annotate(
+			NewLogResource,
+			fx.ResultTags(`group:"vef:api:resources"`),
+		),
+		// Provide health checker and its liveness and readiness endpoints
+		fx.Annotate(
+			NewHealthChecker,
+			fx.ParamTags(``, `group:"vef:health:indicators"`),
+		),
+		fx.Annotate(
+			NewHealthMiddleware,
+			fx.ResultTags(`group:"vef:app:middlewares"`),
+		),
 	),
 )
@@ -0,0 +1,155 @@
+package openapi
+
+// Version is the OpenAPI specification version of generated documents.
+const Version = "3.1.0"
+
+// Document is the root object of an OpenAPI 3.1 document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+}
+
+// Info provides metadata about the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Tag groups operations of the same resource.
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// PathItem describes the operations available on a single path.
+type PathItem struct {
+	Get     *Operation `json:"get,omitempty"`
+	Put     *Operation `json:"put,omitempty"`
+	Post    *Operation `json:"post,omitempty"`
+	Delete  *Operation `json:"delete,omitempty"`
+	Options *Operation `json:"options,omitempty"`
+	Head    *Operation `json:"head,omitempty"`
+	Patch   *Operation `json:"patch,omitempty"`
+	Trace   *Operation `json:"trace,omitempty"`
+}
+
+// Operation describes a single API operation on a path.
+//
+//nolint:tagliatelle // OpenAPI extension fields use the x- prefix
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+	XOperation  *OperationExtension   `json:"x-vef-operation,omitempty"`
+}
+
+// OperationExtension carries VEF specific operation details that have no OpenAPI equivalent.
+type OperationExtension struct {
+	Resource     string     `json:"resource"`
+	Action       string     `json:"action"`
+	Version      string     `json:"version"`
+	AuthStrategy string     `json:"authStrategy"`
+	Permission   string     `json:"permission,omitempty"`
+	RateLimit    *RateLimit `json:"rateLimit,omitempty"`
+	Audit        bool       `json:"audit,omitempty"`
+	Idempotent   bool       `json:"idempotent,omitempty"`
+	Timeout      string     `json:"timeout,omitempty"`
+}
+
+// RateLimit describes the rate limit applied to an operation.
+type RateLimit struct {
+	Algorithm string `json:"algorithm,omitempty"`
+	Max       int    `json:"max"`
+	Period    string `json:"period"`
+}
+
+// Parameter describes a single operation parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody describes a single request body.
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// MediaType provides the schema for a media type.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Response describes a single response of an operation.
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// Header describes a single response header.
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// Components holds reusable objects of the document.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme defines a security scheme that can be used by operations.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// SecurityRequirement lists the security schemes required to execute an operation.
+type SecurityRequirement map[string][]string
+
+// Schema is a JSON Schema (draft 2020-12) object as used by OpenAPI 3.1.
+//
+//nolint:tagliatelle // JSON Schema keywords are not camel case
+type Schema struct {
+	Ref                  string              `json:"$ref,omitempty"`
+	Type                 string              `json:"type,omitempty"`
+	Format               string              `json:"format,omitempty"`
+	Title                string              `json:"title,omitempty"`
+	Description          string              `json:"description,omitempty"`
+	Const                any                 `json:"const,omitempty"`
+	Enum                 []any               `json:"enum,omitempty"`
+	Pattern              string              `json:"pattern,omitempty"`
+	MinLength            *int                `json:"minLength,omitempty"`
+	MaxLength            *int                `json:"maxLength,omitempty"`
+	Minimum              *float64            `json:"minimum,omitempty"`
+	Maximum              *float64            `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64            `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64            `json:"exclusiveMaximum,omitempty"`
+	MinItems             *int                `json:"minItems,omitempty"`
+	MaxItems             *int                `json:"maxItems,omitempty"`
+	Items                *Schema             `json:"items,omitempty"`
+	Properties           map[string]*Schema  `json:"properties,omitempty"`
+	Required             []string            `json:"required,omitempty"`
+	AdditionalProperties *Schema             `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema           `json:"allOf,omitempty"`
+	OneOf                []*Schema           `json:"oneOf,omitempty"`
+	Examples             []any               `json:"examples,omitempty"`
+	XOperation           *OperationExtension `json:"x-vef-operation,omitempty"`
+}
@@ -0,0 +1,537 @@
+package openapi
+
+import (
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/api/handler"
+	"github.com/coldsmirk/vef-framework-go/internal/api/param"
+	"github.com/coldsmirk/vef-framework-go/internal/api/router"
+	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	"github.com/coldsmirk/vef-framework-go/security"
+	"github.com/coldsmirk/vef-framework-go/version"
+)
+
+const (
+	resultSchemaName = "Result"
+	mediaTypeJSON    = "application/json"
+	mediaTypeForm    = "multipart/form-data"
+)
+
+// securityDefinition describes how an auth strategy maps to OpenAPI security schemes.
+type securityDefinition struct {
+	schemes     map[string]*SecurityScheme
+	requirement SecurityRequirement
+}
+
+// securityDefinitions maps built-in auth strategies to their security schemes.
+// Operations using custom strategies only carry the strategy name in the x-vef-operation extension.
+var securityDefinitions = map[string]securityDefinition{
+	api.AuthStrategyBearer: {
+		schemes: map[string]*SecurityScheme{
+			"bearerAuth": {
+				Type:         "http",
+				Scheme:       "bearer",
+				BearerFormat: "JWT",
+				Description:  "Access token, also accepted via the " + security.QueryKeyAccessToken + " query parameter.",
+			},
+		},
+		requirement: SecurityRequirement{"bearerAuth": {}},
+	},
+	api.AuthStrategySignature: {
+		schemes: map[string]*SecurityScheme{
+			"signatureAppId":     {Type: "apiKey", In: "header", Name: api.HeaderXAppID, Description: "Application ID."},
+			"signatureTimestamp": {Type: "apiKey", In: "header", Name: api.HeaderXTimestamp, Description: "Unix timestamp in seconds."},
+			"signatureNonce":     {Type: "apiKey", In: "header", Name: api.HeaderXNonce, Description: "Random nonce."},
+			"signatureValue":     {Type: "apiKey", In: "header", Name: api.HeaderXSignature, Description: "HMAC signature of the request."},
+		},
+		requirement: SecurityRequirement{
+			"signatureAppId":     {},
+			"signatureTimestamp": {},
+			"signatureNonce":     {},
+			"signatureValue":     {},
+		},
+	},
+	api.AuthStrategyAPIKey: {
+		schemes: map[string]*SecurityScheme{
+			"apiKeyAuth": {Type: "apiKey", In: "header", Name: api.HeaderXAPIKey, Description: "API key."},
+		},
+		requirement: SecurityRequirement{"apiKeyAuth": {}},
+	},
+}
+
+// Generator builds OpenAPI documents from the operations registered in the API engine.
+type Generator struct {
+	engine api.Engine
+	info   Info
+}
+
+// NewGenerator creates a new OpenAPI document generator.
+func NewGenerator(engine api.Engine, cfg *config.OpenAPIConfig, appConfig *config.AppConfig) *Generator {
+	return &Generator{
+		engine: engine,
+		info: Info{
+			Title:       lo.CoalesceOrEmpty(cfg.Title, appConfig.Name, "vef-app"),
+			Description: cfg.Description,
+			Version:     lo.CoalesceOrEmpty(cfg.Version, version.VEFVersion),
+		},
+	}
+}
+
+// generation holds the state of a single document generation.
+type generation struct {
+	doc      *Document
+	registry *schemaRegistry
+	result   *Schema
+	tags     map[string]struct{}
+
+	rpcVariants   []*Schema
+	rpcSecurity   []SecurityRequirement
+	rpcPublic     bool
+	rpcMultipart  bool
+	rpcRateLimits bool
+}
+
+// Generate builds a document describing all currently registered operations.
+func (g *Generator) Generate() *Document {
+	registry := newSchemaRegistry()
+	gen := &generation{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    g.info,
+			Paths:   make(map[string]*PathItem),
+			Components: &Components{
+				Schemas:         registry.schemas,
+				SecuritySchemes: make(map[string]*SecurityScheme),
+			},
+		},
+		registry: registry,
+		result:   registry.Define(resultSchemaName, newResultSchema()),
+		tags:     make(map[string]struct{}),
+	}
+
+	for _, op := range g.engine.Operations() {
+		gen.addTag(op.Resource)
+
+		if isREST(op) {
+			gen.addRESTOperation(op)
+		} else {
+			gen.addRPCVariant(op)
+		}
+	}
+
+	gen.addRPCOperation()
+
+	for _, name := range slices.Sorted(maps.Keys(gen.tags)) {
+		gen.doc.Tags = append(gen.doc.Tags, Tag{Name: name})
+	}
+
+	return gen.doc
+}
+
+func newResultSchema() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"code":    {Type: "integer", Format: "int32", Description: "Result code, 0 means success."},
+			"message": {Type: "string", Description: "Localized result message."},
+			"data":    {Description: "Operation specific payload."},
+		},
+		Required: []string{"code", "message"},
+	}
+}
+
+func (g *generation) addTag(name string) {
+	g.tags[name] = struct{}{}
+}
+
+// addRPCVariant registers the request body of an RPC operation as one alternative of the /api endpoint.
+// Variants are discriminated by the constant resource, action and version properties.
+func (g *generation) addRPCVariant(op *api.Operation) {
+	params, meta := handlerInputs(op.Handler)
+	paramsSchema := g.inputSchema(params)
+
+	variant := &Schema{
+		Type:  "object",
+		Title: op.Resource + "/" + op.Action + "@" + op.Version,
+		Properties: map[string]*Schema{
+			"resource": {Type: "string", Const: op.Resource},
+			"action":   {Type: "string", Const: op.Action},
+			"version":  {Type: "string", Const: op.Version},
+			"params":   paramsSchema,
+			"meta":     g.inputSchema(meta),
+		},
+		Required:   []string{"resource", "action", "version"},
+		XOperation: newOperationExtension(op),
+	}
+
+	g.rpcVariants = append(g.rpcVariants, g.registry.Define(operationID(op), variant))
+	g.rpcMultipart = g.rpcMultipart || g.hasBinaryProperty(paramsSchema)
+	g.rpcRateLimits = g.rpcRateLimits || op.HasRateLimit()
+
+	if !op.RequiresAuth() {
+		g.rpcPublic = true
+	}
+
+	if requirement := g.securityFor(op); requirement != nil && !slices.ContainsFunc(g.rpcSecurity, func(existing SecurityRequirement) bool {
+		return sameRequirement(existing, requirement)
+	}) {
+		g.rpcSecurity = append(g.rpcSecurity, requirement)
+	}
+}
+
+// addRPCOperation describes the single RPC endpoint accepting all registered RPC operations.
+func (g *generation) addRPCOperation() {
+	if len(g.rpcVariants) == 0 {
+		return
+	}
+
+	content := map[string]*MediaType{
+		mediaTypeJSON: {Schema: &Schema{OneOf: g.rpcVariants}},
+	}
+
+	if g.rpcMultipart {
+		content[mediaTypeForm] = &MediaType{
+			Schema: &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"resource": {Type: "string"},
+					"action":   {Type: "string"},
+					"version":  {Type: "string"},
+					"params":   {Type: "string", Description: "JSON encoded params."},
+					"meta":     {Type: "string", Description: "JSON encoded meta."},
+				},
+				Required:             []string{"resource", "action", "version"},
+				AdditionalProperties: &Schema{Type: "string", Format: "binary"},
+			},
+		}
+	}
+
+	security := g.rpcSecurity
+	if len(security) > 0 && g.rpcPublic {
+		security = append(security, SecurityRequirement{})
+	}
+
+	g.doc.Paths[router.DefaultRPCEndpoint] = &PathItem{
+		Post: &Operation{
+			Summary:     "RPC endpoint",
+			Description: "Dispatches to the operation identified by resource, action and version in the request body.",
+			OperationID: "rpc",
+			RequestBody: &RequestBody{Required: true, Content: content},
+			Responses:   g.responses(len(g.rpcSecurity) > 0, g.rpcRateLimits),
+			Security:    security,
+		},
+	}
+}
+
+// addRESTOperation describes a REST operation on its own path and method.
+func (g *generation) addRESTOperation(op *api.Operation) {
+	method, path := restRoute(op)
+	path, pathParams := convertPath(path)
+	params, meta := handlerInputs(op.Handler)
+	paramsSchema := g.inputSchema(params)
+
+	operation := &Operation{
+		Tags:        []string{op.Resource},
+		OperationID: operationID(op),
+		Responses:   g.responses(op.RequiresAuth(), op.HasRateLimit()),
+		XOperation:  newOperationExtension(op),
+	}
+
+	if requirement := g.securityFor(op); requirement != nil {
+		operation.Security = []SecurityRequirement{requirement}
+	}
+
+	resolved := g.registry.Resolve(paramsSchema)
+	for _, name := range pathParams {
+		schema := &Schema{Type: "string"}
+		if prop, ok := resolved.Properties[name]; ok {
+			schema = prop
+		}
+
+		operation.Parameters = append(operation.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		mediaType := mediaTypeJSON
+		if g.hasBinaryProperty(paramsSchema) {
+			mediaType = mediaTypeForm
+		}
+
+		operation.RequestBody = &RequestBody{
+			Content: map[string]*MediaType{mediaType: {Schema: paramsSchema}},
+		}
+
+	default:
+		operation.Parameters = append(operation.Parameters, objectParameters(resolved, "query", "", pathParams)...)
+	}
+
+	operation.Parameters = append(operation.Parameters, objectParameters(g.registry.Resolve(g.inputSchema(meta)), "header", api.HeaderXMetaPrefix, nil)...)
+
+	if op.IsIdempotent() {
+		operation.Parameters = append(operation.Parameters, &Parameter{
+			Name:        api.HeaderIdempotencyKey,
+			In:          "header",
+			Description: "Unique key of the request, duplicates replay the first response.",
+			Schema:      &Schema{Type: "string"},
+		})
+	}
+
+	item, ok := g.doc.Paths[path]
+	if !ok {
+		item = new(PathItem)
+		g.doc.Paths[path] = item
+	}
+
+	item.set(method, operation)
+}
+
+// inputSchema returns the schema of the params or meta types accepted by a handler.
+func (g *generation) inputSchema(types []reflect.Type) *Schema {
+	switch len(types) {
+	case 0:
+		return &Schema{Type: "object"}
+	case 1:
+		return g.registry.Schema(types[0])
+	default:
+		return &Schema{AllOf: lo.Map(types, func(t reflect.Type, _ int) *Schema { return g.registry.Schema(t) })}
+	}
+}
+
+// hasBinaryProperty reports whether the object schema contains file upload properties.
+func (g *generation) hasBinaryProperty(schema *Schema) bool {
+	for _, prop := range g.registry.Resolve(schema).Properties {
+		if prop.Format == "binary" || (prop.Items != nil && prop.Items.Format == "binary") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// securityFor returns the security requirement of an operation and registers the used schemes.
+// It returns nil for public operations and for strategies without a known scheme mapping.
+func (g *generation) securityFor(op *api.Operation) SecurityRequirement {
+	if !op.RequiresAuth() {
+		return nil
+	}
+
+	definition, ok := securityDefinitions[op.Auth.Strategy]
+	if !ok {
+		return nil
+	}
+
+	for name, scheme := range definition.schemes {
+		g.doc.Components.SecuritySchemes[name] = scheme
+	}
+
+	return definition.requirement
+}
+
+func (g *generation) responses(requiresAuth, rateLimited bool) map[string]*Response {
+	responses := map[string]*Response{
+		"200":     {Description: "Successful result.", Content: g.resultContent()},
+		"default": {Description: "Error result with a non-zero code.", Content: g.resultContent()},
+	}
+
+	if requiresAuth {
+		responses["401"] = &Response{Description: "Authentication required or failed.", Content: g.resultContent()}
+	}
+
+	if rateLimited {
+		responses["429"] = &Response{
+			Description: "Rate limit exceeded.",
+			Headers:     rateLimitHeaders(),
+			Content:     g.resultContent(),
+		}
+	}
+
+	return responses
+}
+
+// rateLimitHeaders documents the quota headers set by the rate limit middleware.
+func rateLimitHeaders() map[string]*Header {
+	integer := func(description string) *Header {
+		return &Header{Description: description, Schema: &Schema{Type: "integer"}}
+	}
+
+	return map[string]*Header{
+		"X-RateLimit-Limit":     integer("Maximum number of requests allowed within the period."),
+		"X-RateLimit-Remaining": integer("Number of requests still allowed."),
+		"X-RateLimit-Reset":     integer("Seconds until the quota is restored."),
+		"Retry-After":           integer("Seconds to wait before retrying."),
+	}
+}
+
+func (g *generation) resultContent() map[string]*MediaType {
+	return map[string]*MediaType{mediaTypeJSON: {Schema: g.result}}
+}
+
+func (p *PathItem) set(method string, op *Operation) {
+	switch method {
+	case http.MethodGet:
+		p.Get = op
+	case http.MethodPut:
+		p.Put = op
+	case http.MethodPost:
+		p.Post = op
+	case http.MethodDelete:
+		p.Delete = op
+	case http.MethodOptions:
+		p.Options = op
+	case http.MethodHead:
+		p.Head = op
+	case http.MethodPatch:
+		p.Patch = op
+	case http.MethodTrace:
+		p.Trace = op
+	}
+}
+
+// objectParameters expands the top-level properties of an object schema into individual parameters.
+func objectParameters(schema *Schema, in, prefix string, exclude []string) []*Parameter {
+	names := slices.Sorted(maps.Keys(schema.Properties))
+	parameters := make([]*Parameter, 0, len(names))
+
+	for _, name := range names {
+		if slices.Contains(exclude, name) {
+			continue
+		}
+
+		parameters = append(parameters, &Parameter{
+			Name:     prefix + name,
+			In:       in,
+			Required: slices.Contains(schema.Required, name),
+			Schema:   schema.Properties[name],
+		})
+	}
+
+	return parameters
+}
+
+// handlerInputs returns the params and meta types declared by a handler.
+func handlerInputs(h any) (params, meta []reflect.Type) {
+	funcH, ok := h.(handler.Func)
+	if !ok {
+		return nil, nil
+	}
+
+	fnType := funcH.H().Type()
+	if funcH.IsFactory() {
+		fnType = fnType.Out(0)
+	}
+
+	for i := range fnType.NumIn() {
+		switch in := fnType.In(i); {
+		case param.IsParamsType(in):
+			params = append(params, in)
+		case param.IsMetaType(in):
+			meta = append(meta, in)
+		}
+	}
+
+	return params, meta
+}
+
+func isREST(op *api.Operation) bool {
+	if res, ok := op.Meta[shared.MetaKeyResource].(api.Resource); ok {
+		return res.Kind() == api.KindREST
+	}
+
+	_, ok := op.Meta[shared.MetaKeyRESTHTTPMethod]
+
+	return ok
+}
+
+// restRoute returns the HTTP method and fiber path of a REST operation.
+// Operations not yet mounted fall back to the default REST path layout.
+func restRoute(op *api.Operation) (method, path string) {
+	method, _ = op.Meta[shared.MetaKeyRESTHTTPMethod].(string)
+	path, _ = op.Meta[shared.MetaKeyRESTHTTPPath].(string)
+
+	if method != "" && path != "" {
+		return method, path
+	}
+
+	method, subPath, _ := strings.Cut(op.Action, " ")
+	if subPath = strings.TrimSpace(subPath); subPath != "" && !strings.HasPrefix(subPath, "/") {
+		subPath = "/" + subPath
+	}
+
+	return strings.ToUpper(method), router.DefaultRESTBasePath + "/" + op.Resource + subPath
+}
+
+// convertPath converts fiber path parameters (":id", ":id?") into OpenAPI templates ("{id}").
+func convertPath(path string) (string, []string) {
+	segments := strings.Split(path, "/")
+	names := make([]string, 0)
+
+	for i, segment := range segments {
+		name, ok := strings.CutPrefix(segment, ":")
+		if !ok {
+			continue
+		}
+
+		name = strings.TrimSuffix(name, "?")
+		segments[i] = "{" + name + "}"
+		names = append(names, name)
+	}
+
+	return strings.Join(segments, "/"), names
+}
+
+// operationID derives a stable operation identifier that is also a valid component name.
+func operationID(op *api.Operation) string {
+	return sanitizeComponentName(op.Resource + "." + op.Action + "." + op.Version)
+}
+
+func newOperationExtension(op *api.Operation) *OperationExtension {
+	ext := &OperationExtension{
+		Resource:     op.Resource,
+		Action:       op.Action,
+		Version:      op.Version,
+		AuthStrategy: op.Auth.Strategy,
+		Audit:        op.EnableAudit,
+		Idempotent:   op.IsIdempotent(),
+	}
+
+	if op.Timeout > 0 {
+		ext.Timeout = op.Timeout.String()
+	}
+
+	if token, ok := op.Auth.Options[shared.AuthOptionPermToken].(string); ok {
+		ext.Permission = token
+	}
+
+	if op.HasRateLimit() {
+		ext.RateLimit = &RateLimit{
+			Algorithm: string(op.RateLimit.Algorithm),
+			Max:       op.RateLimit.Max,
+			Period:    op.RateLimit.Period.String(),
+		}
+	}
+
+	return ext
+}
+
+func sameRequirement(a, b SecurityRequirement) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
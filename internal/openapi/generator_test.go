@@ -0,0 +1,264 @@
+package openapi
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/api/shared"
+	"github.com/coldsmirk/vef-framework-go/page"
+)
+
+type fakeEngine struct {
+	ops []*api.Operation
+}
+
+func (*fakeEngine) Register(...api.Resource) error       { return nil }
+func (*fakeEngine) Lookup(api.Identifier) *api.Operation { return nil }
+func (*fakeEngine) Mount(fiber.Router) error             { return nil }
+func (e *fakeEngine) Operations() []*api.Operation       { return e.ops }
+
+type fakeHandler struct {
+	factory bool
+	h       any
+}
+
+func (f fakeHandler) IsFactory() bool  { return f.factory }
+func (f fakeHandler) H() reflect.Value { return reflect.ValueOf(f.h) }
+
+type createUserParams struct {
+	api.P
+
+	Username string   `json:"username" validate:"required,alphanum_us,min=3,max=32" label:"用户名"`
+	Email    string   `json:"email" validate:"omitempty,email"`
+	Age      int      `json:"age" validate:"gte=0,lte=150"`
+	Gender   string   `json:"gender" validate:"oneof=male female"`
+	Tags     []string `json:"tags" validate:"max=5,dive,min=1"`
+	Remark   string
+}
+
+type uploadParams struct {
+	api.P
+
+	File *multipart.FileHeader `json:"file" validate:"required"`
+}
+
+type userPathParams struct {
+	api.P
+
+	ID int64 `json:"id" validate:"required"`
+}
+
+type treeNode struct {
+	Name     string      `json:"name"`
+	Children []*treeNode `json:"children"`
+}
+
+type treeParams struct {
+	api.P
+
+	Root treeNode `json:"root"`
+}
+
+func newOperation(resource, action string, auth *api.AuthConfig, h any) *api.Operation {
+	return &api.Operation{
+		Identifier: api.Identifier{Resource: resource, Action: action, Version: api.VersionV1},
+		Auth:       auth,
+		Timeout:    30 * time.Second,
+		RateLimit:  &api.RateLimitConfig{Max: 100, Period: 5 * time.Minute},
+		Handler:    h,
+		Meta: map[string]any{
+			shared.MetaKeyResource: api.NewRPCResource("placeholder"),
+		},
+	}
+}
+
+func newRESTOperation(resource, action, method, path string, auth *api.AuthConfig, h any) *api.Operation {
+	op := newOperation(resource, action, auth, h)
+	op.Meta = map[string]any{
+		shared.MetaKeyRESTHTTPMethod: method,
+		shared.MetaKeyRESTHTTPPath:   path,
+	}
+
+	return op
+}
+
+func idempotent(op *api.Operation) *api.Operation {
+	op.Idempotency = &api.IdempotencyConfig{}
+
+	return op
+}
+
+func generate(ops ...*api.Operation) *Document {
+	generator := NewGenerator(
+		&fakeEngine{ops: ops},
+		&config.OpenAPIConfig{Description: "Test API"},
+		&config.AppConfig{Name: "test-app"},
+	)
+
+	return generator.Generate()
+}
+
+// TestGenerateRPC tests that RPC operations become variants of the single RPC endpoint.
+func TestGenerateRPC(t *testing.T) {
+	withPerm := api.BearerAuth()
+	withPerm.Options = map[string]any{shared.AuthOptionPermToken: "sys:user:create"}
+
+	doc := generate(
+		newOperation("sys/user", "create", withPerm, fakeHandler{
+			factory: true,
+			h: func() func(fiber.Ctx, createUserParams, page.Pageable) error {
+				return nil
+			},
+		}),
+		newOperation("sys/auth", "login", api.Public(), fakeHandler{
+			h: func(fiber.Ctx, uploadParams) error { return nil },
+		}),
+	)
+
+	t.Run("DocumentInfo", func(t *testing.T) {
+		assert.Equal(t, Version, doc.OpenAPI, "Should generate an OpenAPI 3.1 document")
+		assert.Equal(t, "test-app", doc.Info.Title, "Title should default to the application name")
+		assert.Equal(t, "Test API", doc.Info.Description, "Description should come from config")
+		assert.Equal(t, []Tag{{Name: "sys/auth"}, {Name: "sys/user"}}, doc.Tags, "Tags should list resources in order")
+	})
+
+	t.Run("SingleEndpoint", func(t *testing.T) {
+		require.Len(t, doc.Paths, 1, "All RPC operations should share one path")
+		item := doc.Paths["/api"]
+		require.NotNil(t, item, "RPC endpoint should be documented at /api")
+		require.NotNil(t, item.Post, "RPC endpoint should accept POST")
+
+		body := item.Post.RequestBody.Content[mediaTypeJSON].Schema
+		require.Len(t, body.OneOf, 2, "Request body should have one variant per operation")
+		assert.Equal(t, "#/components/schemas/sys_user.create.v1", body.OneOf[0].Ref, "Variants should reference per-operation schemas")
+		assert.Contains(t, item.Post.RequestBody.Content, mediaTypeForm, "File uploads should add a multipart body")
+		assert.Equal(t, []SecurityRequirement{{"bearerAuth": {}}, {}}, item.Post.Security, "Security should allow bearer or anonymous access")
+		require.Contains(t, item.Post.Responses, "429", "Rate limited operations should document 429")
+		assert.Contains(t, item.Post.Responses["429"].Headers, "Retry-After", "429 response should document retry delay")
+	})
+
+	t.Run("VariantDiscriminators", func(t *testing.T) {
+		variant := doc.Components.Schemas["sys_user.create.v1"]
+		require.NotNil(t, variant, "Variant schema should be registered")
+		assert.Equal(t, "sys/user", variant.Properties["resource"].Const, "Resource should be a constant")
+		assert.Equal(t, "create", variant.Properties["action"].Const, "Action should be a constant")
+		assert.Equal(t, "v1", variant.Properties["version"].Const, "Version should be a constant")
+
+		ext := variant.XOperation
+		require.NotNil(t, ext, "Variant should carry operation extension")
+		assert.Equal(t, api.AuthStrategyBearer, ext.AuthStrategy, "Extension should expose auth strategy")
+		assert.Equal(t, "sys:user:create", ext.Permission, "Extension should expose permission token")
+		assert.Equal(t, &RateLimit{Max: 100, Period: "5m0s"}, ext.RateLimit, "Extension should expose rate limit")
+	})
+
+	t.Run("ParamsSchema", func(t *testing.T) {
+		variant := doc.Components.Schemas["sys_user.create.v1"]
+		assert.Equal(t, "#/components/schemas/createUserParams", variant.Properties["params"].Ref, "Params should reference the params type")
+		assert.Equal(t, "#/components/schemas/Pageable", variant.Properties["meta"].Ref, "Meta should reference the builtin pageable type")
+
+		params := doc.Components.Schemas["createUserParams"]
+		require.NotNil(t, params, "Params schema should be registered")
+		assert.Equal(t, []string{"username"}, params.Required, "Only required fields should be listed")
+
+		username := params.Properties["username"]
+		assert.Equal(t, "用户名", username.Title, "Label should become the title")
+		assert.Equal(t, validationPatterns["alphanum_us"], username.Pattern, "Custom rule should become a pattern")
+		assert.Equal(t, new(3), username.MinLength, "Min should become minLength for strings")
+		assert.Equal(t, new(32), username.MaxLength, "Max should become maxLength for strings")
+
+		assert.Equal(t, "email", params.Properties["email"].Format, "Email rule should become a format")
+		assert.Equal(t, new(float64(0)), params.Properties["age"].Minimum, "Gte should become minimum for numbers")
+		assert.Equal(t, new(float64(150)), params.Properties["age"].Maximum, "Lte should become maximum for numbers")
+		assert.Equal(t, []any{"male", "female"}, params.Properties["gender"].Enum, "Oneof should become enum")
+		assert.Equal(t, new(5), params.Properties["tags"].MaxItems, "Max should become maxItems for slices")
+		assert.Equal(t, new(1), params.Properties["tags"].Items.MinLength, "Rules after dive should apply to items")
+		assert.Contains(t, params.Properties, "remark", "Untagged fields should use camel case names")
+	})
+}
+
+// TestGenerateREST tests that REST operations are documented on their own paths.
+func TestGenerateREST(t *testing.T) {
+	doc := generate(
+		newRESTOperation("users", "get /:id", "GET", "/api/users/:id", api.SignatureAuth(), fakeHandler{
+			h: func(fiber.Ctx, userPathParams, page.Pageable) error { return nil },
+		}),
+		idempotent(newRESTOperation("users", "post", "POST", "/api/users", api.BearerAuth(), fakeHandler{
+			h: func(fiber.Ctx, createUserParams) error { return nil },
+		})),
+		newRESTOperation("users", "post avatar", "POST", "/api/users/avatar", api.Public(), fakeHandler{
+			h: func(fiber.Ctx, uploadParams) error { return nil },
+		}),
+	)
+
+	t.Run("PathParameters", func(t *testing.T) {
+		item := doc.Paths["/api/users/{id}"]
+		require.NotNil(t, item, "Fiber path params should be converted to templates")
+		require.NotNil(t, item.Get, "Operation should be mounted on its HTTP method")
+
+		params := item.Get.Parameters
+		require.Len(t, params, 3, "Should document path and meta header parameters")
+		assert.Equal(t, "id", params[0].Name, "Path parameter should be first")
+		assert.Equal(t, "path", params[0].In, "Path parameter should be in path")
+		assert.Equal(t, "integer", params[0].Schema.Type, "Path parameter should use the params field type")
+		assert.Equal(t, "X-Meta-page", params[1].Name, "Meta should be sent as prefixed headers")
+		assert.Equal(t, "header", params[1].In, "Meta should be sent as headers")
+	})
+
+	t.Run("SignatureSecurity", func(t *testing.T) {
+		op := doc.Paths["/api/users/{id}"].Get
+		require.Len(t, op.Security, 1, "Signature auth should have one requirement")
+		assert.Len(t, op.Security[0], 4, "Signature auth should require all signature headers")
+		assert.Equal(t, api.HeaderXSignature, doc.Components.SecuritySchemes["signatureValue"].Name, "Signature scheme should use the signature header")
+	})
+
+	t.Run("RequestBody", func(t *testing.T) {
+		op := doc.Paths["/api/users"].Post
+		require.NotNil(t, op, "Post operation should be documented")
+		require.NotNil(t, op.RequestBody, "Post operation should have a request body")
+		assert.Equal(t, "#/components/schemas/createUserParams", op.RequestBody.Content[mediaTypeJSON].Schema.Ref, "Body should reference params schema")
+		assert.Equal(t, []SecurityRequirement{{"bearerAuth": {}}}, op.Security, "Bearer auth should reference bearer scheme")
+	})
+
+	t.Run("IdempotencyKey", func(t *testing.T) {
+		op := doc.Paths["/api/users"].Post
+		require.Len(t, op.Parameters, 1, "Idempotent operation should document the key header")
+		assert.Equal(t, api.HeaderIdempotencyKey, op.Parameters[0].Name, "Key should be sent as a header")
+		assert.True(t, op.XOperation.Idempotent, "Extension should flag idempotent operations")
+		assert.False(t, doc.Paths["/api/users/avatar"].Post.XOperation.Idempotent, "Other operations should not be flagged")
+	})
+
+	t.Run("MultipartUpload", func(t *testing.T) {
+		op := doc.Paths["/api/users/avatar"].Post
+		require.NotNil(t, op, "Upload operation should be documented")
+		assert.Contains(t, op.RequestBody.Content, mediaTypeForm, "File params should use multipart body")
+		assert.Empty(t, op.Security, "Public operation should not require security")
+		assert.NotContains(t, op.Responses, "401", "Public operation should not document 401")
+	})
+}
+
+// TestSchemaRegistry tests schema reflection edge cases.
+func TestSchemaRegistry(t *testing.T) {
+	t.Run("RecursiveType", func(t *testing.T) {
+		registry := newSchemaRegistry()
+		schema := registry.Schema(reflect.TypeFor[treeParams]())
+
+		root := registry.Resolve(registry.Resolve(schema).Properties["root"])
+		assert.Equal(t, "#/components/schemas/treeNode", root.Properties["children"].Items.Ref, "Recursive types should resolve to references")
+	})
+
+	t.Run("MarshalDocument", func(t *testing.T) {
+		data, err := json.Marshal(generate())
+		require.NoError(t, err, "Document should marshal")
+		assert.Contains(t, string(data), `"openapi":"3.1.0"`, "Document should declare its version")
+		assert.Contains(t, string(data), `"paths":{}`, "Empty document should still have paths")
+	})
+}
@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/fx"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/app"
+	"github.com/coldsmirk/vef-framework-go/internal/logx"
+)
+
+var logger = logx.Named("openapi")
+
+// Middleware serves the generated OpenAPI document.
+type Middleware struct {
+	path      string
+	generator *Generator
+}
+
+// MiddlewareParams contains dependencies for creating the middleware.
+type MiddlewareParams struct {
+	fx.In
+
+	Config    *config.OpenAPIConfig
+	Generator *Generator
+}
+
+// NewMiddleware creates a new OpenAPI middleware.
+// Returns nil if OpenAPI is disabled by configuration.
+func NewMiddleware(params MiddlewareParams) app.Middleware {
+	if !params.Config.Enabled {
+		logger.Info("OpenAPI is disabled by configuration")
+
+		return nil
+	}
+
+	return &Middleware{
+		path:      params.Config.PathOrDefault(),
+		generator: params.Generator,
+	}
+}
+
+func (*Middleware) Name() string {
+	return "openapi"
+}
+
+func (*Middleware) Order() int {
+	return 400
+}
+
+func (m *Middleware) Apply(router fiber.Router) {
+	// The document is generated per request so that dynamically registered operations are included.
+	router.Get(m.path, func(ctx fiber.Ctx) error {
+		return ctx.JSON(m.generator.Generate())
+	})
+	logger.Infof("OpenAPI document registered at GET %s", m.path)
+}
@@ -0,0 +1,14 @@
+package openapi
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"vef:openapi",
+	fx.Provide(
+		NewGenerator,
+		fx.Annotate(
+			NewMiddleware,
+			fx.ResultTags(`group:"vef:app:middlewares"`),
+		),
+	),
+)
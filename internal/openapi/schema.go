@@ -0,0 +1,359 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"mime/multipart"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/decimal"
+	"github.com/coldsmirk/vef-framework-go/reflectx"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+const (
+	componentSchemaPrefix = "#/components/schemas/"
+
+	tagJSON     = "json"
+	tagValidate = "validate"
+	tagLabel    = "label"
+)
+
+var (
+	invalidComponentChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+	apiParamsType     = reflect.TypeFor[api.P]()
+	apiMetaType       = reflect.TypeFor[api.M]()
+	fileHeaderType    = reflect.TypeFor[multipart.FileHeader]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	byteSliceType     = reflect.TypeFor[[]byte]()
+	wellKnownSchemas  = map[reflect.Type]func() *Schema{
+		reflect.TypeFor[time.Time]():       func() *Schema { return &Schema{Type: "string", Format: "date-time"} },
+		reflect.TypeFor[time.Duration]():   func() *Schema { return &Schema{Type: "string", Examples: []any{"30s"}} },
+		reflect.TypeFor[timex.DateTime]():  func() *Schema { return &Schema{Type: "string", Examples: []any{time.DateTime}} },
+		reflect.TypeFor[timex.Date]():      func() *Schema { return &Schema{Type: "string", Format: "date"} },
+		reflect.TypeFor[timex.Time]():      func() *Schema { return &Schema{Type: "string", Format: "time"} },
+		reflect.TypeFor[decimal.Decimal](): func() *Schema { return &Schema{Type: "string", Format: "decimal"} },
+		fileHeaderType:                     func() *Schema { return &Schema{Type: "string", Format: "binary"} },
+		rawMessageType:                     func() *Schema { return &Schema{} },
+	}
+
+	// validationPatterns maps the framework's custom regex rules to JSON Schema patterns.
+	validationPatterns = map[string]string{
+		"alphanum":          `^[a-zA-Z0-9]+$`,
+		"alphanum_us":       `^[a-zA-Z0-9_]+$`,
+		"alphanum_us_slash": `^[a-zA-Z0-9_/]+$`,
+		"alphanum_us_dot":   `^[a-zA-Z0-9_.]+$`,
+		"phone_number":      `^1[3-9]\d{9}$`,
+	}
+
+	// validationFormats maps validator rules to JSON Schema formats.
+	validationFormats = map[string]string{
+		"email":    "email",
+		"url":      "uri",
+		"http_url": "uri",
+		"uri":      "uri",
+		"uuid":     "uuid",
+		"uuid4":    "uuid",
+		"ipv4":     "ipv4",
+		"ipv6":     "ipv6",
+		"hostname": "hostname",
+		"datetime": "date-time",
+	}
+)
+
+// schemaRegistry reflects Go types into JSON schemas and collects named struct types as components.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// Define registers a named component schema and returns a reference to it.
+func (r *schemaRegistry) Define(name string, schema *Schema) *Schema {
+	r.schemas[name] = schema
+
+	return refSchema(name)
+}
+
+// Resolve follows a component reference and returns the referenced schema.
+func (r *schemaRegistry) Resolve(schema *Schema) *Schema {
+	if name, ok := strings.CutPrefix(schema.Ref, componentSchemaPrefix); ok {
+		if resolved, exists := r.schemas[name]; exists {
+			return resolved
+		}
+	}
+
+	return schema
+}
+
+// Schema returns the schema of the given type, registering named structs as components.
+func (r *schemaRegistry) Schema(t reflect.Type) *Schema {
+	t = reflectx.Indirect(t)
+
+	if factory, ok := wellKnownSchemas[t]; ok {
+		return factory()
+	}
+
+	if t.Kind() != reflect.Struct && (t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t == byteSliceType {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: r.Schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.Schema(t.Elem())}
+	case reflect.Struct:
+		return r.structSchema(t)
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema returns a reference for named structs and an inline schema for anonymous ones.
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	if t.Name() == "" {
+		return r.objectSchema(t)
+	}
+
+	if name, ok := r.names[t]; ok {
+		return refSchema(name)
+	}
+
+	name := r.componentName(t)
+	r.names[t] = name
+	// Reserve the name before reflecting fields so that recursive types resolve to a reference.
+	r.schemas[name] = &Schema{}
+	r.schemas[name] = r.objectSchema(t)
+
+	return refSchema(name)
+}
+
+// componentName derives a unique component name, qualifying it with the package on collisions.
+func (r *schemaRegistry) componentName(t reflect.Type) string {
+	name := sanitizeComponentName(t.Name())
+	if _, taken := r.schemas[name]; !taken {
+		return name
+	}
+
+	pkg := t.PkgPath()
+	if idx := strings.LastIndex(pkg, "/"); idx >= 0 {
+		pkg = pkg[idx+1:]
+	}
+
+	qualified := sanitizeComponentName(pkg + "." + t.Name())
+	for i := 2; ; i++ {
+		if _, taken := r.schemas[qualified]; !taken {
+			return qualified
+		}
+
+		qualified = sanitizeComponentName(pkg+"."+t.Name()) + strconv.Itoa(i)
+	}
+}
+
+// objectSchema reflects the exported fields of a struct, flattening embedded structs
+// the same way request params are decoded.
+func (r *schemaRegistry) objectSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	r.collectFields(t, schema)
+
+	return schema
+}
+
+func (r *schemaRegistry) collectFields(t reflect.Type, schema *Schema) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if field.Type == apiParamsType || field.Type == apiMetaType {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get(tagJSON), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			if ft := reflectx.Indirect(field.Type); ft.Kind() == reflect.Struct {
+				r.collectFields(ft, schema)
+
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = lo.CamelCase(field.Name)
+		}
+
+		prop := r.Schema(field.Type)
+		prop.Title = field.Tag.Get(tagLabel)
+
+		if applyValidation(prop, field.Type, field.Tag.Get(tagValidate)) {
+			schema.Required = append(schema.Required, name)
+		}
+
+		schema.Properties[name] = prop
+	}
+}
+
+// applyValidation translates validator rules into schema constraints and reports whether the field is required.
+func applyValidation(schema *Schema, t reflect.Type, tag string) (required bool) {
+	if tag == "" || tag == "-" {
+		return false
+	}
+
+	target := schema
+	kind := reflectx.Indirect(t).Kind()
+
+	for rule := range strings.SplitSeq(tag, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "required":
+			required = target == schema
+		case "dive":
+			if target.Items == nil {
+				return required
+			}
+
+			target = target.Items
+			kind = reflectx.Indirect(t).Elem().Kind()
+		case "min", "gte":
+			setLowerBound(target, kind, value, false)
+		case "max", "lte":
+			setUpperBound(target, kind, value, false)
+		case "gt":
+			setLowerBound(target, kind, value, true)
+		case "lt":
+			setUpperBound(target, kind, value, true)
+		case "len":
+			setLowerBound(target, kind, value, false)
+			setUpperBound(target, kind, value, false)
+		case "oneof":
+			target.Enum = enumValues(kind, strings.Fields(value))
+		default:
+			if pattern, ok := validationPatterns[name]; ok {
+				target.Pattern = pattern
+			} else if format, ok := validationFormats[name]; ok {
+				target.Format = format
+			}
+		}
+	}
+
+	return required
+}
+
+func setLowerBound(schema *Schema, kind reflect.Kind, value string, exclusive bool) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case isNumberKind(kind):
+		if exclusive {
+			schema.ExclusiveMinimum = &number
+		} else {
+			schema.Minimum = &number
+		}
+	case kind == reflect.String:
+		schema.MinLength = new(int(number))
+	case kind == reflect.Slice || kind == reflect.Array:
+		schema.MinItems = new(int(number))
+	}
+}
+
+func setUpperBound(schema *Schema, kind reflect.Kind, value string, exclusive bool) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case isNumberKind(kind):
+		if exclusive {
+			schema.ExclusiveMaximum = &number
+		} else {
+			schema.Maximum = &number
+		}
+	case kind == reflect.String:
+		schema.MaxLength = new(int(number))
+	case kind == reflect.Slice || kind == reflect.Array:
+		schema.MaxItems = new(int(number))
+	}
+}
+
+func enumValues(kind reflect.Kind, values []string) []any {
+	return lo.Map(values, func(value string, _ int) any {
+		if isNumberKind(kind) {
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				return number
+			}
+		}
+
+		return value
+	})
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func refSchema(name string) *Schema {
+	return &Schema{Ref: componentSchemaPrefix + name}
+}
+
+func sanitizeComponentName(name string) string {
+	return strings.Trim(invalidComponentChars.ReplaceAllString(name, "_"), "_")
+}
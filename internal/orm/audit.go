@@ -8,13 +8,16 @@ import (
 	collections "github.com/coldsmirk/go-collections"
 )
 
-// autoColumnHandlers manages audit fields (ID, timestamps, user tracking) on insert/update.
+// autoColumnHandlers manages audit fields (ID, timestamps, user tracking), the optimistic locking version
+// and the tenant column on insert/update.
 var autoColumnHandlers = []ColumnHandler{
 	&IDHandler{},
 	&CreatedAtHandler{},
 	&UpdatedAtHandler{},
 	&CreatedByHandler{},
 	&UpdatedByHandler{},
+	&VersionHandler{},
+	&TenantHandler{},
 }
 
 type InsertAutoColumnPlanItem struct {
@@ -38,6 +41,13 @@ type ColumnHandler interface {
 	Name() string
 }
 
+// FieldMatcher is implemented by handlers that only manage some of the fields mapped to their column,
+// e.g. the version handler, which leaves business columns that happen to be named version alone.
+type FieldMatcher interface {
+	// Matches reports whether the handler manages the field of the table.
+	Matches(table *schema.Table, field *schema.Field) bool
+}
+
 // InsertColumnHandler manages columns automatically during insert operations.
 type InsertColumnHandler interface {
 	ColumnHandler
@@ -76,7 +86,7 @@ func getInsertAutoColumnPlan(table *schema.Table) []InsertAutoColumnPlanItem {
 			}
 
 			field, ok := table.FieldMap[handler.Name()]
-			if !ok {
+			if !ok || !handlerMatches(handler, table, field) {
 				continue
 			}
 
@@ -102,7 +112,7 @@ func getUpdateAutoColumnPlan(table *schema.Table) []UpdateAutoColumnPlanItem {
 			}
 
 			field, ok := table.FieldMap[handler.Name()]
-			if !ok {
+			if !ok || !handlerMatches(handler, table, field) {
 				continue
 			}
 
@@ -118,6 +128,14 @@ func getUpdateAutoColumnPlan(table *schema.Table) []UpdateAutoColumnPlanItem {
 	return plan
 }
 
+// handlerMatches reports whether the handler manages the field, which is the case for every field
+// mapped to its column unless the handler is a FieldMatcher.
+func handlerMatches(handler ColumnHandler, table *schema.Table, field *schema.Field) bool {
+	matcher, ok := handler.(FieldMatcher)
+
+	return !ok || matcher.Matches(table, field)
+}
+
 func applyInsertAutoColumns(
 	query *BunInsertQuery,
 	table *schema.Table,
@@ -0,0 +1,73 @@
+package orm
+
+import (
+	"reflect"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+
+	"github.com/coldsmirk/vef-framework-go/dbx"
+)
+
+// TenantHandler stamps inserted rows with the tenant of the DB the query was created from.
+// A tenant-scoped DB always overrides the model value so that rows cannot be written into another tenant;
+// an unscoped DB keeps whatever tenant the model carries.
+type TenantHandler struct{}
+
+func (*TenantHandler) OnInsert(query *BunInsertQuery, _ *schema.Table, _ *schema.Field, _ any, value reflect.Value) {
+	if tenantID := query.db.tenantID; tenantID != "" {
+		value.SetString(tenantID)
+	}
+}
+
+func (*TenantHandler) Name() string {
+	return ColumnTenantID
+}
+
+// tenantCondition returns the filter that restricts table, optionally referred to by alias, to the rows of the DB's tenant.
+// It reports false when the DB is unscoped or the table has no tenant column.
+func (d *BunDB) tenantCondition(table *schema.Table, alias ...string) (func(ConditionBuilder), bool) {
+	if d.tenantID == "" || table == nil || !table.HasField(ColumnTenantID) {
+		return nil, false
+	}
+
+	var (
+		tenantID = d.tenantID
+		column   = dbx.ColumnWithAlias(ColumnTenantID, alias...)
+	)
+
+	return func(cb ConditionBuilder) {
+		cb.Equals(column, tenantID)
+	}, true
+}
+
+// newScopedWhere returns the builder that collects the WHERE conditions of a query created from a tenant-scoped DB,
+// or nil when the DB is unscoped. Each condition is parenthesized the way bun renders WHERE conditions.
+func newScopedWhere(db *BunDB, qb QueryBuilder) *ClauseConditionBuilder {
+	if db.tenantID == "" {
+		return nil
+	}
+
+	builder := newConditionBuilder(qb)
+	builder.and = func(query string, args ...any) {
+		builder.And("("+query+")", args...)
+	}
+	builder.or = func(query string, args ...any) {
+		builder.Or("("+query+")", args...)
+	}
+
+	return builder
+}
+
+// applyTenantScope appends the collected conditions as a single group and ANDs the tenant filter of table to it,
+// so that a top-level OR in the query's own conditions cannot reach rows of other tenants.
+// Conditions collected after it runs are not rendered, so it must run once every condition has been added.
+func (d *BunDB) applyTenantScope(builder bun.QueryBuilder, qb QueryBuilder, where *ClauseConditionBuilder, table *schema.Table) {
+	if where != nil && len(where.conditions) > 0 {
+		builder.Where("?", where)
+	}
+
+	if cond, ok := d.tenantCondition(table); ok {
+		cond(newQueryConditionBuilder(builder, qb))
+	}
+}
@@ -0,0 +1,104 @@
+package orm
+
+import (
+	"reflect"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// versionedModelType is the embed marking a model as optimistically locked.
+var versionedModelType = reflect.TypeFor[VersionedModel]()
+
+// VersionHandler implements optimistic locking on the version column of models embedding VersionedModel.
+// Inserts start at version 1; updates match the current version and increment it, so a
+// concurrent writer that already bumped the row makes the update affect no rows.
+type VersionHandler struct{}
+
+// Matches reports whether the field is the integer version column of an embedded VersionedModel,
+// so that business columns named version, e.g. the version of a flow, are left alone.
+func (*VersionHandler) Matches(table *schema.Table, field *schema.Field) bool {
+	if len(field.Index) < 2 || !isInteger(field.IndirectType.Kind()) {
+		return false
+	}
+
+	parent := table.Type.FieldByIndex(field.Index[:len(field.Index)-1]).Type
+	for parent.Kind() == reflect.Pointer {
+		parent = parent.Elem()
+	}
+
+	return parent == versionedModelType
+}
+
+func (*VersionHandler) OnInsert(_ *BunInsertQuery, _ *schema.Table, _ *schema.Field, _ any, value reflect.Value) {
+	if value.IsZero() {
+		value.SetInt(1)
+	}
+}
+
+func (vh *VersionHandler) OnUpdate(query *BunUpdateQuery, _ *schema.Table, field *schema.Field, _ any, value reflect.Value) {
+	current := value.Int()
+
+	switch {
+	case query.isBulk:
+		// Bulk updates write each row from the _data CTE, so the row's new version is carried
+		// there and the stored version must be exactly one behind it. The SET list is fixed when
+		// Bulk is called, so a column selection must already include the version column.
+		if query.versionChecks == 0 {
+			query.Where(func(cb ConditionBuilder) {
+				cb.EqualsExpr(field.Name, func(eb ExprBuilder) any {
+					return eb.Expr("_data.? - 1", bun.Name(field.Name))
+				})
+			})
+		}
+
+		value.SetInt(current + 1)
+		query.versionChecks++
+
+	case query.isSliceModel():
+		// A non-bulk slice update shares a single WHERE clause, so only the increment is applied.
+		if query.hasSet {
+			if !query.versionBumped {
+				query.SetExpr(vh.Name(), incrementVersion(field.Name))
+				query.versionBumped = true
+			}
+		} else {
+			value.SetInt(current + 1)
+			query.includeColumn(vh.Name())
+		}
+
+	default:
+		query.Where(func(cb ConditionBuilder) {
+			cb.Equals(field.Name, current)
+		})
+
+		if query.hasSet {
+			query.SetExpr(vh.Name(), incrementVersion(field.Name))
+		} else {
+			value.SetInt(current + 1)
+			query.includeColumn(vh.Name())
+		}
+
+		query.versionChecks = 1
+	}
+}
+
+func (*VersionHandler) Name() string {
+	return ColumnVersion
+}
+
+func isInteger(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+// incrementVersion builds the "version = version + 1" expression used when the query has explicit SET clauses.
+func incrementVersion(column string) func(ExprBuilder) any {
+	return func(eb ExprBuilder) any {
+		return eb.Expr("? + 1", eb.Column(column))
+	}
+}
@@ -0,0 +1,65 @@
+package orm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+type versionedTestModel struct {
+	bun.BaseModel `bun:"table:test_versioned"`
+	Model
+	VersionedModel
+}
+
+type businessVersionTestModel struct {
+	bun.BaseModel `bun:"table:test_business_version"`
+	Model
+
+	Version string `bun:"version"`
+}
+
+type intVersionTestModel struct {
+	bun.BaseModel `bun:"table:test_int_version"`
+	Model
+
+	Version int `bun:"version"`
+}
+
+func TestVersionHandlerMatches(t *testing.T) {
+	tables := schema.NewNopQueryGen().Dialect().Tables()
+	handler := &VersionHandler{}
+
+	versionField := func(t *testing.T, typ reflect.Type) (bool, bool) {
+		t.Helper()
+
+		table := tables.Get(typ)
+		field, ok := table.FieldMap[ColumnVersion]
+		require.True(t, ok, "Model should have a version column")
+
+		return handler.Matches(table, field), len(getUpdateAutoColumnPlan(table)) > 0
+	}
+
+	t.Run("VersionedModel", func(t *testing.T) {
+		matches, planned := versionField(t, reflect.TypeFor[versionedTestModel]())
+
+		assert.True(t, matches, "The version of an embedded VersionedModel should be managed")
+		assert.True(t, planned, "The version column should be part of the update plan")
+	})
+
+	t.Run("BusinessStringColumn", func(t *testing.T) {
+		matches, _ := versionField(t, reflect.TypeFor[businessVersionTestModel]())
+
+		assert.False(t, matches, "A string column named version should be left alone")
+	})
+
+	t.Run("BusinessIntegerColumn", func(t *testing.T) {
+		matches, _ := versionField(t, reflect.TypeFor[intVersionTestModel]())
+
+		assert.False(t, matches, "An integer column named version without VersionedModel should be left alone")
+	})
+}
@@ -37,6 +37,10 @@ const (
 	ColumnUpdatedBy     = "updated_by"
 	ColumnCreatedByName = "created_by_name"
 	ColumnUpdatedByName = "updated_by_name"
+	ColumnDeletedAt     = "deleted_at"
+	ColumnDeletedBy     = "deleted_by"
+	ColumnVersion       = "version"
+	ColumnTenantID      = "tenant_id"
 )
 
 // Go struct field names corresponding to audit columns.
@@ -48,4 +52,8 @@ const (
 	FieldUpdatedBy     = "UpdatedBy"
 	FieldCreatedByName = "CreatedByName"
 	FieldUpdatedByName = "UpdatedByName"
+	FieldDeletedAt     = "DeletedAt"
+	FieldDeletedBy     = "DeletedBy"
+	FieldVersion       = "Version"
+	FieldTenantID      = "TenantID"
 )
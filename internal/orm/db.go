@@ -3,6 +3,7 @@ package orm
 import (
 	"context"
 	"database/sql"
+	"sync/atomic"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/schema"
@@ -64,6 +65,19 @@ type DB interface {
 	ScanRow(ctx context.Context, rows *sql.Rows, dest ...any) error
 	// WithNamedArg returns a new DB that binds a named argument for use in raw SQL (e.g., ?name).
 	WithNamedArg(name string, value any) DB
+	// WithTenant returns a new DB scoped to the given tenant: queries on models with a tenant_id column
+	// only match that tenant's rows and inserts are stamped with it. An empty tenant removes the scope.
+	// The query's own conditions are grouped before the tenant filter is ANDed, so an OR cannot widen the scope.
+	// Raw SQL is never rewritten.
+	WithTenant(tenantID string) DB
+	// TenantID returns the tenant the DB is scoped to, or an empty string when unscoped.
+	TenantID() string
+	// Primary returns a DB that runs every query, including selects, on the primary database.
+	Primary() DB
+	// WithStickyPrimary returns a DB whose selects switch to the primary once a write, raw query or
+	// transaction has been started through it or any DB derived from it, so that a unit of work such
+	// as a request reads its own writes. It has no effect without read replicas.
+	WithStickyPrimary() DB
 	// ModelPKs extracts primary key column names and their values from a model instance.
 	ModelPKs(model any) (map[string]any, error)
 	// ModelPKFields returns the primary key field descriptors for the given model.
@@ -83,8 +97,35 @@ var (
 )
 
 // BunDB is a wrapper around the bun.DB type.
+// Outside transactions, selects may be routed to read replicas; all other queries use db.
 type BunDB struct {
-	db bun.IDB
+	db          bun.IDB
+	tenantID    string
+	replicas    *replicaSet
+	primaryOnly bool
+	// wrote is shared by all DBs derived from a sticky DB and is set once a write has been started.
+	wrote *atomic.Bool
+}
+
+// reader returns the database that serves selects.
+func (d *BunDB) reader() bun.IDB {
+	if d.replicas == nil || d.primaryOnly || (d.wrote != nil && d.wrote.Load()) {
+		return d.db
+	}
+
+	return d.replicas.pick()
+}
+
+// markWrite makes a sticky DB read from the primary from now on.
+func (d *BunDB) markWrite() {
+	if d.wrote != nil {
+		d.wrote.Store(true)
+	}
+}
+
+// inTx wraps a transaction, which always runs on the primary and is never routed.
+func (d *BunDB) inTx(tx bun.Tx) *BunDB {
+	return &BunDB{db: tx, tenantID: d.tenantID}
 }
 
 func (d *BunDB) NewSelect() SelectQuery {
@@ -92,22 +133,32 @@ func (d *BunDB) NewSelect() SelectQuery {
 }
 
 func (d *BunDB) NewInsert() InsertQuery {
+	d.markWrite()
+
 	return NewInsertQuery(d)
 }
 
 func (d *BunDB) NewUpdate() UpdateQuery {
+	d.markWrite()
+
 	return NewUpdateQuery(d)
 }
 
 func (d *BunDB) NewDelete() DeleteQuery {
+	d.markWrite()
+
 	return NewDeleteQuery(d)
 }
 
 func (d *BunDB) NewMerge() MergeQuery {
+	d.markWrite()
+
 	return NewMergeQuery(d)
 }
 
 func (d *BunDB) NewRaw(query string, args ...any) RawQuery {
+	d.markWrite()
+
 	return newRawQuery(d, query, args...)
 }
 
@@ -148,18 +199,26 @@ func (d *BunDB) RunInReadOnlyTX(ctx context.Context, fn func(context.Context, DB
 }
 
 func (d *BunDB) runInTx(ctx context.Context, opts *sql.TxOptions, fn func(context.Context, DB) error) error {
+	if !opts.ReadOnly {
+		d.markWrite()
+	}
+
 	return d.db.RunInTx(ctx, opts, func(ctx context.Context, tx bun.Tx) error {
-		return fn(ctx, &BunDB{db: tx})
+		return fn(ctx, d.inTx(tx))
 	})
 }
 
 func (d *BunDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	if opts == nil || !opts.ReadOnly {
+		d.markWrite()
+	}
+
 	tx, err := d.db.BeginTx(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return &BunTx{BunDB{db: tx}}, nil
+	return &BunTx{*d.inTx(tx)}, nil
 }
 
 func (d *BunDB) Connection(ctx context.Context) (*sql.Conn, error) {
@@ -184,7 +243,14 @@ func (d *BunDB) ScanRow(ctx context.Context, rows *sql.Rows, dest ...any) error
 
 func (d *BunDB) WithNamedArg(name string, value any) DB {
 	if db, ok := d.db.(*bun.DB); ok {
-		return &BunDB{db: db.WithNamedArg(name, value)}
+		clone := *d
+		clone.db = db.WithNamedArg(name, value)
+
+		if d.replicas != nil {
+			clone.replicas = d.replicas.withNamedArg(name, value)
+		}
+
+		return &clone
 	}
 
 	logger.Panicf("%q is not supported within a transaction context", "WithNamedArg")
@@ -192,6 +258,31 @@ func (d *BunDB) WithNamedArg(name string, value any) DB {
 	return d
 }
 
+func (d *BunDB) WithTenant(tenantID string) DB {
+	clone := *d
+	clone.tenantID = tenantID
+
+	return &clone
+}
+
+func (d *BunDB) TenantID() string {
+	return d.tenantID
+}
+
+func (d *BunDB) Primary() DB {
+	clone := *d
+	clone.primaryOnly = true
+
+	return &clone
+}
+
+func (d *BunDB) WithStickyPrimary() DB {
+	clone := *d
+	clone.wrote = new(atomic.Bool)
+
+	return &clone
+}
+
 func (d *BunDB) ModelPKs(model any) (map[string]any, error) {
 	fields := d.ModelPKFields(model)
 	values := make(map[string]any, len(fields))
@@ -6,6 +6,8 @@ import (
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/schema"
+
+	"github.com/coldsmirk/vef-framework-go/timex"
 )
 
 // NewDeleteQuery creates a new DeleteQuery instance with the provided database instance.
@@ -25,6 +27,7 @@ func NewDeleteQuery(db *BunDB) *BunDeleteQuery {
 		returningColumns: newReturningColumns(),
 	}
 	eb.qb = query
+	query.scopedWhere = newScopedWhere(db, query)
 
 	return query
 }
@@ -38,8 +41,14 @@ type BunDeleteQuery struct {
 	dialect schema.Dialect
 	eb      ExprBuilder
 	query   *bun.DeleteQuery
+	// scopedWhere collects the WHERE conditions when the query was created from a tenant-scoped DB.
+	scopedWhere *ClauseConditionBuilder
 
 	returningColumns *returningColumns
+	forceDelete      bool
+	// softDeleteAppliers replays the target, CTE, filter, ordering, limit and returning clauses onto the UPDATE
+	// that replaces this query when the model is soft deletable.
+	softDeleteAppliers []ApplyFunc[UpdateQuery]
 }
 
 func (q *BunDeleteQuery) DB() DB {
@@ -48,6 +57,7 @@ func (q *BunDeleteQuery) DB() DB {
 
 func (q *BunDeleteQuery) With(name string, builder func(SelectQuery)) DeleteQuery {
 	q.query.With(name, q.BuildSubQuery(builder))
+	q.mirror(func(uq UpdateQuery) { uq.With(name, builder) })
 
 	return q
 }
@@ -59,79 +69,95 @@ func (q *BunDeleteQuery) WithValues(name string, model any, withOrder ...bool) D
 	}
 
 	q.query.With(name, values)
+	q.mirror(func(uq UpdateQuery) { uq.WithValues(name, model, withOrder...) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) WithRecursive(name string, builder func(SelectQuery)) DeleteQuery {
 	q.query.WithRecursive(name, q.BuildSubQuery(builder))
+	q.mirror(func(uq UpdateQuery) { uq.WithRecursive(name, builder) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) Model(model any) DeleteQuery {
 	q.query.Model(model)
+	q.mirror(func(uq UpdateQuery) { uq.Model(model) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) ModelTable(name string, alias ...string) DeleteQuery {
 	applyModelTable(name, alias, q.query.ModelTableExpr)
+	q.mirror(func(uq UpdateQuery) { uq.ModelTable(name, alias...) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) Table(name string, alias ...string) DeleteQuery {
 	applyTable(name, alias, q.query.TableExpr, q.query.Table)
+	q.mirror(func(uq UpdateQuery) { uq.Table(name, alias...) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) TableFrom(model any, alias ...string) DeleteQuery {
 	applyTableFrom(q.query.TableExpr, q.db, model, alias)
+	q.mirror(func(uq UpdateQuery) { uq.TableFrom(model, alias...) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) TableExpr(builder func(ExprBuilder) any, alias ...string) DeleteQuery {
 	applyTableExpr(q.query.TableExpr, q.eb, builder, alias)
+	q.mirror(func(uq UpdateQuery) { uq.TableExpr(builder, alias...) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) TableSubQuery(builder func(SelectQuery), alias ...string) DeleteQuery {
 	applyTableSubQuery(q.query.TableExpr, q.BuildSubQuery(builder), alias)
+	q.mirror(func(uq UpdateQuery) { uq.TableSubQuery(builder, alias...) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) Where(builder func(ConditionBuilder)) DeleteQuery {
-	cb := newQueryConditionBuilder(q.query.QueryBuilder(), q)
-	builder(cb)
+	if q.scopedWhere != nil {
+		builder(q.scopedWhere)
+	} else {
+		builder(newQueryConditionBuilder(q.query.QueryBuilder(), q))
+	}
+	q.mirror(func(uq UpdateQuery) { uq.Where(builder) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) WherePK(columns ...string) DeleteQuery {
 	q.query.WherePK(columns...)
+	q.mirror(func(uq UpdateQuery) { uq.WherePK(columns...) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) WhereDeleted() DeleteQuery {
 	q.query.WhereDeleted()
+	q.mirror(func(uq UpdateQuery) { uq.WhereDeleted() })
 
 	return q
 }
 
 func (q *BunDeleteQuery) IncludeDeleted() DeleteQuery {
 	q.query.WhereAllWithDeleted()
+	q.mirror(func(uq UpdateQuery) { uq.IncludeDeleted() })
 
 	return q
 }
 
 func (q *BunDeleteQuery) OrderBy(columns ...string) DeleteQuery {
 	q.query.Order(columns...)
+	q.mirror(func(uq UpdateQuery) { uq.OrderBy(columns...) })
 
 	return q
 }
@@ -141,29 +167,43 @@ func (q *BunDeleteQuery) OrderByDesc(columns ...string) DeleteQuery {
 		q.query.OrderExpr("? DESC", q.eb.Column(column))
 	}
 
+	q.mirror(func(uq UpdateQuery) { uq.OrderByDesc(columns...) })
+
 	return q
 }
 
 func (q *BunDeleteQuery) OrderByExpr(builder func(ExprBuilder) any) DeleteQuery {
 	q.query.OrderExpr("?", builder(q.eb))
+	q.mirror(func(uq UpdateQuery) { uq.OrderByExpr(builder) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) ForceDelete() DeleteQuery {
 	q.query.ForceDelete()
+	q.forceDelete = true
 
 	return q
 }
 
+func (q *BunDeleteQuery) WithDeleted() DeleteQuery {
+	return q.IncludeDeleted()
+}
+
+func (q *BunDeleteQuery) OnlyDeleted() DeleteQuery {
+	return q.WhereDeleted()
+}
+
 func (q *BunDeleteQuery) Limit(limit int) DeleteQuery {
 	q.query.Limit(limit)
+	q.mirror(func(uq UpdateQuery) { uq.Limit(limit) })
 
 	return q
 }
 
 func (q *BunDeleteQuery) Returning(columns ...string) DeleteQuery {
 	q.returningColumns.AddAll(columns...)
+	q.mirror(func(uq UpdateQuery) { uq.Returning(columns...) })
 
 	return q
 }
@@ -171,6 +211,7 @@ func (q *BunDeleteQuery) Returning(columns ...string) DeleteQuery {
 func (q *BunDeleteQuery) ReturningAll() DeleteQuery {
 	q.returningColumns.Clear()
 	q.returningColumns.AddAll(columnAll)
+	q.mirror(func(uq UpdateQuery) { uq.ReturningAll() })
 
 	return q
 }
@@ -178,6 +219,7 @@ func (q *BunDeleteQuery) ReturningAll() DeleteQuery {
 func (q *BunDeleteQuery) ReturningNone() DeleteQuery {
 	q.returningColumns.Clear()
 	q.returningColumns.AddAll(sqlNull)
+	q.mirror(func(uq UpdateQuery) { uq.ReturningNone() })
 
 	return q
 }
@@ -200,13 +242,47 @@ func (q *BunDeleteQuery) ApplyIf(condition bool, fns ...ApplyFunc[DeleteQuery])
 	return q
 }
 
+// mirror records a clause that must also apply to the soft delete UPDATE.
+func (q *BunDeleteQuery) mirror(fn ApplyFunc[UpdateQuery]) {
+	q.softDeleteAppliers = append(q.softDeleteAppliers, fn)
+}
+
+// isSoftDelete reports whether the query targets a soft deletable model and is not forced.
+func (q *BunDeleteQuery) isSoftDelete() bool {
+	table := q.GetTable()
+
+	return table != nil && table.SoftDeleteField != nil && !q.forceDelete
+}
+
+// buildSoftDelete rewrites the DELETE as an UPDATE that only stamps the soft delete columns.
+// Unlike bun's built-in rewrite, this also records the operator in deleted_by.
+func (q *BunDeleteQuery) buildSoftDelete() *BunUpdateQuery {
+	table := q.GetTable()
+	uq := NewUpdateQuery(q.db)
+	uq.skipAutoColumns = true
+	uq.Apply(q.softDeleteAppliers...)
+	uq.Set(table.SoftDeleteField.Name, timex.Now())
+
+	if table.HasField(ColumnDeletedBy) {
+		uq.SetExpr(ColumnDeletedBy, operatorExprBuilder)
+	}
+
+	return uq
+}
+
 func (q *BunDeleteQuery) beforeDelete() {
+	q.db.applyTenantScope(q.query.QueryBuilder(), q, q.scopedWhere, q.GetTable())
+
 	if q.returningColumns.IsNotEmpty() {
 		q.query.Returning("?", buildReturningExpr(q.returningColumns.Values(), q.eb))
 	}
 }
 
 func (q *BunDeleteQuery) Exec(ctx context.Context, dest ...any) (sql.Result, error) {
+	if q.isSoftDelete() {
+		return q.buildSoftDelete().Exec(ctx, dest...)
+	}
+
 	q.beforeDelete()
 
 	res, err := q.query.Exec(ctx, dest...)
@@ -218,6 +294,10 @@ func (q *BunDeleteQuery) Exec(ctx context.Context, dest ...any) (sql.Result, err
 }
 
 func (q *BunDeleteQuery) Scan(ctx context.Context, dest ...any) error {
+	if q.isSoftDelete() {
+		return q.buildSoftDelete().Scan(ctx, dest...)
+	}
+
 	q.beforeDelete()
 
 	if err := q.query.Scan(ctx, dest...); err != nil {
@@ -16,6 +16,7 @@ var (
 	ErrMissingColumnOrExpression    = errors.New("order clause requires at least one column or expression")
 	ErrModelMustBePointerToStruct   = errors.New("model must be a pointer to struct")
 	ErrPrimaryKeyUnsupportedType    = errors.New("unsupported primary key type")
+	ErrVersionConflict              = errors.New("record has been modified by another transaction")
 )
 
 // translateWriteError converts database-specific errors to framework errors.
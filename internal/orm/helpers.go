@@ -78,6 +78,10 @@ func applyRelationSpec(spec *RelationSpec, query SelectQuery) {
 	joinCondition := func(cb ConditionBuilder) {
 		cb.EqualsColumn(dbx.ColumnWithAlias(referencedColumn, alias), foreignColumn)
 
+		if field := table.SoftDeleteField; field != nil && !spec.IncludeDeleted {
+			cb.IsNull(dbx.ColumnWithAlias(field.Name, alias))
+		}
+
 		if spec.On != nil {
 			spec.On(cb)
 		}
@@ -45,6 +45,28 @@ type FullAuditedModel struct {
 	UpdatedByName string         `json:"updatedByName" bun:",scanonly"`
 }
 
+// SoftDeletableModel contains soft delete tracking fields.
+// Embed it alongside an audited model to make DELETE mark rows as deleted instead of removing them;
+// soft-deleted rows are then excluded from queries and relation joins unless explicitly requested.
+type SoftDeletableModel struct {
+	DeletedAt *timex.DateTime `json:"deletedAt,omitempty" bun:",soft_delete,nullzero,type:timestamp"`
+	DeletedBy *string         `json:"deletedBy,omitempty" bun:",nullzero" mold:"translate=user?"`
+}
+
+// VersionedModel contains the optimistic locking version column.
+// Embed it to make every update match the loaded version and increment it; an update whose
+// version no longer matches the stored row fails with ErrVersionConflict instead of overwriting it.
+type VersionedModel struct {
+	Version int64 `json:"version" bun:",notnull,default:0"`
+}
+
+// TenantModel contains the tenant column.
+// Embed it to scope the model to the tenant of the DB it is queried through: inserts are stamped
+// with that tenant, and selects, updates and deletes only match rows of that tenant.
+type TenantModel struct {
+	TenantID string `json:"tenantId" bun:",notnull,skipupdate"`
+}
+
 // RelationSpec specifies how to join a related model using automatic column resolution.
 // It provides a declarative way to define JOIN operations between models with minimal configuration.
 // The spec automatically resolves foreign keys and primary keys based on model metadata and naming conventions.
@@ -69,9 +91,12 @@ type RelationSpec struct {
 	SelectedColumns []ColumnInfo
 	// On is an optional function to add custom conditions to the JOIN clause.
 	// The basic equality condition (foreign_key = referenced_key) is applied automatically.
-	// Use this for additional filters like soft delete checks or status conditions.
+	// Use this for additional filters like status conditions.
 	// Example: func(cb ConditionBuilder) { cb.Equals("status", "active") }
 	On ApplyFunc[ConditionBuilder]
+	// IncludeDeleted keeps soft-deleted rows of the joined model.
+	// By default, soft-deleted rows are excluded from the join for models embedding SoftDeletableModel.
+	IncludeDeleted bool
 }
 
 // ColumnInfo represents the configuration for selecting a column from a related model.
@@ -3,10 +3,13 @@ package orm
 import "go.uber.org/fx"
 
 // Module provides the Orm functionality for the VEF framework.
-// It registers the database provider and logs initialization status.
+// It wraps the primary database and, when configured, its read replicas.
 var Module = fx.Module(
 	"vef:orm",
 	fx.Provide(
-		New,
+		fx.Annotate(
+			NewReplicated,
+			fx.ParamTags(``, `name:"vef:database:replicas" optional:"true"`),
+		),
 	),
 )
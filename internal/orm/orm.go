@@ -16,3 +16,11 @@ func New(db bun.IDB) DB {
 
 	return inst.WithNamedArg(ExprOperator, OperatorSystem)
 }
+
+// NewReplicated creates a new DB that runs selects outside transactions on the given read replicas
+// and everything else on the primary. Without replicas it behaves like New.
+func NewReplicated(db bun.IDB, replicas []bun.IDB) DB {
+	inst := &BunDB{db: db, replicas: newReplicaSet(replicas)}
+
+	return inst.WithNamedArg(ExprOperator, OperatorSystem)
+}
@@ -76,6 +76,7 @@ func (b *BaseQueryBuilder) CreateSubQuery(subQuery *bun.SelectQuery) SelectQuery
 		isSubQuery:   true,
 	}
 	eb.qb = query
+	query.scopedWhere = newScopedWhere(b.db, query)
 
 	return query
 }
@@ -137,6 +137,10 @@ type Filterable[T Executor] interface {
 	WhereDeleted() T
 	// IncludeDeleted disables the automatic soft delete filter to include deleted rows.
 	IncludeDeleted() T
+	// WithDeleted is an alias for IncludeDeleted.
+	WithDeleted() T
+	// OnlyDeleted is an alias for WhereDeleted.
+	OnlyDeleted() T
 }
 
 // Orderable defines ORDER BY methods for query results.
@@ -0,0 +1,40 @@
+package orm
+
+import (
+	"sync/atomic"
+
+	"github.com/uptrace/bun"
+)
+
+// replicaSet spreads reads over read replicas in round-robin order.
+type replicaSet struct {
+	dbs  []bun.IDB
+	next atomic.Uint64
+}
+
+func newReplicaSet(dbs []bun.IDB) *replicaSet {
+	if len(dbs) == 0 {
+		return nil
+	}
+
+	return &replicaSet{dbs: dbs}
+}
+
+// pick returns the replica that serves the next read.
+func (r *replicaSet) pick() bun.IDB {
+	return r.dbs[(r.next.Add(1)-1)%uint64(len(r.dbs))]
+}
+
+// withNamedArg binds the named argument on every replica so that reads format like the primary.
+func (r *replicaSet) withNamedArg(name string, value any) *replicaSet {
+	dbs := make([]bun.IDB, len(r.dbs))
+	for i, db := range r.dbs {
+		if bunDB, ok := db.(*bun.DB); ok {
+			dbs[i] = bunDB.WithNamedArg(name, value)
+		} else {
+			dbs[i] = db
+		}
+	}
+
+	return &replicaSet{dbs: dbs}
+}
@@ -0,0 +1,52 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uptrace/bun"
+)
+
+// selectTarget returns the bun database a new select query of db runs on.
+func selectTarget(db DB) *bun.DB {
+	return db.NewSelect().(*BunSelectQuery).query.DB()
+}
+
+// TestReadReplicaRouting tests that selects are routed to replicas unless the primary is required.
+func TestReadReplicaRouting(t *testing.T) {
+	primary := newTestBunDB(t)
+	replica1 := newTestBunDB(t)
+	replica2 := newTestBunDB(t)
+
+	// Named arguments wrap the bun databases, so targets are compared by their connection pool.
+	db := NewReplicated(primary, []bun.IDB{replica1, replica2})
+
+	t.Run("RoundRobin", func(t *testing.T) {
+		first, second := selectTarget(db), selectTarget(db)
+
+		assert.NotSame(t, primary.DB, first.DB, "Select should not run on the primary")
+		assert.NotSame(t, primary.DB, second.DB, "Select should not run on the primary")
+		assert.NotSame(t, first.DB, second.DB, "Selects should alternate between replicas")
+	})
+
+	t.Run("Primary", func(t *testing.T) {
+		assert.Same(t, primary.DB, selectTarget(db.Primary()).DB, "Primary DB should read from the primary")
+	})
+
+	t.Run("StickyAfterWrite", func(t *testing.T) {
+		sticky := db.WithStickyPrimary()
+		assert.NotSame(t, primary.DB, selectTarget(sticky).DB, "Sticky DB should read from replicas before writing")
+
+		derived := sticky.WithTenant("t1")
+		derived.NewUpdate()
+
+		assert.Same(t, primary.DB, selectTarget(sticky).DB, "Sticky DB should read from the primary after a write")
+		assert.Same(t, primary.DB, selectTarget(derived).DB, "Derived DB should share the sticky state")
+		assert.NotSame(t, primary.DB, selectTarget(db).DB, "Writes should not affect the non-sticky DB")
+	})
+
+	t.Run("WithoutReplicas", func(t *testing.T) {
+		single := NewReplicated(primary, nil)
+		assert.Same(t, primary.DB, selectTarget(single).DB, "Select should run on the primary without replicas")
+	})
+}
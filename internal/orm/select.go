@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect"
@@ -17,7 +18,7 @@ import (
 // It initializes the query builders and sets up the table schema context for proper query building.
 func NewSelectQuery(db *BunDB) *BunSelectQuery {
 	eb := &QueryExprBuilder{}
-	sq := db.db.NewSelect()
+	sq := db.reader().NewSelect()
 	dialect := db.db.Dialect()
 	query := &BunSelectQuery{
 		QueryBuilder: newQueryBuilder(db, dialect, sq, eb),
@@ -28,6 +29,7 @@ func NewSelectQuery(db *BunDB) *BunSelectQuery {
 		query:   sq,
 	}
 	eb.qb = query
+	query.scopedWhere = newScopedWhere(db, query)
 
 	return query
 }
@@ -42,6 +44,8 @@ type BunSelectQuery struct {
 	eb         ExprBuilder
 	query      *bun.SelectQuery
 	isSubQuery bool
+	// scopedWhere collects the WHERE conditions when the query was created from a tenant-scoped DB.
+	scopedWhere *ClauseConditionBuilder
 
 	// State tracking for deferred select operations
 	hasSelectAll          bool
@@ -231,8 +235,9 @@ func (q *BunSelectQuery) TableSubQuery(builder func(query SelectQuery), alias ..
 }
 
 func (q *BunSelectQuery) Join(model any, builder func(ConditionBuilder), alias ...string) SelectQuery {
-	q.joinModel(JoinInner, model, alias...)
+	table, aliasToUse := q.joinModel(JoinInner, model, alias...)
 	q.query.JoinOn("?", q.BuildCondition(builder))
+	q.joinTenantOn(table, aliasToUse)
 
 	return q
 }
@@ -259,8 +264,9 @@ func (q *BunSelectQuery) JoinExpr(eBuilder func(ExprBuilder) any, cBuilder func(
 }
 
 func (q *BunSelectQuery) LeftJoin(model any, builder func(ConditionBuilder), alias ...string) SelectQuery {
-	q.joinModel(JoinLeft, model, alias...)
+	table, aliasToUse := q.joinModel(JoinLeft, model, alias...)
 	q.query.JoinOn("?", q.BuildCondition(builder))
+	q.joinTenantOn(table, aliasToUse)
 
 	return q
 }
@@ -287,8 +293,9 @@ func (q *BunSelectQuery) LeftJoinExpr(eBuilder func(ExprBuilder) any, cBuilder f
 }
 
 func (q *BunSelectQuery) RightJoin(model any, builder func(ConditionBuilder), alias ...string) SelectQuery {
-	q.joinModel(JoinRight, model, alias...)
+	table, aliasToUse := q.joinModel(JoinRight, model, alias...)
 	q.query.JoinOn("?", q.BuildCondition(builder))
+	q.joinTenantOn(table, aliasToUse)
 
 	return q
 }
@@ -315,8 +322,9 @@ func (q *BunSelectQuery) RightJoinExpr(eBuilder func(ExprBuilder) any, cBuilder
 }
 
 func (q *BunSelectQuery) FullJoin(model any, builder func(ConditionBuilder), alias ...string) SelectQuery {
-	q.joinModel(JoinFull, model, alias...)
+	table, aliasToUse := q.joinModel(JoinFull, model, alias...)
 	q.query.JoinOn("?", q.BuildCondition(builder))
+	q.joinTenantOn(table, aliasToUse)
 
 	return q
 }
@@ -343,7 +351,12 @@ func (q *BunSelectQuery) FullJoinExpr(eBuilder func(ExprBuilder) any, cBuilder f
 }
 
 func (q *BunSelectQuery) CrossJoin(model any, alias ...string) SelectQuery {
-	q.joinModel(JoinCross, model, alias...)
+	table, aliasToUse := q.joinModel(JoinCross, model, alias...)
+
+	// A cross join has no ON clause, so the tenant filter of the joined model goes into the WHERE clause.
+	if cond, ok := q.db.tenantCondition(table, aliasToUse); ok {
+		q.Where(cond)
+	}
 
 	return q
 }
@@ -366,8 +379,8 @@ func (q *BunSelectQuery) CrossJoinExpr(eBuilder func(ExprBuilder) any, alias ...
 	return q
 }
 
-// joinModel adds a JOIN clause using a model's table schema.
-func (q *BunSelectQuery) joinModel(joinType JoinType, model any, alias ...string) {
+// joinModel adds a JOIN clause using a model's table schema and returns the joined table with its alias.
+func (q *BunSelectQuery) joinModel(joinType JoinType, model any, alias ...string) (*schema.Table, string) {
 	table := q.db.TableOf(model)
 
 	aliasToUse := table.Alias
@@ -376,6 +389,16 @@ func (q *BunSelectQuery) joinModel(joinType JoinType, model any, alias ...string
 	}
 
 	q.query.Join("? ? AS ?", bun.Safe(joinType.String()), bun.Name(table.Name), bun.Name(aliasToUse))
+
+	return table, aliasToUse
+}
+
+// joinTenantOn ANDs the tenant filter of a joined model into the JOIN ON clause,
+// so that a tenant-scoped query cannot reach rows of other tenants through the join.
+func (q *BunSelectQuery) joinTenantOn(table *schema.Table, alias string) {
+	if cond, ok := q.db.tenantCondition(table, alias); ok {
+		q.query.JoinOn("?", q.BuildCondition(cond))
+	}
 }
 
 // joinTable adds a JOIN clause using a table name string.
@@ -418,10 +441,57 @@ func (q *BunSelectQuery) Relation(name string, apply ...func(query SelectQuery))
 		})
 	}
 
+	q.scopeRelationTenant(name)
+
 	return q
 }
 
+// scopeRelationTenant adds the tenant filter to every related model along a (possibly nested) relation name.
+// Bun renders it into the JOIN ON clause of has-one and belongs-to relations and into the WHERE clause of the
+// separate has-many and many-to-many queries, which refer to the related table by different aliases.
+func (q *BunSelectQuery) scopeRelationTenant(name string) {
+	table := q.GetTable()
+	if q.db.tenantID == "" || table == nil {
+		return
+	}
+
+	segments := strings.Split(name, ".")
+	fieldNames := make([]string, 0, len(segments))
+
+	for i, segment := range segments {
+		relation := table.Relations[segment]
+		if relation == nil {
+			return
+		}
+
+		table = relation.JoinTable
+		fieldNames = append(fieldNames, relation.Field.Name)
+
+		if !table.HasField(ColumnTenantID) {
+			continue
+		}
+
+		// Joined relations are aliased by their field path, e.g. parent__owner; separate queries use the table alias.
+		alias := table.Alias
+		if relation.Type == schema.HasOneRelation || relation.Type == schema.BelongsToRelation {
+			alias = strings.Join(fieldNames, "__")
+		}
+
+		q.query.RelationWithOpts(strings.Join(segments[:i+1], "."), bun.RelationOpts{
+			AdditionalJoinOnConditions: []schema.QueryWithArgs{
+				schema.SafeQuery("?.? = ?", []any{bun.Ident(alias), bun.Ident(ColumnTenantID), q.db.tenantID}),
+			},
+		})
+	}
+}
+
 func (q *BunSelectQuery) Where(builder func(ConditionBuilder)) SelectQuery {
+	if q.scopedWhere != nil {
+		builder(q.scopedWhere)
+
+		return q
+	}
+
 	cb := newQueryConditionBuilder(q.query.QueryBuilder(), q)
 	builder(cb)
 
@@ -446,6 +516,14 @@ func (q *BunSelectQuery) IncludeDeleted() SelectQuery {
 	return q
 }
 
+func (q *BunSelectQuery) WithDeleted() SelectQuery {
+	return q.IncludeDeleted()
+}
+
+func (q *BunSelectQuery) OnlyDeleted() SelectQuery {
+	return q.WhereDeleted()
+}
+
 func (q *BunSelectQuery) GroupBy(columns ...string) SelectQuery {
 	for _, column := range columns {
 		q.query.GroupExpr("?", q.eb.Column(column))
@@ -679,7 +757,7 @@ func (q *BunSelectQuery) clearSelectState() {
 	q.explicitSelects = nil
 }
 
-// applySelectState applies deferred select state before query execution.
+// applySelectState applies deferred select state and the tenant filter before query execution.
 func (q *BunSelectQuery) applySelectState() {
 	if q.selectStateApplied {
 		return
@@ -703,6 +781,8 @@ func (q *BunSelectQuery) applySelectState() {
 		exprFn()
 	}
 
+	q.db.applyTenantScope(q.query.QueryBuilder(), q, q.scopedWhere, q.GetTable())
+
 	q.selectStateApplied = true
 }
 
@@ -0,0 +1,255 @@
+package orm_test
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/internal/orm"
+)
+
+func init() {
+	registry.Add(func(base *BaseTestSuite) suite.TestingSuite {
+		return &SoftDeleteTestSuite{BaseTestSuite: base}
+	})
+}
+
+// Note represents a soft deletable record owned by a user.
+type Note struct {
+	bun.BaseModel `bun:"table:test_note,alias:n"`
+	orm.FullAuditedModel
+	orm.SoftDeletableModel
+
+	Title  string `json:"title"  bun:"title,notnull"`
+	UserID string `json:"userId" bun:"user_id,notnull"`
+}
+
+// NoteComment references a soft deletable note, used to verify relation joins.
+type NoteComment struct {
+	bun.BaseModel `bun:"table:test_note_comment,alias:nc"`
+	orm.Model
+
+	NoteID  string `json:"noteId"  bun:"note_id,notnull"`
+	Content string `json:"content" bun:"content,notnull"`
+}
+
+// SoftDeleteTestSuite tests soft delete behavior of SELECT, DELETE, UPDATE and relation joins.
+type SoftDeleteTestSuite struct {
+	*BaseTestSuite
+}
+
+func (suite *SoftDeleteTestSuite) SetupSuite() {
+	models := []any{(*Note)(nil), (*NoteComment)(nil)}
+	suite.db.RegisterModel(models...)
+	suite.Require().NoError(suite.db.ResetModel(suite.ctx, models...), "Should create soft delete test tables")
+}
+
+func (suite *SoftDeleteTestSuite) TearDownSuite() {
+	for _, model := range []any{(*Note)(nil), (*NoteComment)(nil)} {
+		_, _ = suite.db.NewDropTable().Model(model).IfExists().Exec(suite.ctx)
+	}
+}
+
+func (suite *SoftDeleteTestSuite) SetupTest() {
+	_, err := suite.db.NewDelete().Model((*Note)(nil)).ForceDelete().Where(func(cb orm.ConditionBuilder) {
+		cb.IsNotNull("id")
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should clear notes")
+
+	_, err = suite.db.NewDelete().Model((*NoteComment)(nil)).Where(func(cb orm.ConditionBuilder) {
+		cb.IsNotNull("id")
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should clear note comments")
+}
+
+// insertNotes inserts notes with the given titles and returns them.
+func (suite *SoftDeleteTestSuite) insertNotes(titles ...string) []Note {
+	notes := make([]Note, len(titles))
+	for i, title := range titles {
+		notes[i] = Note{Title: title, UserID: "user1"}
+	}
+
+	_, err := suite.db.NewInsert().Model(&notes).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should insert notes")
+
+	return notes
+}
+
+// countNotes counts notes using the given query modifier.
+func (suite *SoftDeleteTestSuite) countNotes(apply ...orm.ApplyFunc[orm.SelectQuery]) int64 {
+	count, err := suite.db.NewSelect().Model((*Note)(nil)).Apply(apply...).Count(suite.ctx)
+	suite.Require().NoError(err, "Should count notes")
+
+	return count
+}
+
+// TestDeleteMarksRows tests that DELETE stamps deleted_at/deleted_by instead of removing rows.
+func (suite *SoftDeleteTestSuite) TestDeleteMarksRows() {
+	suite.T().Logf("Testing soft delete for %s", suite.ds.Kind)
+
+	notes := suite.insertNotes("Keep", "Remove")
+
+	result, err := suite.db.NewDelete().Model(&notes[1]).WherePK().Exec(suite.ctx)
+	suite.Require().NoError(err, "Soft delete should succeed")
+
+	affected, err := result.RowsAffected()
+	suite.NoError(err, "Should get rows affected")
+	suite.Equal(int64(1), affected, "Should mark exactly one row as deleted")
+
+	suite.Equal(int64(1), suite.countNotes(), "Soft-deleted rows should be excluded by default")
+	suite.Equal(int64(2), suite.countNotes(func(query orm.SelectQuery) { query.WithDeleted() }), "WithDeleted should include soft-deleted rows")
+	suite.Equal(int64(1), suite.countNotes(func(query orm.SelectQuery) { query.OnlyDeleted() }), "OnlyDeleted should return only soft-deleted rows")
+
+	var deleted Note
+	suite.Require().NoError(
+		suite.db.NewSelect().Model(&deleted).OnlyDeleted().Where(func(cb orm.ConditionBuilder) {
+			cb.PKEquals(notes[1].ID)
+		}).Scan(suite.ctx),
+		"Should load the soft-deleted note",
+	)
+	suite.NotNil(deleted.DeletedAt, "deleted_at should be set")
+	suite.NotNil(deleted.DeletedBy, "deleted_by should record the operator")
+
+	suite.Run("DeleteIsIdempotent", func() {
+		result, err := suite.db.NewDelete().Model(&notes[1]).WherePK().Exec(suite.ctx)
+		suite.Require().NoError(err, "Repeated soft delete should succeed")
+
+		affected, err := result.RowsAffected()
+		suite.NoError(err, "Should get rows affected")
+		suite.Equal(int64(0), affected, "Already deleted rows should not be marked again")
+	})
+}
+
+// TestDeleteMany tests soft deleting a slice of models.
+func (suite *SoftDeleteTestSuite) TestDeleteMany() {
+	suite.T().Logf("Testing batch soft delete for %s", suite.ds.Kind)
+
+	notes := suite.insertNotes("Batch 1", "Batch 2", "Batch 3")
+
+	_, err := suite.db.NewDelete().Model(&notes).WherePK().Exec(suite.ctx)
+	suite.Require().NoError(err, "Batch soft delete should succeed")
+
+	suite.Equal(int64(0), suite.countNotes(), "All batch rows should be hidden")
+	suite.Equal(int64(3), suite.countNotes(func(query orm.SelectQuery) { query.OnlyDeleted() }), "All batch rows should be soft-deleted")
+}
+
+// TestDeleteOrderedLimit tests that ordering and limit carry over to the soft delete (MySQL only).
+func (suite *SoftDeleteTestSuite) TestDeleteOrderedLimit() {
+	suite.T().Logf("Testing ordered soft delete with limit for %s", suite.ds.Kind)
+
+	if suite.ds.Kind != config.MySQL {
+		suite.T().Skipf("%s doesn't support UPDATE with ORDER BY/LIMIT", suite.ds.Kind)
+
+		return
+	}
+
+	suite.insertNotes("Ordered B", "Ordered A", "Ordered C")
+
+	_, err := suite.db.NewDelete().Model((*Note)(nil)).
+		Where(func(cb orm.ConditionBuilder) {
+			cb.StartsWith("title", "Ordered")
+		}).
+		OrderByDesc("title").
+		Limit(1).
+		Exec(suite.ctx)
+	suite.Require().NoError(err, "Ordered soft delete should succeed")
+
+	var deleted []Note
+	suite.Require().NoError(suite.db.NewSelect().Model(&deleted).OnlyDeleted().Scan(suite.ctx), "Should load deleted notes")
+	suite.Require().Len(deleted, 1, "Limit should restrict the soft delete to one row")
+	suite.Equal("Ordered C", deleted[0].Title, "Ordering should pick the row to soft delete")
+}
+
+// TestForceDelete tests that ForceDelete removes rows permanently.
+func (suite *SoftDeleteTestSuite) TestForceDelete() {
+	suite.T().Logf("Testing force delete for %s", suite.ds.Kind)
+
+	notes := suite.insertNotes("Purge")
+
+	_, err := suite.db.NewDelete().Model(&notes[0]).WherePK().ForceDelete().Exec(suite.ctx)
+	suite.Require().NoError(err, "Force delete should succeed")
+
+	suite.Equal(int64(0), suite.countNotes(func(query orm.SelectQuery) { query.WithDeleted() }), "Force-deleted row should be gone")
+}
+
+// TestUpdateSkipsDeleted tests that UPDATE ignores soft-deleted rows unless they are included.
+func (suite *SoftDeleteTestSuite) TestUpdateSkipsDeleted() {
+	suite.T().Logf("Testing update of soft-deleted rows for %s", suite.ds.Kind)
+
+	notes := suite.insertNotes("Deleted")
+
+	_, err := suite.db.NewDelete().Model(&notes[0]).WherePK().Exec(suite.ctx)
+	suite.Require().NoError(err, "Soft delete should succeed")
+
+	result, err := suite.db.NewUpdate().Model((*Note)(nil)).Set("title", "Changed").Where(func(cb orm.ConditionBuilder) {
+		cb.PKEquals(notes[0].ID)
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Update should succeed")
+
+	affected, err := result.RowsAffected()
+	suite.NoError(err, "Should get rows affected")
+	suite.Equal(int64(0), affected, "Soft-deleted rows should not be updated")
+
+	result, err = suite.db.NewUpdate().Model((*Note)(nil)).Set("deleted_at", nil).Set("deleted_by", nil).OnlyDeleted().Where(func(cb orm.ConditionBuilder) {
+		cb.PKEquals(notes[0].ID)
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Restore should succeed")
+
+	affected, err = result.RowsAffected()
+	suite.NoError(err, "Should get rows affected")
+	suite.Equal(int64(1), affected, "OnlyDeleted update should restore the row")
+	suite.Equal(int64(1), suite.countNotes(), "Restored row should be visible again")
+}
+
+// TestRelationJoin tests that JoinRelations excludes soft-deleted joined rows.
+func (suite *SoftDeleteTestSuite) TestRelationJoin() {
+	suite.T().Logf("Testing relation joins with soft-deleted rows for %s", suite.ds.Kind)
+
+	notes := suite.insertNotes("Live", "Gone")
+	comments := []NoteComment{
+		{NoteID: notes[0].ID, Content: "On live note"},
+		{NoteID: notes[1].ID, Content: "On deleted note"},
+	}
+
+	_, err := suite.db.NewInsert().Model(&comments).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should insert note comments")
+
+	_, err = suite.db.NewDelete().Model(&notes[1]).WherePK().Exec(suite.ctx)
+	suite.Require().NoError(err, "Soft delete should succeed")
+
+	type commentWithNote struct {
+		ID        string  `bun:"id"`
+		NoteTitle *string `bun:"note_title"`
+	}
+
+	query := func(includeDeleted bool) []commentWithNote {
+		var rows []commentWithNote
+
+		err := suite.db.NewSelect().
+			Model((*NoteComment)(nil)).
+			Select("id").
+			JoinRelations(&orm.RelationSpec{
+				Model:           (*Note)(nil),
+				IncludeDeleted:  includeDeleted,
+				SelectedColumns: []orm.ColumnInfo{{Name: "title", AutoAlias: true}},
+			}).
+			OrderBy("content").
+			Scan(suite.ctx, &rows)
+		suite.Require().NoError(err, "Join query should succeed")
+
+		return rows
+	}
+
+	suite.Run("ExcludesDeleted", func() {
+		rows := query(false)
+		suite.Require().Len(rows, 2, "LEFT JOIN should keep all comments")
+		suite.Nil(rows[0].NoteTitle, "Comment on deleted note should not join the deleted note")
+		suite.NotNil(rows[1].NoteTitle, "Comment on live note should join its note")
+	})
+
+	suite.Run("IncludeDeleted", func() {
+		rows := query(true)
+		suite.Require().Len(rows, 2, "LEFT JOIN should keep all comments")
+		suite.NotNil(rows[0].NoteTitle, "IncludeDeleted should join the deleted note")
+	})
+}
@@ -0,0 +1,294 @@
+package orm_test
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+
+	"github.com/coldsmirk/vef-framework-go/internal/orm"
+)
+
+func init() {
+	registry.Add(func(base *BaseTestSuite) suite.TestingSuite {
+		return &TenantTestSuite{BaseTestSuite: base}
+	})
+}
+
+// Invoice represents a tenant-scoped record used to verify automatic tenant isolation.
+type Invoice struct {
+	bun.BaseModel `bun:"table:test_invoice,alias:inv"`
+	orm.FullAuditedModel
+	orm.SoftDeletableModel
+	orm.TenantModel
+
+	Number string        `json:"number" bun:"number,notnull"`
+	Lines  []InvoiceLine `json:"lines" bun:"rel:has-many,join:id=invoice_id"`
+}
+
+// InvoiceLine is a tenant-scoped record related to an Invoice, used to verify tenant filtering of joins and relations.
+type InvoiceLine struct {
+	bun.BaseModel `bun:"table:test_invoice_line,alias:invl"`
+	orm.FullAuditedModel
+	orm.TenantModel
+
+	InvoiceID string   `json:"invoiceId" bun:"invoice_id,notnull"`
+	Invoice   *Invoice `json:"invoice" bun:"rel:belongs-to,join:invoice_id=id"`
+}
+
+// Contract is a tenant-scoped and versioned record used to verify that tenant filtering keeps optimistic locking.
+type Contract struct {
+	bun.BaseModel `bun:"table:test_contract,alias:ctr"`
+	orm.FullAuditedModel
+	orm.TenantModel
+	orm.VersionedModel
+
+	Title string `json:"title" bun:"title,notnull"`
+}
+
+// TenantTestSuite tests tenant injection on INSERT and tenant filtering on SELECT, UPDATE and DELETE.
+type TenantTestSuite struct {
+	*BaseTestSuite
+}
+
+func (suite *TenantTestSuite) SetupSuite() {
+	suite.db.RegisterModel((*Invoice)(nil), (*InvoiceLine)(nil), (*Contract)(nil))
+	suite.Require().NoError(
+		suite.db.ResetModel(suite.ctx, (*Invoice)(nil), (*InvoiceLine)(nil), (*Contract)(nil)),
+		"Should create tenant test tables",
+	)
+}
+
+func (suite *TenantTestSuite) TearDownSuite() {
+	_, _ = suite.db.NewDropTable().Model((*Contract)(nil)).IfExists().Exec(suite.ctx)
+	_, _ = suite.db.NewDropTable().Model((*InvoiceLine)(nil)).IfExists().Exec(suite.ctx)
+	_, _ = suite.db.NewDropTable().Model((*Invoice)(nil)).IfExists().Exec(suite.ctx)
+}
+
+func (suite *TenantTestSuite) SetupTest() {
+	_, err := suite.db.NewDelete().Model((*Invoice)(nil)).ForceDelete().Where(func(cb orm.ConditionBuilder) {
+		cb.IsNotNull("id")
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should clear invoices")
+
+	_, err = suite.db.NewDelete().Model((*InvoiceLine)(nil)).Where(func(cb orm.ConditionBuilder) {
+		cb.IsNotNull("id")
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should clear invoice lines")
+}
+
+// insertInvoices inserts invoices with the given numbers through db and returns them.
+func (suite *TenantTestSuite) insertInvoices(db orm.DB, numbers ...string) []Invoice {
+	invoices := make([]Invoice, len(numbers))
+	for i, number := range numbers {
+		invoices[i] = Invoice{Number: number}
+	}
+
+	_, err := db.NewInsert().Model(&invoices).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should insert invoices")
+
+	return invoices
+}
+
+// countInvoices counts the invoices visible through db.
+func (suite *TenantTestSuite) countInvoices(db orm.DB) int64 {
+	count, err := db.NewSelect().Model((*Invoice)(nil)).Count(suite.ctx)
+	suite.Require().NoError(err, "Should count invoices")
+
+	return count
+}
+
+// TestInsertStampsTenant tests that inserts through a tenant-scoped DB carry the tenant.
+func (suite *TenantTestSuite) TestInsertStampsTenant() {
+	suite.T().Logf("Testing tenant injection for %s", suite.ds.Kind)
+
+	invoices := suite.insertInvoices(suite.db.WithTenant("t1"), "INV-1")
+	suite.Equal("t1", invoices[0].TenantID, "Inserted model should carry the tenant")
+
+	suite.Run("OverridesForeignTenant", func() {
+		invoice := Invoice{TenantModel: orm.TenantModel{TenantID: "t2"}, Number: "INV-2"}
+
+		_, err := suite.db.WithTenant("t1").NewInsert().Model(&invoice).Exec(suite.ctx)
+		suite.Require().NoError(err, "Should insert invoice")
+		suite.Equal("t1", invoice.TenantID, "Scoped insert should not write into another tenant")
+	})
+
+	suite.Run("UnscopedKeepsModelTenant", func() {
+		invoice := Invoice{TenantModel: orm.TenantModel{TenantID: "t3"}, Number: "INV-3"}
+
+		_, err := suite.db.NewInsert().Model(&invoice).Exec(suite.ctx)
+		suite.Require().NoError(err, "Should insert invoice")
+		suite.Equal("t3", invoice.TenantID, "Unscoped insert should keep the model tenant")
+	})
+}
+
+// TestSelectFiltersTenant tests that selects only return rows of the DB's tenant.
+func (suite *TenantTestSuite) TestSelectFiltersTenant() {
+	suite.T().Logf("Testing tenant select filtering for %s", suite.ds.Kind)
+
+	suite.insertInvoices(suite.db.WithTenant("t1"), "A-1", "A-2")
+	suite.insertInvoices(suite.db.WithTenant("t2"), "B-1")
+
+	suite.Equal(int64(2), suite.countInvoices(suite.db.WithTenant("t1")), "Tenant t1 should see its own invoices")
+	suite.Equal(int64(1), suite.countInvoices(suite.db.WithTenant("t2")), "Tenant t2 should see its own invoices")
+	suite.Equal(int64(3), suite.countInvoices(suite.db), "Unscoped DB should see all invoices")
+
+	suite.Run("TopLevelOr", func() {
+		count, err := suite.db.WithTenant("t1").NewSelect().Model((*Invoice)(nil)).Where(func(cb orm.ConditionBuilder) {
+			cb.Equals("number", "A-1").OrEquals("number", "B-1")
+		}).Count(suite.ctx)
+		suite.Require().NoError(err, "Should count invoices")
+		suite.Equal(int64(1), count, "A top-level OR should not reach invoices of another tenant")
+	})
+
+	suite.Run("InTransaction", func() {
+		err := suite.db.WithTenant("t2").RunInTX(suite.ctx, func(_ context.Context, tx orm.DB) error {
+			suite.Equal("t2", tx.TenantID(), "Transaction should inherit the tenant")
+			suite.Equal(int64(1), suite.countInvoices(tx), "Transaction should stay tenant-scoped")
+
+			return nil
+		})
+		suite.Require().NoError(err, "Transaction should succeed")
+	})
+}
+
+// TestUpdateFiltersTenant tests that updates cannot modify rows of another tenant.
+func (suite *TenantTestSuite) TestUpdateFiltersTenant() {
+	suite.T().Logf("Testing tenant update filtering for %s", suite.ds.Kind)
+
+	invoice := suite.insertInvoices(suite.db.WithTenant("t1"), "A-1")[0]
+
+	res, err := suite.db.WithTenant("t2").NewUpdate().Model((*Invoice)(nil)).
+		Set("number", "Hijacked").
+		Where(func(cb orm.ConditionBuilder) {
+			cb.PKEquals(invoice.ID)
+		}).
+		Exec(suite.ctx)
+	suite.Require().NoError(err, "Cross-tenant update should not fail")
+
+	affected, err := res.RowsAffected()
+	suite.Require().NoError(err, "Should read affected rows")
+	suite.Zero(affected, "Cross-tenant update should affect no rows")
+
+	res, err = suite.db.WithTenant("t2").NewUpdate().Model((*Invoice)(nil)).
+		Set("number", "Hijacked").
+		Where(func(cb orm.ConditionBuilder) {
+			cb.Equals("number", "unknown").OrPKEquals(invoice.ID)
+		}).
+		Exec(suite.ctx)
+	suite.Require().NoError(err, "Cross-tenant update with a top-level OR should not fail")
+
+	affected, err = res.RowsAffected()
+	suite.Require().NoError(err, "Should read affected rows")
+	suite.Zero(affected, "A top-level OR should not reach rows of another tenant")
+
+	invoice.Number = "Edited"
+	_, err = suite.db.WithTenant("t1").NewUpdate().Model(&invoice).WherePK().Exec(suite.ctx)
+	suite.Require().NoError(err, "Same-tenant update should succeed")
+
+	var stored Invoice
+	suite.Require().NoError(suite.db.NewSelect().Model(&stored).Where(func(cb orm.ConditionBuilder) {
+		cb.PKEquals(invoice.ID)
+	}).Scan(suite.ctx), "Should load the invoice")
+	suite.Equal("Edited", stored.Number, "Same-tenant update should be applied")
+	suite.Equal("t1", stored.TenantID, "Update should keep the tenant")
+}
+
+// TestUpdateKeepsVersionCheck tests that a tenant-scoped update still rejects a stale version.
+func (suite *TenantTestSuite) TestUpdateKeepsVersionCheck() {
+	suite.T().Logf("Testing tenant-scoped optimistic locking for %s", suite.ds.Kind)
+
+	db := suite.db.WithTenant("t1")
+	contract := Contract{Title: "Draft"}
+
+	_, err := db.NewInsert().Model(&contract).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should insert contract")
+
+	first, second := contract, contract
+
+	first.Title = "First writer"
+	_, err = db.NewUpdate().Model(&first).WherePK().Exec(suite.ctx)
+	suite.Require().NoError(err, "First writer should succeed")
+
+	second.Title = "Second writer"
+	_, err = db.NewUpdate().Model(&second).WherePK().Exec(suite.ctx)
+	suite.ErrorIs(err, orm.ErrVersionConflict, "Stale tenant-scoped update should get a version conflict")
+
+	var stored Contract
+	suite.Require().NoError(db.NewSelect().Model(&stored).Where(func(cb orm.ConditionBuilder) {
+		cb.PKEquals(contract.ID)
+	}).Scan(suite.ctx), "Should load the contract")
+	suite.Equal("First writer", stored.Title, "Stale update should not overwrite the row")
+	suite.Equal(int64(2), stored.Version, "Stale update should not bump the version")
+}
+
+// TestDeleteFiltersTenant tests that soft and hard deletes cannot remove rows of another tenant.
+func (suite *TenantTestSuite) TestDeleteFiltersTenant() {
+	suite.T().Logf("Testing tenant delete filtering for %s", suite.ds.Kind)
+
+	invoices := suite.insertInvoices(suite.db.WithTenant("t1"), "A-1", "A-2")
+	other := suite.db.WithTenant("t2")
+
+	_, err := other.NewDelete().Model((*Invoice)(nil)).Where(func(cb orm.ConditionBuilder) {
+		cb.PKEquals(invoices[0].ID)
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Cross-tenant soft delete should not fail")
+
+	_, err = other.NewDelete().Model((*Invoice)(nil)).ForceDelete().Where(func(cb orm.ConditionBuilder) {
+		cb.PKEquals(invoices[1].ID)
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Cross-tenant hard delete should not fail")
+
+	suite.Equal(int64(2), suite.countInvoices(suite.db.WithTenant("t1")), "Invoices of t1 should survive deletes of t2")
+
+	_, err = suite.db.WithTenant("t1").NewDelete().Model((*Invoice)(nil)).Where(func(cb orm.ConditionBuilder) {
+		cb.PKEquals(invoices[0].ID)
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Same-tenant delete should succeed")
+	suite.Equal(int64(1), suite.countInvoices(suite.db.WithTenant("t1")), "Same-tenant delete should remove the invoice")
+}
+
+// TestJoinsAndRelationsFilterTenant tests that joined and related rows of another tenant are not reachable.
+func (suite *TenantTestSuite) TestJoinsAndRelationsFilterTenant() {
+	suite.T().Logf("Testing tenant filtering of joins and relations for %s", suite.ds.Kind)
+
+	invoice := suite.insertInvoices(suite.db.WithTenant("t1"), "A-1")[0]
+
+	// A line of t2 referencing an invoice of t1, as a forged or stale foreign key would.
+	line := InvoiceLine{TenantModel: orm.TenantModel{TenantID: "t2"}, InvoiceID: invoice.ID}
+	_, err := suite.db.NewInsert().Model(&line).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should insert invoice line")
+
+	other := suite.db.WithTenant("t2")
+
+	suite.Run("Join", func() {
+		count, err := other.NewSelect().Model((*InvoiceLine)(nil)).
+			Join((*Invoice)(nil), func(cb orm.ConditionBuilder) {
+				cb.EqualsColumn("inv.id", "invl.invoice_id")
+			}).
+			Count(suite.ctx)
+		suite.Require().NoError(err, "Should count joined lines")
+		suite.Zero(count, "Join should not match invoices of another tenant")
+	})
+
+	suite.Run("BelongsTo", func() {
+		var lines []InvoiceLine
+		suite.Require().NoError(other.NewSelect().Model(&lines).Relation("Invoice").Scan(suite.ctx), "Should load lines")
+		suite.Require().Len(lines, 1, "Tenant t2 should see its own line")
+		suite.Nil(lines[0].Invoice, "Related invoice of another tenant should not be loaded")
+	})
+
+	suite.Run("HasMany", func() {
+		var invoices []Invoice
+		suite.Require().NoError(suite.db.WithTenant("t1").NewSelect().Model(&invoices).Relation("Lines").Scan(suite.ctx), "Should load invoices")
+		suite.Require().Len(invoices, 1, "Tenant t1 should see its own invoice")
+		suite.Empty(invoices[0].Lines, "Related lines of another tenant should not be loaded")
+	})
+
+	suite.Run("Unscoped", func() {
+		var lines []InvoiceLine
+		suite.Require().NoError(suite.db.NewSelect().Model(&lines).Relation("Invoice").Scan(suite.ctx), "Should load lines")
+		suite.Require().Len(lines, 1, "Unscoped DB should see the line")
+		suite.NotNil(lines[0].Invoice, "Unscoped DB should load the related invoice")
+	})
+}
@@ -3,6 +3,7 @@ package orm
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"reflect"
 	"strings"
 
@@ -31,6 +32,7 @@ func NewUpdateQuery(db *BunDB) *BunUpdateQuery {
 		returningColumns: newReturningColumns(),
 	}
 	eb.qb = query
+	query.scopedWhere = newScopedWhere(db, query)
 
 	return query
 }
@@ -44,8 +46,12 @@ type BunUpdateQuery struct {
 	dialect          schema.Dialect
 	eb               ExprBuilder
 	query            *bun.UpdateQuery
+	scopedWhere      *ClauseConditionBuilder
 	hasSet           bool
 	isBulk           bool
+	skipAutoColumns  bool
+	versionChecks    int
+	versionBumped    bool
 	selectedColumns  collections.Set[string]
 	returningColumns *returningColumns
 }
@@ -114,6 +120,12 @@ func (q *BunUpdateQuery) TableSubQuery(builder func(SelectQuery), alias ...strin
 }
 
 func (q *BunUpdateQuery) Where(builder func(ConditionBuilder)) UpdateQuery {
+	if q.scopedWhere != nil {
+		builder(q.scopedWhere)
+
+		return q
+	}
+
 	cb := newQueryConditionBuilder(q.query.QueryBuilder(), q)
 	builder(cb)
 
@@ -138,6 +150,14 @@ func (q *BunUpdateQuery) IncludeDeleted() UpdateQuery {
 	return q
 }
 
+func (q *BunUpdateQuery) WithDeleted() UpdateQuery {
+	return q.IncludeDeleted()
+}
+
+func (q *BunUpdateQuery) OnlyDeleted() UpdateQuery {
+	return q.WhereDeleted()
+}
+
 func (q *BunUpdateQuery) SelectAll() UpdateQuery {
 	return q
 }
@@ -282,7 +302,7 @@ func (q *BunUpdateQuery) ApplyIf(condition bool, fns ...ApplyFunc[UpdateQuery])
 }
 
 func (q *BunUpdateQuery) beforeUpdate() {
-	if table := q.GetTable(); table != nil {
+	if table := q.GetTable(); table != nil && !q.skipAutoColumns {
 		q.skipCreateAuditColumns(table)
 
 		modelValue := q.query.GetModel().Value()
@@ -291,11 +311,50 @@ func (q *BunUpdateQuery) beforeUpdate() {
 		processAutoColumns(q, table, modelValue, mv)
 	}
 
+	// The tenant scope is applied after the auto columns, whose handlers may add conditions such as the version check,
+	// and also applies to the UPDATE that replaces a soft delete.
+	q.db.applyTenantScope(q.query.QueryBuilder(), q, q.scopedWhere, q.GetTable())
+
 	if q.returningColumns.IsNotEmpty() {
 		q.query.Returning("?", buildReturningExpr(q.returningColumns.Values(), q.eb))
 	}
 }
 
+// isSliceModel reports whether the query updates a slice of models.
+func (q *BunUpdateQuery) isSliceModel() bool {
+	model := q.query.GetModel()
+	if model == nil {
+		return false
+	}
+
+	return reflect.Indirect(reflect.ValueOf(model.Value())).Kind() == reflect.Slice
+}
+
+// includeColumn keeps an auto-managed column in the update when only selected columns are written.
+func (q *BunUpdateQuery) includeColumn(name string) {
+	if q.selectedColumns.IsNotEmpty() && !q.selectedColumns.Contains(name) {
+		q.Select(name)
+	}
+}
+
+// checkVersionConflict reports ErrVersionConflict when fewer rows than version-checked models were updated.
+func (q *BunUpdateQuery) checkVersionConflict(res sql.Result) error {
+	if q.versionChecks == 0 {
+		return nil
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected < int64(q.versionChecks) {
+		return ErrVersionConflict
+	}
+
+	return nil
+}
+
 func (q *BunUpdateQuery) skipCreateAuditColumns(table *schema.Table) {
 	if q.hasSet || q.selectedColumns.IsNotEmpty() {
 		return
@@ -318,6 +377,10 @@ func (q *BunUpdateQuery) Exec(ctx context.Context, dest ...any) (sql.Result, err
 		return nil, translateWriteError(err)
 	}
 
+	if err := q.checkVersionConflict(res); err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
@@ -325,6 +388,10 @@ func (q *BunUpdateQuery) Scan(ctx context.Context, dest ...any) error {
 	q.beforeUpdate()
 
 	if err := q.query.Scan(ctx, dest...); err != nil {
+		if q.versionChecks > 0 && errors.Is(err, sql.ErrNoRows) {
+			return ErrVersionConflict
+		}
+
 		return translateWriteError(err)
 	}
 
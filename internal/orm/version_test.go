@@ -0,0 +1,222 @@
+package orm_test
+
+import (
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun"
+
+	"github.com/coldsmirk/vef-framework-go/internal/orm"
+)
+
+func init() {
+	registry.Add(func(base *BaseTestSuite) suite.TestingSuite {
+		return &VersionTestSuite{BaseTestSuite: base}
+	})
+}
+
+// Document represents a versioned record used to verify optimistic locking.
+type Document struct {
+	bun.BaseModel `bun:"table:test_document,alias:d"`
+	orm.FullAuditedModel
+	orm.VersionedModel
+
+	Title string `json:"title" bun:"title,notnull"`
+}
+
+// TenantDocument represents a versioned record of a tenant, used to verify optimistic locking under tenant scoping.
+type TenantDocument struct {
+	bun.BaseModel `bun:"table:test_tenant_document,alias:td"`
+	orm.FullAuditedModel
+	orm.TenantModel
+	orm.VersionedModel
+
+	Title string `json:"title" bun:"title,notnull"`
+}
+
+// VersionTestSuite tests optimistic locking through the version column on INSERT and UPDATE.
+type VersionTestSuite struct {
+	*BaseTestSuite
+}
+
+func (suite *VersionTestSuite) SetupSuite() {
+	suite.db.RegisterModel((*Document)(nil), (*TenantDocument)(nil))
+	suite.Require().NoError(suite.db.ResetModel(suite.ctx, (*Document)(nil), (*TenantDocument)(nil)), "Should create version test tables")
+}
+
+func (suite *VersionTestSuite) TearDownSuite() {
+	_, _ = suite.db.NewDropTable().Model((*Document)(nil)).IfExists().Exec(suite.ctx)
+	_, _ = suite.db.NewDropTable().Model((*TenantDocument)(nil)).IfExists().Exec(suite.ctx)
+}
+
+func (suite *VersionTestSuite) SetupTest() {
+	_, err := suite.db.NewDelete().Model((*Document)(nil)).Where(func(cb orm.ConditionBuilder) {
+		cb.IsNotNull("id")
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should clear documents")
+
+	_, err = suite.db.NewDelete().Model((*TenantDocument)(nil)).Where(func(cb orm.ConditionBuilder) {
+		cb.IsNotNull("id")
+	}).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should clear tenant documents")
+}
+
+// insertDocuments inserts documents with the given titles and returns them.
+func (suite *VersionTestSuite) insertDocuments(titles ...string) []Document {
+	docs := make([]Document, len(titles))
+	for i, title := range titles {
+		docs[i] = Document{Title: title}
+	}
+
+	_, err := suite.db.NewInsert().Model(&docs).Exec(suite.ctx)
+	suite.Require().NoError(err, "Should insert documents")
+
+	return docs
+}
+
+// loadDocument reloads a document by primary key.
+func (suite *VersionTestSuite) loadDocument(id string) Document {
+	var doc Document
+	suite.Require().NoError(
+		suite.db.NewSelect().Model(&doc).Where(func(cb orm.ConditionBuilder) {
+			cb.PKEquals(id)
+		}).Scan(suite.ctx),
+		"Should load the document",
+	)
+
+	return doc
+}
+
+// TestInsertInitializesVersion tests that new rows start at version 1.
+func (suite *VersionTestSuite) TestInsertInitializesVersion() {
+	suite.T().Logf("Testing version initialization for %s", suite.ds.Kind)
+
+	docs := suite.insertDocuments("Draft")
+
+	suite.Equal(int64(1), docs[0].Version, "Inserted model should carry the initial version")
+	suite.Equal(int64(1), suite.loadDocument(docs[0].ID).Version, "Stored row should have the initial version")
+}
+
+// TestUpdateIncrementsVersion tests that a model update matches the current version and increments it.
+func (suite *VersionTestSuite) TestUpdateIncrementsVersion() {
+	suite.T().Logf("Testing version increment for %s", suite.ds.Kind)
+
+	doc := suite.insertDocuments("Draft")[0]
+	doc.Title = "Published"
+
+	_, err := suite.db.NewUpdate().Model(&doc).WherePK().Exec(suite.ctx)
+	suite.Require().NoError(err, "Update with the current version should succeed")
+
+	suite.Equal(int64(2), doc.Version, "Updated model should carry the new version")
+
+	stored := suite.loadDocument(doc.ID)
+	suite.Equal(int64(2), stored.Version, "Stored version should be incremented")
+	suite.Equal("Published", stored.Title, "Stored title should be updated")
+}
+
+// TestStaleUpdateConflicts tests that an update based on an outdated version is rejected.
+func (suite *VersionTestSuite) TestStaleUpdateConflicts() {
+	suite.T().Logf("Testing stale update conflict for %s", suite.ds.Kind)
+
+	doc := suite.insertDocuments("Draft")[0]
+	first, second := doc, doc
+
+	first.Title = "First writer"
+	_, err := suite.db.NewUpdate().Model(&first).WherePK().Exec(suite.ctx)
+	suite.Require().NoError(err, "First writer should succeed")
+
+	second.Title = "Second writer"
+	_, err = suite.db.NewUpdate().Model(&second).WherePK().Exec(suite.ctx)
+	suite.ErrorIs(err, orm.ErrVersionConflict, "Second writer should get a version conflict")
+
+	stored := suite.loadDocument(doc.ID)
+	suite.Equal("First writer", stored.Title, "Stale update should not overwrite the row")
+	suite.Equal(int64(2), stored.Version, "Stale update should not bump the version")
+}
+
+// TestSetUpdateIncrementsVersion tests that updates with explicit SET clauses increment the version in SQL.
+func (suite *VersionTestSuite) TestSetUpdateIncrementsVersion() {
+	suite.T().Logf("Testing SET update versioning for %s", suite.ds.Kind)
+
+	doc := suite.insertDocuments("Draft")[0]
+
+	_, err := suite.db.NewUpdate().Model(&doc).Set("title", "Renamed").WherePK().Exec(suite.ctx)
+	suite.Require().NoError(err, "SET update with the current version should succeed")
+	suite.Equal(int64(2), suite.loadDocument(doc.ID).Version, "SET update should increment the stored version")
+
+	suite.Run("StaleVersion", func() {
+		_, err := suite.db.NewUpdate().Model(&doc).Set("title", "Stale").WherePK().Exec(suite.ctx)
+		suite.ErrorIs(err, orm.ErrVersionConflict, "SET update with an outdated version should conflict")
+	})
+}
+
+// TestBulkUpdate tests that bulk updates check and increment the version of every row.
+func (suite *VersionTestSuite) TestBulkUpdate() {
+	suite.T().Logf("Testing bulk update versioning for %s", suite.ds.Kind)
+
+	docs := suite.insertDocuments("First", "Second")
+	for i := range docs {
+		docs[i].Title += " updated"
+	}
+
+	_, err := suite.db.NewUpdate().Model(&docs).Bulk().Exec(suite.ctx)
+	suite.Require().NoError(err, "Bulk update with current versions should succeed")
+
+	for _, doc := range docs {
+		suite.Equal(int64(2), suite.loadDocument(doc.ID).Version, "Each stored version should be incremented")
+	}
+
+	suite.Run("StaleVersion", func() {
+		stale := []Document{docs[0], docs[1]}
+		stale[1].Version = 1
+
+		_, err := suite.db.NewUpdate().Model(&stale).Bulk().Exec(suite.ctx)
+		suite.ErrorIs(err, orm.ErrVersionConflict, "Bulk update with any outdated version should conflict")
+	})
+}
+
+// TestTenantScopedUpdate tests that updates through a tenant-scoped DB check and increment the version as well.
+func (suite *VersionTestSuite) TestTenantScopedUpdate() {
+	suite.T().Logf("Testing tenant-scoped versioning for %s", suite.ds.Kind)
+
+	db := suite.db.WithTenant("t1")
+	insert := func(titles ...string) []TenantDocument {
+		docs := make([]TenantDocument, len(titles))
+		for i, title := range titles {
+			docs[i] = TenantDocument{Title: title}
+		}
+
+		_, err := db.NewInsert().Model(&docs).Exec(suite.ctx)
+		suite.Require().NoError(err, "Should insert tenant documents")
+
+		return docs
+	}
+
+	suite.Run("Single", func() {
+		doc := insert("Draft")[0]
+		stale := doc
+
+		doc.Title = "Published"
+		_, err := db.NewUpdate().Model(&doc).WherePK().Exec(suite.ctx)
+		suite.Require().NoError(err, "Update with the current version should succeed")
+		suite.Equal(int64(2), doc.Version, "Updated model should carry the new version")
+
+		stale.Title = "Stale"
+		_, err = db.NewUpdate().Model(&stale).WherePK().Exec(suite.ctx)
+		suite.ErrorIs(err, orm.ErrVersionConflict, "Update with an outdated version should conflict")
+	})
+
+	suite.Run("Bulk", func() {
+		docs := insert("First", "Second")
+		for i := range docs {
+			docs[i].Title += " updated"
+		}
+
+		_, err := db.NewUpdate().Model(&docs).Bulk().Exec(suite.ctx)
+		suite.Require().NoError(err, "Bulk update with current versions should succeed")
+
+		stale := []TenantDocument{docs[0], docs[1]}
+		stale[1].Version = 1
+
+		_, err = db.NewUpdate().Model(&stale).Bulk().Exec(suite.ctx)
+		suite.ErrorIs(err, orm.ErrVersionConflict, "Bulk update with any outdated version should conflict")
+	})
+}
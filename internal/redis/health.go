@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/coldsmirk/vef-framework-go/monitor"
+)
+
+// healthIndicator pings the Redis server. The client is only created if a component of the application
+// depends on it, so the check is skipped until then rather than connecting to a server the application does not use.
+type healthIndicator struct {
+	client atomic.Pointer[redis.Client]
+}
+
+func newHealthIndicator() *healthIndicator {
+	return new(healthIndicator)
+}
+
+func (*healthIndicator) Name() string {
+	return "redis"
+}
+
+func (h *healthIndicator) Check(ctx context.Context) error {
+	client := h.client.Load()
+	if client == nil {
+		return monitor.ErrHealthCheckSkipped
+	}
+
+	return client.Ping(ctx).Err()
+}
@@ -2,30 +2,38 @@ package redis
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/fx"
+
+	"github.com/coldsmirk/vef-framework-go/monitor"
 )
 
 // Module provides Redis client functionality with automatic lifecycle management.
 var Module = fx.Module(
 	"vef:redis",
+	fx.Provide(
+		fx.Private,
+		newHealthIndicator,
+	),
 	fx.Provide(
 		fx.Annotate(
-			NewClient,
-			fx.OnStart(func(ctx context.Context, client *redis.Client) error {
-				if err := client.Ping(ctx).Err(); err != nil {
-					return fmt.Errorf("failed to connect to redis: %w", err)
-				}
-
-				return logRedisServerInfo(ctx, client)
+			func(indicator *healthIndicator) monitor.HealthIndicator {
+				return indicator
+			},
+			fx.ResultTags(`group:"vef:health:indicators"`),
+		),
+		fx.Annotate(
+			newClientProvider,
+			fx.OnStart(func(ctx context.Context, provider *ClientProvider) error {
+				return provider.start(ctx)
 			}),
-			fx.OnStop(func(client *redis.Client) error {
-				logger.Info("Closing Redis client...")
-
-				return client.Close()
+			fx.OnStop(func(provider *ClientProvider) error {
+				return provider.stop()
 			}),
 		),
+		func(provider *ClientProvider) *redis.Client {
+			return provider.Client()
+		},
 	),
 )
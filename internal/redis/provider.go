@@ -0,0 +1,79 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+)
+
+// ClientProvider creates the shared Redis client the first time it is needed.
+// Components that can work without Redis ask it for the configured client instead of depending on *redis.Client,
+// so that an application without Redis does not connect to a server on startup.
+type ClientProvider struct {
+	cfg       *config.RedisConfig
+	appCfg    *config.AppConfig
+	indicator *healthIndicator
+
+	once   sync.Once
+	client *redis.Client
+}
+
+func newClientProvider(cfg *config.RedisConfig, appCfg *config.AppConfig, indicator *healthIndicator) *ClientProvider {
+	return &ClientProvider{
+		cfg:       cfg,
+		appCfg:    appCfg,
+		indicator: indicator,
+	}
+}
+
+// Client returns the shared client, creating it on first use.
+func (p *ClientProvider) Client() *redis.Client {
+	p.once.Do(func() {
+		p.client = NewClient(p.cfg, p.appCfg)
+		p.indicator.client.Store(p.client)
+	})
+
+	return p.client
+}
+
+// ConfiguredClient returns the shared client when Redis is configured, i.e. vef.redis.host is set, or nil otherwise.
+func (p *ClientProvider) ConfiguredClient() *redis.Client {
+	if p.cfg.Host == "" {
+		return nil
+	}
+
+	return p.Client()
+}
+
+// created returns the client if it has been created.
+func (p *ClientProvider) created() *redis.Client {
+	return p.indicator.client.Load()
+}
+
+func (p *ClientProvider) start(ctx context.Context) error {
+	client := p.created()
+	if client == nil {
+		return nil
+	}
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return logRedisServerInfo(ctx, client)
+}
+
+func (p *ClientProvider) stop() error {
+	client := p.created()
+	if client == nil {
+		return nil
+	}
+
+	logger.Info("Closing Redis client...")
+
+	return client.Close()
+}
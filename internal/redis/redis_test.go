@@ -224,6 +224,27 @@ func TestBuildRedisAddr(t *testing.T) {
 	}
 }
 
+// TestClientProvider tests that the client is only created when needed and shared once created.
+func TestClientProvider(t *testing.T) {
+	t.Run("NotConfigured", func(t *testing.T) {
+		provider := newClientProvider(&config.RedisConfig{}, &config.AppConfig{}, newHealthIndicator())
+
+		assert.Nil(t, provider.ConfiguredClient(), "Should not create a client when Redis is not configured")
+		assert.Nil(t, provider.created(), "Should not create a client until it is needed")
+		assert.NoError(t, provider.start(context.Background()), "Should not connect without a client")
+		assert.NoError(t, provider.stop(), "Should not close without a client")
+	})
+
+	t.Run("Configured", func(t *testing.T) {
+		provider := newClientProvider(&config.RedisConfig{Host: "redis.example.com"}, &config.AppConfig{}, newHealthIndicator())
+
+		client := provider.ConfiguredClient()
+		assert.NotNil(t, client, "Should create a client when Redis is configured")
+		assert.Same(t, client, provider.Client(), "Should share the created client")
+		assert.NoError(t, provider.stop(), "Should close the created client")
+	})
+}
+
 // TestGetPoolSize tests get pool size functionality.
 func TestGetPoolSize(t *testing.T) {
 	poolSize := getPoolSize()
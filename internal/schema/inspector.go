@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 
+	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/mysql"
 	"ariga.io/atlas/sql/postgres"
 	"ariga.io/atlas/sql/sqlite"
@@ -37,26 +38,44 @@ type AtlasInspector struct {
 
 // NewInspector creates a new Atlas Inspector for the given database connection.
 func NewInspector(db *sql.DB, kind config.DBKind, schemaName string) (Inspector, error) {
-	var (
-		inspector as.Inspector
-		schema    string
-		err       error
-	)
+	inspector, err := OpenDriver(db, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema string
 
 	switch kind {
 	case config.Postgres:
-		inspector, err = postgres.Open(db)
 		schema = lo.CoalesceOrEmpty(schemaName, "public")
-
 	case config.MySQL:
-		inspector, err = mysql.Open(db)
 		// For MySQL, schema is the database name, which is already set in the connection
 		schema = ""
-
 	case config.SQLite:
-		inspector, err = sqlite.Open(db)
 		schema = "main"
+	}
+
+	return &AtlasInspector{
+		inspector: inspector,
+		schema:    schema,
+	}, nil
+}
+
+// OpenDriver opens the Atlas driver for the given database connection.
+// Besides inspection, the driver diffs schemas and plans the statements of schema changes.
+func OpenDriver(db *sql.DB, kind config.DBKind) (migrate.Driver, error) {
+	var (
+		driver migrate.Driver
+		err    error
+	)
 
+	switch kind {
+	case config.Postgres:
+		driver, err = postgres.Open(db)
+	case config.MySQL:
+		driver, err = mysql.Open(db)
+	case config.SQLite:
+		driver, err = sqlite.Open(db)
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDBKind, kind)
 	}
@@ -65,10 +84,21 @@ func NewInspector(db *sql.DB, kind config.DBKind, schemaName string) (Inspector,
 		return nil, fmt.Errorf("failed to open %s inspector: %w", kind, err)
 	}
 
-	return &AtlasInspector{
-		inspector: inspector,
-		schema:    schema,
-	}, nil
+	return driver, nil
+}
+
+// ParseType parses a raw column type in the dialect of the given database kind.
+func ParseType(kind config.DBKind, raw string) (as.Type, error) {
+	switch kind {
+	case config.Postgres:
+		return postgres.ParseType(raw)
+	case config.MySQL:
+		return mysql.ParseType(raw)
+	case config.SQLite:
+		return sqlite.ParseType(raw)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDBKind, kind)
+	}
 }
 
 func (i *AtlasInspector) InspectSchema(ctx context.Context) (*as.Schema, error) {
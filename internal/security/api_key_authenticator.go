@@ -0,0 +1,132 @@
+package security
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coldsmirk/vef-framework-go/contextx"
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// AuthTypeAPIKey is the authentication type for API key authentication.
+const AuthTypeAPIKey = "api_key"
+
+// APIKeyAuthenticator authenticates requests made with an API key as the user or external app owning the key.
+// The principal is the plaintext key; the owner is loaded on every request,
+// so disabling the owner or revoking its roles takes effect on its keys immediately.
+type APIKeyAuthenticator struct {
+	apiKeys    *security.APIKeys
+	userLoader security.UserLoader
+	appLoader  security.ExternalAppLoader
+}
+
+// NewAPIKeyAuthenticator creates a new API key authenticator.
+func NewAPIKeyAuthenticator(
+	apiKeys *security.APIKeys,
+	userLoader security.UserLoader,
+	appLoader security.ExternalAppLoader,
+) security.Authenticator {
+	return &APIKeyAuthenticator{
+		apiKeys:    apiKeys,
+		userLoader: userLoader,
+		appLoader:  appLoader,
+	}
+}
+
+func (*APIKeyAuthenticator) Supports(authType string) bool {
+	return authType == AuthTypeAPIKey
+}
+
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, authentication security.Authentication) (*security.Principal, error) {
+	if authentication.Principal == "" {
+		return nil, result.ErrAPIKeyRequired
+	}
+
+	key, err := a.apiKeys.Verify(ctx, authentication.Principal, contextx.RequestIP(ctx))
+	if err != nil {
+		return nil, mapAPIKeyError(err)
+	}
+
+	principal, err := a.loadOwner(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty scope list must keep granting nothing rather than lift the limit.
+	principal.Scopes = append([]string{}, key.Scopes...)
+
+	logger.Infof("API key authentication successful for %s %q with key %q", principal.Type, principal.ID, key.ID)
+
+	return principal, nil
+}
+
+// loadOwner loads the user or external app the key acts as.
+func (a *APIKeyAuthenticator) loadOwner(ctx context.Context, key *security.APIKey) (*security.Principal, error) {
+	switch key.OwnerType {
+	case security.PrincipalTypeUser:
+		if a.userLoader == nil {
+			return nil, result.ErrNotImplemented(i18n.T(result.ErrMessageUserLoaderNotImplemented))
+		}
+
+		principal, err := a.userLoader.LoadByID(ctx, key.OwnerID)
+		if err != nil {
+			if result.IsRecordNotFound(err) {
+				logger.Infof("User %q of API key %q no longer exists", key.OwnerID, key.ID)
+
+				return nil, result.ErrAPIKeyInvalid
+			}
+
+			return nil, err
+		}
+
+		if principal == nil {
+			return nil, result.ErrAPIKeyInvalid
+		}
+
+		return principal, nil
+
+	case security.PrincipalTypeExternalApp:
+		if a.appLoader == nil {
+			return nil, result.ErrNotImplemented(i18n.T(result.ErrMessageExternalAppLoaderNotImplemented))
+		}
+
+		principal, _, err := a.appLoader.LoadByID(ctx, key.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if principal == nil {
+			return nil, result.ErrExternalAppNotFound
+		}
+
+		if err := validateExternalApp(ctx, principal); err != nil {
+			return nil, err
+		}
+
+		return principal, nil
+
+	default:
+		logger.Warnf("API key %q has unsupported owner type %q", key.ID, key.OwnerType)
+
+		return nil, result.ErrAPIKeyInvalid
+	}
+}
+
+// mapAPIKeyError converts security.APIKeys verification errors to result errors.
+// Unknown keys and wrong secrets are reported alike so key IDs cannot be probed.
+func mapAPIKeyError(err error) error {
+	switch {
+	case errors.Is(err, security.ErrAPIKeyExpired):
+		return result.ErrAPIKeyExpired
+	case errors.Is(err, security.ErrAPIKeyIPNotAllowed):
+		return result.ErrIPNotAllowed
+	case errors.Is(err, security.ErrAPIKeyMalformed),
+		errors.Is(err, security.ErrAPIKeyNotFound),
+		errors.Is(err, security.ErrAPIKeySecretInvalid):
+		return result.ErrAPIKeyInvalid
+	default:
+		return err
+	}
+}
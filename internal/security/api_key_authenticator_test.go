@@ -0,0 +1,173 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/contextx"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+type APIKeyAuthenticatorTestSuite struct {
+	suite.Suite
+
+	ctx        context.Context
+	apiKeys    *security.APIKeys
+	userLoader *MockUserLoader
+	appLoader  *MockExternalAppLoader
+	auth       security.Authenticator
+}
+
+func (s *APIKeyAuthenticatorTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.apiKeys = security.NewAPIKeys(security.NewMemoryAPIKeyStore(), nil)
+	s.userLoader = new(MockUserLoader)
+	s.appLoader = new(MockExternalAppLoader)
+	s.auth = NewAPIKeyAuthenticator(s.apiKeys, s.userLoader, s.appLoader)
+}
+
+func (s *APIKeyAuthenticatorTestSuite) create(ownerType security.PrincipalType, ownerID string, spec security.APIKeySpec) string {
+	s.T().Helper()
+
+	key, err := s.apiKeys.Create(s.ctx, ownerType, ownerID, spec)
+	s.Require().NoError(err, "Should create API key")
+
+	return key.Key
+}
+
+func (s *APIKeyAuthenticatorTestSuite) authenticate(ctx context.Context, key string) (*security.Principal, error) {
+	return s.auth.Authenticate(ctx, security.Authentication{Type: AuthTypeAPIKey, Principal: key})
+}
+
+// TestSupports verifies type matching.
+func (s *APIKeyAuthenticatorTestSuite) TestSupports() {
+	s.True(s.auth.Supports(AuthTypeAPIKey), "Should support api key type")
+	s.False(s.auth.Supports(AuthTypeSignature), "Should not support signature type")
+}
+
+// TestUserKey verifies that user keys authenticate as the owner limited to the key scopes.
+func (s *APIKeyAuthenticatorTestSuite) TestUserKey() {
+	key := s.create(security.PrincipalTypeUser, "user1", security.APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}})
+	s.userLoader.On("LoadByID", mock.Anything, "user1").Return(security.NewUser("user1", "Alice", "admin"), nil)
+
+	principal, err := s.authenticate(s.ctx, key)
+	s.Require().NoError(err, "Should authenticate with the key")
+	s.Equal("user1", principal.ID, "Should authenticate as the owner")
+	s.Equal([]string{"admin"}, principal.Roles, "Should keep the owner's roles")
+	s.Equal([]string{"sys:user:query"}, principal.Scopes, "Should limit the principal to the key scopes")
+	s.True(principal.InScope("sys:user:query"), "Should allow a scoped permission")
+	s.False(principal.InScope("sys:user:delete"), "Should refuse a permission outside the scopes")
+}
+
+// TestEmptyScopes verifies that keys without scopes grant nothing rather than everything.
+func (s *APIKeyAuthenticatorTestSuite) TestEmptyScopes() {
+	key := s.create(security.PrincipalTypeUser, "user1", security.APIKeySpec{Name: "ci"})
+	s.userLoader.On("LoadByID", mock.Anything, "user1").Return(security.NewUser("user1", "Alice", "admin"), nil)
+
+	principal, err := s.authenticate(s.ctx, key)
+	s.Require().NoError(err, "Should authenticate with the key")
+	s.NotNil(principal.Scopes, "Should set an empty scope list")
+	s.False(principal.InScope("sys:user:query"), "Should grant nothing")
+}
+
+// TestDeletedUser verifies that keys of deleted users are rejected.
+func (s *APIKeyAuthenticatorTestSuite) TestDeletedUser() {
+	key := s.create(security.PrincipalTypeUser, "user1", security.APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}})
+	s.userLoader.On("LoadByID", mock.Anything, "user1").Return(nil, result.ErrRecordNotFound)
+
+	_, err := s.authenticate(s.ctx, key)
+	s.ErrorIs(err, result.ErrAPIKeyInvalid, "Should reject the key of a deleted user")
+}
+
+// TestExternalAppKey verifies external app keys, including the checks of the app itself.
+func (s *APIKeyAuthenticatorTestSuite) TestExternalAppKey() {
+	s.Run("Enabled", func() {
+		key := s.create(security.PrincipalTypeExternalApp, "app1", security.APIKeySpec{Name: "sync", Scopes: []string{"sys:user:query"}})
+		app := security.NewExternalApp("app1", "Sync", "integration")
+		app.Details = &security.ExternalAppConfig{Enabled: true}
+		s.appLoader.On("LoadByID", mock.Anything, "app1").Return(app, "secret", nil).Once()
+
+		principal, err := s.authenticate(s.ctx, key)
+		s.Require().NoError(err, "Should authenticate with the app key")
+		s.Equal(security.PrincipalTypeExternalApp, principal.Type, "Should authenticate as the external app")
+		s.Equal([]string{"sys:user:query"}, principal.Scopes, "Should limit the app to the key scopes")
+	})
+
+	s.Run("Disabled", func() {
+		key := s.create(security.PrincipalTypeExternalApp, "app2", security.APIKeySpec{Name: "sync", Scopes: []string{"sys:user:query"}})
+		app := security.NewExternalApp("app2", "Sync")
+		app.Details = &security.ExternalAppConfig{Enabled: false}
+		s.appLoader.On("LoadByID", mock.Anything, "app2").Return(app, "secret", nil).Once()
+
+		_, err := s.authenticate(s.ctx, key)
+		s.ErrorIs(err, result.ErrExternalAppDisabled, "Should reject keys of disabled apps")
+	})
+
+	s.Run("AppIPWhitelist", func() {
+		key := s.create(security.PrincipalTypeExternalApp, "app3", security.APIKeySpec{Name: "sync", Scopes: []string{"sys:user:query"}})
+		app := security.NewExternalApp("app3", "Sync")
+		app.Details = &security.ExternalAppConfig{Enabled: true, IPWhitelist: "10.0.0.1"}
+		s.appLoader.On("LoadByID", mock.Anything, "app3").Return(app, "secret", nil).Once()
+
+		_, err := s.authenticate(contextx.SetRequestIP(s.ctx, "10.0.0.2"), key)
+		s.ErrorIs(err, result.ErrIPNotAllowed, "Should apply the IP whitelist of the app")
+	})
+
+	s.Run("WithoutLoader", func() {
+		key := s.create(security.PrincipalTypeExternalApp, "app4", security.APIKeySpec{Name: "sync", Scopes: []string{"sys:user:query"}})
+		auth := NewAPIKeyAuthenticator(s.apiKeys, s.userLoader, nil)
+
+		_, err := auth.Authenticate(s.ctx, security.Authentication{Type: AuthTypeAPIKey, Principal: key})
+		s.Error(err, "Should fail without an external app loader")
+	})
+}
+
+// TestRejectedKeys verifies the mapping of verification failures to result errors.
+func (s *APIKeyAuthenticatorTestSuite) TestRejectedKeys() {
+	s.Run("Missing", func() {
+		_, err := s.authenticate(s.ctx, "")
+		s.ErrorIs(err, result.ErrAPIKeyRequired, "Should require a key")
+	})
+
+	s.Run("Malformed", func() {
+		_, err := s.authenticate(s.ctx, "not-a-key")
+		s.ErrorIs(err, result.ErrAPIKeyInvalid, "Should reject a malformed key")
+	})
+
+	s.Run("Unknown", func() {
+		_, err := s.authenticate(s.ctx, "vef_unknown_secret")
+		s.ErrorIs(err, result.ErrAPIKeyInvalid, "Should reject an unknown key")
+	})
+
+	s.Run("Expired", func() {
+		expiresAt := timex.Of(time.Now().Add(-time.Second))
+		key := s.create(security.PrincipalTypeUser, "user1", security.APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}, ExpiresAt: &expiresAt})
+
+		_, err := s.authenticate(s.ctx, key)
+		s.ErrorIs(err, result.ErrAPIKeyExpired, "Should reject an expired key")
+	})
+
+	s.Run("IPNotAllowed", func() {
+		key := s.create(security.PrincipalTypeUser, "user1", security.APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}, IPWhitelist: "10.0.0.0/8"})
+
+		_, err := s.authenticate(contextx.SetRequestIP(s.ctx, "192.168.0.1"), key)
+		s.ErrorIs(err, result.ErrIPNotAllowed, "Should reject a key used outside its IP whitelist")
+	})
+
+	s.Run("IPUnknown", func() {
+		key := s.create(security.PrincipalTypeUser, "user1", security.APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}, IPWhitelist: "10.0.0.0/8"})
+
+		_, err := s.authenticate(s.ctx, key)
+		s.ErrorIs(err, result.ErrIPNotAllowed, "Should reject a whitelisted key when the client IP is unknown")
+	})
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	suite.Run(t, new(APIKeyAuthenticatorTestSuite))
+}
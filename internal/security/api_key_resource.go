@@ -0,0 +1,216 @@
+package security
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// APIKeyResource exposes the endpoints users manage their own API keys with,
+// and admin endpoints to manage the API keys of external apps.
+type APIKeyResource struct {
+	api.Resource
+
+	apiKeys   *security.APIKeys
+	checker   security.PermissionChecker
+	appLoader security.ExternalAppLoader
+}
+
+// NewAPIKeyResource creates a new API key management resource.
+func NewAPIKeyResource(
+	apiKeys *security.APIKeys,
+	checker security.PermissionChecker,
+	appLoader security.ExternalAppLoader,
+) api.Resource {
+	return &APIKeyResource{
+		apiKeys:   apiKeys,
+		checker:   checker,
+		appLoader: appLoader,
+		Resource: api.NewRPCResource(
+			"security/api_key",
+			api.WithOperations(
+				api.OperationSpec{Action: "create"},
+				api.OperationSpec{Action: "list"},
+				api.OperationSpec{Action: "revoke"},
+				api.OperationSpec{Action: "create_app_key", PermToken: "security:api_key:manage"},
+				api.OperationSpec{Action: "list_app_keys", PermToken: "security:api_key:query"},
+				api.OperationSpec{Action: "revoke_app_key", PermToken: "security:api_key:manage"},
+			),
+		),
+	}
+}
+
+// CreateAPIKeyParams represents the request parameters for creating an API key.
+type CreateAPIKeyParams struct {
+	api.P
+
+	Name        string          `json:"name" validate:"required,max=64" label_i18n:"auth_api_key_name"`
+	Scopes      []string        `json:"scopes" validate:"required,min=1,dive,required" label_i18n:"auth_api_key_scopes"`
+	IPWhitelist string          `json:"ipWhitelist" label_i18n:"auth_ip"`
+	ExpiresAt   *timex.DateTime `json:"expiresAt"`
+}
+
+func (p CreateAPIKeyParams) spec() security.APIKeySpec {
+	return security.APIKeySpec{
+		Name:        p.Name,
+		Scopes:      p.Scopes,
+		IPWhitelist: p.IPWhitelist,
+		ExpiresAt:   p.ExpiresAt,
+	}
+}
+
+// Create creates an API key for the current user, limited to scopes the user holds.
+// The plaintext key is only returned here.
+func (r *APIKeyResource) Create(ctx fiber.Ctx, principal *security.Principal, params CreateAPIKeyParams) error {
+	if err := checkKeyManager(principal); err != nil {
+		return err
+	}
+
+	return r.create(ctx, principal, params)
+}
+
+// List lists the API keys of the current user.
+func (r *APIKeyResource) List(ctx fiber.Ctx, principal *security.Principal) error {
+	if err := checkKeyManager(principal); err != nil {
+		return err
+	}
+
+	keys, err := r.apiKeys.List(ctx.Context(), principal.Type, principal.ID)
+	if err != nil {
+		return err
+	}
+
+	return result.Ok(keys).Response(ctx)
+}
+
+// RevokeAPIKeyParams represents the request parameters for revoking an API key of the current user.
+type RevokeAPIKeyParams struct {
+	api.P
+
+	ID string `json:"id" validate:"required" label_i18n:"auth_api_key_id"`
+}
+
+// Revoke revokes an API key of the current user.
+func (r *APIKeyResource) Revoke(ctx fiber.Ctx, principal *security.Principal, params RevokeAPIKeyParams) error {
+	if err := checkKeyManager(principal); err != nil {
+		return err
+	}
+
+	if err := r.apiKeys.Revoke(ctx.Context(), principal.Type, principal.ID, params.ID); err != nil {
+		return err
+	}
+
+	return result.Ok().Response(ctx)
+}
+
+// CreateAppKeyParams represents the request parameters for creating an API key of an external app.
+type CreateAppKeyParams struct {
+	CreateAPIKeyParams
+
+	AppID string `json:"appId" validate:"required" label_i18n:"auth_app_id"`
+}
+
+// CreateAppKey creates an API key for an external app, limited to scopes the app holds.
+// The plaintext key is only returned here.
+func (r *APIKeyResource) CreateAppKey(ctx fiber.Ctx, params CreateAppKeyParams) error {
+	app, err := r.loadApp(ctx.Context(), params.AppID)
+	if err != nil {
+		return err
+	}
+
+	return r.create(ctx, app, params.CreateAPIKeyParams)
+}
+
+// AppKeysParams identifies the external app whose API keys to list.
+type AppKeysParams struct {
+	api.P
+
+	AppID string `json:"appId" validate:"required" label_i18n:"auth_app_id"`
+}
+
+// ListAppKeys lists the API keys of an external app.
+func (r *APIKeyResource) ListAppKeys(ctx fiber.Ctx, params AppKeysParams) error {
+	keys, err := r.apiKeys.List(ctx.Context(), security.PrincipalTypeExternalApp, params.AppID)
+	if err != nil {
+		return err
+	}
+
+	return result.Ok(keys).Response(ctx)
+}
+
+// RevokeAppKeyParams represents the request parameters for revoking an API key of an external app.
+type RevokeAppKeyParams struct {
+	api.P
+
+	AppID string `json:"appId" validate:"required" label_i18n:"auth_app_id"`
+	ID    string `json:"id" validate:"required" label_i18n:"auth_api_key_id"`
+}
+
+// RevokeAppKey revokes an API key of an external app.
+func (r *APIKeyResource) RevokeAppKey(ctx fiber.Ctx, params RevokeAppKeyParams) error {
+	if err := r.apiKeys.Revoke(ctx.Context(), security.PrincipalTypeExternalApp, params.AppID, params.ID); err != nil {
+		return err
+	}
+
+	return result.Ok().Response(ctx)
+}
+
+// create checks that the owner holds every scope and creates the key.
+func (r *APIKeyResource) create(ctx fiber.Ctx, owner *security.Principal, params CreateAPIKeyParams) error {
+	if !r.apiKeys.Enabled() {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageAPIKeyStoreNotImplemented))
+	}
+
+	for _, scope := range params.Scopes {
+		granted, err := r.checker.HasPermission(ctx.Context(), owner, scope)
+		if err != nil {
+			return err
+		}
+
+		if !granted {
+			logger.Infof("Refused API key for %s %q: scope %q is not granted", owner.Type, owner.ID, scope)
+
+			return result.ErrAPIKeyScopeNotGranted
+		}
+	}
+
+	key, err := r.apiKeys.Create(ctx.Context(), owner.Type, owner.ID, params.spec())
+	if err != nil {
+		return err
+	}
+
+	return result.Ok(key).Response(ctx)
+}
+
+func (r *APIKeyResource) loadApp(ctx context.Context, appID string) (*security.Principal, error) {
+	if r.appLoader == nil {
+		return nil, result.ErrNotImplemented(i18n.T(result.ErrMessageExternalAppLoaderNotImplemented))
+	}
+
+	app, _, err := r.appLoader.LoadByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	if app == nil {
+		return nil, result.ErrExternalAppNotFound
+	}
+
+	return app, nil
+}
+
+// checkKeyManager keeps API keys from managing keys, sessions and credentials themselves,
+// so a leaked key can neither mint longer-lived keys or passkeys nor lock its owner out.
+func checkKeyManager(principal *security.Principal) error {
+	if principal.Scopes != nil {
+		return result.ErrAccessDenied
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"context"
 	"slices"
+	"time"
 
 	"github.com/coldsmirk/go-streams"
 	"github.com/gofiber/fiber/v3"
@@ -13,10 +14,13 @@ import (
 	"github.com/coldsmirk/vef-framework-go/config"
 	"github.com/coldsmirk/vef-framework-go/contextx"
 	"github.com/coldsmirk/vef-framework-go/event"
+	"github.com/coldsmirk/vef-framework-go/hashx"
 	"github.com/coldsmirk/vef-framework-go/httpx"
 	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/id"
 	"github.com/coldsmirk/vef-framework-go/result"
 	"github.com/coldsmirk/vef-framework-go/security"
+	"github.com/coldsmirk/vef-framework-go/timex"
 )
 
 // AuthResourceParams holds the dependencies for AuthResource construction.
@@ -28,6 +32,10 @@ type AuthResourceParams struct {
 	ChallengeTokenStore security.ChallengeTokenStore
 	UserInfoLoader      security.UserInfoLoader      `optional:"true"`
 	ChallengeProviders  []security.ChallengeProvider `group:"vef:security:challenge_providers"`
+	SessionStore        security.SessionStore        `optional:"true"`
+	OIDCAuthenticator   *OIDCAuthenticator
+	WebAuthn            *security.WebAuthn
+	LoginGuard          *LoginGuard
 	Publisher           event.Publisher
 	SecurityConfig      *config.SecurityConfig
 }
@@ -44,7 +52,13 @@ func NewAuthResource(params AuthResourceParams) api.Resource {
 		challengeTokenStore: params.ChallengeTokenStore,
 		userInfoLoader:      params.UserInfoLoader,
 		challengeProviders:  params.ChallengeProviders,
+		captchaProvider:     findCaptchaProvider(params.ChallengeProviders),
+		sessionStore:        params.SessionStore,
+		oidcAuthenticator:   params.OIDCAuthenticator,
+		webAuthn:            params.WebAuthn,
+		loginGuard:          params.LoginGuard,
 		publisher:           params.Publisher,
+		tokenExpires:        params.SecurityConfig.TokenExpires,
 
 		Resource: api.NewRPCResource(
 			"security/auth",
@@ -62,19 +76,64 @@ func NewAuthResource(params AuthResourceParams) api.Resource {
 				api.OperationSpec{
 					Action: "logout",
 				},
+				api.OperationSpec{
+					Action: "logout_all",
+				},
+				api.OperationSpec{
+					Action: "list_sessions",
+				},
+				api.OperationSpec{
+					Action: "revoke_session",
+				},
 				api.OperationSpec{
 					Action:    "resolve_challenge",
 					Public:    true,
 					RateLimit: &api.RateLimitConfig{Max: params.SecurityConfig.LoginRateLimit},
 				},
 				api.OperationSpec{
-					Action: "get_user_info",
+					Action:      "get_user_info",
+					AllowScoped: true,
+				},
+				api.OperationSpec{
+					Action:    "oidc_authorize",
+					Handler:   "OIDCAuthorize",
+					Public:    true,
+					RateLimit: &api.RateLimitConfig{Max: params.SecurityConfig.LoginRateLimit},
+				},
+				api.OperationSpec{
+					Action:    "oidc_login",
+					Handler:   "OIDCLogin",
+					Public:    true,
+					RateLimit: &api.RateLimitConfig{Max: params.SecurityConfig.LoginRateLimit},
+				},
+				api.OperationSpec{
+					Action:    "webauthn_begin_login",
+					Handler:   "WebAuthnBeginLogin",
+					Public:    true,
+					RateLimit: &api.RateLimitConfig{Max: params.SecurityConfig.LoginRateLimit},
+				},
+				api.OperationSpec{
+					Action:    "webauthn_login",
+					Handler:   "WebAuthnLogin",
+					Public:    true,
+					RateLimit: &api.RateLimitConfig{Max: params.SecurityConfig.LoginRateLimit},
 				},
 			),
 		),
 	}
 }
 
+// findCaptchaProvider returns the registered captcha provider, which the login consults before authentication.
+func findCaptchaProvider(providers []security.ChallengeProvider) *security.CaptchaChallengeProvider {
+	for _, provider := range providers {
+		if captcha, ok := provider.(*security.CaptchaChallengeProvider); ok {
+			return captcha
+		}
+	}
+
+	return nil
+}
+
 // AuthResource handles authentication-related API endpoints.
 type AuthResource struct {
 	api.Resource
@@ -84,7 +143,13 @@ type AuthResource struct {
 	challengeTokenStore security.ChallengeTokenStore
 	userInfoLoader      security.UserInfoLoader
 	challengeProviders  []security.ChallengeProvider
+	captchaProvider     *security.CaptchaChallengeProvider
+	sessionStore        security.SessionStore
+	oidcAuthenticator   *OIDCAuthenticator
+	webAuthn            *security.WebAuthn
+	loginGuard          *LoginGuard
 	publisher           event.Publisher
+	tokenExpires        time.Duration
 }
 
 // LoginParams represents the request parameters for user login.
@@ -94,52 +159,155 @@ type LoginParams struct {
 	Type        string `json:"type" validate:"required" label_i18n:"auth_type"`
 	Principal   string `json:"principal" validate:"required" label_i18n:"auth_principal"`
 	Credentials any    `json:"credentials" validate:"required" label_i18n:"auth_credentials"`
+	// Captcha is the answer to the captcha issued by a previous login flagged by the lockout policy.
+	Captcha any `json:"captcha"`
 }
 
 // Login authenticates a user and returns a LoginResult.
 // When challenge providers are configured and applicable, the result contains
 // a challenge token and pending challenges instead of auth tokens.
 func (a *AuthResource) Login(ctx fiber.Ctx, params LoginParams) error {
-	principal, err := a.authManager.Authenticate(ctx.Context(), security.Authentication{
+	return a.login(ctx, security.Authentication{
 		Type:        params.Type,
 		Principal:   params.Principal,
 		Credentials: params.Credentials,
-	})
+	}, params.Principal, params.Captcha)
+}
+
+// OIDCAuthorizeParams represents the request parameters for starting an OpenID Connect login.
+type OIDCAuthorizeParams struct {
+	api.P
+
+	Provider string `json:"provider" validate:"required" label_i18n:"auth_oidc_provider"`
+}
+
+// OIDCAuthorize starts an OpenID Connect login, returning the provider URL to redirect the user to
+// and the flow token to complete the login with on callback.
+func (a *AuthResource) OIDCAuthorize(ctx fiber.Ctx, params OIDCAuthorizeParams) error {
+	authorization, err := a.oidcAuthenticator.Authorize(ctx.Context(), params.Provider)
 	if err != nil {
-		var (
-			failReason string
-			errorCode  int
-		)
-
-		if resErr, ok := result.AsErr(err); ok {
-			failReason = resErr.Message
-			errorCode = resErr.Code
-		} else {
-			failReason = err.Error()
-			errorCode = result.ErrCodeUnknown
+		return err
+	}
+
+	return result.Ok(authorization).Response(ctx)
+}
+
+// OIDCLoginParams represents the request parameters for completing an OpenID Connect login.
+type OIDCLoginParams struct {
+	api.P
+
+	FlowToken string `json:"flowToken" validate:"required" label_i18n:"auth_oidc_flow_token"`
+	Code      string `json:"code" validate:"required" label_i18n:"auth_oidc_code"`
+	State     string `json:"state" validate:"required" label_i18n:"auth_oidc_state"`
+}
+
+// OIDCLogin completes an OpenID Connect login with the parameters the provider redirected the user back with.
+// Like Login, the result holds either auth tokens or the next login challenge.
+func (a *AuthResource) OIDCLogin(ctx fiber.Ctx, params OIDCLoginParams) error {
+	return a.login(ctx, security.Authentication{
+		Type:      AuthTypeOIDC,
+		Principal: params.FlowToken,
+		Credentials: &security.OIDCCredentials{
+			Code:  params.Code,
+			State: params.State,
+		},
+	}, "", nil)
+}
+
+// WebAuthnBeginLogin starts a passwordless passkey login, returning the options to get an assertion with
+// and the ceremony token to complete the login with.
+func (a *AuthResource) WebAuthnBeginLogin(ctx fiber.Ctx) error {
+	options, err := a.webAuthn.BeginAssertion(ctx.Context(), nil)
+	if err != nil {
+		return err
+	}
+
+	return result.Ok(options).Response(ctx)
+}
+
+// WebAuthnLoginParams represents the request parameters for completing a passkey login.
+type WebAuthnLoginParams struct {
+	api.P
+
+	CeremonyToken string                             `json:"ceremonyToken" validate:"required" label_i18n:"auth_webauthn_ceremony_token"`
+	Credential    security.WebAuthnAssertionResponse `json:"credential" label_i18n:"auth_webauthn_credential"`
+}
+
+// WebAuthnLogin completes a passkey login with the assertion of the user's authenticator.
+// Like Login, the result holds either auth tokens or the next login challenge.
+func (a *AuthResource) WebAuthnLogin(ctx fiber.Ctx, params WebAuthnLoginParams) error {
+	return a.login(ctx, security.Authentication{
+		Type:        AuthTypeWebAuthn,
+		Principal:   params.CeremonyToken,
+		Credentials: &params.Credential,
+	}, "", nil)
+}
+
+// login authenticates the request and either starts the first applicable challenge or issues auth tokens,
+// publishing a login event for the outcome. The username is recorded on the event when known up front.
+// Password logins pass the LoginGuard, which rejects locked usernames and blocked IPs and may demand a captcha.
+// A demanded captcha is issued and verified before the credentials are checked.
+func (a *AuthResource) login(ctx fiber.Ctx, authentication security.Authentication, username string, captcha any) error {
+	var (
+		authCtx = ctx.Context()
+		ip      = httpx.GetIP(ctx)
+		guarded = a.loginGuard.Guards(authentication.Type)
+	)
+
+	if guarded {
+		captchaRequired, lockoutReason, err := a.loginGuard.Check(authCtx, username, ip)
+		if err != nil {
+			a.publishLoginFailure(ctx, authentication.Type, username, err, lockoutReason)
+
+			return err
 		}
 
-		loginEvent := security.NewLoginEvent(security.LoginEventParams{
-			AuthType:   params.Type,
-			Username:   params.Principal,
-			LoginIP:    httpx.GetIP(ctx),
-			UserAgent:  ctx.Get(fiber.HeaderUserAgent),
-			TraceID:    contextx.RequestID(ctx),
-			IsOk:       false,
-			FailReason: failReason,
-			ErrorCode:  errorCode,
-		})
-		a.publisher.Publish(loginEvent)
+		if captchaRequired && a.captchaProvider != nil {
+			if captcha == nil {
+				challenge, err := a.captchaProvider.Issue(authCtx, username)
+				if err != nil {
+					return err
+				}
+
+				return result.Ok(&security.LoginResult{Challenge: challenge}).Response(ctx)
+			}
+
+			if err := a.captchaProvider.Verify(authCtx, username, captcha); err != nil {
+				a.publishLoginFailure(ctx, authentication.Type, username, err, "")
+
+				return err
+			}
+		}
+	}
+
+	principal, err := a.authManager.Authenticate(authCtx, authentication)
+	if err != nil {
+		var lockoutReason string
+
+		if resErr, ok := result.AsErr(err); guarded && ok && resErr.Code == result.ErrCodeCredentialsInvalid {
+			var recordErr error
+			if lockoutReason, recordErr = a.loginGuard.RecordFailure(authCtx, username, ip); recordErr != nil {
+				logger.Warnf("Failed to record failed login of %q: %v", username, recordErr)
+			}
+		}
+
+		a.publishLoginFailure(ctx, authentication.Type, username, err, lockoutReason)
 
 		return err
 	}
 
+	if guarded {
+		if err := a.loginGuard.RecordSuccess(authCtx, username); err != nil {
+			return err
+		}
+	}
+
 	pending := streams.MapTo(
 		streams.FromSlice(a.challengeProviders),
 		func(p security.ChallengeProvider) string { return p.Type() },
 	).Collect()
 
-	challenge, pending, err := a.evaluateNextChallenge(ctx.Context(), principal, pending)
+	challenge, pending, err := a.evaluateNextChallenge(authCtx, principal, pending)
 	if err != nil {
 		return err
 	}
@@ -156,16 +324,17 @@ func (a *AuthResource) Login(ctx fiber.Ctx, params LoginParams) error {
 		}).Response(ctx)
 	}
 
-	tokens, err := a.tokenGenerator.Generate(principal)
+	tokens, err := a.startSession(ctx, principal)
 	if err != nil {
 		return err
 	}
 
 	loginEvent := security.NewLoginEvent(security.LoginEventParams{
-		AuthType:  params.Type,
+		AuthType:  authentication.Type,
 		UserID:    &principal.ID,
-		Username:  params.Principal,
-		LoginIP:   httpx.GetIP(ctx),
+		Username:  cmp.Or(username, principal.Name),
+		TenantID:  cmp.Or(principal.TenantID, contextx.TenantID(ctx)),
+		LoginIP:   ip,
 		UserAgent: ctx.Get(fiber.HeaderUserAgent),
 		TraceID:   contextx.RequestID(ctx),
 		IsOk:      true,
@@ -175,6 +344,36 @@ func (a *AuthResource) Login(ctx fiber.Ctx, params LoginParams) error {
 	return result.Ok(&security.LoginResult{Tokens: tokens}).Response(ctx)
 }
 
+// publishLoginFailure publishes the login event of a failed login.
+func (a *AuthResource) publishLoginFailure(ctx fiber.Ctx, authType, username string, err error, lockoutReason string) {
+	var (
+		failReason string
+		errorCode  int
+	)
+
+	if resErr, ok := result.AsErr(err); ok {
+		failReason = resErr.Message
+		errorCode = resErr.Code
+	} else {
+		failReason = err.Error()
+		errorCode = result.ErrCodeUnknown
+	}
+
+	loginEvent := security.NewLoginEvent(security.LoginEventParams{
+		AuthType:      authType,
+		Username:      username,
+		TenantID:      contextx.TenantID(ctx),
+		LoginIP:       httpx.GetIP(ctx),
+		UserAgent:     ctx.Get(fiber.HeaderUserAgent),
+		TraceID:       contextx.RequestID(ctx),
+		IsOk:          false,
+		FailReason:    failReason,
+		ErrorCode:     errorCode,
+		LockoutReason: lockoutReason,
+	})
+	a.publisher.Publish(loginEvent)
+}
+
 // RefreshParams represents the request parameters for token refresh operation.
 type RefreshParams struct {
 	api.P
@@ -183,7 +382,8 @@ type RefreshParams struct {
 }
 
 // Refresh refreshes the access token using a valid refresh token.
-// User data reload logic is handled by JwtRefreshAuthenticator.
+// User data reload and refresh token reuse detection are handled by JwtRefreshAuthenticator;
+// the new refresh token then replaces the exchanged one as the only valid refresh token of the session.
 func (a *AuthResource) Refresh(ctx fiber.Ctx, params RefreshParams) error {
 	principal, err := a.authManager.Authenticate(ctx.Context(), security.Authentication{
 		Type:      AuthTypeRefresh,
@@ -198,12 +398,102 @@ func (a *AuthResource) Refresh(ctx fiber.Ctx, params RefreshParams) error {
 		return err
 	}
 
+	if err := a.rotateSession(ctx.Context(), principal.SessionID, params.RefreshToken, credentials.RefreshToken); err != nil {
+		return err
+	}
+
 	return result.Ok(credentials).Response(ctx)
 }
 
-// Logout returns success immediately.
-// Token invalidation should be handled on the client side by removing stored tokens.
-func (*AuthResource) Logout(ctx fiber.Ctx) error {
+// Logout revokes the current session so its tokens are rejected from now on.
+// Without a SessionStore tokens are stateless, and invalidation is left to the client removing stored tokens.
+func (a *AuthResource) Logout(ctx fiber.Ctx, principal *security.Principal) error {
+	if a.sessionStore != nil && principal.SessionID != "" {
+		if err := a.sessionStore.Delete(ctx.Context(), principal.SessionID); err != nil {
+			return err
+		}
+	}
+
+	return result.Ok().Response(ctx)
+}
+
+// LogoutAll revokes every session of the current user, logging out all of their devices.
+func (a *AuthResource) LogoutAll(ctx fiber.Ctx, principal *security.Principal) error {
+	if err := checkKeyManager(principal); err != nil {
+		return err
+	}
+
+	if a.sessionStore == nil {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageSessionStoreNotImplemented))
+	}
+
+	if err := a.sessionStore.DeleteByUser(ctx.Context(), principal.ID); err != nil {
+		return err
+	}
+
+	return result.Ok().Response(ctx)
+}
+
+// SessionView is a session as listed to its user.
+type SessionView struct {
+	*security.Session
+
+	// Current reports whether the session is the one making the request.
+	Current bool `json:"current"`
+}
+
+// ListSessions lists the active sessions of the current user, oldest first.
+func (a *AuthResource) ListSessions(ctx fiber.Ctx, principal *security.Principal) error {
+	if a.sessionStore == nil {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageSessionStoreNotImplemented))
+	}
+
+	sessions, err := a.sessionStore.ListByUser(ctx.Context(), principal.ID)
+	if err != nil {
+		return err
+	}
+
+	views := make([]SessionView, len(sessions))
+	for i, session := range sessions {
+		views[i] = SessionView{
+			Session: session,
+			Current: session.ID == principal.SessionID,
+		}
+	}
+
+	return result.Ok(views).Response(ctx)
+}
+
+// RevokeSessionParams represents the request parameters for revoking a session.
+type RevokeSessionParams struct {
+	api.P
+
+	SessionID string `json:"sessionId" validate:"required" label_i18n:"auth_session_id"`
+}
+
+// RevokeSession revokes one of the current user's own sessions, e.g. a lost device.
+func (a *AuthResource) RevokeSession(ctx fiber.Ctx, principal *security.Principal, params RevokeSessionParams) error {
+	if err := checkKeyManager(principal); err != nil {
+		return err
+	}
+
+	if a.sessionStore == nil {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageSessionStoreNotImplemented))
+	}
+
+	session, err := a.sessionStore.Get(ctx.Context(), params.SessionID)
+	if err != nil {
+		return err
+	}
+
+	if session == nil || session.UserID != principal.ID {
+		return result.ErrRecordNotFound
+	}
+
+	if err := a.sessionStore.Delete(ctx.Context(), session.ID); err != nil {
+		return err
+	}
+
 	return result.Ok().Response(ctx)
 }
 
@@ -259,13 +549,14 @@ func (a *AuthResource) ResolveChallenge(ctx fiber.Ctx, params ResolveChallengePa
 		}).Response(ctx)
 	}
 
-	tokens, err := a.tokenGenerator.Generate(principal)
+	tokens, err := a.startSession(ctx, principal)
 	if err != nil {
 		return err
 	}
 
 	loginEvent := security.NewLoginEvent(security.LoginEventParams{
 		UserID:    &principal.ID,
+		TenantID:  cmp.Or(principal.TenantID, contextx.TenantID(ctx)),
 		LoginIP:   httpx.GetIP(ctx),
 		UserAgent: ctx.Get(fiber.HeaderUserAgent),
 		TraceID:   contextx.RequestID(ctx),
@@ -291,6 +582,80 @@ func (a *AuthResource) GetUserInfo(ctx fiber.Ctx, principal *security.Principal,
 	return result.Ok(userInfo).Response(ctx)
 }
 
+// startSession issues the tokens of a new login and records its session when a SessionStore is configured.
+func (a *AuthResource) startSession(ctx fiber.Ctx, principal *security.Principal) (*security.AuthTokens, error) {
+	principal.SessionID = id.GenerateUUID()
+
+	tokens, err := a.tokenGenerator.Generate(principal)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.sessionStore == nil {
+		return tokens, nil
+	}
+
+	now := timex.Now()
+	session := &security.Session{
+		ID:          principal.SessionID,
+		UserID:      principal.ID,
+		IP:          httpx.GetIP(ctx),
+		UserAgent:   ctx.Get(fiber.HeaderUserAgent),
+		CreatedAt:   now,
+		RefreshedAt: now,
+		ExpiresAt:   now.Add(a.tokenExpires),
+	}
+	session.SetRefreshToken(tokens.RefreshToken)
+
+	if err := a.sessionStore.Save(ctx.Context(), session); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// rotateSession makes refreshToken the only refresh token of the session and extends the session to its expiry.
+// The swap only succeeds while exchangedToken is still the latest refresh token, so of two concurrent refreshes
+// with the same token only one wins; the other is treated as reuse and revokes the session.
+func (a *AuthResource) rotateSession(ctx context.Context, sessionID, exchangedToken, refreshToken string) error {
+	if a.sessionStore == nil {
+		return nil
+	}
+
+	session, err := a.sessionStore.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session == nil {
+		return result.ErrSessionRevoked
+	}
+
+	exchangedHash := hashx.SHA256(exchangedToken)
+
+	now := timex.Now()
+	session.RefreshedAt = now
+	session.ExpiresAt = now.Add(a.tokenExpires)
+	session.SetRefreshToken(refreshToken)
+
+	swapped, err := a.sessionStore.CompareAndSwap(ctx, session, exchangedHash)
+	if err != nil {
+		return err
+	}
+
+	if !swapped {
+		logger.Warnf("Refresh token reuse detected for session %q of user %q, revoking the session", sessionID, session.UserID)
+
+		if err := a.sessionStore.Delete(ctx, sessionID); err != nil {
+			return err
+		}
+
+		return result.ErrRefreshTokenReused
+	}
+
+	return nil
+}
+
 // findProvider returns the challenge provider matching the given type, or nil.
 func (a *AuthResource) findProvider(challengeType string) security.ChallengeProvider {
 	return streams.FromSlice(a.challengeProviders).
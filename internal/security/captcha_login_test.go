@@ -0,0 +1,159 @@
+package security_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/event"
+	"github.com/coldsmirk/vef-framework-go/internal/apptest"
+	isecurity "github.com/coldsmirk/vef-framework-go/internal/security"
+	"github.com/coldsmirk/vef-framework-go/password"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// fixedCaptchaVerifier accepts a single fixed answer for any username.
+type fixedCaptchaVerifier struct{}
+
+func (fixedCaptchaVerifier) Issue(context.Context, string) (any, error) {
+	return map[string]any{"image": "captcha"}, nil
+}
+
+func (fixedCaptchaVerifier) Verify(_ context.Context, _ string, response any) (bool, error) {
+	return response == "x7k2", nil
+}
+
+// CaptchaLoginTestSuite tests that a captcha demanded by the lockout policy is solved before the credentials are checked.
+type CaptchaLoginTestSuite struct {
+	apptest.Suite
+
+	userLoader *MockUserLoader
+	publisher  *MockPublisher
+}
+
+func (s *CaptchaLoginTestSuite) SetupSuite() {
+	s.userLoader = new(MockUserLoader)
+	s.publisher = new(MockPublisher)
+
+	hashedPassword, err := password.NewBcryptEncoder().Encode("password123")
+	s.Require().NoError(err, "Should encode the password")
+
+	s.SetupApp(
+		fx.Supply(
+			fx.Annotate(
+				s.userLoader,
+				fx.As(new(security.UserLoader)),
+			),
+		),
+		fx.Supply(
+			fx.Annotate(
+				security.NewCaptchaChallengeProvider(fixedCaptchaVerifier{}),
+				fx.As(new(security.ChallengeProvider)),
+				fx.ResultTags(`group:"vef:security:challenge_providers"`),
+			),
+		),
+		fx.Replace(
+			fx.Annotate(
+				s.publisher,
+				fx.As(new(event.Publisher)),
+			),
+		),
+		fx.Replace(
+			&config.DataSourceConfig{
+				Kind: "sqlite",
+			},
+			&config.SecurityConfig{
+				TokenExpires:     24 * time.Hour,
+				RefreshNotBefore: 1 * time.Millisecond,
+				LoginRateLimit:   1000,
+				RefreshRateLimit: 1000,
+				Lockout: config.LockoutConfig{
+					Enabled:         true,
+					MaxAttempts:     10,
+					CaptchaAttempts: 1,
+				},
+			},
+			&security.JWTConfig{
+				Secret:   testJWTSecret,
+				Audience: "test-app",
+			},
+		),
+		fx.Invoke(func() {
+			s.userLoader.On("LoadByUsername", mock.Anything, "testuser").
+				Return(security.NewUser("user001", "Test User"), hashedPassword, nil).
+				Maybe()
+
+			s.publisher.On("Publish", mock.Anything).Maybe()
+		}),
+	)
+}
+
+func (s *CaptchaLoginTestSuite) TearDownSuite() {
+	s.TearDownApp()
+}
+
+// login performs a password login of testuser and returns the result.
+func (s *CaptchaLoginTestSuite) login(pwd string, captcha any) result.Result {
+	params := map[string]any{
+		"type":        isecurity.AuthTypePassword,
+		"principal":   "testuser",
+		"credentials": pwd,
+	}
+	if captcha != nil {
+		params["captcha"] = captcha
+	}
+
+	resp := s.MakeRPCRequest(api.Request{
+		Identifier: api.Identifier{
+			Resource: "security/auth",
+			Action:   "login",
+			Version:  "v1",
+		},
+		Params: params,
+	})
+
+	return s.ReadResult(resp)
+}
+
+// TestCaptchaBeforeAuthentication tests the captcha flow of a username flagged after a failed login.
+func (s *CaptchaLoginTestSuite) TestCaptchaBeforeAuthentication() {
+	body := s.login("wrong", nil)
+	s.Require().Equal(result.ErrCodeCredentialsInvalid, body.Code, "First failure should be reported as invalid credentials")
+
+	s.Run("IssuesCaptcha", func() {
+		s.userLoader.Calls = nil
+
+		body := s.login("password123", nil)
+		s.Require().True(body.IsOk(), "Login without an answer should return the captcha")
+
+		data := s.ReadDataAsMap(body.Data)
+		s.Nil(data["tokens"], "Should not issue tokens before the captcha is solved")
+		s.Equal(security.ChallengeTypeCaptcha, s.ReadDataAsMap(data["challenge"])["type"], "Should return a captcha challenge")
+		s.userLoader.AssertNotCalled(s.T(), "LoadByUsername", mock.Anything, mock.Anything)
+	})
+
+	s.Run("RejectsWrongAnswer", func() {
+		s.userLoader.Calls = nil
+
+		body := s.login("password123", "wrong")
+		s.Equal(result.ErrCodeCaptchaInvalid, body.Code, "Should reject a wrong answer")
+		s.userLoader.AssertNotCalled(s.T(), "LoadByUsername", mock.Anything, mock.Anything)
+	})
+
+	s.Run("AuthenticatesAfterAnswer", func() {
+		body := s.login("password123", "x7k2")
+		s.Require().True(body.IsOk(), "Should log in with the correct answer")
+		s.NotNil(s.ReadDataAsMap(body.Data)["tokens"], "Should issue tokens")
+	})
+}
+
+func TestCaptchaLogin(t *testing.T) {
+	suite.Run(t, new(CaptchaLoginTestSuite))
+}
@@ -0,0 +1,16 @@
+package security
+
+import "errors"
+
+var (
+	ErrOIDCRequestFailed           = errors.New("oidc provider request failed")
+	ErrOIDCIssuerMismatch          = errors.New("oidc discovery issuer does not match the configured issuer")
+	ErrOIDCIDTokenMissing          = errors.New("oidc token response has no id token")
+	ErrOIDCNonceMismatch           = errors.New("oidc id token nonce does not match the login flow")
+	ErrOIDCAuthorizedPartyMismatch = errors.New("oidc id token was issued to another client")
+	ErrOIDCSigningKeyNotFound      = errors.New("oidc id token signing key not found")
+	ErrOIDCSubjectMismatch         = errors.New("oidc userinfo subject does not match the id token")
+	ErrJWKUnsupported              = errors.New("unsupported json web key")
+
+	ErrPolicyResultNotBool = errors.New("policy expression did not return a boolean")
+)
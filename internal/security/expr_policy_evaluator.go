@@ -0,0 +1,120 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+
+	"github.com/coldsmirk/vef-framework-go/cache"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// maxCompiledPolicies bounds the number of compiled policy programs kept in memory.
+const maxCompiledPolicies = 1024
+
+// policyEnv holds the variables expressions see. Programs are compiled against its types once and then run
+// for every request; the resource is untyped since its shape depends on the PolicyResourceLoader.
+type policyEnv struct {
+	Principal *security.Principal      `expr:"principal"`
+	Operation security.PolicyOperation `expr:"operation"`
+	Params    map[string]any           `expr:"params"`
+	Resource  any                      `expr:"resource"`
+	Now       time.Time                `expr:"now"`
+}
+
+// ExprPolicyEvaluator evaluates policies written as expr-lang expressions.
+// Expressions see the principal, the invoked operation, the request params, the resource loaded by the
+// PolicyResourceLoader and the current time.
+type ExprPolicyEvaluator struct {
+	loader         security.PolicyLoader
+	resourceLoader security.PolicyResourceLoader
+	programs       cache.Cache[*vm.Program]
+}
+
+// NewExprPolicyEvaluator creates an expr-lang policy evaluator.
+// Without a PolicyLoader no token is policy-guarded; without a PolicyResourceLoader resource is always nil.
+// Compiled expressions are cached by policy name and expression, so an edited policy is recompiled.
+func NewExprPolicyEvaluator(loader security.PolicyLoader, resourceLoader security.PolicyResourceLoader) security.PolicyEvaluator {
+	return &ExprPolicyEvaluator{
+		loader:         loader,
+		resourceLoader: resourceLoader,
+		programs:       cache.NewMemory[*vm.Program](cache.WithMemMaxSize(maxCompiledPolicies)),
+	}
+}
+
+func (e *ExprPolicyEvaluator) Evaluate(ctx context.Context, request *security.PolicyRequest) (*security.PolicyDecision, error) {
+	decision := &security.PolicyDecision{Allowed: true, Results: []security.PolicyResult{}}
+	if e.loader == nil {
+		return decision, nil
+	}
+
+	policies, err := e.loader.LoadPolicies(ctx, request.PermToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policies) == 0 {
+		return decision, nil
+	}
+
+	// The resource is loaded only for policy-guarded tokens, and once for all their policies.
+	var resource any
+	if e.resourceLoader != nil {
+		if resource, err = e.resourceLoader.LoadResource(ctx, request); err != nil {
+			return nil, err
+		}
+	}
+
+	env := policyEnv{
+		Principal: request.Principal,
+		Operation: request.Operation,
+		Params:    request.Params,
+		Resource:  resource,
+		Now:       time.Now(),
+	}
+
+	for _, policy := range policies {
+		policyResult := security.PolicyResult{
+			Name:        policy.Name,
+			Expression:  policy.Expression,
+			Description: policy.Description,
+		}
+
+		allowed, err := e.evaluatePolicy(ctx, policy, env)
+		if err != nil {
+			logger.Warnf("Failed to evaluate policy %q of permission %q: %v", policy.Name, request.PermToken, err)
+
+			policyResult.Error = err.Error()
+		}
+
+		policyResult.Allowed = allowed
+		decision.Allowed = decision.Allowed && allowed
+		decision.Results = append(decision.Results, policyResult)
+	}
+
+	return decision, nil
+}
+
+func (e *ExprPolicyEvaluator) evaluatePolicy(ctx context.Context, policy security.Policy, env policyEnv) (bool, error) {
+	program, err := e.programs.GetOrLoad(ctx, policy.Name+"\x00"+policy.Expression, func(context.Context) (*vm.Program, error) {
+		return expr.Compile(policy.Expression, expr.Env(policyEnv{}), expr.AsBool())
+	})
+	if err != nil {
+		return false, fmt.Errorf("compile expression: %w", err)
+	}
+
+	output, err := expr.Run(program, env)
+	if err != nil {
+		return false, fmt.Errorf("run expression: %w", err)
+	}
+
+	allowed, ok := output.(bool)
+	if !ok {
+		return false, fmt.Errorf("%w: %T", ErrPolicyResultNotBool, output)
+	}
+
+	return allowed, nil
+}
@@ -0,0 +1,176 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+type ExprPolicyEvaluatorTestSuite struct {
+	suite.Suite
+
+	ctx       context.Context
+	principal *security.Principal
+	loader    security.PolicyLoader
+}
+
+func (s *ExprPolicyEvaluatorTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.principal = security.NewUser("u1", "Alice", "finance").WithDepartmentID("d1")
+	s.loader = security.NewStaticPolicyLoader(
+		security.Policy{
+			Name:       "amount_limit",
+			PermToken:  "expense:approve",
+			Expression: "resource.amount < 10000",
+		},
+		security.Policy{
+			Name:       "same_department",
+			PermToken:  "expense:approve",
+			Expression: "resource.departmentId == principal.DepartmentID",
+		},
+		security.Policy{
+			Name:       "own_comment",
+			PermToken:  "comment:edit",
+			Expression: "params.authorId == principal.ID",
+		},
+		security.Policy{
+			Name:       "broken",
+			PermToken:  "report:export",
+			Expression: "params.limit",
+		},
+	)
+}
+
+func (s *ExprPolicyEvaluatorTestSuite) evaluate(evaluator security.PolicyEvaluator, permToken string, params map[string]any) *security.PolicyDecision {
+	decision, err := evaluator.Evaluate(s.ctx, &security.PolicyRequest{
+		Principal: s.principal,
+		PermToken: permToken,
+		Params:    params,
+	})
+	s.Require().NoError(err, "Should evaluate policies")
+
+	return decision
+}
+
+// TestResourcePolicies verifies all policies of a token must allow the loaded resource.
+func (s *ExprPolicyEvaluatorTestSuite) TestResourcePolicies() {
+	s.Run("AllPoliciesAllow", func() {
+		resourceLoader := new(MockPolicyResourceLoader)
+		resourceLoader.On("LoadResource", mock.Anything, mock.Anything).
+			Return(map[string]any{"amount": 5000, "departmentId": "d1"}, nil).
+			Once()
+
+		decision := s.evaluate(NewExprPolicyEvaluator(s.loader, resourceLoader), "expense:approve", nil)
+		s.True(decision.Allowed, "Should allow when every policy holds")
+		s.Len(decision.Results, 2, "Should report every policy")
+		resourceLoader.AssertExpectations(s.T())
+	})
+
+	s.Run("OnePolicyDenies", func() {
+		resourceLoader := new(MockPolicyResourceLoader)
+		resourceLoader.On("LoadResource", mock.Anything, mock.Anything).
+			Return(map[string]any{"amount": 50000, "departmentId": "d1"}, nil).
+			Once()
+
+		decision := s.evaluate(NewExprPolicyEvaluator(s.loader, resourceLoader), "expense:approve", nil)
+		s.False(decision.Allowed, "Should deny when a policy fails")
+		s.False(decision.Results[0].Allowed, "Amount policy should deny")
+		s.True(decision.Results[1].Allowed, "Department policy should allow")
+	})
+}
+
+// TestParamsPolicy verifies policies can compare request params with the principal.
+func (s *ExprPolicyEvaluatorTestSuite) TestParamsPolicy() {
+	evaluator := NewExprPolicyEvaluator(s.loader, nil)
+
+	s.True(s.evaluate(evaluator, "comment:edit", map[string]any{"authorId": "u1"}).Allowed, "Should allow the author")
+	s.False(s.evaluate(evaluator, "comment:edit", map[string]any{"authorId": "u2"}).Allowed, "Should deny other users")
+}
+
+// TestOperationPolicy verifies policies can tell apart the operations sharing a permission token.
+func (s *ExprPolicyEvaluatorTestSuite) TestOperationPolicy() {
+	loader := security.NewStaticPolicyLoader(security.Policy{
+		Name:       "no_bulk_delete",
+		PermToken:  "order:delete",
+		Expression: `operation.Action != "delete_many"`,
+	})
+	evaluate := func(action string) bool {
+		decision, err := NewExprPolicyEvaluator(loader, nil).Evaluate(s.ctx, &security.PolicyRequest{
+			Principal: s.principal,
+			PermToken: "order:delete",
+			Operation: security.PolicyOperation{Resource: "order", Action: action},
+		})
+		s.Require().NoError(err, "Should evaluate policies")
+
+		return decision.Allowed
+	}
+
+	s.True(evaluate("delete"), "Should allow the single delete")
+	s.False(evaluate("delete_many"), "Should deny the bulk delete")
+}
+
+// TestFailingPolicyDenies verifies a policy that does not yield a boolean denies access and reports its error.
+func (s *ExprPolicyEvaluatorTestSuite) TestFailingPolicyDenies() {
+	decision := s.evaluate(NewExprPolicyEvaluator(s.loader, nil), "report:export", map[string]any{"limit": 10})
+
+	s.False(decision.Allowed, "Should deny when a policy fails to evaluate")
+	s.Require().Len(decision.Results, 1, "Should report the failing policy")
+	s.NotEmpty(decision.Results[0].Error, "Should report the evaluation error")
+}
+
+// TestUnguardedToken verifies tokens without policies are allowed without loading the resource.
+func (s *ExprPolicyEvaluatorTestSuite) TestUnguardedToken() {
+	resourceLoader := new(MockPolicyResourceLoader)
+
+	decision := s.evaluate(NewExprPolicyEvaluator(s.loader, resourceLoader), "user:query", nil)
+	s.True(decision.Allowed, "Should allow tokens without policies")
+	s.Empty(decision.Results, "Should report no policies")
+	resourceLoader.AssertNotCalled(s.T(), "LoadResource", mock.Anything, mock.Anything)
+
+	s.True(s.evaluate(NewExprPolicyEvaluator(nil, nil), "expense:approve", nil).Allowed, "Should allow everything without a loader")
+}
+
+// TestResourceLoaderError verifies resource loading failures are returned.
+func (s *ExprPolicyEvaluatorTestSuite) TestResourceLoaderError() {
+	loaderErr := errors.New("db down")
+	resourceLoader := new(MockPolicyResourceLoader)
+	resourceLoader.On("LoadResource", mock.Anything, mock.Anything).Return(nil, loaderErr).Once()
+
+	_, err := NewExprPolicyEvaluator(s.loader, resourceLoader).Evaluate(s.ctx, &security.PolicyRequest{
+		Principal: s.principal,
+		PermToken: "expense:approve",
+	})
+	s.ErrorIs(err, loaderErr, "Should return the resource loader error")
+}
+
+// TestCompiledPolicyCache verifies expressions are compiled once and reused for resources of any shape.
+func (s *ExprPolicyEvaluatorTestSuite) TestCompiledPolicyCache() {
+	resourceLoader := new(MockPolicyResourceLoader)
+	resourceLoader.On("LoadResource", mock.Anything, mock.Anything).
+		Return(map[string]any{"amount": 5000, "departmentId": "d1"}, nil).
+		Once()
+	resourceLoader.On("LoadResource", mock.Anything, mock.Anything).
+		Return(struct {
+			Amount       int    `expr:"amount"`
+			DepartmentID string `expr:"departmentId"`
+		}{Amount: 20000, DepartmentID: "d1"}, nil).
+		Once()
+
+	evaluator := NewExprPolicyEvaluator(s.loader, resourceLoader)
+
+	s.True(s.evaluate(evaluator, "expense:approve", nil).Allowed, "Should allow the map resource")
+	s.False(s.evaluate(evaluator, "expense:approve", nil).Allowed, "Should evaluate the cached programs against the struct resource")
+
+	size, err := evaluator.(*ExprPolicyEvaluator).programs.Size(s.ctx)
+	s.Require().NoError(err, "Should report the cache size")
+	s.Equal(int64(2), size, "Should compile each policy once")
+}
+
+func TestExprPolicyEvaluator(t *testing.T) {
+	suite.Run(t, new(ExprPolicyEvaluatorTestSuite))
+}
@@ -0,0 +1,47 @@
+package security
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/internal/app"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+const (
+	// JWKSPath is the well-known path of the public JWT verification keys.
+	JWKSPath = "/.well-known/jwks.json"
+	// jwksCacheControl lets verifiers cache the key set; upcoming keys are published ahead of activation.
+	jwksCacheControl = "public, max-age=300"
+)
+
+// JWKSMiddleware serves the public keys that verify issued tokens, so other services can verify them without the signing key.
+type JWKSMiddleware struct {
+	jwt *security.JWT
+}
+
+// NewJWKSMiddleware creates a new JWKS middleware.
+// Returns nil when tokens are signed with a shared secret only, as there is nothing to publish.
+func NewJWKSMiddleware(jwt *security.JWT) app.Middleware {
+	if len(jwt.JWKS().Keys) == 0 {
+		return nil
+	}
+
+	return &JWKSMiddleware{jwt: jwt}
+}
+
+func (*JWKSMiddleware) Name() string {
+	return "jwks"
+}
+
+func (*JWKSMiddleware) Order() int {
+	return 400
+}
+
+func (m *JWKSMiddleware) Apply(router fiber.Router) {
+	router.Get(JWKSPath, func(ctx fiber.Ctx) error {
+		ctx.Set(fiber.HeaderCacheControl, jwksCacheControl)
+
+		return ctx.JSON(m.jwt.JWKS())
+	})
+	logger.Infof("JWKS registered at GET %s", JWKSPath)
+}
@@ -0,0 +1,63 @@
+package security
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// TestJWKSMiddleware tests publishing of the JWT verification keys.
+func TestJWKSMiddleware(t *testing.T) {
+	t.Run("SecretOnly", func(t *testing.T) {
+		assert.Nil(t, NewJWKSMiddleware(newTestJWT(t)), "Should not be registered without public keys")
+	})
+
+	t.Run("ServesKeySet", func(t *testing.T) {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err, "Should generate Ed25519 key")
+
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		require.NoError(t, err, "Should marshal private key")
+
+		jwt, err := security.NewJWT(&security.JWTConfig{
+			Keys: []security.JWTKeyConfig{{
+				ID:         "key-1",
+				Algorithm:  security.JWTAlgorithmEdDSA,
+				PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+			}},
+		})
+		require.NoError(t, err, "Should create JWT with keys")
+
+		middleware := NewJWKSMiddleware(jwt)
+		require.NotNil(t, middleware, "Should be registered with public keys")
+
+		app := fiber.New()
+		middleware.Apply(app)
+
+		req := httptest.NewRequestWithContext(context.Background(), fiber.MethodGet, JWKSPath, nil)
+		resp, err := app.Test(req)
+		require.NoError(t, err, "Request should succeed")
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode, "Should return 200 status")
+		assert.Equal(t, jwksCacheControl, resp.Header.Get(fiber.HeaderCacheControl), "Should be cacheable")
+
+		var jwks security.JWKS
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks), "Should decode key set")
+		require.Len(t, jwks.Keys, 1, "Should publish the key")
+		assert.Equal(t, "key-1", jwks.Keys[0].KeyID, "Should publish the key ID")
+		assert.Equal(t, "OKP", jwks.Keys[0].KeyType, "Should publish the key type")
+	})
+}
@@ -14,14 +14,19 @@ const (
 )
 
 type JWTRefreshAuthenticator struct {
-	jwt        *security.JWT
-	userLoader security.UserLoader
+	jwt          *security.JWT
+	userLoader   security.UserLoader
+	sessionStore security.SessionStore
 }
 
-func NewJWTRefreshAuthenticator(jwt *security.JWT, userLoader security.UserLoader) security.Authenticator {
+// NewJWTRefreshAuthenticator creates a JWT refresh token authenticator.
+// When sessionStore is non-nil, only the latest refresh token of a live session is accepted;
+// presenting an older one revokes the session as the token is assumed stolen.
+func NewJWTRefreshAuthenticator(jwt *security.JWT, userLoader security.UserLoader, sessionStore security.SessionStore) security.Authenticator {
 	return &JWTRefreshAuthenticator{
-		jwt:        jwt,
-		userLoader: userLoader,
+		jwt:          jwt,
+		userLoader:   userLoader,
+		sessionStore: sessionStore,
 	}
 }
 
@@ -50,6 +55,11 @@ func (j *JWTRefreshAuthenticator) Authenticate(ctx context.Context, authenticati
 		return nil, result.ErrTokenInvalid
 	}
 
+	sessionID := claimsAccessor.SessionID()
+	if err := j.checkSession(ctx, sessionID, token); err != nil {
+		return nil, err
+	}
+
 	subjectParts := strings.SplitN(claimsAccessor.Subject(), "@", 2)
 	userID := subjectParts[0]
 
@@ -67,5 +77,35 @@ func (j *JWTRefreshAuthenticator) Authenticate(ctx context.Context, authenticati
 		return nil, result.ErrRecordNotFound
 	}
 
+	principal.SessionID = sessionID
+
 	return principal, nil
 }
+
+// checkSession verifies that token is the latest refresh token of a live session.
+func (j *JWTRefreshAuthenticator) checkSession(ctx context.Context, sessionID, token string) error {
+	if j.sessionStore == nil {
+		return nil
+	}
+
+	session, err := j.sessionStore.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session == nil {
+		return result.ErrSessionRevoked
+	}
+
+	if !session.MatchesRefreshToken(token) {
+		logger.Warnf("Refresh token reuse detected for session %q of user %q, revoking the session", sessionID, session.UserID)
+
+		if err := j.sessionStore.Delete(ctx, sessionID); err != nil {
+			return err
+		}
+
+		return result.ErrRefreshTokenReused
+	}
+
+	return nil
+}
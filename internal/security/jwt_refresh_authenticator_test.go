@@ -11,6 +11,7 @@ import (
 
 	"github.com/coldsmirk/vef-framework-go/result"
 	"github.com/coldsmirk/vef-framework-go/security"
+	"github.com/coldsmirk/vef-framework-go/timex"
 )
 
 type JWTRefreshAuthenticatorTestSuite struct {
@@ -27,7 +28,7 @@ func (s *JWTRefreshAuthenticatorTestSuite) SetupSuite() {
 
 // TestSupports verifies type matching.
 func (s *JWTRefreshAuthenticatorTestSuite) TestSupports() {
-	auth := NewJWTRefreshAuthenticator(s.jwt, nil)
+	auth := NewJWTRefreshAuthenticator(s.jwt, nil, nil)
 	s.True(auth.Supports(AuthTypeRefresh), "Should support refresh type")
 	s.False(auth.Supports("token"), "Should not support token type")
 	s.False(auth.Supports(""), "Should not support empty type")
@@ -38,7 +39,7 @@ func (s *JWTRefreshAuthenticatorTestSuite) TestAuthenticate() {
 	ctx := context.Background()
 
 	s.Run("NilUserLoader", func() {
-		auth := NewJWTRefreshAuthenticator(s.jwt, nil)
+		auth := NewJWTRefreshAuthenticator(s.jwt, nil, nil)
 
 		_, err := auth.Authenticate(ctx, security.Authentication{
 			Type:      AuthTypeRefresh,
@@ -53,7 +54,7 @@ func (s *JWTRefreshAuthenticatorTestSuite) TestAuthenticate() {
 
 	s.Run("EmptyToken", func() {
 		loader := new(MockUserLoader)
-		auth := NewJWTRefreshAuthenticator(s.jwt, loader)
+		auth := NewJWTRefreshAuthenticator(s.jwt, loader, nil)
 
 		_, err := auth.Authenticate(ctx, security.Authentication{
 			Type:      AuthTypeRefresh,
@@ -64,7 +65,7 @@ func (s *JWTRefreshAuthenticatorTestSuite) TestAuthenticate() {
 
 	s.Run("InvalidJWT", func() {
 		loader := new(MockUserLoader)
-		auth := NewJWTRefreshAuthenticator(s.jwt, loader)
+		auth := NewJWTRefreshAuthenticator(s.jwt, loader, nil)
 
 		_, err := auth.Authenticate(ctx, security.Authentication{
 			Type:      AuthTypeRefresh,
@@ -79,7 +80,7 @@ func (s *JWTRefreshAuthenticatorTestSuite) TestAuthenticate() {
 		s.Require().NoError(err, "Should generate tokens")
 
 		loader := new(MockUserLoader)
-		auth := NewJWTRefreshAuthenticator(s.jwt, loader)
+		auth := NewJWTRefreshAuthenticator(s.jwt, loader, nil)
 
 		_, err = auth.Authenticate(ctx, security.Authentication{
 			Type:      AuthTypeRefresh,
@@ -96,7 +97,7 @@ func (s *JWTRefreshAuthenticatorTestSuite) TestAuthenticate() {
 		loader := new(MockUserLoader)
 		loader.On("LoadByID", mock.Anything, "user1").Return(nil, nil)
 
-		auth := NewJWTRefreshAuthenticator(s.jwt, loader)
+		auth := NewJWTRefreshAuthenticator(s.jwt, loader, nil)
 
 		_, err = auth.Authenticate(ctx, security.Authentication{
 			Type:      AuthTypeRefresh,
@@ -114,7 +115,7 @@ func (s *JWTRefreshAuthenticatorTestSuite) TestAuthenticate() {
 		loader := new(MockUserLoader)
 		loader.On("LoadByID", mock.Anything, "user1").Return(nil, errors.New("db error"))
 
-		auth := NewJWTRefreshAuthenticator(s.jwt, loader)
+		auth := NewJWTRefreshAuthenticator(s.jwt, loader, nil)
 
 		_, err = auth.Authenticate(ctx, security.Authentication{
 			Type:      AuthTypeRefresh,
@@ -135,7 +136,7 @@ func (s *JWTRefreshAuthenticatorTestSuite) TestAuthenticate() {
 		loader := new(MockUserLoader)
 		loader.On("LoadByID", mock.Anything, "user1").Return(reloaded, nil)
 
-		auth := NewJWTRefreshAuthenticator(s.jwt, loader)
+		auth := NewJWTRefreshAuthenticator(s.jwt, loader, nil)
 
 		got, err := auth.Authenticate(ctx, security.Authentication{
 			Type:      AuthTypeRefresh,
@@ -155,7 +156,7 @@ func (s *JWTRefreshAuthenticatorTestSuite) TestAuthenticate() {
 		s.Require().NoError(err, "Should generate expired token")
 
 		loader := new(MockUserLoader)
-		auth := NewJWTRefreshAuthenticator(s.jwt, loader)
+		auth := NewJWTRefreshAuthenticator(s.jwt, loader, nil)
 
 		_, err = auth.Authenticate(ctx, security.Authentication{
 			Type:      AuthTypeRefresh,
@@ -169,6 +170,66 @@ func (s *JWTRefreshAuthenticatorTestSuite) TestAuthenticate() {
 	})
 }
 
+// TestSessionCheck verifies refresh token rotation and reuse detection against the session store.
+func (s *JWTRefreshAuthenticatorTestSuite) TestSessionCheck() {
+	ctx := context.Background()
+
+	newSession := func(store security.SessionStore, refreshToken string) {
+		session := &security.Session{
+			ID:        "session-1",
+			UserID:    "user1",
+			ExpiresAt: timex.Now().Add(time.Hour),
+		}
+		session.SetRefreshToken(refreshToken)
+		s.Require().NoError(store.Save(ctx, session), "Should save session")
+	}
+
+	principal := security.NewUser("user1", "Alice")
+	principal.SessionID = "session-1"
+	tokens, err := s.gen.Generate(principal)
+	s.Require().NoError(err, "Should generate tokens")
+
+	s.Run("LatestRefreshToken", func() {
+		store := security.NewMemorySessionStore()
+		newSession(store, tokens.RefreshToken)
+
+		loader := new(MockUserLoader)
+		loader.On("LoadByID", mock.Anything, "user1").Return(security.NewUser("user1", "Alice"), nil)
+
+		got, err := NewJWTRefreshAuthenticator(s.jwt, loader, store).Authenticate(ctx, security.Authentication{
+			Type:      AuthTypeRefresh,
+			Principal: tokens.RefreshToken,
+		})
+		s.Require().NoError(err, "Should accept the latest refresh token")
+		s.Equal("session-1", got.SessionID, "Should keep the session on the reloaded principal")
+	})
+
+	s.Run("RevokedSession", func() {
+		store := security.NewMemorySessionStore()
+
+		_, err := NewJWTRefreshAuthenticator(s.jwt, new(MockUserLoader), store).Authenticate(ctx, security.Authentication{
+			Type:      AuthTypeRefresh,
+			Principal: tokens.RefreshToken,
+		})
+		s.ErrorIs(err, result.ErrSessionRevoked, "Should reject refresh token of a revoked session")
+	})
+
+	s.Run("ReusedRefreshToken", func() {
+		store := security.NewMemorySessionStore()
+		newSession(store, "rotated-refresh-token")
+
+		_, err := NewJWTRefreshAuthenticator(s.jwt, new(MockUserLoader), store).Authenticate(ctx, security.Authentication{
+			Type:      AuthTypeRefresh,
+			Principal: tokens.RefreshToken,
+		})
+		s.ErrorIs(err, result.ErrRefreshTokenReused, "Should reject a superseded refresh token")
+
+		session, err := store.Get(ctx, "session-1")
+		s.Require().NoError(err, "Should get session")
+		s.Nil(session, "Reuse should revoke the whole session")
+	})
+}
+
 func TestJWTRefreshAuthenticator(t *testing.T) {
 	suite.Run(t, new(JWTRefreshAuthenticatorTestSuite))
 }
@@ -13,12 +13,16 @@ const (
 )
 
 type JWTTokenAuthenticator struct {
-	jwt *security.JWT
+	jwt          *security.JWT
+	sessionStore security.SessionStore
 }
 
-func NewJWTAuthenticator(jwt *security.JWT) security.Authenticator {
+// NewJWTAuthenticator creates a JWT access token authenticator.
+// When sessionStore is non-nil, tokens whose session has been revoked are rejected.
+func NewJWTAuthenticator(jwt *security.JWT, sessionStore security.SessionStore) security.Authenticator {
 	return &JWTTokenAuthenticator{
-		jwt: jwt,
+		jwt:          jwt,
+		sessionStore: sessionStore,
 	}
 }
 
@@ -26,7 +30,7 @@ func (*JWTTokenAuthenticator) Supports(authType string) bool {
 	return authType == AuthTypeToken
 }
 
-func (ja *JWTTokenAuthenticator) Authenticate(_ context.Context, authentication security.Authentication) (*security.Principal, error) {
+func (ja *JWTTokenAuthenticator) Authenticate(ctx context.Context, authentication security.Authentication) (*security.Principal, error) {
 	token := authentication.Principal
 	if token == "" {
 		return nil, result.ErrTokenInvalid
@@ -46,7 +50,22 @@ func (ja *JWTTokenAuthenticator) Authenticate(_ context.Context, authentication
 		return nil, result.ErrTokenInvalid
 	}
 
-	principal := security.NewUser(subjectParts[0], subjectParts[1], claimsAccessor.Roles()...)
+	sessionID := claimsAccessor.SessionID()
+	if ja.sessionStore != nil {
+		session, err := ja.sessionStore.Get(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		if session == nil {
+			return nil, result.ErrSessionRevoked
+		}
+	}
+
+	principal := security.NewUser(subjectParts[0], subjectParts[1], claimsAccessor.Roles()...).
+		WithTenantID(claimsAccessor.TenantID()).
+		WithDepartmentID(claimsAccessor.DepartmentID())
+	principal.SessionID = sessionID
 	principal.AttemptUnmarshalDetails(claimsAccessor.Details())
 
 	return principal, nil
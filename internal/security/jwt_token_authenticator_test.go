@@ -9,6 +9,7 @@ import (
 
 	"github.com/coldsmirk/vef-framework-go/result"
 	"github.com/coldsmirk/vef-framework-go/security"
+	"github.com/coldsmirk/vef-framework-go/timex"
 )
 
 type JWTTokenAuthenticatorTestSuite struct {
@@ -21,7 +22,7 @@ type JWTTokenAuthenticatorTestSuite struct {
 
 func (s *JWTTokenAuthenticatorTestSuite) SetupSuite() {
 	s.jwt = newTestJWT(s.T())
-	s.auth = NewJWTAuthenticator(s.jwt)
+	s.auth = NewJWTAuthenticator(s.jwt, nil)
 	s.gen = newTestTokenGenerator(s.jwt)
 }
 
@@ -158,6 +159,43 @@ func (s *JWTTokenAuthenticatorTestSuite) TestAuthenticate() {
 	})
 }
 
+// TestSessionCheck verifies tokens are only accepted while their session exists.
+func (s *JWTTokenAuthenticatorTestSuite) TestSessionCheck() {
+	ctx := context.Background()
+	store := security.NewMemorySessionStore()
+	auth := NewJWTAuthenticator(s.jwt, store)
+
+	principal := security.NewUser("user1", "Alice", "admin")
+	principal.SessionID = "session-1"
+	tokens, err := s.gen.Generate(principal)
+	s.Require().NoError(err, "Should generate tokens")
+
+	s.Run("LiveSession", func() {
+		s.Require().NoError(store.Save(ctx, &security.Session{
+			ID:        "session-1",
+			UserID:    "user1",
+			ExpiresAt: timex.Now().Add(time.Hour),
+		}), "Should save session")
+
+		got, err := auth.Authenticate(ctx, security.Authentication{
+			Type:      AuthTypeToken,
+			Principal: tokens.AccessToken,
+		})
+		s.Require().NoError(err, "Should accept token of a live session")
+		s.Equal("session-1", got.SessionID, "Should expose the session on the principal")
+	})
+
+	s.Run("RevokedSession", func() {
+		s.Require().NoError(store.Delete(ctx, "session-1"), "Should delete session")
+
+		_, err := auth.Authenticate(ctx, security.Authentication{
+			Type:      AuthTypeToken,
+			Principal: tokens.AccessToken,
+		})
+		s.ErrorIs(err, result.ErrSessionRevoked, "Should reject token of a revoked session")
+	})
+}
+
 func TestJWTTokenAuthenticator(t *testing.T) {
 	suite.Run(t, new(JWTTokenAuthenticatorTestSuite))
 }
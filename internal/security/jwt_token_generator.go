@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/samber/lo"
+
 	"github.com/coldsmirk/vef-framework-go/config"
 	"github.com/coldsmirk/vef-framework-go/id"
 	"github.com/coldsmirk/vef-framework-go/security"
@@ -27,6 +29,8 @@ func NewJWTTokenGenerator(jwt *security.JWT, securityConfig *config.SecurityConf
 	}
 }
 
+// Generate issues a token pair bound to the principal's session.
+// Tokens of a principal without a session carry no session claim and are identified by their JWT ID.
 func (g *JWTTokenGenerator) Generate(principal *security.Principal) (*security.AuthTokens, error) {
 	jwtID := id.GenerateUUID()
 
@@ -54,8 +58,11 @@ func (g *JWTTokenGenerator) Generate(principal *security.Principal) (*security.A
 func (g *JWTTokenGenerator) generateAccessToken(jwtID string, principal *security.Principal) (string, error) {
 	claimsBuilder := security.NewJWTClaimsBuilder().
 		WithID(jwtID).
+		WithSessionID(lo.CoalesceOrEmpty(principal.SessionID, jwtID)).
 		WithSubject(fmt.Sprintf("%s@%s", principal.ID, principal.Name)).
 		WithRoles(principal.Roles).
+		WithTenantID(principal.TenantID).
+		WithDepartmentID(principal.DepartmentID).
 		WithDetails(principal.Details).
 		WithType(security.TokenTypeAccess)
 
@@ -65,6 +72,7 @@ func (g *JWTTokenGenerator) generateAccessToken(jwtID string, principal *securit
 func (g *JWTTokenGenerator) generateRefreshToken(jwtID string, principal *security.Principal) (string, error) {
 	claimsBuilder := security.NewJWTClaimsBuilder().
 		WithID(jwtID).
+		WithSessionID(lo.CoalesceOrEmpty(principal.SessionID, jwtID)).
 		WithSubject(fmt.Sprintf("%s@%s", principal.ID, principal.Name)).
 		WithType(security.TokenTypeRefresh)
 
@@ -116,6 +116,36 @@ func (s *JWTTokenGeneratorTestSuite) TestAccessTokenClaims() {
 	})
 }
 
+// TestSessionClaim verifies both tokens are bound to the principal's session.
+func (s *JWTTokenGeneratorTestSuite) TestSessionClaim() {
+	s.Run("WithSession", func() {
+		principal := security.NewUser("user1", "Alice")
+		principal.SessionID = "session-1"
+
+		tokens, err := s.generator.Generate(principal)
+		s.Require().NoError(err, "Should generate tokens without error")
+
+		for _, token := range []string{tokens.AccessToken, tokens.RefreshToken} {
+			claims, err := s.jwt.Parse(token)
+			s.Require().NoError(err, "Should parse token without error")
+			s.Equal("session-1", claims.SessionID(), "Should carry the principal's session")
+			s.NotEqual("session-1", claims.ID(), "JWT ID should differ from the session ID")
+		}
+	})
+
+	s.Run("WithoutSession", func() {
+		principal := security.NewUser("user1", "Alice")
+
+		tokens, err := s.generator.Generate(principal)
+		s.Require().NoError(err, "Should generate tokens without error")
+		s.Empty(principal.SessionID, "Should not modify the principal")
+
+		claims, err := s.jwt.Parse(tokens.AccessToken)
+		s.Require().NoError(err, "Should parse token without error")
+		s.Equal(claims.ID(), claims.SessionID(), "Session should default to the JWT ID")
+	})
+}
+
 // TestRefreshTokenClaims verifies refresh token carries only essential claims.
 func (s *JWTTokenGeneratorTestSuite) TestRefreshTokenClaims() {
 	principal := security.NewUser("user1", "Alice", "admin")
@@ -0,0 +1,72 @@
+package security
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// LockoutResource exposes admin endpoints to inspect and lift account lockouts and IP blocks.
+type LockoutResource struct {
+	api.Resource
+
+	loginGuard *LoginGuard
+}
+
+// NewLockoutResource creates a new lockout admin resource.
+func NewLockoutResource(loginGuard *LoginGuard) api.Resource {
+	return &LockoutResource{
+		loginGuard: loginGuard,
+		Resource: api.NewRPCResource(
+			"security/lockout",
+			api.WithOperations(
+				api.OperationSpec{Action: "get_status", PermToken: "security:lockout:query"},
+				api.OperationSpec{Action: "unlock", PermToken: "security:lockout:unlock"},
+			),
+		),
+	}
+}
+
+// LockoutParams identifies the username and client IP to act on; at least one is required.
+type LockoutParams struct {
+	api.P
+
+	Username string `json:"username" validate:"required_without=IP" label_i18n:"auth_username"`
+	IP       string `json:"ip" validate:"required_without=Username" label_i18n:"auth_ip"`
+}
+
+// LockoutStatus holds the failed login records of a username and a client IP.
+type LockoutStatus struct {
+	User *security.LoginAttempts `json:"user,omitempty"`
+	IP   *security.LoginAttempts `json:"ip,omitempty"`
+}
+
+// GetStatus returns the failed logins and lockout state of the username and the IP.
+func (r *LockoutResource) GetStatus(ctx fiber.Ctx, params LockoutParams) error {
+	if !r.loginGuard.Enabled() {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageAccountLockoutNotEnabled))
+	}
+
+	user, ip, err := r.loginGuard.Status(ctx.Context(), params.Username, params.IP)
+	if err != nil {
+		return err
+	}
+
+	return result.Ok(&LockoutStatus{User: user, IP: ip}).Response(ctx)
+}
+
+// Unlock lifts the lockout of the username and the block of the IP, and clears their failed logins.
+func (r *LockoutResource) Unlock(ctx fiber.Ctx, params LockoutParams) error {
+	if !r.loginGuard.Enabled() {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageAccountLockoutNotEnabled))
+	}
+
+	if err := r.loginGuard.Unlock(ctx.Context(), params.Username, params.IP); err != nil {
+		return err
+	}
+
+	return result.Ok().Response(ctx)
+}
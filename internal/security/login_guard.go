@@ -0,0 +1,237 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// Key prefixes separating usernames, users changing their password and client IPs in the LoginAttemptStore.
+const (
+	loginAttemptUserPrefix           = "user:"
+	loginAttemptPasswordChangePrefix = "password_change:"
+	loginAttemptIPPrefix             = "ip:"
+)
+
+// LoginGuard protects password logins against brute force.
+// It counts failed logins per username and per client IP, locks a username or blocks an IP once its failures
+// reach the configured limit, and asks for a captcha before that when failures pile up on a username.
+// Consecutive lockouts double in length up to the configured maximum.
+// Wrong current passwords given to change a password count the same way, per user ID and client IP.
+type LoginGuard struct {
+	config config.LockoutConfig
+	store  security.LoginAttemptStore
+}
+
+// NewLoginGuard creates a login guard from the lockout settings.
+// Without a LoginAttemptStore, failures are counted in memory.
+func NewLoginGuard(securityConfig *config.SecurityConfig, store security.LoginAttemptStore) *LoginGuard {
+	if securityConfig.Lockout.Enabled && store == nil {
+		store = security.NewMemoryLoginAttemptStore()
+	}
+
+	return &LoginGuard{
+		config: securityConfig.Lockout,
+		store:  store,
+	}
+}
+
+// Guards reports whether logins of the given authentication type are protected.
+func (g *LoginGuard) Guards(authType string) bool {
+	return g.config.Enabled && authType == AuthTypePassword
+}
+
+// Enabled reports whether account lockout is enabled.
+func (g *LoginGuard) Enabled() bool {
+	return g.config.Enabled
+}
+
+// Check rejects logins of a locked username or from a blocked IP, returning the lockout reason with the error,
+// and otherwise reports whether the login must solve a captcha.
+func (g *LoginGuard) Check(ctx context.Context, username, ip string) (captchaRequired bool, lockoutReason string, err error) {
+	attempts, lockoutReason, err := g.check(ctx, loginAttemptUserPrefix+username, ip)
+	if err != nil {
+		return false, lockoutReason, err
+	}
+
+	return g.config.CaptchaAttempts > 0 && attempts.Failures >= g.config.CaptchaAttempts, "", nil
+}
+
+// RecordFailure counts a failed login of the username from ip, locking out whichever reached its limit,
+// and returns the lockout reason when the failure triggered a lockout.
+func (g *LoginGuard) RecordFailure(ctx context.Context, username, ip string) (string, error) {
+	return g.recordFailure(ctx, loginAttemptUserPrefix+username, fmt.Sprintf("username %q", username), ip)
+}
+
+// RecordSuccess clears the failures and lockout history of the username after a successful login.
+// The IP counter is kept, as one valid account must not let an attacker reset the counter of its address.
+func (g *LoginGuard) RecordSuccess(ctx context.Context, username string) error {
+	return g.store.Reset(ctx, loginAttemptUserPrefix+username)
+}
+
+// CheckPasswordChange rejects password changes of a user locked out by wrong current passwords or from a blocked IP.
+// It passes everything when account lockout is disabled.
+func (g *LoginGuard) CheckPasswordChange(ctx context.Context, userID, ip string) error {
+	if !g.config.Enabled {
+		return nil
+	}
+
+	_, _, err := g.check(ctx, loginAttemptPasswordChangePrefix+userID, ip)
+
+	return err
+}
+
+// RecordPasswordChangeFailure counts a wrong current password given by the user from ip to change their password,
+// locking out whichever reached its limit.
+func (g *LoginGuard) RecordPasswordChangeFailure(ctx context.Context, userID, ip string) error {
+	if !g.config.Enabled {
+		return nil
+	}
+
+	_, err := g.recordFailure(ctx, loginAttemptPasswordChangePrefix+userID, fmt.Sprintf("password changes of user %q", userID), ip)
+
+	return err
+}
+
+// RecordPasswordChangeSuccess clears the password change failures of the user after a successful change.
+func (g *LoginGuard) RecordPasswordChangeSuccess(ctx context.Context, userID string) error {
+	if !g.config.Enabled {
+		return nil
+	}
+
+	return g.store.Reset(ctx, loginAttemptPasswordChangePrefix+userID)
+}
+
+// Status returns the failed login records of the username and the IP; an empty argument yields a nil record.
+func (g *LoginGuard) Status(ctx context.Context, username, ip string) (user, address *security.LoginAttempts, err error) {
+	if username != "" {
+		if user, err = g.store.Get(ctx, loginAttemptUserPrefix+username); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if ip != "" {
+		if address, err = g.store.Get(ctx, loginAttemptIPPrefix+ip); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return user, address, nil
+}
+
+// Unlock lifts the lockout of the username and the block of the IP, clearing their records; empty arguments are skipped.
+func (g *LoginGuard) Unlock(ctx context.Context, username, ip string) error {
+	if username != "" {
+		if err := g.store.Reset(ctx, loginAttemptUserPrefix+username); err != nil {
+			return err
+		}
+	}
+
+	if ip != "" {
+		if err := g.store.Reset(ctx, loginAttemptIPPrefix+ip); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// check rejects attempts on the locked key or from a blocked IP, returning the lockout reason with the error,
+// and otherwise returns the record of the key.
+func (g *LoginGuard) check(ctx context.Context, key, ip string) (*security.LoginAttempts, string, error) {
+	now := time.Now()
+
+	if g.guardsIP(ip) {
+		attempts, err := g.store.Get(ctx, loginAttemptIPPrefix+ip)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if attempts.IsLocked(now) {
+			return nil, security.LockoutReasonIPBlocked, result.ErrIPBlocked
+		}
+	}
+
+	attempts, err := g.store.Get(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if attempts.IsLocked(now) {
+		return nil, security.LockoutReasonAccountLocked, result.ErrAccountLocked
+	}
+
+	return attempts, "", nil
+}
+
+// recordFailure counts a failure of key and of ip, locking out whichever reached its limit,
+// and returns the lockout reason when the failure triggered a lockout. The subject names the key in logs.
+func (g *LoginGuard) recordFailure(ctx context.Context, key, subject, ip string) (string, error) {
+	var lockoutReason string
+
+	locked, err := g.addFailure(ctx, key, g.config.MaxAttempts)
+	if err != nil {
+		return "", err
+	}
+
+	if locked {
+		logger.Warnf("Locked %s after %d failed attempts", subject, g.config.MaxAttempts)
+
+		lockoutReason = security.LockoutReasonAccountLocked
+	}
+
+	if g.guardsIP(ip) {
+		blocked, err := g.addFailure(ctx, loginAttemptIPPrefix+ip, g.config.IPMaxAttempts)
+		if err != nil {
+			return "", err
+		}
+
+		if blocked {
+			logger.Warnf("Blocked IP %q after %d failed attempts", ip, g.config.IPMaxAttempts)
+
+			if lockoutReason == "" {
+				lockoutReason = security.LockoutReasonIPBlocked
+			}
+		}
+	}
+
+	return lockoutReason, nil
+}
+
+func (g *LoginGuard) guardsIP(ip string) bool {
+	return ip != "" && g.config.IPMaxAttempts > 0
+}
+
+// addFailure counts a failure of key and locks key once its failures reach limit, reporting whether it did.
+func (g *LoginGuard) addFailure(ctx context.Context, key string, limit int) (bool, error) {
+	failures, err := g.store.AddFailure(ctx, key, g.config.Window)
+	if err != nil || failures < limit {
+		return false, err
+	}
+
+	lockouts, err := g.store.AddLockout(ctx, key, g.config.MaxDuration)
+	if err != nil {
+		return false, err
+	}
+
+	if err := g.store.Lock(ctx, key, time.Now().Add(g.lockDuration(lockouts))); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// lockDuration returns the duration of the given consecutive lockout: the base duration doubled for each
+// earlier lockout, capped at the maximum duration.
+func (g *LoginGuard) lockDuration(lockouts int) time.Duration {
+	duration := g.config.Duration
+	for i := 1; i < lockouts && duration < g.config.MaxDuration; i++ {
+		duration *= 2
+	}
+
+	return min(duration, g.config.MaxDuration)
+}
@@ -0,0 +1,177 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+type LoginGuardTestSuite struct {
+	suite.Suite
+
+	ctx   context.Context
+	guard *LoginGuard
+}
+
+func (s *LoginGuardTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.guard = NewLoginGuard(&config.SecurityConfig{
+		Lockout: config.LockoutConfig{
+			Enabled:         true,
+			MaxAttempts:     3,
+			IPMaxAttempts:   5,
+			CaptchaAttempts: 2,
+			Window:          time.Minute,
+			Duration:        time.Minute,
+			MaxDuration:     3 * time.Minute,
+		},
+	}, nil)
+}
+
+// fail records n failed logins of the username from ip and returns the lockout reason of the last one.
+func (s *LoginGuardTestSuite) fail(username, ip string, n int) string {
+	var reason string
+
+	for range n {
+		var err error
+
+		reason, err = s.guard.RecordFailure(s.ctx, username, ip)
+		s.Require().NoError(err, "Should record failure")
+	}
+
+	return reason
+}
+
+// TestGuards verifies only password logins are guarded, and only when enabled.
+func (s *LoginGuardTestSuite) TestGuards() {
+	s.True(s.guard.Guards(AuthTypePassword), "Should guard password logins")
+	s.False(s.guard.Guards(AuthTypeOIDC), "Should not guard other logins")
+
+	disabled := NewLoginGuard(&config.SecurityConfig{}, nil)
+	s.False(disabled.Enabled(), "Should be disabled by default")
+	s.False(disabled.Guards(AuthTypePassword), "Should not guard when disabled")
+}
+
+// TestCaptcha verifies a captcha is required once failures reach the captcha threshold.
+func (s *LoginGuardTestSuite) TestCaptcha() {
+	s.Empty(s.fail("alice", "10.0.0.1", 1), "One failure should not lock")
+
+	captcha, _, err := s.guard.Check(s.ctx, "alice", "10.0.0.1")
+	s.Require().NoError(err, "Should pass the check")
+	s.False(captcha, "Should not require a captcha below the threshold")
+
+	s.fail("alice", "10.0.0.1", 1)
+
+	captcha, _, err = s.guard.Check(s.ctx, "alice", "10.0.0.1")
+	s.Require().NoError(err, "Should pass the check")
+	s.True(captcha, "Should require a captcha at the threshold")
+}
+
+// TestAccountLockout verifies the username locks at the limit and consecutive lockouts grow longer.
+func (s *LoginGuardTestSuite) TestAccountLockout() {
+	s.Equal(security.LockoutReasonAccountLocked, s.fail("alice", "10.0.0.1", 3), "Reaching the limit should lock the username")
+
+	_, reason, err := s.guard.Check(s.ctx, "alice", "10.0.0.2")
+	s.ErrorIs(err, result.ErrAccountLocked, "Locked username should be rejected from any IP")
+	s.Equal(security.LockoutReasonAccountLocked, reason, "Should report the lockout reason")
+
+	_, _, err = s.guard.Check(s.ctx, "bob", "10.0.0.1")
+	s.NoError(err, "Other usernames should not be locked")
+
+	user, _, err := s.guard.Status(s.ctx, "alice", "")
+	s.Require().NoError(err, "Should get status")
+	s.Equal(1, user.Lockouts, "Should count the lockout")
+	s.Zero(user.Failures, "Lockout should clear failures")
+	s.WithinDuration(time.Now().Add(time.Minute), user.LockedUntil.Unwrap(), 5*time.Second, "First lockout should last the base duration")
+
+	s.Run("ProgressiveDuration", func() {
+		s.Equal(time.Minute, s.guard.lockDuration(1), "First lockout should last the base duration")
+		s.Equal(2*time.Minute, s.guard.lockDuration(2), "Second lockout should last twice as long")
+		s.Equal(3*time.Minute, s.guard.lockDuration(3), "Lockouts should be capped at the maximum duration")
+		s.Equal(3*time.Minute, s.guard.lockDuration(10), "Lockouts should stay at the maximum duration")
+	})
+}
+
+// TestIPBlock verifies an IP is blocked once failures across usernames reach the IP limit.
+func (s *LoginGuardTestSuite) TestIPBlock() {
+	for _, username := range []string{"u1", "u2", "u3", "u4"} {
+		s.Empty(s.fail(username, "10.0.0.9", 1), "Failures below the IP limit should not block")
+	}
+
+	s.Equal(security.LockoutReasonIPBlocked, s.fail("u5", "10.0.0.9", 1), "Reaching the IP limit should block the IP")
+
+	_, reason, err := s.guard.Check(s.ctx, "u6", "10.0.0.9")
+	s.ErrorIs(err, result.ErrIPBlocked, "Blocked IP should be rejected for any username")
+	s.Equal(security.LockoutReasonIPBlocked, reason, "Should report the block reason")
+
+	_, _, err = s.guard.Check(s.ctx, "u6", "10.0.0.10")
+	s.NoError(err, "Other IPs should not be blocked")
+}
+
+// TestRecordSuccess verifies a successful login clears the username but not the IP.
+func (s *LoginGuardTestSuite) TestRecordSuccess() {
+	s.fail("alice", "10.0.0.1", 2)
+	s.Require().NoError(s.guard.RecordSuccess(s.ctx, "alice"), "Should record success")
+
+	user, ip, err := s.guard.Status(s.ctx, "alice", "10.0.0.1")
+	s.Require().NoError(err, "Should get status")
+	s.Zero(user.Failures, "Success should clear the username failures")
+	s.Equal(2, ip.Failures, "Success should keep the IP failures")
+}
+
+// TestPasswordChange verifies wrong current passwords lock password changes of the user apart from logins.
+func (s *LoginGuardTestSuite) TestPasswordChange() {
+	for range 3 {
+		s.Require().NoError(s.guard.RecordPasswordChangeFailure(s.ctx, "u1", "10.0.0.1"), "Should record failure")
+	}
+
+	s.ErrorIs(s.guard.CheckPasswordChange(s.ctx, "u1", "10.0.0.2"), result.ErrAccountLocked, "Locked user should be rejected from any IP")
+	s.NoError(s.guard.CheckPasswordChange(s.ctx, "u2", "10.0.0.2"), "Other users should not be locked")
+
+	_, _, err := s.guard.Check(s.ctx, "u1", "10.0.0.2")
+	s.NoError(err, "Password change failures should not lock logins")
+
+	_, ip, err := s.guard.Status(s.ctx, "", "10.0.0.1")
+	s.Require().NoError(err, "Should get status")
+	s.Equal(3, ip.Failures, "Password change failures should count towards the IP")
+
+	s.Run("Success", func() {
+		s.Require().NoError(s.guard.RecordPasswordChangeFailure(s.ctx, "u2", ""), "Should record failure")
+		s.Require().NoError(s.guard.RecordPasswordChangeSuccess(s.ctx, "u2"), "Should record success")
+
+		attempts, err := s.guard.store.Get(s.ctx, loginAttemptPasswordChangePrefix+"u2")
+		s.Require().NoError(err, "Should get attempts")
+		s.Zero(attempts.Failures, "Success should clear the failures")
+	})
+
+	s.Run("Disabled", func() {
+		disabled := NewLoginGuard(&config.SecurityConfig{}, nil)
+		s.NoError(disabled.RecordPasswordChangeFailure(s.ctx, "u1", "10.0.0.1"), "Should skip recording when disabled")
+		s.NoError(disabled.CheckPasswordChange(s.ctx, "u1", "10.0.0.1"), "Should pass when disabled")
+	})
+}
+
+// TestUnlock verifies admins can lift lockouts and blocks.
+func (s *LoginGuardTestSuite) TestUnlock() {
+	s.fail("alice", "10.0.0.1", 3)
+
+	s.Require().NoError(s.guard.Unlock(s.ctx, "alice", "10.0.0.1"), "Should unlock")
+
+	_, _, err := s.guard.Check(s.ctx, "alice", "10.0.0.1")
+	s.NoError(err, "Unlocked username should log in again")
+
+	user, ip, err := s.guard.Status(s.ctx, "alice", "10.0.0.1")
+	s.Require().NoError(err, "Should get status")
+	s.Equal(&security.LoginAttempts{}, user, "Unlock should clear the username record")
+	s.Equal(&security.LoginAttempts{}, ip, "Unlock should clear the IP record")
+}
+
+func TestLoginGuard(t *testing.T) {
+	suite.Run(t, new(LoginGuardTestSuite))
+}
@@ -152,3 +152,14 @@ func (m *MockNonceStore) StoreIfAbsent(ctx context.Context, appID, nonce string,
 
 	return args.Bool(0), args.Error(1)
 }
+
+// MockPolicyResourceLoader is a mock implementation of security.PolicyResourceLoader.
+type MockPolicyResourceLoader struct {
+	mock.Mock
+}
+
+func (m *MockPolicyResourceLoader) LoadResource(ctx context.Context, request *security.PolicyRequest) (any, error) {
+	args := m.Called(ctx, request)
+
+	return args.Get(0), args.Error(1)
+}
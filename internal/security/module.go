@@ -1,10 +1,13 @@
 package security
 
 import (
-	"github.com/samber/lo"
+	"context"
+	"time"
+
 	"go.uber.org/fx"
 
 	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/contextx"
 	"github.com/coldsmirk/vef-framework-go/event"
 	"github.com/coldsmirk/vef-framework-go/internal/logx"
 	"github.com/coldsmirk/vef-framework-go/password"
@@ -28,6 +31,29 @@ var Module = fx.Module(
 			cfg.RefreshRateLimit = 1
 		}
 
+		lockout := &cfg.Lockout
+		if lockout.MaxAttempts <= 0 {
+			lockout.MaxAttempts = 5
+		}
+
+		if lockout.IPMaxAttempts == 0 {
+			lockout.IPMaxAttempts = 50
+		}
+
+		if lockout.Window <= 0 {
+			lockout.Window = 15 * time.Minute
+		}
+
+		if lockout.Duration <= 0 {
+			lockout.Duration = 15 * time.Minute
+		}
+
+		if lockout.MaxDuration <= 0 {
+			lockout.MaxDuration = 24 * time.Hour
+		}
+
+		lockout.MaxDuration = max(lockout.MaxDuration, lockout.Duration)
+
 		return cfg
 	}),
 	fx.Decorate(
@@ -37,25 +63,30 @@ var Module = fx.Module(
 					return nil
 				}
 
-				return security.NewCachedRolePermissionsLoader(loader, bus)
+				// Roles of different tenants may share names, so their permissions are cached per tenant.
+				return security.NewCachedRolePermissionsLoader(loader, bus, security.WithCacheScope(tenantCacheScope))
 			},
 			fx.ParamTags(`optional:"true"`),
 		),
 	),
 	fx.Provide(
 		password.NewBcryptEncoder,
-		func(config *config.AppConfig) (*security.JWT, error) {
-			return security.NewJWT(&security.JWTConfig{
-				Audience: lo.SnakeCase(config.Name),
-			})
+		func(jwtConfig *security.JWTConfig, securityConfig *config.SecurityConfig) (*security.JWT, error) {
+			// Retired keys keep verifying until the longest-lived tokens they signed have expired.
+			if jwtConfig.GracePeriod <= 0 {
+				jwtConfig.GracePeriod = securityConfig.TokenExpires
+			}
+
+			return security.NewJWT(jwtConfig)
 		},
 		fx.Annotate(
 			NewJWTAuthenticator,
+			fx.ParamTags(``, `optional:"true"`),
 			fx.ResultTags(`group:"vef:security:authenticators"`),
 		),
 		fx.Annotate(
 			NewJWTRefreshAuthenticator,
-			fx.ParamTags(``, `optional:"true"`),
+			fx.ParamTags(``, `optional:"true"`, `optional:"true"`),
 			fx.ResultTags(`group:"vef:security:authenticators"`),
 		),
 		NewJWTTokenGenerator,
@@ -70,6 +101,41 @@ var Module = fx.Module(
 			fx.ParamTags(`optional:"true"`, `optional:"true"`),
 			fx.ResultTags(`group:"vef:security:authenticators"`),
 		),
+		fx.Annotate(
+			NewOIDCAuthenticator,
+			fx.ParamTags(``, ``, `optional:"true"`, `optional:"true"`),
+		),
+		fx.Annotate(
+			func(authenticator *OIDCAuthenticator) security.Authenticator { return authenticator },
+			fx.ResultTags(`group:"vef:security:authenticators"`),
+		),
+		fx.Annotate(
+			security.NewWebAuthn,
+			fx.ParamTags(``, ``, `optional:"true"`, `optional:"true"`),
+		),
+		fx.Annotate(
+			NewWebAuthnAuthenticator,
+			fx.ParamTags(``, `optional:"true"`),
+			fx.ResultTags(`group:"vef:security:authenticators"`),
+		),
+		fx.Annotate(
+			// API key secrets are not hashed with the password encoder, see security.NewAPIKeys.
+			security.NewAPIKeys,
+			fx.ParamTags(`optional:"true"`, `name:"vef:security:api_key_encoder" optional:"true"`),
+		),
+		fx.Annotate(
+			NewAPIKeyAuthenticator,
+			fx.ParamTags(``, `optional:"true"`, `optional:"true"`),
+			fx.ResultTags(`group:"vef:security:authenticators"`),
+		),
+		fx.Annotate(
+			security.NewPasswordPolicy,
+			fx.ParamTags(``, ``, `optional:"true"`),
+		),
+		fx.Annotate(
+			NewLoginGuard,
+			fx.ParamTags(``, `optional:"true"`),
+		),
 		fx.Annotate(
 			NewAuthManager,
 			fx.ParamTags(`group:"vef:security:authenticators"`),
@@ -82,9 +148,58 @@ var Module = fx.Module(
 			NewRBACDataPermissionResolver,
 			fx.ParamTags(`optional:"true"`),
 		),
+		fx.Annotate(
+			NewExprPolicyEvaluator,
+			fx.ParamTags(`optional:"true"`, `optional:"true"`),
+		),
 		fx.Annotate(
 			NewAuthResource,
 			fx.ResultTags(`group:"vef:api:resources"`),
 		),
+		fx.Annotate(
+			NewJWKSMiddleware,
+			fx.ResultTags(`group:"vef:app:middlewares"`),
+		),
+		fx.Annotate(
+			NewSessionResource,
+			fx.ParamTags(`optional:"true"`),
+			fx.ResultTags(`group:"vef:api:resources"`),
+		),
+		fx.Annotate(
+			NewLockoutResource,
+			fx.ResultTags(`group:"vef:api:resources"`),
+		),
+		fx.Annotate(
+			NewPolicyResource,
+			fx.ResultTags(`group:"vef:api:resources"`),
+		),
+		fx.Annotate(
+			NewWebAuthnResource,
+			fx.ResultTags(`group:"vef:api:resources"`),
+		),
+		fx.Annotate(
+			NewPasswordResource,
+			fx.ParamTags(``, ``, ``, `optional:"true"`),
+			fx.ResultTags(`group:"vef:api:resources"`),
+		),
+		fx.Annotate(
+			NewAPIKeyResource,
+			fx.ParamTags(``, ``, `optional:"true"`),
+			fx.ResultTags(`group:"vef:api:resources"`),
+		),
 	),
 )
+
+// tenantCacheScope scopes cached entries by the tenant of the request. Permissions are checked before the tenant
+// of the request is resolved, so it falls back to the tenant of the principal.
+func tenantCacheScope(ctx context.Context) string {
+	if tenantID := contextx.TenantID(ctx); tenantID != "" {
+		return tenantID
+	}
+
+	if principal := contextx.Principal(ctx); principal != nil {
+		return principal.TenantID
+	}
+
+	return ""
+}
@@ -0,0 +1,212 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"maps"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cast"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/id"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+const (
+	// AuthTypeOIDC is the authentication type for OpenID Connect logins.
+	AuthTypeOIDC = "oidc"
+
+	// OIDCFlowExpires bounds the time the user has to sign in at the provider.
+	OIDCFlowExpires = 10 * time.Minute
+
+	claimOIDCState        = "stt"
+	claimOIDCNonce        = "nnc"
+	claimOIDCCodeVerifier = "cvf"
+)
+
+// OIDCAuthenticator signs users in with OpenID Connect providers using the authorization code flow with PKCE.
+// The flow state (state, nonce and code verifier) travels in a short-lived signed flow token held by the client,
+// so no server-side storage is needed between redirecting the user to the provider and the callback.
+type OIDCAuthenticator struct {
+	jwt       *security.JWT
+	mapper    security.ExternalIdentityMapper
+	providers map[string]*oidcProvider
+}
+
+// NewOIDCAuthenticator creates an OpenID Connect authenticator for the configured providers.
+// Without an ExternalIdentityMapper, identities are linked to local users by username through userLoader.
+func NewOIDCAuthenticator(
+	jwt *security.JWT,
+	oidcConfig *config.OIDCConfig,
+	mapper security.ExternalIdentityMapper,
+	userLoader security.UserLoader,
+) *OIDCAuthenticator {
+	if mapper == nil && userLoader != nil {
+		mapper = security.NewUserLoaderIdentityMapper(userLoader)
+	}
+
+	client := &http.Client{Timeout: oidcRequestTimeout}
+	providers := make(map[string]*oidcProvider, len(oidcConfig.Providers))
+
+	for _, providerConfig := range oidcConfig.Providers {
+		providers[providerConfig.Name] = newOIDCProvider(providerConfig, client)
+	}
+
+	return &OIDCAuthenticator{
+		jwt:       jwt,
+		mapper:    mapper,
+		providers: providers,
+	}
+}
+
+func (*OIDCAuthenticator) Supports(authType string) bool {
+	return authType == AuthTypeOIDC
+}
+
+// Authorize starts a login with the named provider.
+func (a *OIDCAuthenticator) Authorize(ctx context.Context, providerName string) (*security.OIDCAuthorization, error) {
+	provider, ok := a.providers[providerName]
+	if !ok {
+		return nil, result.ErrOIDCProviderNotFound
+	}
+
+	var (
+		state        = rand.Text()
+		nonce        = rand.Text()
+		codeVerifier = newPKCEVerifier()
+	)
+
+	authorizationURL, err := provider.authorizationURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		logger.Warnf("Failed to start login with OIDC provider %q: %v", providerName, err)
+
+		return nil, result.ErrOIDCLoginFailed
+	}
+
+	claimsBuilder := security.NewJWTClaimsBuilder().
+		WithID(id.GenerateUUID()).
+		WithSubject(providerName).
+		WithType(security.TokenTypeOIDCFlow).
+		WithClaim(claimOIDCState, state).
+		WithClaim(claimOIDCNonce, nonce).
+		WithClaim(claimOIDCCodeVerifier, codeVerifier)
+
+	flowToken, err := a.jwt.Generate(claimsBuilder, OIDCFlowExpires, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &security.OIDCAuthorization{
+		AuthorizationURL: authorizationURL,
+		FlowToken:        flowToken,
+	}, nil
+}
+
+// Authenticate completes a login started by Authorize.
+// The principal is the flow token and the credentials are the *security.OIDCCredentials of the provider callback.
+func (a *OIDCAuthenticator) Authenticate(ctx context.Context, authentication security.Authentication) (*security.Principal, error) {
+	if a.mapper == nil {
+		return nil, result.ErrNotImplemented(i18n.T(result.ErrMessageUserLoaderNotImplemented))
+	}
+
+	credentials, ok := authentication.Credentials.(*security.OIDCCredentials)
+	if !ok || credentials == nil {
+		return nil, result.ErrCredentialsInvalid(i18n.T(result.ErrMessageCredentialsFormatInvalid))
+	}
+
+	if credentials.Code == "" || credentials.State == "" {
+		return nil, result.ErrCredentialsInvalid(i18n.T(result.ErrMessageCredentialsFieldsRequired))
+	}
+
+	claimsAccessor, err := a.jwt.Parse(authentication.Principal)
+	if err != nil || claimsAccessor.Type() != security.TokenTypeOIDCFlow {
+		return nil, result.ErrOIDCFlowInvalid
+	}
+
+	providerName := claimsAccessor.Subject()
+
+	provider, ok := a.providers[providerName]
+	if !ok {
+		return nil, result.ErrOIDCProviderNotFound
+	}
+
+	// The state binds the callback to the browser that started the flow, defeating login CSRF.
+	state := cast.ToString(claimsAccessor.Claim(claimOIDCState))
+	if subtle.ConstantTimeCompare([]byte(state), []byte(credentials.State)) != 1 {
+		return nil, result.ErrOIDCFlowInvalid
+	}
+
+	identity, err := a.resolveIdentity(
+		ctx,
+		provider,
+		credentials.Code,
+		cast.ToString(claimsAccessor.Claim(claimOIDCCodeVerifier)),
+		cast.ToString(claimsAccessor.Claim(claimOIDCNonce)),
+	)
+	if err != nil {
+		logger.Warnf("OIDC login with provider %q failed: %v", providerName, err)
+
+		return nil, result.ErrOIDCLoginFailed
+	}
+
+	principal, err := a.mapper.Map(ctx, identity)
+	if err != nil {
+		logger.Infof("Failed to map OIDC identity %q of provider %q: %v", identity.Subject, providerName, err)
+
+		return nil, err
+	}
+
+	if principal == nil {
+		return nil, result.ErrExternalIdentityNotLinked
+	}
+
+	logger.Infof("OIDC authentication successful for principal %q via provider %q", principal.ID, providerName)
+
+	return principal, nil
+}
+
+// resolveIdentity redeems the authorization code and returns the identity asserted by the verified ID token,
+// completed with the userinfo claims.
+func (*OIDCAuthenticator) resolveIdentity(ctx context.Context, provider *oidcProvider, code, codeVerifier, nonce string) (*security.ExternalIdentity, error) {
+	tokens, err := provider.exchange(ctx, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := provider.verifyIDToken(ctx, tokens.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil {
+		return nil, err
+	}
+
+	if subject == "" {
+		return nil, jwt.ErrTokenRequiredClaimMissing
+	}
+
+	userInfo, err := provider.userInfo(ctx, tokens.AccessToken, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string]any, len(claims)+len(userInfo))
+	maps.Copy(merged, claims)
+
+	// Token claims are authoritative; userinfo only adds the profile claims the ID token left out.
+	for key, value := range userInfo {
+		if _, exists := merged[key]; !exists {
+			merged[key] = value
+		}
+	}
+
+	return provider.identity(merged), nil
+}
@@ -0,0 +1,490 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+const (
+	testOIDCProvider     = "corp"
+	testOIDCClientID     = "vef-app"
+	testOIDCClientSecret = "s3cret"
+	testOIDCRedirectURL  = "https://app.example.com/oidc/callback"
+)
+
+// testIDPGrant is an authorization code issued by the stand-in provider.
+type testIDPGrant struct {
+	nonce         string
+	codeChallenge string
+}
+
+// testIDP is a minimal OpenID Connect provider serving discovery, token, JWKS and userinfo endpoints.
+// Tests sign the user in by approving the authorization URL, which yields the code the provider redirects back with.
+type testIDP struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	key      *rsa.PrivateKey
+	keyID    string
+	grants   map[string]testIDPGrant
+	tokens   map[string]string
+	subject  string
+	username string
+	// idTokenClaims overrides claims of issued ID tokens.
+	idTokenClaims jwt.MapClaims
+}
+
+func newTestIDP(t *testing.T) *testIDP {
+	t.Helper()
+
+	idp := &testIDP{
+		grants:   make(map[string]testIDPGrant),
+		tokens:   make(map[string]string),
+		subject:  "248289761001",
+		username: "alice",
+	}
+	idp.rotateKey(t, "idp-key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", idp.handleDiscovery)
+	mux.HandleFunc("POST /token", idp.handleToken)
+	mux.HandleFunc("GET /jwks", idp.handleJWKS)
+	mux.HandleFunc("GET /userinfo", idp.handleUserInfo)
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (p *testIDP) rotateKey(t *testing.T, keyID string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate provider key: %v", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.key = key
+	p.keyID = keyID
+}
+
+// overrideIDTokenClaims makes the provider issue ID tokens with the given claims replaced.
+func (p *testIDP) overrideIDTokenClaims(claims jwt.MapClaims) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.idTokenClaims = claims
+}
+
+func (p *testIDP) providerConfig() config.OIDCProviderConfig {
+	return config.OIDCProviderConfig{
+		Name:         testOIDCProvider,
+		Issuer:       p.server.URL,
+		ClientID:     testOIDCClientID,
+		ClientSecret: testOIDCClientSecret,
+		RedirectURL:  testOIDCRedirectURL,
+	}
+}
+
+// approve signs the user in for the given authorization URL and returns the callback code and state.
+func (p *testIDP) approve(authorizationURL string) (code, state string) {
+	parsed, _ := url.Parse(authorizationURL)
+	query := parsed.Query()
+	code = rand.Text()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.grants[code] = testIDPGrant{
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+
+	return code, query.Get("state")
+}
+
+func (p *testIDP) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeTestJSON(w, http.StatusOK, map[string]any{
+		"issuer":                 p.server.URL,
+		"authorization_endpoint": p.server.URL + "/authorize",
+		"token_endpoint":         p.server.URL + "/token",
+		"userinfo_endpoint":      p.server.URL + "/userinfo",
+		"jwks_uri":               p.server.URL + "/jwks",
+	})
+}
+
+func (p *testIDP) handleToken(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	clientID, clientSecret, _ := r.BasicAuth()
+	if clientID != testOIDCClientID || clientSecret != testOIDCClientSecret {
+		writeTestJSON(w, http.StatusUnauthorized, map[string]any{"error": "invalid_client"})
+
+		return
+	}
+
+	code := r.PostFormValue("code")
+	grant, ok := p.grants[code]
+	delete(p.grants, code)
+
+	if !ok ||
+		r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != testOIDCRedirectURL ||
+		pkceChallenge(r.PostFormValue("code_verifier")) != grant.codeChallenge {
+		writeTestJSON(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant"})
+
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   p.server.URL,
+		"sub":   p.subject,
+		"aud":   testOIDCClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": grant.nonce,
+		"name":  "Alice Liddell",
+	}
+	for key, value := range p.idTokenClaims {
+		claims[key] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = p.keyID
+
+	idToken, _ := token.SignedString(p.key)
+	accessToken := rand.Text()
+	p.tokens[accessToken] = p.subject
+
+	writeTestJSON(w, http.StatusOK, map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func (p *testIDP) handleJWKS(w http.ResponseWriter, _ *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	writeTestJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]any{{
+			"kty": "RSA",
+			"kid": p.keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   encodeTestJWKInt(p.key.N.Bytes()),
+			"e":   encodeTestJWKInt([]byte{1, 0, 1}),
+		}},
+	})
+}
+
+func (p *testIDP) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	subject, ok := p.tokens[r.Header.Get("Authorization")[len("Bearer "):]]
+	if !ok {
+		writeTestJSON(w, http.StatusUnauthorized, map[string]any{"error": "invalid_token"})
+
+		return
+	}
+
+	writeTestJSON(w, http.StatusOK, map[string]any{
+		"sub":                subject,
+		"preferred_username": p.username,
+		"email":              "alice@example.com",
+		"email_verified":     true,
+		"name":               "Alice from userinfo",
+	})
+}
+
+func writeTestJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func encodeTestJWKInt(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// MockExternalIdentityMapper is a mock implementation of security.ExternalIdentityMapper.
+type MockExternalIdentityMapper struct {
+	mock.Mock
+}
+
+func (m *MockExternalIdentityMapper) Map(ctx context.Context, identity *security.ExternalIdentity) (*security.Principal, error) {
+	args := m.Called(ctx, identity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*security.Principal), args.Error(1)
+}
+
+type OIDCAuthenticatorTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+	jwt *security.JWT
+	idp *testIDP
+}
+
+func (s *OIDCAuthenticatorTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.jwt = newTestJWT(s.T())
+	s.idp = newTestIDP(s.T())
+}
+
+func (s *OIDCAuthenticatorTestSuite) newAuthenticator(mapper security.ExternalIdentityMapper, loader security.UserLoader) *OIDCAuthenticator {
+	return NewOIDCAuthenticator(s.jwt, &config.OIDCConfig{
+		Providers: []config.OIDCProviderConfig{s.idp.providerConfig()},
+	}, mapper, loader)
+}
+
+// signIn runs the flow up to the provider callback and returns the flow token with the callback credentials.
+func (s *OIDCAuthenticatorTestSuite) signIn(auth *OIDCAuthenticator) (string, *security.OIDCCredentials) {
+	authorization, err := auth.Authorize(s.ctx, testOIDCProvider)
+	s.Require().NoError(err, "Should start the login")
+
+	code, state := s.idp.approve(authorization.AuthorizationURL)
+
+	return authorization.FlowToken, &security.OIDCCredentials{Code: code, State: state}
+}
+
+func (s *OIDCAuthenticatorTestSuite) authenticate(auth *OIDCAuthenticator, flowToken string, credentials *security.OIDCCredentials) (*security.Principal, error) {
+	return auth.Authenticate(s.ctx, security.Authentication{
+		Type:        AuthTypeOIDC,
+		Principal:   flowToken,
+		Credentials: credentials,
+	})
+}
+
+func (s *OIDCAuthenticatorTestSuite) assertErrCode(err error, code int, msg string) {
+	s.Require().Error(err, msg)
+
+	resErr, ok := result.AsErr(err)
+	s.Require().True(ok, "Should return a result.Error")
+	s.Equal(code, resErr.Code, msg)
+}
+
+// TestSupports verifies type matching.
+func (s *OIDCAuthenticatorTestSuite) TestSupports() {
+	auth := s.newAuthenticator(nil, nil)
+	s.True(auth.Supports(AuthTypeOIDC), "Should support oidc type")
+	s.False(auth.Supports(AuthTypePassword), "Should not support password type")
+}
+
+// TestAuthorize verifies the authorization URL requests a PKCE-protected code flow.
+func (s *OIDCAuthenticatorTestSuite) TestAuthorize() {
+	auth := s.newAuthenticator(nil, new(MockUserLoader))
+
+	authorization, err := auth.Authorize(s.ctx, testOIDCProvider)
+	s.Require().NoError(err, "Should start the login")
+	s.NotEmpty(authorization.FlowToken, "Should return a flow token")
+
+	parsed, err := url.Parse(authorization.AuthorizationURL)
+	s.Require().NoError(err, "Should return a valid URL")
+	s.Equal(s.idp.server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path, "Should use the discovered authorization endpoint")
+
+	query := parsed.Query()
+	s.Equal("code", query.Get("response_type"), "Should request an authorization code")
+	s.Equal(testOIDCClientID, query.Get("client_id"), "Should send the client ID")
+	s.Equal(testOIDCRedirectURL, query.Get("redirect_uri"), "Should send the redirect URL")
+	s.Equal("openid profile email", query.Get("scope"), "Should request the default scopes")
+	s.Equal("S256", query.Get("code_challenge_method"), "Should use the S256 PKCE method")
+	s.NotEmpty(query.Get("code_challenge"), "Should send a code challenge")
+	s.NotEmpty(query.Get("state"), "Should send a state")
+	s.NotEmpty(query.Get("nonce"), "Should send a nonce")
+
+	s.Run("UnknownProvider", func() {
+		_, err := auth.Authorize(s.ctx, "unknown")
+		s.assertErrCode(err, result.ErrCodeOIDCProviderNotFound, "Should reject an unknown provider")
+	})
+
+	s.Run("DiscoveryIssuerMismatch", func() {
+		providerConfig := s.idp.providerConfig()
+		providerConfig.Issuer += "/"
+
+		auth := NewOIDCAuthenticator(s.jwt, &config.OIDCConfig{
+			Providers: []config.OIDCProviderConfig{providerConfig},
+		}, nil, nil)
+
+		_, err := auth.Authorize(s.ctx, testOIDCProvider)
+		s.assertErrCode(err, result.ErrCodeOIDCLoginFailed, "Should reject a provider announcing another issuer")
+	})
+}
+
+// TestAuthenticate verifies the callback is validated end to end before the identity is mapped.
+func (s *OIDCAuthenticatorTestSuite) TestAuthenticate() {
+	alice := security.NewUser("user1", "Alice", "admin")
+
+	s.Run("Success", func() {
+		loader := new(MockUserLoader)
+		loader.On("LoadByUsername", mock.Anything, "alice").Return(alice, "", nil).Once()
+		auth := s.newAuthenticator(nil, loader)
+
+		flowToken, credentials := s.signIn(auth)
+		principal, err := s.authenticate(auth, flowToken, credentials)
+		s.Require().NoError(err, "Should authenticate successfully")
+		s.Equal("user1", principal.ID, "Should return the linked local user")
+		loader.AssertExpectations(s.T())
+	})
+
+	s.Run("IdentityClaims", func() {
+		mapper := new(MockExternalIdentityMapper)
+		mapper.On("Map", mock.Anything, mock.MatchedBy(func(identity *security.ExternalIdentity) bool {
+			return identity.Provider == testOIDCProvider &&
+				identity.Subject == s.idp.subject &&
+				identity.Username == "alice" &&
+				identity.Email == "alice@example.com" &&
+				identity.EmailVerified &&
+				identity.Name == "Alice Liddell"
+		})).Return(alice, nil).Once()
+		auth := s.newAuthenticator(mapper, nil)
+
+		flowToken, credentials := s.signIn(auth)
+		_, err := s.authenticate(auth, flowToken, credentials)
+		s.Require().NoError(err, "Should map the identity from ID token and userinfo claims, preferring the ID token")
+		mapper.AssertExpectations(s.T())
+	})
+
+	s.Run("ProvisioningMapper", func() {
+		provisioned := security.NewUser("user2", "Bob")
+		mapper := new(MockExternalIdentityMapper)
+		mapper.On("Map", mock.Anything, mock.Anything).Return(provisioned, nil).Once()
+		loader := new(MockUserLoader)
+		auth := s.newAuthenticator(mapper, loader)
+
+		flowToken, credentials := s.signIn(auth)
+		principal, err := s.authenticate(auth, flowToken, credentials)
+		s.Require().NoError(err, "Should authenticate with the custom mapper")
+		s.Equal("user2", principal.ID, "Should return the principal of the custom mapper")
+		loader.AssertNotCalled(s.T(), "LoadByUsername", mock.Anything, mock.Anything)
+	})
+
+	s.Run("UnlinkedIdentity", func() {
+		loader := new(MockUserLoader)
+		loader.On("LoadByUsername", mock.Anything, "alice").Return(nil, "", result.ErrRecordNotFound).Once()
+		auth := s.newAuthenticator(nil, loader)
+
+		flowToken, credentials := s.signIn(auth)
+		_, err := s.authenticate(auth, flowToken, credentials)
+		s.assertErrCode(err, result.ErrCodeExternalIdentityNotLinked, "Should reject an identity without local user")
+	})
+
+	s.Run("NoMapper", func() {
+		auth := s.newAuthenticator(nil, nil)
+
+		_, err := s.authenticate(auth, "token", &security.OIDCCredentials{Code: "code", State: "state"})
+		s.assertErrCode(err, result.ErrCodeNotImplemented, "Should require a mapper or user loader")
+	})
+
+	s.Run("MissingCredentials", func() {
+		auth := s.newAuthenticator(nil, new(MockUserLoader))
+
+		_, err := s.authenticate(auth, "token", &security.OIDCCredentials{State: "state"})
+		s.assertErrCode(err, result.ErrCodeCredentialsInvalid, "Should require the authorization code")
+	})
+
+	s.Run("InvalidFlowToken", func() {
+		auth := s.newAuthenticator(nil, new(MockUserLoader))
+
+		_, err := s.authenticate(auth, "not-a-token", &security.OIDCCredentials{Code: "code", State: "state"})
+		s.assertErrCode(err, result.ErrCodeOIDCFlowInvalid, "Should reject an invalid flow token")
+	})
+
+	s.Run("StateMismatch", func() {
+		auth := s.newAuthenticator(nil, new(MockUserLoader))
+
+		flowToken, credentials := s.signIn(auth)
+		credentials.State = "forged"
+
+		_, err := s.authenticate(auth, flowToken, credentials)
+		s.assertErrCode(err, result.ErrCodeOIDCFlowInvalid, "Should reject a callback of another flow")
+	})
+
+	s.Run("CodeVerifierMismatch", func() {
+		auth := s.newAuthenticator(nil, new(MockUserLoader))
+
+		_, credentials := s.signIn(auth)
+		otherFlowToken, otherCredentials := s.signIn(auth)
+		credentials.State = otherCredentials.State
+
+		_, err := s.authenticate(auth, otherFlowToken, credentials)
+		s.assertErrCode(err, result.ErrCodeOIDCLoginFailed, "Should fail when the code was issued for another code challenge")
+	})
+
+	s.Run("InvalidIDTokens", func() {
+		tests := []struct {
+			name   string
+			claims jwt.MapClaims
+		}{
+			{"NonceMismatch", jwt.MapClaims{"nonce": "replayed"}},
+			{"WrongAudience", jwt.MapClaims{"aud": "other-app"}},
+			{"WrongIssuer", jwt.MapClaims{"iss": "https://evil.example.com"}},
+			{"Expired", jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}},
+			{"AuthorizedPartyMismatch", jwt.MapClaims{"aud": []string{testOIDCClientID, "other-app"}, "azp": "other-app"}},
+		}
+
+		for _, tt := range tests {
+			s.Run(tt.name, func() {
+				s.idp.overrideIDTokenClaims(tt.claims)
+				defer s.idp.overrideIDTokenClaims(nil)
+
+				loader := new(MockUserLoader)
+				auth := s.newAuthenticator(nil, loader)
+
+				flowToken, credentials := s.signIn(auth)
+				_, err := s.authenticate(auth, flowToken, credentials)
+				s.assertErrCode(err, result.ErrCodeOIDCLoginFailed, "Should reject the ID token")
+				loader.AssertNotCalled(s.T(), "LoadByUsername", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	s.Run("ProviderKeyRotation", func() {
+		loader := new(MockUserLoader)
+		loader.On("LoadByUsername", mock.Anything, "alice").Return(alice, "", nil).Twice()
+		auth := s.newAuthenticator(nil, loader)
+
+		flowToken, credentials := s.signIn(auth)
+		_, err := s.authenticate(auth, flowToken, credentials)
+		s.Require().NoError(err, "Should authenticate with the first provider key")
+
+		s.idp.rotateKey(s.T(), "idp-key-2")
+
+		flowToken, credentials = s.signIn(auth)
+		_, err = s.authenticate(auth, flowToken, credentials)
+		s.Require().NoError(err, "Should fetch the rotated provider key")
+		loader.AssertExpectations(s.T())
+	})
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	suite.Run(t, new(OIDCAuthenticatorTestSuite))
+}
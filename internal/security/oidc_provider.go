@@ -0,0 +1,458 @@
+package security
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/cast"
+
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+const (
+	oidcDiscoveryPath    = "/.well-known/openid-configuration"
+	oidcRequestTimeout   = 10 * time.Second
+	oidcClockSkew        = time.Minute
+	oidcMaxResponseBytes = 1 << 20
+)
+
+// oidcSigningMethods are the ID token algorithms accepted from providers.
+// Symmetric algorithms are rejected, as they would let anyone holding the client secret forge ID tokens.
+var oidcSigningMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// oidcDiscovery is the subset of the provider metadata (OpenID Connect Discovery 1.0) used for login.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcTokenResponse is the token endpoint response of the authorization code grant.
+type oidcTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+}
+
+// oidcJWK is a JSON Web Key as published by a provider.
+type oidcJWK struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	Curve   string `json:"crv"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+// oidcProvider is the client of one OpenID Connect provider.
+// The discovery document and signing keys are fetched on first use and cached;
+// the keys are fetched again when an ID token is signed by a key not seen before, following provider key rotation.
+type oidcProvider struct {
+	config config.OIDCProviderConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]crypto.PublicKey
+}
+
+// newOIDCProvider creates a provider client, applying the default scopes and username claim.
+func newOIDCProvider(providerConfig config.OIDCProviderConfig, client *http.Client) *oidcProvider {
+	if len(providerConfig.Scopes) == 0 {
+		providerConfig.Scopes = []string{"openid", "profile", "email"}
+	}
+
+	if providerConfig.UsernameClaim == "" {
+		providerConfig.UsernameClaim = "preferred_username"
+	}
+
+	return &oidcProvider{
+		config: providerConfig,
+		client: client,
+	}
+}
+
+// metadata returns the provider's discovery document, fetching it on first use.
+func (p *oidcProvider) metadata(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var discovery oidcDiscovery
+	if err := p.getJSON(ctx, strings.TrimSuffix(p.config.Issuer, "/")+oidcDiscoveryPath, "", &discovery); err != nil {
+		return nil, err
+	}
+
+	if discovery.Issuer != p.config.Issuer {
+		return nil, fmt.Errorf("%w: %q", ErrOIDCIssuerMismatch, discovery.Issuer)
+	}
+
+	p.discovery = &discovery
+
+	return p.discovery, nil
+}
+
+// authorizationURL returns the URL the user is redirected to for signing in at the provider.
+func (p *oidcProvider) authorizationURL(ctx context.Context, state, nonce, codeVerifier string) (string, error) {
+	discovery, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {pkceChallenge(codeVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// exchange redeems an authorization code at the token endpoint, proving possession of the PKCE code verifier.
+func (p *oidcProvider) exchange(ctx context.Context, code, codeVerifier string) (*oidcTokenResponse, error) {
+	discovery, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"client_id":     {p.config.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	var tokens oidcTokenResponse
+	if err := p.doJSON(req, &tokens); err != nil {
+		return nil, err
+	}
+
+	if tokens.IDToken == "" {
+		return nil, ErrOIDCIDTokenMissing
+	}
+
+	return &tokens, nil
+}
+
+// verifyIDToken validates the ID token signature, issuer, audience, expiry and nonce, and returns its claims.
+func (p *oidcProvider) verifyIDToken(ctx context.Context, idToken, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(
+		idToken,
+		claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+
+			return p.publicKey(ctx, kid)
+		},
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(oidcClockSkew),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// An ID token issued to several audiences must name this client as its authorized party.
+	if audience, _ := claims.GetAudience(); len(audience) > 1 {
+		if azp := cast.ToString(claims["azp"]); azp != p.config.ClientID {
+			return nil, fmt.Errorf("%w: %q", ErrOIDCAuthorizedPartyMismatch, azp)
+		}
+	}
+
+	if cast.ToString(claims["nonce"]) != nonce {
+		return nil, ErrOIDCNonceMismatch
+	}
+
+	return claims, nil
+}
+
+// userInfo fetches the claims of the userinfo endpoint, or nil when the provider has none.
+func (p *oidcProvider) userInfo(ctx context.Context, accessToken, subject string) (map[string]any, error) {
+	discovery, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if discovery.UserInfoEndpoint == "" || accessToken == "" {
+		return nil, nil
+	}
+
+	var claims map[string]any
+	if err := p.getJSON(ctx, discovery.UserInfoEndpoint, accessToken, &claims); err != nil {
+		return nil, err
+	}
+
+	// The userinfo response is only trusted when it describes the user the ID token was issued for.
+	if sub := cast.ToString(claims["sub"]); sub != subject {
+		return nil, fmt.Errorf("%w: %q", ErrOIDCSubjectMismatch, sub)
+	}
+
+	return claims, nil
+}
+
+// identity maps the verified claims to an external identity.
+func (p *oidcProvider) identity(claims map[string]any) *security.ExternalIdentity {
+	return &security.ExternalIdentity{
+		Provider:      p.config.Name,
+		Subject:       cast.ToString(claims["sub"]),
+		Username:      cast.ToString(claims[p.config.UsernameClaim]),
+		Email:         cast.ToString(claims["email"]),
+		EmailVerified: cast.ToBool(claims["email_verified"]),
+		Name:          cast.ToString(claims["name"]),
+		Claims:        claims,
+	}
+}
+
+// publicKey returns the provider key with the given ID, refreshing the cached key set once when it is unknown.
+// Tokens without a kid are accepted only while the provider publishes a single key.
+func (p *oidcProvider) publicKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	discovery, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	keys, err := p.fetchKeys(ctx, discovery.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	p.keys = keys
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrOIDCSigningKeyNotFound, kid)
+}
+
+func (p *oidcProvider) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+
+	key, ok := p.keys[kid]
+
+	return key, ok
+}
+
+// fetchKeys downloads the provider key set, skipping encryption keys and key types that cannot verify ID tokens.
+func (p *oidcProvider) fetchKeys(ctx context.Context, jwksURI string) (map[string]crypto.PublicKey, error) {
+	var jwks struct {
+		Keys []oidcJWK `json:"keys"`
+	}
+
+	if err := p.getJSON(ctx, jwksURI, "", &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := parseJWK(jwk)
+		if err != nil {
+			logger.Warnf("Skipping key %q of OIDC provider %q: %v", jwk.KeyID, p.config.Name, err)
+
+			continue
+		}
+
+		keys[jwk.KeyID] = key
+	}
+
+	return keys, nil
+}
+
+func (p *oidcProvider) getJSON(ctx context.Context, endpoint, accessToken string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	if accessToken != "" {
+		req.Header.Set("Authorization", security.AuthSchemeBearer+" "+accessToken)
+	}
+
+	return p.doJSON(req, target)
+}
+
+func (p *oidcProvider) doJSON(req *http.Request, target any) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrOIDCRequestFailed, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, oidcMaxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrOIDCRequestFailed, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s %s returned %d: %s", ErrOIDCRequestFailed, req.Method, req.URL.Path, resp.StatusCode, body)
+	}
+
+	return json.Unmarshal(body, target)
+}
+
+// parseJWK converts an RSA, EC or Ed25519 JSON Web Key to a public key.
+func parseJWK(jwk oidcJWK) (crypto.PublicKey, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := decodeJWKInt(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeJWKInt(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() {
+			return nil, fmt.Errorf("%w: rsa exponent too large", ErrJWKUnsupported)
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("%w: curve %q", ErrJWKUnsupported, jwk.Curve)
+		}
+
+		x, err := decodeJWKInt(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeJWKInt(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		key := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		if _, err := key.ECDH(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrJWKUnsupported, err)
+		}
+
+		return key, nil
+
+	case "OKP":
+		if jwk.Curve != "Ed25519" {
+			return nil, fmt.Errorf("%w: curve %q", ErrJWKUnsupported, jwk.Curve)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid ed25519 key size", ErrJWKUnsupported)
+		}
+
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("%w: key type %q", ErrJWKUnsupported, jwk.KeyType)
+	}
+}
+
+func decodeJWKInt(value string) (*big.Int, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(bytes), nil
+}
+
+// newPKCEVerifier returns a random PKCE code verifier (RFC 7636) of 52 unreserved characters.
+func newPKCEVerifier() string {
+	return rand.Text() + rand.Text()
+}
+
+// pkceChallenge returns the S256 code challenge of a PKCE code verifier.
+func pkceChallenge(codeVerifier string) string {
+	digest := sha256.Sum256([]byte(codeVerifier))
+
+	return base64.RawURLEncoding.EncodeToString(digest[:])
+}
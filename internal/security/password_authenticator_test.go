@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/coldsmirk/vef-framework-go/orm"
+	"github.com/coldsmirk/vef-framework-go/password"
 	"github.com/coldsmirk/vef-framework-go/result"
 	"github.com/coldsmirk/vef-framework-go/security"
 )
@@ -260,6 +261,76 @@ func (s *PasswordAuthenticatorTestSuite) TestAuthenticate() {
 	})
 }
 
+// userTable is an in-memory user table serving both the UserLoader of the login and the PasswordStore
+// of the password policy, as the two must share their storage.
+type userTable struct {
+	principal *security.Principal
+	record    security.PasswordRecord
+}
+
+func (t *userTable) LoadByUsername(_ context.Context, username string) (*security.Principal, string, error) {
+	if username != t.principal.ID {
+		return nil, "", result.ErrRecordNotFound
+	}
+
+	return t.principal, t.record.EncodedPassword, nil
+}
+
+func (t *userTable) LoadByID(_ context.Context, id string) (*security.Principal, error) {
+	if id != t.principal.ID {
+		return nil, result.ErrRecordNotFound
+	}
+
+	return t.principal, nil
+}
+
+func (t *userTable) Load(_ context.Context, userID string) (*security.PasswordRecord, error) {
+	if userID != t.principal.ID {
+		return nil, nil
+	}
+
+	record := t.record
+
+	return &record, nil
+}
+
+func (t *userTable) Save(_ context.Context, userID string, record *security.PasswordRecord) error {
+	if userID == t.principal.ID {
+		t.record = *record
+	}
+
+	return nil
+}
+
+// TestLoginAfterPasswordChange verifies the login accepts the password set through the password policy
+// and rejects the replaced one when the UserLoader and the PasswordStore share their storage.
+func (s *PasswordAuthenticatorTestSuite) TestLoginAfterPasswordChange() {
+	var (
+		ctx     = context.Background()
+		encoder = password.NewBcryptEncoder()
+		users   = &userTable{principal: security.NewUser("alice", "Alice")}
+		policy  = security.NewPasswordPolicy(&security.PasswordPolicyConfig{}, encoder, users)
+		auth    = NewPasswordAuthenticator(users, encoder)
+	)
+
+	login := func(plaintext string) error {
+		_, err := auth.Authenticate(ctx, security.Authentication{
+			Type:        AuthTypePassword,
+			Principal:   "alice",
+			Credentials: plaintext,
+		})
+
+		return err
+	}
+
+	s.Require().NoError(policy.ResetPassword(ctx, "alice", "Old#Pass9x"), "Should reset the password")
+	s.Require().NoError(login("Old#Pass9x"), "Should log in with the reset password")
+
+	s.Require().NoError(policy.ChangeOwnPassword(ctx, users.principal, "Old#Pass9x", "Xk9#mQ2vLp"), "Should change the password")
+	s.NoError(login("Xk9#mQ2vLp"), "Should log in with the new password")
+	s.Error(login("Old#Pass9x"), "Should reject the replaced password")
+}
+
 func TestPasswordAuthenticator(t *testing.T) {
 	suite.Run(t, new(PasswordAuthenticatorTestSuite))
 }
@@ -0,0 +1,173 @@
+package security
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/httpx"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// PasswordResource exposes the password policy, the endpoint users change their password with,
+// and the admin endpoint to reset the password of any user.
+// Wrong current passwords count towards the lockout of the LoginGuard, and once a password changes
+// the other sessions of the user are revoked when a SessionStore is present.
+// Passwords are written to the PasswordStore of the policy, which must share its storage with the UserLoader
+// the login reads them from.
+type PasswordResource struct {
+	api.Resource
+
+	policy       *security.PasswordPolicy
+	loginGuard   *LoginGuard
+	sessionStore security.SessionStore
+}
+
+// NewPasswordResource creates a new password resource.
+func NewPasswordResource(
+	policy *security.PasswordPolicy,
+	securityConfig *config.SecurityConfig,
+	loginGuard *LoginGuard,
+	sessionStore security.SessionStore,
+) api.Resource {
+	return &PasswordResource{
+		policy:       policy,
+		loginGuard:   loginGuard,
+		sessionStore: sessionStore,
+		Resource: api.NewRPCResource(
+			"security/password",
+			api.WithOperations(
+				api.OperationSpec{Action: "get_policy", Public: true},
+				api.OperationSpec{
+					Action:    "evaluate",
+					Public:    true,
+					RateLimit: &api.RateLimitConfig{Max: securityConfig.LoginRateLimit},
+				},
+				api.OperationSpec{
+					Action:    "change",
+					RateLimit: &api.RateLimitConfig{Max: securityConfig.LoginRateLimit},
+				},
+				api.OperationSpec{Action: "reset", PermToken: "security:password:reset"},
+			),
+		),
+	}
+}
+
+// GetPolicy returns the password policy rules, e.g. to show them next to a password field.
+func (r *PasswordResource) GetPolicy(ctx fiber.Ctx) error {
+	return result.Ok(r.policy.Config()).Response(ctx)
+}
+
+// EvaluatePasswordParams represents the request parameters for evaluating a password.
+type EvaluatePasswordParams struct {
+	api.P
+
+	Password string `json:"password" validate:"required" label_i18n:"auth_new_password"`
+	// Username and Name are compared with the password, since the user may not be signed in yet.
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// Evaluate checks a password against the policy rules without changing anything, for password strength meters.
+func (r *PasswordResource) Evaluate(ctx fiber.Ctx, params EvaluatePasswordParams) error {
+	return result.Ok(r.policy.Evaluate(params.Password, params.Username, params.Name)).Response(ctx)
+}
+
+// ChangePasswordParams represents the request parameters for changing the password of the current user.
+type ChangePasswordParams struct {
+	api.P
+
+	CurrentPassword string `json:"currentPassword" validate:"required" label_i18n:"auth_current_password"`
+	NewPassword     string `json:"newPassword" validate:"required" label_i18n:"auth_new_password"`
+}
+
+// Change changes the password of the current user after verifying the current password,
+// then revokes the other sessions of the user.
+func (r *PasswordResource) Change(ctx fiber.Ctx, principal *security.Principal, params ChangePasswordParams) error {
+	if err := checkKeyManager(principal); err != nil {
+		return err
+	}
+
+	var (
+		changeCtx = ctx.Context()
+		ip        = httpx.GetIP(ctx)
+	)
+
+	if err := r.loginGuard.CheckPasswordChange(changeCtx, principal.ID, ip); err != nil {
+		return err
+	}
+
+	if err := r.policy.ChangeOwnPassword(changeCtx, principal, params.CurrentPassword, params.NewPassword); err != nil {
+		if errors.Is(err, result.ErrCurrentPasswordInvalid) {
+			if recordErr := r.loginGuard.RecordPasswordChangeFailure(changeCtx, principal.ID, ip); recordErr != nil {
+				logger.Warnf("Failed to record failed password change of user %q: %v", principal.ID, recordErr)
+			}
+		}
+
+		return err
+	}
+
+	if err := r.loginGuard.RecordPasswordChangeSuccess(changeCtx, principal.ID); err != nil {
+		return err
+	}
+
+	if err := r.revokeSessions(changeCtx, principal.ID, principal.SessionID); err != nil {
+		return err
+	}
+
+	return result.Ok().Response(ctx)
+}
+
+// ResetPasswordParams represents the request parameters for resetting the password of a user.
+type ResetPasswordParams struct {
+	api.P
+
+	UserID      string `json:"userId" validate:"required" label_i18n:"auth_user_id"`
+	NewPassword string `json:"newPassword" validate:"required" label_i18n:"auth_new_password"`
+}
+
+// Reset sets the password of a user, who has to change it at the next login, and revokes all sessions of the user.
+func (r *PasswordResource) Reset(ctx fiber.Ctx, params ResetPasswordParams) error {
+	if err := r.policy.ResetPassword(ctx.Context(), params.UserID, params.NewPassword); err != nil {
+		return err
+	}
+
+	if err := r.revokeSessions(ctx.Context(), params.UserID, ""); err != nil {
+		return err
+	}
+
+	return result.Ok().Response(ctx)
+}
+
+// revokeSessions revokes the sessions of the user except keepSessionID, so that tokens issued
+// before a password change stop working. Without a SessionStore tokens are stateless and nothing is revoked.
+func (r *PasswordResource) revokeSessions(ctx context.Context, userID, keepSessionID string) error {
+	if r.sessionStore == nil {
+		return nil
+	}
+
+	if keepSessionID == "" {
+		return r.sessionStore.DeleteByUser(ctx, userID)
+	}
+
+	sessions, err := r.sessionStore.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if session.ID == keepSessionID {
+			continue
+		}
+
+		if err := r.sessionStore.Delete(ctx, session.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package security
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// PolicyResource exposes an admin endpoint to dry-run access decisions.
+type PolicyResource struct {
+	api.Resource
+
+	checker   security.PermissionChecker
+	evaluator security.PolicyEvaluator
+}
+
+// NewPolicyResource creates a new policy admin resource.
+func NewPolicyResource(checker security.PermissionChecker, evaluator security.PolicyEvaluator) api.Resource {
+	return &PolicyResource{
+		checker:   checker,
+		evaluator: evaluator,
+		Resource: api.NewRPCResource(
+			"security/policy",
+			api.WithOperations(
+				api.OperationSpec{Action: "explain", PermToken: "security:policy:explain"},
+			),
+		),
+	}
+}
+
+// ExplainParams describes the access request to dry-run.
+type ExplainParams struct {
+	api.P
+
+	PermToken string `json:"permToken" validate:"required" label_i18n:"policy_perm_token"`
+	// Operation is the operation the request invokes, for resource loaders and policies that depend on it.
+	Operation security.PolicyOperation `json:"operation"`
+	Params    map[string]any           `json:"params"`
+	// Principal is the principal to evaluate the request as; the caller when omitted.
+	Principal *security.Principal `json:"principal"`
+}
+
+// Explanation is the access decision of a dry-run request.
+type Explanation struct {
+	// Allowed reports whether the request would be let through.
+	Allowed bool `json:"allowed"`
+	// InScope reports whether the principal's scopes cover the permission token.
+	InScope bool `json:"inScope"`
+	// PermissionGranted reports whether the principal's roles grant the permission token.
+	PermissionGranted bool `json:"permissionGranted"`
+	// Policy is the decision of the access policies of the permission token.
+	Policy *security.PolicyDecision `json:"policy"`
+}
+
+// Explain evaluates an access request without executing it, reporting the scope check, the role check and the outcome
+// of every policy. All checks run even when an earlier one fails, so administrators see every reason for a denial.
+func (r *PolicyResource) Explain(ctx fiber.Ctx, principal *security.Principal, params ExplainParams) error {
+	if params.Principal != nil {
+		principal = params.Principal
+	}
+
+	inScope := principal.InScope(params.PermToken)
+
+	granted, err := r.checker.HasPermission(ctx.Context(), principal, params.PermToken)
+	if err != nil {
+		return err
+	}
+
+	decision, err := r.evaluator.Evaluate(ctx.Context(), &security.PolicyRequest{
+		Principal: principal,
+		PermToken: params.PermToken,
+		Operation: params.Operation,
+		Params:    params.Params,
+	})
+	if err != nil {
+		return err
+	}
+
+	return result.Ok(&Explanation{
+		Allowed:           inScope && granted && decision.Allowed,
+		InScope:           inScope,
+		PermissionGranted: granted,
+		Policy:            decision,
+	}).Response(ctx)
+}
@@ -0,0 +1,70 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// grantAllChecker grants every permission token.
+type grantAllChecker struct{}
+
+func (grantAllChecker) HasPermission(context.Context, *security.Principal, string) (bool, error) {
+	return true, nil
+}
+
+// explain runs a dry-run request for principal through the policy resource.
+func explain(t *testing.T, principal *security.Principal, params ExplainParams) Explanation {
+	t.Helper()
+
+	resource := NewPolicyResource(grantAllChecker{}, NewExprPolicyEvaluator(nil, nil)).(*PolicyResource)
+
+	app := fiber.New()
+	app.Post("/", func(ctx fiber.Ctx) error {
+		return resource.Explain(ctx, principal, params)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/", nil))
+	require.NoError(t, err, "Request should not fail")
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "Body should be readable")
+
+	var (
+		explanation Explanation
+		res         = result.Result{Data: &explanation}
+	)
+	require.NoError(t, json.Unmarshal(body, &res), "Body should be a result")
+
+	return explanation
+}
+
+// TestExplainScopes tests that the dry-run applies the scopes of the principal like the auth middleware does.
+func TestExplainScopes(t *testing.T) {
+	t.Run("Unscoped", func(t *testing.T) {
+		explanation := explain(t, security.NewUser("u1", "Alice"), ExplainParams{PermToken: "order:delete"})
+		assert.True(t, explanation.InScope, "Unscoped principals should be in scope")
+		assert.True(t, explanation.Allowed, "Granted request should be allowed")
+	})
+
+	t.Run("OutOfScope", func(t *testing.T) {
+		principal := security.NewUser("u1", "Alice")
+		principal.Scopes = []string{"order:query"}
+
+		explanation := explain(t, principal, ExplainParams{PermToken: "order:delete"})
+		assert.False(t, explanation.InScope, "Token outside the scopes should be reported")
+		assert.True(t, explanation.PermissionGranted, "Role check should still be reported")
+		assert.False(t, explanation.Allowed, "Out-of-scope request should be denied")
+	})
+}
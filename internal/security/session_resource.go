@@ -0,0 +1,90 @@
+package security
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// SessionResource exposes admin endpoints to inspect and revoke the sessions of any user.
+type SessionResource struct {
+	api.Resource
+
+	sessionStore security.SessionStore
+}
+
+// NewSessionResource creates a new session admin resource.
+func NewSessionResource(sessionStore security.SessionStore) api.Resource {
+	return &SessionResource{
+		sessionStore: sessionStore,
+		Resource: api.NewRPCResource(
+			"security/session",
+			api.WithOperations(
+				api.OperationSpec{Action: "find_user_sessions", PermToken: "security:session:query"},
+				api.OperationSpec{Action: "force_logout", PermToken: "security:session:force_logout"},
+			),
+		),
+	}
+}
+
+// FindUserSessionsParams represents the request parameters for listing the sessions of a user.
+type FindUserSessionsParams struct {
+	api.P
+
+	UserID string `json:"userId" validate:"required" label_i18n:"auth_user_id"`
+}
+
+// FindUserSessions lists the active sessions of a user, oldest first.
+func (r *SessionResource) FindUserSessions(ctx fiber.Ctx, params FindUserSessionsParams) error {
+	if r.sessionStore == nil {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageSessionStoreNotImplemented))
+	}
+
+	sessions, err := r.sessionStore.ListByUser(ctx.Context(), params.UserID)
+	if err != nil {
+		return err
+	}
+
+	return result.Ok(sessions).Response(ctx)
+}
+
+// ForceLogoutParams represents the request parameters for forcing a user to log out.
+type ForceLogoutParams struct {
+	api.P
+
+	UserID    string `json:"userId" validate:"required" label_i18n:"auth_user_id"`
+	SessionID string `json:"sessionId"`
+}
+
+// ForceLogout revokes a single session of the user when SessionID is given, otherwise all of the user's sessions.
+func (r *SessionResource) ForceLogout(ctx fiber.Ctx, params ForceLogoutParams) error {
+	if r.sessionStore == nil {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageSessionStoreNotImplemented))
+	}
+
+	if params.SessionID == "" {
+		if err := r.sessionStore.DeleteByUser(ctx.Context(), params.UserID); err != nil {
+			return err
+		}
+
+		return result.Ok().Response(ctx)
+	}
+
+	session, err := r.sessionStore.Get(ctx.Context(), params.SessionID)
+	if err != nil {
+		return err
+	}
+
+	if session == nil || session.UserID != params.UserID {
+		return result.ErrRecordNotFound
+	}
+
+	if err := r.sessionStore.Delete(ctx.Context(), session.ID); err != nil {
+		return err
+	}
+
+	return result.Ok().Response(ctx)
+}
@@ -0,0 +1,320 @@
+package security_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/event"
+	"github.com/coldsmirk/vef-framework-go/internal/apptest"
+	isecurity "github.com/coldsmirk/vef-framework-go/internal/security"
+	"github.com/coldsmirk/vef-framework-go/password"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// staticRolePermissionsLoader grants a fixed set of permission tokens to every role.
+type staticRolePermissionsLoader struct {
+	permissions map[string]security.DataScope
+}
+
+func (l *staticRolePermissionsLoader) LoadPermissions(context.Context, string) (map[string]security.DataScope, error) {
+	return l.permissions, nil
+}
+
+// SessionTestSuite tests the server-side session lifecycle through the auth and session resources.
+type SessionTestSuite struct {
+	apptest.Suite
+
+	userLoader *MockUserLoader
+	publisher  *MockPublisher
+	store      security.SessionStore
+}
+
+func (s *SessionTestSuite) SetupSuite() {
+	s.userLoader = new(MockUserLoader)
+	s.publisher = new(MockPublisher)
+	s.store = security.NewMemorySessionStore()
+
+	hashedPassword, err := password.NewBcryptEncoder().Encode("password123")
+	s.Require().NoError(err, "Should not return error")
+
+	testUser := security.NewUser("user001", "Test User", "user")
+	adminUser := security.NewUser("admin001", "Admin User", "admin")
+
+	s.SetupApp(
+		fx.Supply(
+			fx.Annotate(
+				s.userLoader,
+				fx.As(new(security.UserLoader)),
+			),
+			fx.Annotate(
+				s.store,
+				fx.As(new(security.SessionStore)),
+			),
+			fx.Annotate(
+				&staticRolePermissionsLoader{permissions: map[string]security.DataScope{
+					"security:session:query":        nil,
+					"security:session:force_logout": nil,
+				}},
+				fx.As(new(security.RolePermissionsLoader)),
+			),
+		),
+		fx.Replace(
+			fx.Annotate(
+				s.publisher,
+				fx.As(new(event.Publisher)),
+			),
+		),
+		fx.Replace(
+			&config.DataSourceConfig{
+				Kind: "sqlite",
+			},
+			&config.SecurityConfig{
+				TokenExpires:     24 * time.Hour,
+				RefreshNotBefore: 1 * time.Millisecond,
+				LoginRateLimit:   1000,
+				RefreshRateLimit: 1000,
+			},
+			&security.JWTConfig{
+				Secret:   testJWTSecret,
+				Audience: "test-app",
+			},
+		),
+		fx.Invoke(func() {
+			s.userLoader.On("LoadByUsername", mock.Anything, "testuser").
+				Return(testUser, hashedPassword, nil).
+				Maybe()
+			s.userLoader.On("LoadByUsername", mock.Anything, "admin").
+				Return(adminUser, hashedPassword, nil).
+				Maybe()
+			s.userLoader.On("LoadByID", mock.Anything, "user001").
+				Return(testUser, nil).
+				Maybe()
+
+			s.publisher.On("Publish", mock.Anything).Maybe()
+		}),
+	)
+}
+
+func (s *SessionTestSuite) TearDownSuite() {
+	s.TearDownApp()
+}
+
+func (s *SessionTestSuite) SetupTest() {
+	s.Require().NoError(s.store.DeleteByUser(context.Background(), "user001"), "Should clear user sessions")
+	s.Require().NoError(s.store.DeleteByUser(context.Background(), "admin001"), "Should clear admin sessions")
+}
+
+// login logs username in and returns the issued tokens.
+func (s *SessionTestSuite) login(username string) (accessToken, refreshToken string) {
+	resp := s.MakeRPCRequest(api.Request{
+		Identifier: api.Identifier{Resource: "security/auth", Action: "login", Version: "v1"},
+		Params: map[string]any{
+			"type":        isecurity.AuthTypePassword,
+			"principal":   username,
+			"credentials": "password123",
+		},
+	})
+
+	body := s.ReadResult(resp)
+	s.Require().True(body.IsOk(), "Login should succeed")
+
+	tokens := s.ReadDataAsMap(s.ReadDataAsMap(body.Data)["tokens"])
+
+	return tokens["accessToken"].(string), tokens["refreshToken"].(string)
+}
+
+// call invokes an action with the given access token and returns the decoded result.
+func (s *SessionTestSuite) call(resource, action, token string, params map[string]any) result.Result {
+	resp := s.MakeRPCRequestWithToken(api.Request{
+		Identifier: api.Identifier{Resource: resource, Action: action, Version: "v1"},
+		Params:     params,
+	}, token)
+
+	return s.ReadResult(resp)
+}
+
+// refresh exchanges refreshToken and returns the decoded result.
+func (s *SessionTestSuite) refresh(refreshToken string) result.Result {
+	resp := s.MakeRPCRequest(api.Request{
+		Identifier: api.Identifier{Resource: "security/auth", Action: "refresh", Version: "v1"},
+		Params:     map[string]any{"refreshToken": refreshToken},
+	})
+
+	return s.ReadResult(resp)
+}
+
+// TestListSessions tests that each login starts a session listed to its user.
+func (s *SessionTestSuite) TestListSessions() {
+	s.login("testuser")
+	accessToken, _ := s.login("testuser")
+
+	body := s.call("security/auth", "list_sessions", accessToken, nil)
+	s.Require().True(body.IsOk(), "Listing sessions should succeed")
+
+	sessions := s.ReadDataAsSlice(body.Data)
+	s.Require().Len(sessions, 2, "Each login should start a session")
+	s.False(s.ReadDataAsMap(sessions[0])["current"].(bool), "Older session should not be current")
+	s.True(s.ReadDataAsMap(sessions[1])["current"].(bool), "Requesting session should be current")
+	s.NotContains(s.ReadDataAsMap(sessions[1]), "refreshTokenHash", "Refresh token fingerprint should not be exposed")
+}
+
+// TestLogout tests that logging out revokes the access token immediately.
+func (s *SessionTestSuite) TestLogout() {
+	accessToken, refreshToken := s.login("testuser")
+
+	body := s.call("security/auth", "logout", accessToken, nil)
+	s.Require().True(body.IsOk(), "Logout should succeed")
+
+	body = s.call("security/auth", "list_sessions", accessToken, nil)
+	s.Equal(result.ErrCodeSessionRevoked, body.Code, "Access token should be revoked by logout")
+
+	body = s.refresh(refreshToken)
+	s.Equal(result.ErrCodeSessionRevoked, body.Code, "Refresh token should be revoked by logout")
+}
+
+// TestLogoutAll tests that logging out all devices revokes every session of the user.
+func (s *SessionTestSuite) TestLogoutAll() {
+	firstToken, _ := s.login("testuser")
+	secondToken, _ := s.login("testuser")
+
+	body := s.call("security/auth", "logout_all", firstToken, nil)
+	s.Require().True(body.IsOk(), "Logout all should succeed")
+
+	for _, token := range []string{firstToken, secondToken} {
+		body = s.call("security/auth", "list_sessions", token, nil)
+		s.Equal(result.ErrCodeSessionRevoked, body.Code, "Every session of the user should be revoked")
+	}
+}
+
+// TestRevokeSession tests revoking one of the user's own sessions.
+func (s *SessionTestSuite) TestRevokeSession() {
+	firstToken, _ := s.login("testuser")
+	secondToken, _ := s.login("testuser")
+
+	sessions, err := s.store.ListByUser(context.Background(), "user001")
+	s.Require().NoError(err, "Should list sessions")
+	s.Require().Len(sessions, 2, "Should have two sessions")
+
+	body := s.call("security/auth", "revoke_session", firstToken, map[string]any{"sessionId": sessions[1].ID})
+	s.Require().True(body.IsOk(), "Revoking an own session should succeed")
+
+	body = s.call("security/auth", "list_sessions", secondToken, nil)
+	s.Equal(result.ErrCodeSessionRevoked, body.Code, "Revoked session should be rejected")
+
+	body = s.call("security/auth", "list_sessions", firstToken, nil)
+	s.True(body.IsOk(), "Other sessions should stay active")
+
+	s.Run("OtherUsersSession", func() {
+		adminToken, _ := s.login("admin")
+		adminSessions, err := s.store.ListByUser(context.Background(), "admin001")
+		s.Require().NoError(err, "Should list admin sessions")
+
+		body := s.call("security/auth", "revoke_session", firstToken, map[string]any{"sessionId": adminSessions[0].ID})
+		s.Equal(result.ErrCodeRecordNotFound, body.Code, "Sessions of other users should not be revocable")
+
+		body = s.call("security/auth", "list_sessions", adminToken, nil)
+		s.True(body.IsOk(), "Admin session should stay active")
+	})
+}
+
+// TestRefreshRotation tests that refreshing rotates the refresh token and that reusing an old one revokes the session.
+func (s *SessionTestSuite) TestRefreshRotation() {
+	_, refreshToken := s.login("testuser")
+
+	body := s.refresh(refreshToken)
+	s.Require().True(body.IsOk(), "Refreshing with the latest refresh token should succeed")
+
+	rotated := s.ReadDataAsMap(body.Data)
+	accessToken := rotated["accessToken"].(string)
+
+	body = s.call("security/auth", "list_sessions", accessToken, nil)
+	s.Require().True(body.IsOk(), "Refreshed access token should belong to the same session")
+	s.Len(s.ReadDataAsSlice(body.Data), 1, "Refreshing should not start a new session")
+
+	body = s.refresh(refreshToken)
+	s.Equal(result.ErrCodeRefreshTokenReused, body.Code, "Reusing an exchanged refresh token should be detected")
+
+	body = s.refresh(rotated["refreshToken"].(string))
+	s.Equal(result.ErrCodeSessionRevoked, body.Code, "Reuse should revoke the whole session")
+
+	body = s.call("security/auth", "list_sessions", accessToken, nil)
+	s.Equal(result.ErrCodeSessionRevoked, body.Code, "Access tokens of the session should be revoked")
+}
+
+// TestConcurrentRefresh tests that of several concurrent refreshes with the same refresh token only one succeeds.
+func (s *SessionTestSuite) TestConcurrentRefresh() {
+	_, refreshToken := s.login("testuser")
+
+	const attempts = 5
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]result.Result, attempts)
+	)
+
+	for i := range attempts {
+		wg.Go(func() {
+			results[i] = s.refresh(refreshToken)
+		})
+	}
+
+	wg.Wait()
+
+	succeeded := lo.CountBy(results, func(body result.Result) bool {
+		return body.IsOk()
+	})
+	s.Equal(1, succeeded, "Only one refresh with the same refresh token should succeed")
+}
+
+// TestForceLogout tests the admin endpoints for inspecting and revoking the sessions of a user.
+func (s *SessionTestSuite) TestForceLogout() {
+	adminToken, _ := s.login("admin")
+	firstToken, _ := s.login("testuser")
+	secondToken, _ := s.login("testuser")
+
+	body := s.call("security/session", "find_user_sessions", adminToken, map[string]any{"userId": "user001"})
+	s.Require().True(body.IsOk(), "Finding user sessions should succeed")
+
+	sessions := s.ReadDataAsSlice(body.Data)
+	s.Require().Len(sessions, 2, "Should list the sessions of the user")
+
+	s.Run("SingleSession", func() {
+		body := s.call("security/session", "force_logout", adminToken, map[string]any{
+			"userId":    "user001",
+			"sessionId": s.ReadDataAsMap(sessions[0])["id"],
+		})
+		s.Require().True(body.IsOk(), "Forcing a session out should succeed")
+
+		body = s.call("security/auth", "list_sessions", firstToken, nil)
+		s.Equal(result.ErrCodeSessionRevoked, body.Code, "Forced out session should be revoked")
+
+		body = s.call("security/auth", "list_sessions", secondToken, nil)
+		s.True(body.IsOk(), "Other sessions should stay active")
+	})
+
+	s.Run("AllSessions", func() {
+		body := s.call("security/session", "force_logout", adminToken, map[string]any{"userId": "user001"})
+		s.Require().True(body.IsOk(), "Forcing a user out should succeed")
+
+		body = s.call("security/auth", "list_sessions", secondToken, nil)
+		s.Equal(result.ErrCodeSessionRevoked, body.Code, "Every session of the user should be revoked")
+
+		body = s.call("security/auth", "list_sessions", adminToken, nil)
+		s.True(body.IsOk(), "Admin session should stay active")
+	})
+}
+
+// TestSession runs the session test suite.
+func TestSession(t *testing.T) {
+	suite.Run(t, new(SessionTestSuite))
+}
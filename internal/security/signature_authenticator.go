@@ -63,7 +63,7 @@ func (a *SignatureAuthenticator) Authenticate(ctx context.Context, authenticatio
 		return nil, result.ErrExternalAppNotFound
 	}
 
-	if err := a.validateIPWhitelist(ctx, principal); err != nil {
+	if err := validateExternalApp(ctx, principal); err != nil {
 		return nil, err
 	}
 
@@ -93,7 +93,8 @@ func (a *SignatureAuthenticator) verifySignature(
 	return nil
 }
 
-func (*SignatureAuthenticator) validateIPWhitelist(ctx context.Context, principal *security.Principal) error {
+// validateExternalApp checks that an external app is enabled and that the request comes from its IP whitelist.
+func validateExternalApp(ctx context.Context, principal *security.Principal) error {
 	details, ok := principal.Details.(*security.ExternalAppConfig)
 	if !ok || details == nil {
 		return nil
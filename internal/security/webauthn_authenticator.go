@@ -0,0 +1,86 @@
+package security
+
+import (
+	"context"
+
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/mapx"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// AuthTypeWebAuthn is the authentication type for passwordless passkey logins.
+const AuthTypeWebAuthn = "webauthn"
+
+// WebAuthnAuthenticator signs users in with a discoverable passkey, without a username or password.
+// The principal is the ceremony token of security.WebAuthn.BeginAssertion and the credentials are the assertion,
+// either a *security.WebAuthnAssertionResponse or its JSON object.
+type WebAuthnAuthenticator struct {
+	webAuthn *security.WebAuthn
+	loader   security.UserLoader
+}
+
+// NewWebAuthnAuthenticator creates a passkey authenticator that loads the signed-in users through loader.
+func NewWebAuthnAuthenticator(webAuthn *security.WebAuthn, loader security.UserLoader) security.Authenticator {
+	return &WebAuthnAuthenticator{
+		webAuthn: webAuthn,
+		loader:   loader,
+	}
+}
+
+func (*WebAuthnAuthenticator) Supports(authType string) bool { return authType == AuthTypeWebAuthn }
+
+func (a *WebAuthnAuthenticator) Authenticate(ctx context.Context, authentication security.Authentication) (*security.Principal, error) {
+	if a.loader == nil {
+		return nil, result.ErrNotImplemented(i18n.T(result.ErrMessageUserLoaderNotImplemented))
+	}
+
+	if authentication.Principal == "" {
+		return nil, result.ErrWebAuthnCeremonyInvalid
+	}
+
+	response, err := decodeWebAuthnAssertion(authentication.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := a.webAuthn.FinishAssertion(ctx, nil, authentication.Principal, response)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err := a.loader.LoadByID(ctx, credential.UserID)
+	if err != nil {
+		if result.IsRecordNotFound(err) {
+			logger.Infof("User %q of WebAuthn credential %q no longer exists", credential.UserID, credential.ID)
+
+			return nil, result.ErrWebAuthnVerificationFailed
+		}
+
+		return nil, err
+	}
+
+	if principal == nil {
+		return nil, result.ErrWebAuthnVerificationFailed
+	}
+
+	logger.Infof("WebAuthn authentication successful for principal %q", principal.ID)
+
+	return principal, nil
+}
+
+// decodeWebAuthnAssertion accepts the assertion as decoded by a handler or as the raw JSON object of a generic login.
+func decodeWebAuthnAssertion(credentials any) (*security.WebAuthnAssertionResponse, error) {
+	switch value := credentials.(type) {
+	case *security.WebAuthnAssertionResponse:
+		if value != nil {
+			return value, nil
+		}
+	case map[string]any:
+		if response, err := mapx.FromMap[security.WebAuthnAssertionResponse](value); err == nil {
+			return response, nil
+		}
+	}
+
+	return nil, result.ErrCredentialsInvalid(i18n.T(result.ErrMessageCredentialsFormatInvalid))
+}
@@ -0,0 +1,169 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+const (
+	testWebAuthnRPID   = "example.com"
+	testWebAuthnOrigin = "https://example.com"
+)
+
+type WebAuthnAuthenticatorTestSuite struct {
+	suite.Suite
+
+	ctx           context.Context
+	webAuthn      *security.WebAuthn
+	authenticator *testx.WebAuthnAuthenticator
+}
+
+func (s *WebAuthnAuthenticatorTestSuite) SetupTest() {
+	jwt, err := security.NewJWT(&security.JWTConfig{Secret: security.DefaultJWTSecret})
+	s.Require().NoError(err, "Should create JWT instance without error")
+
+	s.ctx = context.Background()
+	s.webAuthn, err = security.NewWebAuthn(
+		&security.WebAuthnConfig{RPID: testWebAuthnRPID},
+		jwt,
+		security.NewMemoryWebAuthnCredentialStore(),
+		nil,
+	)
+	s.Require().NoError(err, "Should create WebAuthn instance without error")
+	s.authenticator = testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+
+	principal := security.NewUser("alice", "Alice")
+	options, err := s.webAuthn.BeginRegistration(s.ctx, principal)
+	s.Require().NoError(err, "Should begin registration")
+
+	attestation := s.authenticator.Register(options.PublicKey.Challenge.String(), options.PublicKey.User.ID.(protocol.URLEncodedBase64).String())
+	_, err = s.webAuthn.FinishRegistration(s.ctx, principal, options.CeremonyToken, "Laptop", &security.WebAuthnRegistrationResponse{
+		ID:    attestation.ID,
+		RawID: attestation.ID,
+		Type:  "public-key",
+		Response: security.WebAuthnAttestationData{
+			ClientDataJSON:    attestation.ClientDataJSON,
+			AttestationObject: attestation.AttestationObject,
+		},
+	})
+	s.Require().NoError(err, "Should register the passkey")
+}
+
+// login begins a passwordless login and returns its ceremony token with the authenticator's assertion.
+func (s *WebAuthnAuthenticatorTestSuite) login() (string, *security.WebAuthnAssertionResponse) {
+	options, err := s.webAuthn.BeginAssertion(s.ctx, nil)
+	s.Require().NoError(err, "Should begin assertion")
+
+	assertion := s.authenticator.Assert(options.PublicKey.Challenge.String())
+
+	return options.CeremonyToken, &security.WebAuthnAssertionResponse{
+		ID:    assertion.ID,
+		RawID: assertion.ID,
+		Type:  "public-key",
+		Response: security.WebAuthnAssertionData{
+			ClientDataJSON:    assertion.ClientDataJSON,
+			AuthenticatorData: assertion.AuthenticatorData,
+			Signature:         assertion.Signature,
+			UserHandle:        assertion.UserHandle,
+		},
+	}
+}
+
+// TestSupports verifies type matching.
+func (s *WebAuthnAuthenticatorTestSuite) TestSupports() {
+	auth := NewWebAuthnAuthenticator(s.webAuthn, nil)
+	s.True(auth.Supports(AuthTypeWebAuthn), "Should support webauthn type")
+	s.False(auth.Supports(AuthTypePassword), "Should not support password type")
+}
+
+// TestAuthenticate verifies all authentication paths.
+func (s *WebAuthnAuthenticatorTestSuite) TestAuthenticate() {
+	s.Run("NilLoader", func() {
+		auth := NewWebAuthnAuthenticator(s.webAuthn, nil)
+
+		_, err := auth.Authenticate(s.ctx, security.Authentication{Type: AuthTypeWebAuthn, Principal: "token"})
+
+		resErr, ok := result.AsErr(err)
+		s.Require().True(ok, "Should return a result.Error")
+		s.Equal(result.ErrCodeNotImplemented, resErr.Code, "Should return not implemented code")
+	})
+
+	s.Run("Success", func() {
+		loader := new(MockUserLoader)
+		loader.On("LoadByID", mock.Anything, "alice").Return(security.NewUser("alice", "Alice"), nil).Once()
+
+		ceremonyToken, response := s.login()
+
+		principal, err := NewWebAuthnAuthenticator(s.webAuthn, loader).Authenticate(s.ctx, security.Authentication{
+			Type:        AuthTypeWebAuthn,
+			Principal:   ceremonyToken,
+			Credentials: response,
+		})
+		s.Require().NoError(err, "Should authenticate with the passkey")
+		s.Equal("alice", principal.ID, "Should sign in the passkey's user")
+		loader.AssertExpectations(s.T())
+	})
+
+	s.Run("CredentialsAsJSONObject", func() {
+		loader := new(MockUserLoader)
+		loader.On("LoadByID", mock.Anything, "alice").Return(security.NewUser("alice", "Alice"), nil).Once()
+
+		ceremonyToken, response := s.login()
+
+		body, err := json.Marshal(response)
+		s.Require().NoError(err, "Should encode the assertion")
+
+		var credentials map[string]any
+		s.Require().NoError(json.Unmarshal(body, &credentials), "Should decode the assertion")
+
+		principal, err := NewWebAuthnAuthenticator(s.webAuthn, loader).Authenticate(s.ctx, security.Authentication{
+			Type:        AuthTypeWebAuthn,
+			Principal:   ceremonyToken,
+			Credentials: credentials,
+		})
+		s.Require().NoError(err, "Should authenticate through the generic login")
+		s.Equal("alice", principal.ID, "Should sign in the passkey's user")
+	})
+
+	s.Run("InvalidCredentials", func() {
+		_, err := NewWebAuthnAuthenticator(s.webAuthn, new(MockUserLoader)).Authenticate(s.ctx, security.Authentication{
+			Type:        AuthTypeWebAuthn,
+			Principal:   "token",
+			Credentials: "not an assertion",
+		})
+
+		resErr, ok := result.AsErr(err)
+		s.Require().True(ok, "Should return a result.Error")
+		s.Equal(result.ErrCodeCredentialsInvalid, resErr.Code, "Should reject malformed credentials")
+	})
+
+	s.Run("UserNoLongerExists", func() {
+		loader := new(MockUserLoader)
+		loader.On("LoadByID", mock.Anything, "alice").Return(nil, result.ErrRecordNotFound).Once()
+
+		ceremonyToken, response := s.login()
+
+		_, err := NewWebAuthnAuthenticator(s.webAuthn, loader).Authenticate(s.ctx, security.Authentication{
+			Type:        AuthTypeWebAuthn,
+			Principal:   ceremonyToken,
+			Credentials: response,
+		})
+
+		resErr, ok := result.AsErr(err)
+		s.Require().True(ok, "Should return a result.Error")
+		s.Equal(result.ErrCodeWebAuthnVerificationFailed, resErr.Code, "Should reject passkeys of deleted users")
+	})
+}
+
+func TestWebAuthnAuthenticator(t *testing.T) {
+	suite.Run(t, new(WebAuthnAuthenticatorTestSuite))
+}
@@ -0,0 +1,100 @@
+package security
+
+import (
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/security"
+)
+
+// WebAuthnResource exposes the endpoints users manage their own passkeys with.
+type WebAuthnResource struct {
+	api.Resource
+
+	webAuthn *security.WebAuthn
+}
+
+// NewWebAuthnResource creates a new passkey management resource.
+func NewWebAuthnResource(webAuthn *security.WebAuthn) api.Resource {
+	return &WebAuthnResource{
+		webAuthn: webAuthn,
+		Resource: api.NewRPCResource(
+			"security/webauthn",
+			api.WithOperations(
+				api.OperationSpec{Action: "begin_registration"},
+				api.OperationSpec{Action: "finish_registration"},
+				api.OperationSpec{Action: "list_credentials"},
+				api.OperationSpec{Action: "remove_credential"},
+			),
+		),
+	}
+}
+
+// BeginRegistration starts registering a passkey for the current user,
+// returning the options to create it with and the ceremony token to finish the registration with.
+func (r *WebAuthnResource) BeginRegistration(ctx fiber.Ctx, principal *security.Principal) error {
+	if err := checkKeyManager(principal); err != nil {
+		return err
+	}
+
+	options, err := r.webAuthn.BeginRegistration(ctx.Context(), principal)
+	if err != nil {
+		return err
+	}
+
+	return result.Ok(options).Response(ctx)
+}
+
+// FinishRegistrationParams represents the request parameters for finishing a passkey registration.
+type FinishRegistrationParams struct {
+	api.P
+
+	CeremonyToken string                                `json:"ceremonyToken" validate:"required" label_i18n:"auth_webauthn_ceremony_token"`
+	Name          string                                `json:"name" validate:"max=64" label_i18n:"auth_webauthn_credential_name"`
+	Credential    security.WebAuthnRegistrationResponse `json:"credential" label_i18n:"auth_webauthn_credential"`
+}
+
+// FinishRegistration verifies the created passkey and registers it for the current user.
+func (r *WebAuthnResource) FinishRegistration(ctx fiber.Ctx, principal *security.Principal, params FinishRegistrationParams) error {
+	if err := checkKeyManager(principal); err != nil {
+		return err
+	}
+
+	credential, err := r.webAuthn.FinishRegistration(ctx.Context(), principal, params.CeremonyToken, params.Name, &params.Credential)
+	if err != nil {
+		return err
+	}
+
+	return result.Ok(credential).Response(ctx)
+}
+
+// ListCredentials lists the passkeys of the current user.
+func (r *WebAuthnResource) ListCredentials(ctx fiber.Ctx, principal *security.Principal) error {
+	credentials, err := r.webAuthn.Credentials(ctx.Context(), principal.ID)
+	if err != nil {
+		return err
+	}
+
+	return result.Ok(credentials).Response(ctx)
+}
+
+// RemoveCredentialParams represents the request parameters for removing a passkey.
+type RemoveCredentialParams struct {
+	api.P
+
+	ID string `json:"id" validate:"required" label_i18n:"auth_webauthn_credential_id"`
+}
+
+// RemoveCredential removes a passkey of the current user.
+func (r *WebAuthnResource) RemoveCredential(ctx fiber.Ctx, principal *security.Principal, params RemoveCredentialParams) error {
+	if err := checkKeyManager(principal); err != nil {
+		return err
+	}
+
+	if err := r.webAuthn.RemoveCredential(ctx.Context(), principal.ID, params.ID); err != nil {
+		return err
+	}
+
+	return result.Ok().Response(ctx)
+}
@@ -2,9 +2,11 @@ package sequence
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"strings"
 
+	"github.com/coldsmirk/vef-framework-go/contextx"
 	"github.com/coldsmirk/vef-framework-go/sequence"
 	"github.com/coldsmirk/vef-framework-go/timex"
 )
@@ -35,7 +37,7 @@ func (e *Engine) GenerateN(ctx context.Context, key string, count int) ([]string
 
 	now := timex.Now()
 
-	rule, newValue, err := e.store.Reserve(ctx, key, count, now)
+	rule, newValue, err := e.reserve(ctx, key, count, now)
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +45,23 @@ func (e *Engine) GenerateN(ctx context.Context, key string, count int) ([]string
 	return buildSerialNumbers(rule, newValue, count, now), nil
 }
 
+// reserve reserves from the counter of the context tenant, or from the shared rule for requests without a tenant.
+// Stores that cannot keep per-tenant counters fall back to the shared rule unless a tenant-specific rule is registered.
+func (e *Engine) reserve(ctx context.Context, key string, count int, now timex.DateTime) (*sequence.Rule, int, error) {
+	if tenantID := contextx.TenantID(ctx); tenantID != "" {
+		if store, ok := e.store.(sequence.TenantStore); ok {
+			return store.ReserveTenant(ctx, tenantID, key, count, now)
+		}
+
+		rule, newValue, err := e.store.Reserve(ctx, sequence.TenantKey(tenantID, key), count, now)
+		if !errors.Is(err, sequence.ErrRuleNotFound) {
+			return rule, newValue, err
+		}
+	}
+
+	return e.store.Reserve(ctx, key, count, now)
+}
+
 // buildSerialNumbers constructs serial number strings for a batch.
 // NewValue is the final counter value after incrementing; we work backwards to get each value.
 func buildSerialNumbers(rule *sequence.Rule, newValue, count int, now timex.DateTime) []string {
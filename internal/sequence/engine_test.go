@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/coldsmirk/vef-framework-go/config"
+	"github.com/coldsmirk/vef-framework-go/contextx"
 	"github.com/coldsmirk/vef-framework-go/id"
 	"github.com/coldsmirk/vef-framework-go/internal/testx"
 	"github.com/coldsmirk/vef-framework-go/sequence"
@@ -132,6 +133,39 @@ func TestGenerateOverflowErrorShouldNotAdvanceCounter(t *testing.T) {
 	require.ErrorIs(t, err, sequence.ErrSequenceOverflow, "Counter should not advance after overflow error")
 }
 
+func TestGenerateTenantRule(t *testing.T) {
+	key := "tenant-order"
+
+	store := sequence.NewMemoryStore().(*sequence.MemoryStore)
+	store.Register(
+		newTestRule(key, func(r *sequence.Rule) { r.Prefix = "S" }),
+		newTestRule(sequence.TenantKey("t1", key), func(r *sequence.Rule) { r.Prefix = "T" }),
+	)
+	generator := NewGenerator(store)
+
+	t.Run("UsesTenantRule", func(t *testing.T) {
+		serialNo, err := generator.Generate(contextx.SetTenantID(context.Background(), "t1"), key)
+		require.NoError(t, err, "Should generate from the tenant rule")
+		assert.Equal(t, "T0001", serialNo, "Should use the tenant-specific counter")
+	})
+
+	t.Run("CopiesSharedRulePerTenant", func(t *testing.T) {
+		serialNo, err := generator.Generate(contextx.SetTenantID(context.Background(), "t2"), key)
+		require.NoError(t, err, "Should generate from a copy of the shared rule")
+		assert.Equal(t, "S0001", serialNo, "Should start a counter for the tenant")
+
+		serialNo, err = generator.Generate(contextx.SetTenantID(context.Background(), "t3"), key)
+		require.NoError(t, err, "Should generate from a copy of the shared rule")
+		assert.Equal(t, "S0001", serialNo, "Tenants should not share a counter")
+	})
+
+	t.Run("WithoutTenant", func(t *testing.T) {
+		serialNo, err := generator.Generate(context.Background(), key)
+		require.NoError(t, err, "Should generate from the shared rule")
+		assert.Equal(t, "S0001", serialNo, "Tenant generation should not consume the shared counter")
+	})
+}
+
 func TestGenerateOverflowResetShouldUseSingleIncrementCall(t *testing.T) {
 	key := "overflow-reset-single-reserve"
 	store := newScriptedReserveStore(newTestRule(key, func(rule *sequence.Rule) {
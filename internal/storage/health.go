@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/coldsmirk/vef-framework-go/monitor"
+	"github.com/coldsmirk/vef-framework-go/storage"
+)
+
+// newHealthIndicator checks that the storage backend is reachable by listing at most one object of the bucket.
+func newHealthIndicator(service storage.Service) monitor.HealthIndicator {
+	return monitor.NewHealthIndicator("storage", func(ctx context.Context) error {
+		_, err := service.ListObjects(ctx, storage.ListObjectsOptions{MaxKeys: 1})
+
+		return err
+	})
+}
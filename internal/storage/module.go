@@ -1,11 +1,13 @@
 package storage
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 
 	"go.uber.org/fx"
 
+	"github.com/coldsmirk/vef-framework-go/config"
 	"github.com/coldsmirk/vef-framework-go/internal/contract"
 	"github.com/coldsmirk/vef-framework-go/internal/logx"
 	"github.com/coldsmirk/vef-framework-go/storage"
@@ -17,7 +19,14 @@ var Module = fx.Module(
 	"vef:storage",
 	fx.Provide(
 		fx.Annotate(
-			NewService,
+			func(cfg *config.StorageConfig, appCfg *config.AppConfig) (storage.Service, error) {
+				service, err := NewService(cfg, appCfg)
+				if err != nil {
+					return nil, err
+				}
+
+				return newTracedService(service, string(cmp.Or(cfg.Provider, config.StorageMemory))), nil
+			},
 			fx.OnStart(func(ctx context.Context, service storage.Service) error {
 				if initializer, ok := service.(contract.Initializer); ok {
 					if err := initializer.Init(ctx); err != nil {
@@ -36,5 +45,9 @@ var Module = fx.Module(
 			NewProxyMiddleware,
 			fx.ResultTags(`group:"vef:app:middlewares"`),
 		),
+		fx.Annotate(
+			newHealthIndicator,
+			fx.ResultTags(`group:"vef:health:indicators"`),
+		),
 	),
 )
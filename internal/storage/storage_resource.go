@@ -11,6 +11,7 @@ import (
 	"github.com/gofiber/fiber/v3"
 
 	"github.com/coldsmirk/vef-framework-go/api"
+	"github.com/coldsmirk/vef-framework-go/contextx"
 	"github.com/coldsmirk/vef-framework-go/httpx"
 	"github.com/coldsmirk/vef-framework-go/i18n"
 	"github.com/coldsmirk/vef-framework-go/id"
@@ -55,6 +56,7 @@ type UploadParams struct {
 }
 
 // Upload generates date-partitioned keys (temp/YYYY/MM/DD/{uuid}{ext}) to organize uploads and avoid conflicts.
+// Uploads of a tenant are placed under temp/tenants/{tenantID}/.
 func (r *Resource) Upload(ctx fiber.Ctx, params UploadParams) error {
 	if httpx.IsJSON(ctx) {
 		return result.Err(i18n.T("upload_requires_multipart"))
@@ -64,7 +66,7 @@ func (r *Resource) Upload(ctx fiber.Ctx, params UploadParams) error {
 		return result.Err(i18n.T("upload_requires_file"))
 	}
 
-	key := r.generateObjectKey(params.File.Filename)
+	key := storage.TenantKey(contextx.TenantID(ctx), r.generateObjectKey(params.File.Filename))
 
 	file, err := params.File.Open()
 	if err != nil {
@@ -124,6 +126,10 @@ type GetPresignedURLParams struct {
 }
 
 func (r *Resource) GetPresignedURL(ctx fiber.Ctx, params GetPresignedURLParams) error {
+	if err := checkTenantKeys(ctx, params.Key); err != nil {
+		return err
+	}
+
 	expires := params.Expires
 	if expires <= 0 {
 		expires = 3600 // 1 hour default
@@ -154,6 +160,10 @@ type DeleteTempParams struct {
 
 // DeleteTemp restricts deletion to temp/ prefix to prevent accidental removal of permanent files.
 func (r *Resource) DeleteTemp(ctx fiber.Ctx, params DeleteTempParams) error {
+	if err := checkTenantKeys(ctx, params.Key); err != nil {
+		return err
+	}
+
 	if !strings.HasPrefix(params.Key, storage.TempPrefix) {
 		return result.Err(i18n.T("invalid_temp_key"))
 	}
@@ -174,6 +184,10 @@ type DeleteParams struct {
 }
 
 func (r *Resource) Delete(ctx fiber.Ctx, params DeleteParams) error {
+	if err := checkTenantKeys(ctx, params.Key); err != nil {
+		return err
+	}
+
 	if err := r.service.DeleteObject(ctx.Context(), storage.DeleteObjectOptions{
 		Key: params.Key,
 	}); err != nil {
@@ -190,6 +204,10 @@ type DeleteManyParams struct {
 }
 
 func (r *Resource) DeleteMany(ctx fiber.Ctx, params DeleteManyParams) error {
+	if err := checkTenantKeys(ctx, params.Keys...); err != nil {
+		return err
+	}
+
 	if err := r.service.DeleteObjects(ctx.Context(), storage.DeleteObjectsOptions{
 		Keys: params.Keys,
 	}); err != nil {
@@ -207,9 +225,10 @@ type ListParams struct {
 	MaxKeys   int    `json:"maxKeys"`
 }
 
+// List confines tenant requests to the tenant's storage area by placing the prefix inside it.
 func (r *Resource) List(ctx fiber.Ctx, params ListParams) error {
 	objects, err := r.service.ListObjects(ctx.Context(), storage.ListObjectsOptions{
-		Prefix:    params.Prefix,
+		Prefix:    storage.TenantKey(contextx.TenantID(ctx), params.Prefix),
 		Recursive: params.Recursive,
 		MaxKeys:   params.MaxKeys,
 	})
@@ -228,6 +247,10 @@ type CopyParams struct {
 }
 
 func (r *Resource) Copy(ctx fiber.Ctx, params CopyParams) error {
+	if err := checkTenantKeys(ctx, params.SourceKey, params.DestKey); err != nil {
+		return err
+	}
+
 	info, err := r.service.CopyObject(ctx.Context(), storage.CopyObjectOptions{
 		SourceKey: params.SourceKey,
 		DestKey:   params.DestKey,
@@ -247,6 +270,10 @@ type MoveParams struct {
 }
 
 func (r *Resource) Move(ctx fiber.Ctx, params MoveParams) error {
+	if err := checkTenantKeys(ctx, params.SourceKey, params.DestKey); err != nil {
+		return err
+	}
+
 	info, err := r.service.MoveObject(ctx.Context(), storage.MoveObjectOptions{
 		CopyObjectOptions: storage.CopyObjectOptions{
 			SourceKey: params.SourceKey,
@@ -267,6 +294,10 @@ type StatParams struct {
 }
 
 func (r *Resource) Stat(ctx fiber.Ctx, params StatParams) error {
+	if err := checkTenantKeys(ctx, params.Key); err != nil {
+		return err
+	}
+
 	info, err := r.service.StatObject(ctx.Context(), storage.StatObjectOptions{
 		Key: params.Key,
 	})
@@ -280,3 +311,23 @@ func (r *Resource) Stat(ctx fiber.Ctx, params StatParams) error {
 
 	return result.Ok(info).Response(ctx)
 }
+
+// checkTenantKeys rejects keys outside the storage area of the request tenant.
+// Requests without a tenant are not restricted.
+func checkTenantKeys(ctx fiber.Ctx, keys ...string) error {
+	tenantID := contextx.TenantID(ctx)
+	if tenantID == "" {
+		return nil
+	}
+
+	for _, key := range keys {
+		if !storage.IsTenantKey(tenantID, key) {
+			return result.Err(
+				i18n.T(result.ErrMessageInvalidFileKey),
+				result.WithCode(result.ErrCodeInvalidFileKey),
+			)
+		}
+	}
+
+	return nil
+}
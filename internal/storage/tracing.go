@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/coldsmirk/vef-framework-go/internal/contract"
+	"github.com/coldsmirk/vef-framework-go/storage"
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+// tracedService records the calls of a traced request or job to the storage backend as spans.
+type tracedService struct {
+	service storage.Service
+	backend string
+}
+
+// newTracedService wraps the storage service so that its calls are traced.
+func newTracedService(service storage.Service, backend string) storage.Service {
+	return &tracedService{
+		service: service,
+		backend: backend,
+	}
+}
+
+// Init initializes the wrapped service if it requires initialization.
+func (s *tracedService) Init(ctx context.Context) error {
+	if initializer, ok := s.service.(contract.Initializer); ok {
+		return initializer.Init(ctx)
+	}
+
+	return nil
+}
+
+func (s *tracedService) start(ctx context.Context, operation, key string) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{attribute.String(tracing.AttrStorageBackend, s.backend)}
+	if key != "" {
+		attributes = append(attributes, attribute.String(tracing.AttrStorageKey, key))
+	}
+
+	return tracing.StartChild(
+		ctx,
+		"storage "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+func (s *tracedService) PutObject(ctx context.Context, opts storage.PutObjectOptions) (*storage.ObjectInfo, error) {
+	ctx, span := s.start(ctx, "put_object", opts.Key)
+	info, err := s.service.PutObject(ctx, opts)
+	tracing.End(span, err)
+
+	return info, err
+}
+
+func (s *tracedService) GetObject(ctx context.Context, opts storage.GetObjectOptions) (io.ReadCloser, error) {
+	ctx, span := s.start(ctx, "get_object", opts.Key)
+	reader, err := s.service.GetObject(ctx, opts)
+	tracing.End(span, err)
+
+	return reader, err
+}
+
+func (s *tracedService) DeleteObject(ctx context.Context, opts storage.DeleteObjectOptions) error {
+	ctx, span := s.start(ctx, "delete_object", opts.Key)
+	err := s.service.DeleteObject(ctx, opts)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (s *tracedService) DeleteObjects(ctx context.Context, opts storage.DeleteObjectsOptions) error {
+	ctx, span := s.start(ctx, "delete_objects", "")
+	span.SetAttributes(attribute.Int("vef.storage.key_count", len(opts.Keys)))
+	err := s.service.DeleteObjects(ctx, opts)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (s *tracedService) ListObjects(ctx context.Context, opts storage.ListObjectsOptions) ([]storage.ObjectInfo, error) {
+	ctx, span := s.start(ctx, "list_objects", opts.Prefix)
+	objects, err := s.service.ListObjects(ctx, opts)
+	tracing.End(span, err)
+
+	return objects, err
+}
+
+func (s *tracedService) GetPresignedURL(ctx context.Context, opts storage.PresignedURLOptions) (string, error) {
+	ctx, span := s.start(ctx, "get_presigned_url", opts.Key)
+	url, err := s.service.GetPresignedURL(ctx, opts)
+	tracing.End(span, err)
+
+	return url, err
+}
+
+func (s *tracedService) CopyObject(ctx context.Context, opts storage.CopyObjectOptions) (*storage.ObjectInfo, error) {
+	ctx, span := s.start(ctx, "copy_object", opts.DestKey)
+	info, err := s.service.CopyObject(ctx, opts)
+	tracing.End(span, err)
+
+	return info, err
+}
+
+func (s *tracedService) MoveObject(ctx context.Context, opts storage.MoveObjectOptions) (*storage.ObjectInfo, error) {
+	ctx, span := s.start(ctx, "move_object", opts.DestKey)
+	info, err := s.service.MoveObject(ctx, opts)
+	tracing.End(span, err)
+
+	return info, err
+}
+
+func (s *tracedService) StatObject(ctx context.Context, opts storage.StatObjectOptions) (*storage.ObjectInfo, error) {
+	ctx, span := s.start(ctx, "stat_object", opts.Key)
+	info, err := s.service.StatObject(ctx, opts)
+	tracing.End(span, err)
+
+	return info, err
+}
+
+func (s *tracedService) PromoteObject(ctx context.Context, tempKey string) (*storage.ObjectInfo, error) {
+	ctx, span := s.start(ctx, "promote_object", tempKey)
+	info, err := s.service.PromoteObject(ctx, tempKey)
+	tracing.End(span, err)
+
+	return info, err
+}
@@ -0,0 +1,31 @@
+package testx
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+// MetricValue returns the value of a single counter or gauge, or the sample count of a single histogram,
+// e.g. a child of a vector obtained with WithLabelValues.
+func MetricValue(t *testing.T, metric prometheus.Metric) float64 {
+	t.Helper()
+
+	var m dto.Metric
+	require.NoError(t, metric.Write(&m), "Should write the metric")
+
+	switch {
+	case m.Counter != nil:
+		return m.Counter.GetValue()
+	case m.Gauge != nil:
+		return m.Gauge.GetValue()
+	case m.Histogram != nil:
+		return float64(m.Histogram.GetSampleCount())
+	default:
+		require.FailNow(t, "Unsupported metric type")
+
+		return 0
+	}
+}
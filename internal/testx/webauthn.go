@@ -0,0 +1,249 @@
+package testx
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"slices"
+)
+
+// WebAuthnAttestation is the response of a software authenticator to a registration, base64url-encoded.
+type WebAuthnAttestation struct {
+	ID                string
+	ClientDataJSON    string
+	AttestationObject string
+}
+
+// WebAuthnAssertion is the response of a software authenticator to an assertion, base64url-encoded.
+type WebAuthnAssertion struct {
+	ID                string
+	ClientDataJSON    string
+	AuthenticatorData string
+	Signature         string
+	UserHandle        string
+}
+
+// WebAuthnAuthenticator is a software WebAuthn authenticator holding a single credential,
+// used to run registration and assertion ceremonies in unit tests without a browser.
+// Its fields may be changed between ceremonies to produce responses a relying party must reject.
+type WebAuthnAuthenticator struct {
+	// RPID is the relying party ID the authenticator signs its authenticator data for.
+	RPID string
+	// Origin is the origin the client data is collected at.
+	Origin string
+	// UserVerified reports whether the authenticator sets the user verification flag.
+	UserVerified bool
+	// SignCount is the signature counter, incremented before every assertion.
+	SignCount uint32
+	// NoCounter makes the authenticator report a zero signature counter, as synced passkeys do.
+	NoCounter bool
+	// UserHandle is the base64url-encoded user handle of the credential, set by Register.
+	UserHandle string
+
+	credentialID []byte
+	signer       crypto.Signer
+	coseKey      []byte
+}
+
+// NewWebAuthnAuthenticator creates a software authenticator with an ES256 (P-256) credential.
+func NewWebAuthnAuthenticator(rpID, origin string) *WebAuthnAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	point, err := key.PublicKey.Bytes()
+	if err != nil {
+		panic(err)
+	}
+
+	// COSE_Key {1: 2 (EC2), 3: -7 (ES256), -1: 1 (P-256), -2: x, -3: y}
+	coseKey := slices.Concat(
+		cborHead(5, 5),
+		cborInt(1), cborInt(2),
+		cborInt(3), cborInt(-7),
+		cborInt(-1), cborInt(1),
+		cborInt(-2), cborBytes(point[1:33]),
+		cborInt(-3), cborBytes(point[33:]),
+	)
+
+	return newWebAuthnAuthenticator(rpID, origin, key, coseKey)
+}
+
+// NewEd25519WebAuthnAuthenticator creates a software authenticator with an EdDSA (Ed25519) credential.
+func NewEd25519WebAuthnAuthenticator(rpID, origin string) *WebAuthnAuthenticator {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	// COSE_Key {1: 1 (OKP), 3: -8 (EdDSA), -1: 6 (Ed25519), -2: x}
+	coseKey := slices.Concat(
+		cborHead(5, 4),
+		cborInt(1), cborInt(1),
+		cborInt(3), cborInt(-8),
+		cborInt(-1), cborInt(6),
+		cborInt(-2), cborBytes(publicKey),
+	)
+
+	return newWebAuthnAuthenticator(rpID, origin, privateKey, coseKey)
+}
+
+func newWebAuthnAuthenticator(rpID, origin string, signer crypto.Signer, coseKey []byte) *WebAuthnAuthenticator {
+	credentialID := make([]byte, 16)
+	_, _ = rand.Read(credentialID)
+
+	return &WebAuthnAuthenticator{
+		RPID:         rpID,
+		Origin:       origin,
+		UserVerified: true,
+		credentialID: credentialID,
+		signer:       signer,
+		coseKey:      coseKey,
+	}
+}
+
+// CredentialID returns the base64url-encoded ID of the authenticator's credential.
+func (a *WebAuthnAuthenticator) CredentialID() string {
+	return base64.RawURLEncoding.EncodeToString(a.credentialID)
+}
+
+// Register creates the credential for the base64url-encoded challenge and user handle of a registration,
+// returning an attestation with the "none" format.
+func (a *WebAuthnAuthenticator) Register(challenge, userHandle string) WebAuthnAttestation {
+	a.UserHandle = userHandle
+
+	authData := slices.Concat(
+		a.authenticatorData(0x40),
+		make([]byte, 16), // AAGUID
+		binary.BigEndian.AppendUint16(nil, uint16(len(a.credentialID))),
+		a.credentialID,
+		a.coseKey,
+	)
+
+	// {"fmt": "none", "attStmt": {}, "authData": authData}
+	attestationObject := slices.Concat(
+		cborHead(5, 3),
+		cborText("fmt"), cborText("none"),
+		cborText("attStmt"), cborHead(5, 0),
+		cborText("authData"), cborBytes(authData),
+	)
+
+	return WebAuthnAttestation{
+		ID:                a.CredentialID(),
+		ClientDataJSON:    a.clientData("webauthn.create", challenge),
+		AttestationObject: base64.RawURLEncoding.EncodeToString(attestationObject),
+	}
+}
+
+// Assert signs the base64url-encoded challenge of an assertion with the credential.
+func (a *WebAuthnAuthenticator) Assert(challenge string) WebAuthnAssertion {
+	if !a.NoCounter {
+		a.SignCount++
+	}
+
+	var (
+		authData       = a.authenticatorData(0)
+		clientData     = a.clientData("webauthn.get", challenge)
+		rawClientData  = mustDecodeBase64URL(clientData)
+		clientDataHash = sha256.Sum256(rawClientData)
+		signed         = slices.Concat(authData, clientDataHash[:])
+		signature      []byte
+		err            error
+	)
+
+	if key, ok := a.signer.(ed25519.PrivateKey); ok {
+		signature = ed25519.Sign(key, signed)
+	} else {
+		digest := sha256.Sum256(signed)
+		if signature, err = a.signer.Sign(rand.Reader, digest[:], crypto.SHA256); err != nil {
+			panic(err)
+		}
+	}
+
+	return WebAuthnAssertion{
+		ID:                a.CredentialID(),
+		ClientDataJSON:    clientData,
+		AuthenticatorData: base64.RawURLEncoding.EncodeToString(authData),
+		Signature:         base64.RawURLEncoding.EncodeToString(signature),
+		UserHandle:        a.UserHandle,
+	}
+}
+
+// authenticatorData encodes the fixed part of the authenticator data with the user flags and extra flags set.
+func (a *WebAuthnAuthenticator) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.RPID))
+
+	flags |= 0x01 // User present
+	if a.UserVerified {
+		flags |= 0x04
+	}
+
+	var signCount uint32
+	if !a.NoCounter {
+		signCount = a.SignCount
+	}
+
+	return slices.Concat(rpIDHash[:], []byte{flags}, binary.BigEndian.AppendUint32(nil, signCount))
+}
+
+func (a *WebAuthnAuthenticator) clientData(ceremonyType, challenge string) string {
+	data, err := json.Marshal(map[string]any{
+		"type":        ceremonyType,
+		"challenge":   challenge,
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func mustDecodeBase64URL(value string) []byte {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return decoded
+}
+
+// cborHead encodes the initial bytes of a CBOR item of the major type with the argument.
+func cborHead(majorType byte, argument uint64) []byte {
+	switch {
+	case argument < 24:
+		return []byte{majorType<<5 | byte(argument)}
+	case argument <= 0xff:
+		return []byte{majorType<<5 | 24, byte(argument)}
+	case argument <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{majorType<<5 | 25}, uint16(argument))
+	case argument <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{majorType<<5 | 26}, uint32(argument))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{majorType<<5 | 27}, argument)
+	}
+}
+
+func cborInt(value int64) []byte {
+	if value < 0 {
+		return cborHead(1, uint64(-1-value))
+	}
+
+	return cborHead(0, uint64(value))
+}
+
+func cborBytes(value []byte) []byte {
+	return slices.Concat(cborHead(2, uint64(len(value))), value)
+}
+
+func cborText(value string) []byte {
+	return slices.Concat(cborHead(3, uint64(len(value))), []byte(value))
+}
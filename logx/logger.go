@@ -1,5 +1,10 @@
 package logx
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Level represents a logging priority. Higher levels are more important.
 type Level int8
 
@@ -34,10 +39,31 @@ func (l Level) String() string {
 	}
 }
 
+// ParseLevel parses a level name (debug|info|warn|error|panic), case-insensitively.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	case "panic":
+		return LevelPanic, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
 // Logger defines the core logging interface for structured logging across the framework.
 type Logger interface {
 	// Named creates a child logger with the given namespace.
 	Named(name string) Logger
+	// With creates a child logger that adds the given key-value pairs as structured fields to every entry,
+	// e.g. logger.With("user_id", id, "attempt", 3).
+	With(keysAndValues ...any) Logger
 	// WithCallerSkip adjusts the number of stack frames to skip when reporting caller location.
 	WithCallerSkip(skip int) Logger
 	// Enabled checks whether the given log level is enabled.
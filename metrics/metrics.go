@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Namespace prefixes the names of the framework metrics, e.g. vef_api_requests_total.
+const Namespace = "vef"
+
+var (
+	registry = newRegistry()
+	factory  = promauto.With(registry)
+)
+
+func newRegistry() *prometheus.Registry {
+	r := prometheus.NewRegistry()
+	r.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return r
+}
+
+// Registerer returns the registry exposed at the metrics endpoint,
+// e.g. for libraries instrumenting themselves against a prometheus.Registerer.
+func Registerer() prometheus.Registerer {
+	return registry
+}
+
+// Gatherer returns the registry exposed at the metrics endpoint.
+func Gatherer() prometheus.Gatherer {
+	return registry
+}
+
+// Register registers the collector, which is exposed at the metrics endpoint from then on.
+// Registering a collector describing the same metrics as one registered before is not an error,
+// e.g. when an application is started again within the same process.
+func Register(collector prometheus.Collector) error {
+	if err := registry.Register(collector); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegistered) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Unregister removes the collector, reporting whether it was registered.
+func Unregister(collector prometheus.Collector) bool {
+	return registry.Unregister(collector)
+}
+
+// NewCounter creates and registers a counter, panicking if the metric is invalid or registered already.
+// Business metrics are typically created once as package-level variables.
+func NewCounter(opts prometheus.CounterOpts) prometheus.Counter {
+	return factory.NewCounter(opts)
+}
+
+// NewCounterVec creates and registers a counter partitioned by the labels.
+func NewCounterVec(opts prometheus.CounterOpts, labelNames ...string) *prometheus.CounterVec {
+	return factory.NewCounterVec(opts, labelNames)
+}
+
+// NewGauge creates and registers a gauge.
+func NewGauge(opts prometheus.GaugeOpts) prometheus.Gauge {
+	return factory.NewGauge(opts)
+}
+
+// NewGaugeVec creates and registers a gauge partitioned by the labels.
+func NewGaugeVec(opts prometheus.GaugeOpts, labelNames ...string) *prometheus.GaugeVec {
+	return factory.NewGaugeVec(opts, labelNames)
+}
+
+// NewGaugeFunc creates and registers a gauge whose value is read from fn at every scrape.
+func NewGaugeFunc(opts prometheus.GaugeOpts, fn func() float64) prometheus.GaugeFunc {
+	return factory.NewGaugeFunc(opts, fn)
+}
+
+// NewHistogram creates and registers a histogram, with prometheus.DefBuckets if opts has no buckets.
+func NewHistogram(opts prometheus.HistogramOpts) prometheus.Histogram {
+	return factory.NewHistogram(opts)
+}
+
+// NewHistogramVec creates and registers a histogram partitioned by the labels.
+func NewHistogramVec(opts prometheus.HistogramOpts, labelNames ...string) *prometheus.HistogramVec {
+	return factory.NewHistogramVec(opts, labelNames)
+}
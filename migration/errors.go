@@ -0,0 +1,20 @@
+package migration
+
+import "errors"
+
+var (
+	// ErrInvalidFileName indicates a migration file does not follow the <version>_<name>.<up|down>.sql convention.
+	ErrInvalidFileName = errors.New("invalid migration file name")
+	// ErrDuplicateVersion indicates two migrations share the same version.
+	ErrDuplicateVersion = errors.New("duplicate migration version")
+	// ErrMissingUp indicates a migration has no up step.
+	ErrMissingUp = errors.New("migration has no up step")
+	// ErrIrreversible indicates a migration to roll back has no down step.
+	ErrIrreversible = errors.New("migration has no down step")
+	// ErrUnknownVersion indicates an applied version has no matching migration in any source.
+	ErrUnknownVersion = errors.New("applied migration version not found in sources")
+	// ErrLockTimeout indicates the migration lock could not be acquired in time.
+	ErrLockTimeout = errors.New("timed out waiting for migration lock")
+	// ErrEmptyName indicates a migration was created without a name.
+	ErrEmptyName = errors.New("migration name must not be empty")
+)
@@ -0,0 +1,57 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
+// VersionLayout is the time layout of migration versions created by Create.
+const VersionLayout = "20060102150405"
+
+// Create writes the up and down SQL files of a new migration named name into dir and returns their paths.
+// The version is derived from at. When diff is not empty its statements fill the files; otherwise they are left blank.
+func Create(dir, name string, at time.Time, diff *Diff) (upPath, downPath string, err error) {
+	name = lo.SnakeCase(name)
+	if name == "" {
+		return "", "", ErrEmptyName
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", fmt.Errorf("create migration directory: %w", err)
+	}
+
+	base := at.UTC().Format(VersionLayout) + "_" + name
+	upPath = filepath.Join(dir, base+"."+string(DirectionUp)+".sql")
+	downPath = filepath.Join(dir, base+"."+string(DirectionDown)+".sql")
+
+	var up, down []string
+	if diff != nil {
+		up, down = diff.Up, diff.Down
+	}
+
+	if err := os.WriteFile(upPath, []byte(joinStatements(up)), 0o644); err != nil {
+		return "", "", fmt.Errorf("write up migration: %w", err)
+	}
+
+	if err := os.WriteFile(downPath, []byte(joinStatements(down)), 0o644); err != nil {
+		return "", "", fmt.Errorf("write down migration: %w", err)
+	}
+
+	return upPath, downPath, nil
+}
+
+// joinStatements renders statements one per line, each terminated by a semicolon.
+func joinStatements(statements []string) string {
+	var sb strings.Builder
+	for _, statement := range statements {
+		sb.WriteString(strings.TrimSuffix(strings.TrimSpace(statement), ";"))
+		sb.WriteString(";\n")
+	}
+
+	return sb.String()
+}
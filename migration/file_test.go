@@ -0,0 +1,61 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("Empty", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "migrations")
+
+		upPath, downPath, err := Create(dir, "AddOrderRemark", at, nil)
+		require.NoError(t, err, "Should create migration files")
+
+		assert.Equal(t, filepath.Join(dir, "20260102030405_add_order_remark.up.sql"), upPath, "Should name the up file by version and snake-cased name")
+		assert.Equal(t, filepath.Join(dir, "20260102030405_add_order_remark.down.sql"), downPath, "Should name the down file by version and snake-cased name")
+
+		content, err := os.ReadFile(upPath)
+		require.NoError(t, err, "Should read the up file")
+		assert.Empty(t, content, "Up file should be blank")
+	})
+
+	t.Run("WithDiff", func(t *testing.T) {
+		dir := t.TempDir()
+
+		upPath, downPath, err := Create(dir, "sync", at, &Diff{
+			Up:   []string{"CREATE TABLE t (id INT)", "ALTER TABLE u ADD c INT;"},
+			Down: []string{"ALTER TABLE u DROP c", "DROP TABLE t"},
+		})
+		require.NoError(t, err, "Should create migration files from a diff")
+
+		up, err := os.ReadFile(upPath)
+		require.NoError(t, err, "Should read the up file")
+		assert.Equal(t, "CREATE TABLE t (id INT);\nALTER TABLE u ADD c INT;\n", string(up), "Should write one terminated statement per line")
+
+		down, err := os.ReadFile(downPath)
+		require.NoError(t, err, "Should read the down file")
+		assert.Equal(t, "ALTER TABLE u DROP c;\nDROP TABLE t;\n", string(down), "Should write the reverse statements")
+
+		_, _, _, err = ParseFileName(filepath.Base(upPath))
+		assert.NoError(t, err, "Created files should be loadable")
+	})
+
+	t.Run("EmptyName", func(t *testing.T) {
+		_, _, err := Create(t.TempDir(), "  ", at, nil)
+		assert.ErrorIs(t, err, ErrEmptyName, "Should reject an empty name")
+	})
+}
+
+func TestDiffIsEmpty(t *testing.T) {
+	assert.True(t, (*Diff)(nil).IsEmpty(), "Nil diff should be empty")
+	assert.True(t, new(Diff).IsEmpty(), "Diff without statements should be empty")
+	assert.False(t, (&Diff{Up: []string{"SELECT 1"}}).IsEmpty(), "Diff with statements should not be empty")
+}
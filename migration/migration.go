@@ -0,0 +1,64 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	"github.com/coldsmirk/vef-framework-go/orm"
+)
+
+// Func is a migration step implemented in Go.
+// It runs inside the transaction that also records the migration, so returning an error rolls both back.
+type Func func(ctx context.Context, db orm.DB) error
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	// Version orders migrations and identifies them in the tracking table, conventionally a yyyyMMddHHmmss timestamp.
+	Version int64
+	// Name describes the change, e.g. create_order.
+	Name string
+	// Up applies the change.
+	Up Func
+	// Down reverts the change; nil when the migration cannot be rolled back.
+	Down Func
+}
+
+// Source supplies migrations to the migrator.
+type Source interface {
+	// Migrations returns the migrations of this source in any order.
+	Migrations() ([]*Migration, error)
+}
+
+// Status describes whether a migration has been applied.
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+}
+
+// Diff holds the statements that bring the live schema in line with the registered models.
+type Diff struct {
+	// Up lists the statements that migrate the live schema to the models.
+	Up []string
+	// Down lists the statements that revert Up, in execution order.
+	Down []string
+}
+
+// IsEmpty reports whether the live schema already matches the models.
+func (d *Diff) IsEmpty() bool {
+	return d == nil || len(d.Up) == 0
+}
+
+// Migrator applies and reverts versioned migrations.
+// Every operation that changes the schema holds a database lock, so only one instance migrates at a time.
+type Migrator interface {
+	// Up applies all pending migrations in version order and returns the applied ones.
+	Up(ctx context.Context) ([]*Migration, error)
+	// Down reverts the latest steps applied migrations in reverse version order and returns the reverted ones.
+	Down(ctx context.Context, steps int) ([]*Migration, error)
+	// Status returns every known migration together with its applied state, in version order.
+	Status(ctx context.Context) ([]Status, error)
+	// Diff compares the given models, or all registered models when none are given, against the live schema.
+	Diff(ctx context.Context, models ...any) (*Diff, error)
+}
@@ -0,0 +1,159 @@
+package migration
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/coldsmirk/vef-framework-go/orm"
+)
+
+// Direction identifies the step of a migration a SQL file implements.
+type Direction string
+
+const (
+	// DirectionUp marks a file applying a migration.
+	DirectionUp Direction = "up"
+	// DirectionDown marks a file reverting a migration.
+	DirectionDown Direction = "down"
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_]+)\.(up|down)\.sql$`)
+
+// ParseFileName parses a migration file name of the form <version>_<name>.<up|down>.sql.
+func ParseFileName(name string) (version int64, title string, direction Direction, err error) {
+	matches := fileNamePattern.FindStringSubmatch(name)
+	if matches == nil {
+		return 0, "", "", fmt.Errorf("%w: %s", ErrInvalidFileName, name)
+	}
+
+	if version, err = strconv.ParseInt(matches[1], 10, 64); err != nil {
+		return 0, "", "", fmt.Errorf("%w: %s", ErrInvalidFileName, name)
+	}
+
+	return version, matches[2], Direction(matches[3]), nil
+}
+
+// SQL returns a step that executes the statements as a single raw query.
+// Blank statements produce a no-op step, so freshly created empty files are valid.
+func SQL(statements string) Func {
+	return func(ctx context.Context, db orm.DB) error {
+		if strings.TrimSpace(statements) == "" {
+			return nil
+		}
+
+		_, err := db.NewRaw(statements).Exec(ctx)
+
+		return err
+	}
+}
+
+type fsSource struct {
+	fsys fs.FS
+	dir  string
+}
+
+// FS returns a Source loading SQL migrations from dir in fsys, typically an embed.FS.
+// Each migration consists of a <version>_<name>.up.sql file and an optional <version>_<name>.down.sql file;
+// files without the .sql extension are ignored.
+func FS(fsys fs.FS, dir string) Source {
+	return &fsSource{fsys: fsys, dir: dir}
+}
+
+func (s *fsSource) Migrations() ([]*Migration, error) {
+	entries, err := fs.ReadDir(s.fsys, s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migration directory %q: %w", s.dir, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		version, name, direction, err := ParseFileName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := fs.ReadFile(s.fsys, path.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read migration file %q: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("%w: %d (%s, %s)", ErrDuplicateVersion, version, migration.Name, name)
+		}
+
+		if direction == DirectionUp {
+			migration.Up = SQL(string(data))
+		} else {
+			migration.Down = SQL(string(data))
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == nil {
+			return nil, fmt.Errorf("%w: %d_%s", ErrMissingUp, migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, migration)
+	}
+
+	return migrations, nil
+}
+
+type goSource []*Migration
+
+// Go returns a Source of migrations implemented in Go.
+func Go(migrations ...*Migration) Source {
+	return goSource(migrations)
+}
+
+func (s goSource) Migrations() ([]*Migration, error) {
+	return s, nil
+}
+
+// Collect loads the migrations of all sources and returns them sorted by version.
+// It fails when a migration has no up step or two migrations share a version.
+func Collect(sources ...Source) ([]*Migration, error) {
+	var migrations []*Migration
+
+	for _, source := range sources {
+		loaded, err := source.Migrations()
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, loaded...)
+	}
+
+	slices.SortFunc(migrations, func(a, b *Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	for i, migration := range migrations {
+		if migration.Up == nil {
+			return nil, fmt.Errorf("%w: %d_%s", ErrMissingUp, migration.Version, migration.Name)
+		}
+
+		if i > 0 && migrations[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateVersion, migration.Version)
+		}
+	}
+
+	return migrations, nil
+}
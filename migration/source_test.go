@@ -0,0 +1,115 @@
+package migration
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/orm"
+)
+
+func TestParseFileName(t *testing.T) {
+	t.Run("Up", func(t *testing.T) {
+		version, name, direction, err := ParseFileName("20260101120000_create_order.up.sql")
+		require.NoError(t, err, "Should parse a valid up file name")
+		assert.Equal(t, int64(20260101120000), version, "Should parse the version")
+		assert.Equal(t, "create_order", name, "Should parse the name")
+		assert.Equal(t, DirectionUp, direction, "Should parse the up direction")
+	})
+
+	t.Run("Down", func(t *testing.T) {
+		_, _, direction, err := ParseFileName("1_init.down.sql")
+		require.NoError(t, err, "Should parse a valid down file name")
+		assert.Equal(t, DirectionDown, direction, "Should parse the down direction")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, name := range []string{"create_order.up.sql", "1_init.sql", "1_init.sideways.sql", "1_bad-name.up.sql"} {
+			_, _, _, err := ParseFileName(name)
+			assert.ErrorIs(t, err, ErrInvalidFileName, "Should reject %s", name)
+		}
+	})
+}
+
+func TestFS(t *testing.T) {
+	t.Run("LoadsPairs", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/2_add_remark.up.sql":   {Data: []byte("ALTER TABLE t ADD remark TEXT")},
+			"migrations/1_create_t.up.sql":     {Data: []byte("CREATE TABLE t (id INT)")},
+			"migrations/1_create_t.down.sql":   {Data: []byte("DROP TABLE t")},
+			"migrations/README.md":             {Data: []byte("ignored")},
+			"migrations/nested/3_other.up.sql": {Data: []byte("ignored")},
+		}
+
+		migrations, err := Collect(FS(fsys, "migrations"))
+		require.NoError(t, err, "Should load migrations")
+		require.Len(t, migrations, 2, "Should load one migration per version")
+
+		assert.Equal(t, int64(1), migrations[0].Version, "Should sort by version")
+		assert.Equal(t, "create_t", migrations[0].Name, "Should keep the name")
+		assert.NotNil(t, migrations[0].Down, "Should load the down step")
+		assert.Equal(t, int64(2), migrations[1].Version, "Should sort by version")
+		assert.Nil(t, migrations[1].Down, "Migration without down file should be irreversible")
+	})
+
+	t.Run("MissingUp", func(t *testing.T) {
+		fsys := fstest.MapFS{"m/1_create_t.down.sql": {Data: []byte("DROP TABLE t")}}
+
+		_, err := FS(fsys, "m").Migrations()
+		assert.ErrorIs(t, err, ErrMissingUp, "Should reject a migration without up file")
+	})
+
+	t.Run("ConflictingNames", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"m/1_create_t.up.sql": {Data: []byte("CREATE TABLE t (id INT)")},
+			"m/1_create_u.up.sql": {Data: []byte("CREATE TABLE u (id INT)")},
+		}
+
+		_, err := FS(fsys, "m").Migrations()
+		assert.ErrorIs(t, err, ErrDuplicateVersion, "Should reject two names for one version")
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		fsys := fstest.MapFS{"m/create_t.up.sql": {Data: []byte("CREATE TABLE t (id INT)")}}
+
+		_, err := FS(fsys, "m").Migrations()
+		assert.ErrorIs(t, err, ErrInvalidFileName, "Should reject a malformed file name")
+	})
+}
+
+func TestCollect(t *testing.T) {
+	noop := func(context.Context, orm.DB) error { return nil }
+
+	t.Run("MergesSources", func(t *testing.T) {
+		fsys := fstest.MapFS{"m/2_sql.up.sql": {Data: []byte("SELECT 1")}}
+
+		migrations, err := Collect(
+			Go(&Migration{Version: 3, Name: "go_late", Up: noop}, &Migration{Version: 1, Name: "go_early", Up: noop}),
+			FS(fsys, "m"),
+		)
+		require.NoError(t, err, "Should merge SQL and Go sources")
+
+		names := []string{migrations[0].Name, migrations[1].Name, migrations[2].Name}
+		assert.Equal(t, []string{"go_early", "sql", "go_late"}, names, "Should order migrations across sources by version")
+	})
+
+	t.Run("DuplicateVersion", func(t *testing.T) {
+		_, err := Collect(
+			Go(&Migration{Version: 1, Name: "a", Up: noop}),
+			Go(&Migration{Version: 1, Name: "b", Up: noop}),
+		)
+		assert.ErrorIs(t, err, ErrDuplicateVersion, "Should reject duplicate versions across sources")
+	})
+
+	t.Run("MissingUp", func(t *testing.T) {
+		_, err := Collect(Go(&Migration{Version: 1, Name: "a"}))
+		assert.ErrorIs(t, err, ErrMissingUp, "Should reject a Go migration without up step")
+	})
+}
+
+func TestSQLBlank(t *testing.T) {
+	assert.NoError(t, SQL(" \n\t")(context.Background(), nil), "Blank statements should not touch the database")
+}
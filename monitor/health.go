@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrHealthCheckSkipped is returned by a HealthIndicator whose dependency is not in use by the application,
+// e.g. Redis when no component has connected to it. The indicator is left out of the report.
+var ErrHealthCheckSkipped = errors.New("health check skipped")
+
+// HealthIndicator checks a dependency the application needs to serve requests, e.g. a database or a broker.
+// Business modules provide indicators with vef.ProvideHealthIndicator; the readiness endpoint
+// reports the application as ready only if all of them pass.
+type HealthIndicator interface {
+	// Name identifies the dependency in the report and in the timeout configuration.
+	Name() string
+	// Check returns an error if the dependency is unavailable. The context is canceled once the timeout of the check expires.
+	Check(ctx context.Context) error
+}
+
+// NewHealthIndicator creates a HealthIndicator from a check function.
+func NewHealthIndicator(name string, check func(ctx context.Context) error) HealthIndicator {
+	return &healthIndicatorFunc{name: name, check: check}
+}
+
+type healthIndicatorFunc struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func (h *healthIndicatorFunc) Name() string {
+	return h.name
+}
+
+func (h *healthIndicatorFunc) Check(ctx context.Context) error {
+	return h.check(ctx)
+}
+
+// HealthStatus is the status of the application or of one of its dependencies.
+type HealthStatus string
+
+const (
+	HealthStatusUp   HealthStatus = "up"
+	HealthStatusDown HealthStatus = "down"
+)
+
+// HealthReport is the result of checking the dependencies of the application.
+type HealthReport struct {
+	Status HealthStatus            `json:"status"`
+	Checks map[string]*HealthCheck `json:"checks,omitempty"`
+}
+
+// HealthCheck is the result of a single HealthIndicator.
+type HealthCheck struct {
+	Status HealthStatus `json:"status"`
+	// Duration is the time the check took in milliseconds.
+	Duration int64  `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
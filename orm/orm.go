@@ -40,6 +40,9 @@ type (
 	FullTrackedModel            = orm.FullTrackedModel
 	CreationAuditedModel        = orm.CreationAuditedModel
 	FullAuditedModel            = orm.FullAuditedModel
+	SoftDeletableModel          = orm.SoftDeletableModel
+	VersionedModel              = orm.VersionedModel
+	TenantModel                 = orm.TenantModel
 	PKField                     = orm.PKField
 	ExprBuilder                 = orm.ExprBuilder
 	OrderBuilder                = orm.OrderBuilder
@@ -184,6 +187,10 @@ const (
 	ColumnUpdatedBy     = orm.ColumnUpdatedBy
 	ColumnCreatedByName = orm.ColumnCreatedByName
 	ColumnUpdatedByName = orm.ColumnUpdatedByName
+	ColumnDeletedAt     = orm.ColumnDeletedAt
+	ColumnDeletedBy     = orm.ColumnDeletedBy
+	ColumnVersion       = orm.ColumnVersion
+	ColumnTenantID      = orm.ColumnTenantID
 
 	// Go struct field names corresponding to audit columns.
 	FieldID            = orm.FieldID
@@ -193,6 +200,10 @@ const (
 	FieldUpdatedBy     = orm.FieldUpdatedBy
 	FieldCreatedByName = orm.FieldCreatedByName
 	FieldUpdatedByName = orm.FieldUpdatedByName
+	FieldDeletedAt     = orm.FieldDeletedAt
+	FieldDeletedBy     = orm.FieldDeletedBy
+	FieldVersion       = orm.FieldVersion
+	FieldTenantID      = orm.FieldTenantID
 
 	// ReferenceAction constants.
 	ReferenceCascade    = orm.ReferenceCascade
@@ -218,6 +229,9 @@ const (
 var (
 	ApplySort = orm.ApplySort
 
+	// ErrVersionConflict is returned when an update on a versioned model matches no row.
+	ErrVersionConflict = orm.ErrVersionConflict
+
 	// DataType is the factory for creating type-safe SQL data type definitions.
 	DataType = orm.DataType
 )
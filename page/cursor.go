@@ -0,0 +1,137 @@
+package page
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// CursorPageable represents keyset pagination parameters for querying data.
+// Cursor is empty for the first page and otherwise one of the cursors returned by the previous page.
+type CursorPageable struct {
+	Cursor string `json:"cursor"`
+	Size   int    `json:"size"`
+}
+
+// Normalize normalizes the cursor pageable parameters.
+func (p *CursorPageable) Normalize(size ...int) {
+	if p.Size < 1 {
+		if len(size) > 0 && size[0] > 0 {
+			p.Size = size[0]
+		} else {
+			p.Size = DefaultPageSize
+		}
+	}
+
+	if p.Size > MaxPageSize {
+		p.Size = MaxPageSize
+	}
+}
+
+// CursorPage represents a keyset paginated response.
+// NextCursor and PrevCursor are empty when there is no page in that direction;
+// Total is only present when counting is enabled.
+type CursorPage[T any] struct {
+	Size       int    `json:"size"`
+	Total      *int64 `json:"total,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty"`
+	Items      []T    `json:"items"`
+}
+
+// HasNext returns true if there are more items after the current page.
+func (page CursorPage[T]) HasNext() bool {
+	return page.NextCursor != ""
+}
+
+// HasPrevious returns true if there are items before the current page.
+func (page CursorPage[T]) HasPrevious() bool {
+	return page.PrevCursor != ""
+}
+
+// NewCursorPage creates a new cursor page from pageable parameters and items.
+// It ensures items is never nil and returns an empty slice if needed.
+func NewCursorPage[T any](pageable CursorPageable, items []T) CursorPage[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return CursorPage[T]{
+		Size:  pageable.Size,
+		Items: items,
+	}
+}
+
+// Cursor is the decoded position of a keyset page boundary.
+type Cursor struct {
+	// Key identifies the ordering the cursor was issued for, so it cannot be replayed against another one.
+	Key string `json:"k"`
+	// Values holds the encoded sort column values of the boundary row.
+	Values []json.RawMessage `json:"v"`
+	// Backward reports whether the cursor points to the items before the boundary row.
+	Backward bool `json:"b,omitempty"`
+}
+
+// CursorCodec encodes cursors into opaque tokens and decodes them back.
+type CursorCodec interface {
+	// Encode returns the opaque token of the cursor.
+	Encode(cursor *Cursor) (string, error)
+	// Decode parses and verifies a token, returning ErrInvalidCursor if it was tampered with.
+	Decode(token string) (*Cursor, error)
+}
+
+// hmacCursorCodec signs cursors with HMAC-SHA256 so that clients cannot forge positions.
+type hmacCursorCodec struct {
+	secret []byte
+}
+
+// NewCursorCodec creates a cursor codec that signs tokens with the given secret.
+func NewCursorCodec(secret []byte) CursorCodec {
+	return &hmacCursorCodec{secret: secret}
+}
+
+func (c *hmacCursorCodec) Encode(cursor *Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(c.sign(payload)), nil
+}
+
+func (c *hmacCursorCodec) Decode(token string) (*Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	encoding := base64.RawURLEncoding
+
+	payload, err := encoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	signature, err := encoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, c.sign(payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+func (c *hmacCursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,90 @@
+package page
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorCodec(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		cursor := &Cursor{
+			Key:      "age asc,id asc",
+			Values:   []json.RawMessage{json.RawMessage(`30`), json.RawMessage(`"abc"`)},
+			Backward: true,
+		}
+
+		token, err := codec.Encode(cursor)
+		require.NoError(t, err, "Encode should succeed")
+		assert.NotContains(t, token, "=", "Token should be unpadded and URL safe")
+
+		decoded, err := codec.Decode(token)
+		require.NoError(t, err, "Decode should accept its own token")
+		assert.Equal(t, cursor, decoded, "Decoded cursor should match the original")
+	})
+
+	t.Run("TamperedPayload", func(t *testing.T) {
+		token, err := codec.Encode(&Cursor{Key: "id desc", Values: []json.RawMessage{json.RawMessage(`"a"`)}})
+		require.NoError(t, err, "Encode should succeed")
+
+		payload, signature, _ := strings.Cut(token, ".")
+		forged, err := codec.Encode(&Cursor{Key: "id desc", Values: []json.RawMessage{json.RawMessage(`"b"`)}})
+		require.NoError(t, err, "Encode should succeed")
+
+		forgedPayload, _, _ := strings.Cut(forged, ".")
+		assert.NotEqual(t, payload, forgedPayload, "Payloads should differ")
+
+		_, err = codec.Decode(forgedPayload + "." + signature)
+		assert.ErrorIs(t, err, ErrInvalidCursor, "Swapped payload should fail verification")
+	})
+
+	t.Run("DifferentSecret", func(t *testing.T) {
+		token, err := NewCursorCodec([]byte("other")).Encode(&Cursor{Key: "id desc"})
+		require.NoError(t, err, "Encode should succeed")
+
+		_, err = codec.Decode(token)
+		assert.ErrorIs(t, err, ErrInvalidCursor, "Token signed with another secret should be rejected")
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		for _, token := range []string{"", "abc", "abc.", ".abc", "!!!.???"} {
+			_, err := codec.Decode(token)
+			assert.ErrorIs(t, err, ErrInvalidCursor, "Malformed token %q should be rejected", token)
+		}
+	})
+}
+
+func TestCursorPageable(t *testing.T) {
+	t.Run("DefaultSize", func(t *testing.T) {
+		pageable := CursorPageable{}
+		pageable.Normalize()
+		assert.Equal(t, DefaultPageSize, pageable.Size, "Zero size should fall back to the default")
+	})
+
+	t.Run("CustomDefaultSize", func(t *testing.T) {
+		pageable := CursorPageable{}
+		pageable.Normalize(3)
+		assert.Equal(t, 3, pageable.Size, "Zero size should fall back to the provided default")
+	})
+
+	t.Run("MaxSize", func(t *testing.T) {
+		pageable := CursorPageable{Size: MaxPageSize + 1}
+		pageable.Normalize()
+		assert.Equal(t, MaxPageSize, pageable.Size, "Size should be capped at the maximum")
+	})
+}
+
+func TestCursorPage(t *testing.T) {
+	cp := NewCursorPage[int](CursorPageable{Size: 10}, nil)
+	assert.NotNil(t, cp.Items, "Items should never be nil")
+	assert.False(t, cp.HasNext(), "Page without next cursor should not have a next page")
+	assert.False(t, cp.HasPrevious(), "Page without previous cursor should not have a previous page")
+
+	cp.NextCursor = "x"
+	assert.True(t, cp.HasNext(), "Page with next cursor should have a next page")
+}
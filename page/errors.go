@@ -0,0 +1,6 @@
+package page
+
+import "errors"
+
+// ErrInvalidCursor is returned when a cursor is malformed or its signature does not match.
+var ErrInvalidCursor = errors.New("invalid cursor")
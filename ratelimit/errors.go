@@ -0,0 +1,6 @@
+package ratelimit
+
+import "errors"
+
+// ErrUnknownAlgorithm is returned when a limit uses an unsupported algorithm.
+var ErrUnknownAlgorithm = errors.New("unknown rate limit algorithm")
@@ -0,0 +1,162 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/coldsmirk/vef-framework-go/cache"
+)
+
+// MemoryStore implements Store using in-memory state.
+// This implementation is suitable for development and single-instance deployments.
+// For distributed systems, use RedisStore instead.
+type MemoryStore struct {
+	windows cache.Cache[windowState]
+	buckets cache.Cache[bucketState]
+	mu      sync.Mutex
+	now     func() time.Time
+}
+
+// windowState holds the counters of the current and previous fixed windows.
+type windowState struct {
+	Window   int64
+	Current  int
+	Previous int
+}
+
+// bucketState holds the remaining tokens and the last refill time in milliseconds.
+type bucketState struct {
+	Tokens    float64
+	UpdatedAt int64
+}
+
+// NewMemoryStore creates a new in-memory rate limit store.
+func NewMemoryStore() Store {
+	return &MemoryStore{
+		windows: cache.NewMemory[windowState](),
+		buckets: cache.NewMemory[bucketState](),
+		now:     time.Now,
+	}
+}
+
+// Allow records a hit for key and reports whether it is within the limit.
+func (s *MemoryStore) Allow(ctx context.Context, key string, limit Limit) (*Result, error) {
+	if limit.Max <= 0 || limit.Period <= 0 {
+		return unlimitedResult(), nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch algorithm := algorithmOf(limit); algorithm {
+	case SlidingWindow:
+		return s.slidingWindow(ctx, key, limit)
+	case TokenBucket:
+		return s.tokenBucket(ctx, key, limit)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, algorithm)
+	}
+}
+
+func (s *MemoryStore) slidingWindow(ctx context.Context, key string, limit Limit) (*Result, error) {
+	period := limit.Period.Milliseconds()
+	nowMillis := s.now().UnixMilli()
+	window := nowMillis / period
+
+	state, _ := s.windows.Get(ctx, key)
+
+	switch state.Window {
+	case window:
+	case window - 1:
+		state = windowState{Window: window, Previous: state.Current}
+	default:
+		state = windowState{Window: window}
+	}
+
+	elapsed := nowMillis % period
+	estimated := estimateSlidingWindow(state.Previous, state.Current, elapsed, period)
+
+	allowed := estimated < limit.Max
+	if allowed {
+		state.Current++
+		estimated++
+
+		if err := s.windows.Set(ctx, key, state, 2*limit.Period); err != nil {
+			return nil, err
+		}
+	}
+
+	return slidingWindowResult(limit, allowed, state.Previous, state.Current, estimated, elapsed, period), nil
+}
+
+func (s *MemoryStore) tokenBucket(ctx context.Context, key string, limit Limit) (*Result, error) {
+	nowMillis := s.now().UnixMilli()
+	rate := float64(limit.Max) / float64(limit.Period.Milliseconds())
+
+	state, ok := s.buckets.Get(ctx, key)
+	if !ok {
+		state = bucketState{Tokens: float64(limit.Max), UpdatedAt: nowMillis}
+	}
+
+	state.Tokens = math.Min(float64(limit.Max), state.Tokens+float64(nowMillis-state.UpdatedAt)*rate)
+	state.UpdatedAt = nowMillis
+
+	allowed := state.Tokens >= 1
+	if allowed {
+		state.Tokens--
+	}
+
+	if err := s.buckets.Set(ctx, key, state, limit.Period); err != nil {
+		return nil, err
+	}
+
+	return tokenBucketResult(limit, allowed, state.Tokens, rate), nil
+}
+
+// estimateSlidingWindow weights the previous window by the part of it still covered by the rolling window.
+func estimateSlidingWindow(previous, current int, elapsed, period int64) int {
+	return int(float64(previous)*float64(period-elapsed)/float64(period)) + current
+}
+
+func slidingWindowResult(limit Limit, allowed bool, previous, current, estimated int, elapsed, period int64) *Result {
+	result := &Result{
+		Allowed:    allowed,
+		Limit:      limit.Max,
+		Remaining:  max(limit.Max-estimated, 0),
+		ResetAfter: time.Duration(period-elapsed) * time.Millisecond,
+	}
+
+	if !allowed {
+		// The estimate drops below the limit once enough of the previous window has slid out,
+		// or not before the current window ends if the current window alone exhausts the quota.
+		result.RetryAfter = result.ResetAfter
+		if current < limit.Max && previous > 0 {
+			wait := float64(period-elapsed) - float64(limit.Max-current)*float64(period)/float64(previous)
+			result.RetryAfter = time.Duration(max(math.Ceil(wait), 1)) * time.Millisecond
+		}
+	}
+
+	return result
+}
+
+func tokenBucketResult(limit Limit, allowed bool, tokens, rate float64) *Result {
+	result := &Result{
+		Allowed:    allowed,
+		Limit:      limit.Max,
+		Remaining:  int(tokens),
+		ResetAfter: time.Duration(math.Ceil((float64(limit.Max)-tokens)/rate)) * time.Millisecond,
+	}
+
+	if !allowed {
+		result.RetryAfter = time.Duration(math.Ceil((1-tokens)/rate)) * time.Millisecond
+	}
+
+	return result
+}
+
+func unlimitedResult() *Result {
+	return &Result{Allowed: true, Limit: 0, Remaining: math.MaxInt}
+}
@@ -0,0 +1,121 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemoryStore(now *time.Time) *MemoryStore {
+	store := NewMemoryStore().(*MemoryStore)
+	store.now = func() time.Time { return *now }
+
+	return store
+}
+
+// TestMemoryStoreSlidingWindow tests sliding window quota enforcement.
+func TestMemoryStoreSlidingWindow(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Max: 3, Period: time.Minute}
+
+	t.Run("AllowsUpToMax", func(t *testing.T) {
+		now := time.UnixMilli(0)
+		store := newTestMemoryStore(&now)
+
+		for i := range 3 {
+			res, err := store.Allow(ctx, "key", limit)
+			require.NoError(t, err, "Allow should not fail")
+			assert.True(t, res.Allowed, "Request within quota should be allowed")
+			assert.Equal(t, 2-i, res.Remaining, "Remaining should decrease with each hit")
+			assert.Equal(t, 3, res.Limit, "Limit should echo max")
+		}
+
+		res, err := store.Allow(ctx, "key", limit)
+		require.NoError(t, err, "Allow should not fail")
+		assert.False(t, res.Allowed, "Request over quota should be rejected")
+		assert.Equal(t, 0, res.Remaining, "Remaining should be zero when rejected")
+		assert.Equal(t, time.Minute, res.RetryAfter, "Should retry when the current window ends")
+	})
+
+	t.Run("PreviousWindowIsWeighted", func(t *testing.T) {
+		now := time.UnixMilli(0)
+		store := newTestMemoryStore(&now)
+
+		for range 3 {
+			_, err := store.Allow(ctx, "key", limit)
+			require.NoError(t, err, "Allow should not fail")
+		}
+
+		// Ten seconds into the next window five sixths of the previous hits still count.
+		now = time.UnixMilli(70_000)
+		res, err := store.Allow(ctx, "key", limit)
+		require.NoError(t, err, "Allow should not fail")
+		assert.True(t, res.Allowed, "One slot should be available after part of the window slid out")
+		assert.Equal(t, 0, res.Remaining, "Weighted previous hits should consume the quota")
+
+		res, err = store.Allow(ctx, "key", limit)
+		require.NoError(t, err, "Allow should not fail")
+		assert.False(t, res.Allowed, "Weighted quota should be exhausted")
+		assert.Equal(t, 10*time.Second, res.RetryAfter, "Should retry once another hit of the previous window slid out")
+	})
+
+	t.Run("KeysAreIsolated", func(t *testing.T) {
+		now := time.UnixMilli(0)
+		store := newTestMemoryStore(&now)
+
+		for range 3 {
+			_, err := store.Allow(ctx, "a", limit)
+			require.NoError(t, err, "Allow should not fail")
+		}
+
+		res, err := store.Allow(ctx, "b", limit)
+		require.NoError(t, err, "Allow should not fail")
+		assert.True(t, res.Allowed, "Other keys should have their own quota")
+	})
+}
+
+// TestMemoryStoreTokenBucket tests token bucket refill and burst behavior.
+func TestMemoryStoreTokenBucket(t *testing.T) {
+	ctx := context.Background()
+	limit := Limit{Algorithm: TokenBucket, Max: 2, Period: 2 * time.Second}
+
+	now := time.UnixMilli(0)
+	store := newTestMemoryStore(&now)
+
+	for range 2 {
+		res, err := store.Allow(ctx, "key", limit)
+		require.NoError(t, err, "Allow should not fail")
+		assert.True(t, res.Allowed, "Burst up to capacity should be allowed")
+	}
+
+	res, err := store.Allow(ctx, "key", limit)
+	require.NoError(t, err, "Allow should not fail")
+	assert.False(t, res.Allowed, "Empty bucket should reject")
+	assert.Equal(t, time.Second, res.RetryAfter, "One token should refill after one second")
+	assert.Equal(t, 2*time.Second, res.ResetAfter, "Bucket should be full after the period")
+
+	now = now.Add(time.Second)
+	res, err = store.Allow(ctx, "key", limit)
+	require.NoError(t, err, "Allow should not fail")
+	assert.True(t, res.Allowed, "Refilled token should be consumed")
+}
+
+// TestMemoryStoreEdgeCases tests unlimited and unsupported limits.
+func TestMemoryStoreEdgeCases(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	t.Run("ZeroMaxIsUnlimited", func(t *testing.T) {
+		res, err := store.Allow(ctx, "key", Limit{Period: time.Minute})
+		require.NoError(t, err, "Allow should not fail")
+		assert.True(t, res.Allowed, "Zero max should not limit")
+	})
+
+	t.Run("UnknownAlgorithm", func(t *testing.T) {
+		_, err := store.Allow(ctx, "key", Limit{Algorithm: "leaky", Max: 1, Period: time.Minute})
+		assert.ErrorIs(t, err, ErrUnknownAlgorithm, "Unknown algorithm should be rejected")
+	})
+}
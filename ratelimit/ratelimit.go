@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Algorithm is the rate limiting algorithm applied to a key.
+type Algorithm string
+
+const (
+	// SlidingWindow approximates a rolling window by weighting the previous fixed window
+	// with the elapsed part of the current one. It is the default algorithm.
+	SlidingWindow Algorithm = "sliding_window"
+	// TokenBucket refills Max tokens evenly over Period and allows bursts up to Max requests.
+	TokenBucket Algorithm = "token_bucket"
+)
+
+// Limit describes the quota enforced for a key.
+type Limit struct {
+	// Algorithm selects the rate limiting algorithm, empty means SlidingWindow.
+	Algorithm Algorithm
+	// Max is the maximum number of requests allowed within Period.
+	Max int
+	// Period is the time window of the quota.
+	Period time.Duration
+}
+
+// Result is the outcome of a rate limit check.
+type Result struct {
+	// Allowed reports whether the request is within the quota.
+	Allowed bool
+	// Limit is the maximum number of requests allowed within the period.
+	Limit int
+	// Remaining is the number of requests still allowed.
+	Remaining int
+	// ResetAfter is the duration until the quota is fully or partially restored.
+	ResetAfter time.Duration
+	// RetryAfter is the duration to wait before retrying a rejected request.
+	RetryAfter time.Duration
+}
+
+// Store records request hits and decides whether a request is allowed.
+// Implementations must be safe for concurrent use; distributed deployments
+// should use a shared store such as RedisStore so that all nodes enforce one quota.
+type Store interface {
+	// Allow records a hit for key and reports whether it is within the limit.
+	Allow(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+// algorithmOf returns the effective algorithm of a limit.
+func algorithmOf(limit Limit) Algorithm {
+	if limit.Algorithm == "" {
+		return SlidingWindow
+	}
+
+	return limit.Algorithm
+}
@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "vef:ratelimit:"
+
+// slidingWindowScript keeps one counter per fixed window and weights the previous window
+// by the part still covered by the rolling window. Redis server time is used so that all
+// nodes share the same clock.
+//
+// KEYS[1]: key prefix; ARGV[1]: max; ARGV[2]: period in milliseconds.
+// Returns: {allowed, previous, current, estimated, elapsed}.
+var slidingWindowScript = redis.NewScript(`
+local max = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local window = math.floor(now / period)
+local elapsed = now % period
+local currentKey = KEYS[1] .. ':' .. window
+local previous = tonumber(redis.call('GET', KEYS[1] .. ':' .. (window - 1)) or '0')
+local current = tonumber(redis.call('GET', currentKey) or '0')
+local estimated = math.floor(previous * (period - elapsed) / period) + current
+if estimated >= max then
+	return {0, previous, current, estimated, elapsed}
+end
+current = redis.call('INCR', currentKey)
+if current == 1 then
+	redis.call('PEXPIRE', currentKey, period * 2)
+end
+return {1, previous, current, estimated + 1, elapsed}
+`)
+
+// tokenBucketScript refills the bucket based on the time elapsed since the last request.
+//
+// KEYS[1]: bucket key; ARGV[1]: capacity; ARGV[2]: period in milliseconds.
+// Returns: {allowed, tokens} where tokens is a decimal string.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local rate = capacity / period
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local updatedAt = tonumber(bucket[2])
+if tokens == nil or updatedAt == nil then
+	tokens = capacity
+	updatedAt = now
+end
+tokens = math.min(capacity, tokens + (now - updatedAt) * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], period)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore implements Store using Redis for distributed deployments.
+// Each check is a single atomic script execution, so all nodes enforce one shared quota.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a new Redis-backed rate limit store.
+func NewRedisStore(client *redis.Client) Store {
+	return &RedisStore{client: client}
+}
+
+// buildKey wraps the key in a hash tag so that all keys of one limit map to the same cluster slot.
+func (*RedisStore) buildKey(algorithm Algorithm, key string) string {
+	return redisKeyPrefix + string(algorithm) + ":{" + key + "}"
+}
+
+// Allow records a hit for key and reports whether it is within the limit.
+func (s *RedisStore) Allow(ctx context.Context, key string, limit Limit) (*Result, error) {
+	if limit.Max <= 0 || limit.Period <= 0 {
+		return unlimitedResult(), nil
+	}
+
+	switch algorithm := algorithmOf(limit); algorithm {
+	case SlidingWindow:
+		return s.slidingWindow(ctx, key, limit)
+	case TokenBucket:
+		return s.tokenBucket(ctx, key, limit)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, algorithm)
+	}
+}
+
+func (s *RedisStore) slidingWindow(ctx context.Context, key string, limit Limit) (*Result, error) {
+	period := limit.Period.Milliseconds()
+
+	values, err := slidingWindowScript.Run(ctx, s.client, []string{s.buildKey(SlidingWindow, key)}, limit.Max, period).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate sliding window: %w", err)
+	}
+
+	return slidingWindowResult(limit, values[0] == 1, int(values[1]), int(values[2]), int(values[3]), values[4], period), nil
+}
+
+func (s *RedisStore) tokenBucket(ctx context.Context, key string, limit Limit) (*Result, error) {
+	period := limit.Period.Milliseconds()
+
+	values, err := tokenBucketScript.Run(ctx, s.client, []string{s.buildKey(TokenBucket, key)}, limit.Max, period).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate token bucket: %w", err)
+	}
+
+	allowed, _ := values[0].(int64)
+	tokens, err := strconv.ParseFloat(fmt.Sprint(values[1]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token bucket state: %w", err)
+	}
+
+	return tokenBucketResult(limit, allowed == 1, tokens, float64(limit.Max)/float64(period)), nil
+}
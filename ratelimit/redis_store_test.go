@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+)
+
+type RedisStoreTestSuite struct {
+	suite.Suite
+
+	container *testx.RedisContainer
+	client    *redis.Client
+	store     Store
+}
+
+func (s *RedisStoreTestSuite) SetupSuite() {
+	ctx := context.Background()
+	s.container = testx.NewRedisContainer(ctx, s.T())
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: fmt.Sprintf("%s:%d", s.container.Redis.Host, s.container.Redis.Port),
+		DB:   int(s.container.Redis.Database),
+	})
+
+	err := s.client.Ping(ctx).Err()
+	s.Require().NoError(err, "Should connect to Redis")
+
+	s.store = NewRedisStore(s.client)
+}
+
+func (s *RedisStoreTestSuite) TearDownSuite() {
+	if s.client != nil {
+		s.client.Close()
+	}
+}
+
+func (s *RedisStoreTestSuite) SetupTest() {
+	s.client.FlushDB(context.Background())
+}
+
+// TestSlidingWindow tests that the shared sliding window enforces the quota.
+func (s *RedisStoreTestSuite) TestSlidingWindow() {
+	ctx := context.Background()
+	limit := Limit{Max: 3, Period: time.Minute}
+
+	for i := range 3 {
+		res, err := s.store.Allow(ctx, "client", limit)
+		s.Require().NoError(err, "Allow should not fail")
+		s.True(res.Allowed, "Request within quota should be allowed")
+		s.LessOrEqual(res.Remaining, 2-i, "Remaining should decrease with each hit")
+	}
+
+	res, err := s.store.Allow(ctx, "client", limit)
+	s.Require().NoError(err, "Allow should not fail")
+	s.False(res.Allowed, "Request over quota should be rejected")
+	s.Positive(res.RetryAfter, "Rejected request should carry a retry delay")
+
+	res, err = s.store.Allow(ctx, "other", limit)
+	s.Require().NoError(err, "Allow should not fail")
+	s.True(res.Allowed, "Other keys should have their own quota")
+}
+
+// TestTokenBucket tests burst capacity and refill of the shared token bucket.
+func (s *RedisStoreTestSuite) TestTokenBucket() {
+	ctx := context.Background()
+	limit := Limit{Algorithm: TokenBucket, Max: 2, Period: 200 * time.Millisecond}
+
+	for range 2 {
+		res, err := s.store.Allow(ctx, "client", limit)
+		s.Require().NoError(err, "Allow should not fail")
+		s.True(res.Allowed, "Burst up to capacity should be allowed")
+	}
+
+	res, err := s.store.Allow(ctx, "client", limit)
+	s.Require().NoError(err, "Allow should not fail")
+	s.False(res.Allowed, "Empty bucket should reject")
+	s.Positive(res.RetryAfter, "Rejected request should carry a retry delay")
+
+	time.Sleep(res.RetryAfter + 20*time.Millisecond)
+
+	res, err = s.store.Allow(ctx, "client", limit)
+	s.Require().NoError(err, "Allow should not fail")
+	s.True(res.Allowed, "Refilled token should be consumed")
+}
+
+// TestConcurrentAllow tests that concurrent hits never exceed the quota.
+func (s *RedisStoreTestSuite) TestConcurrentAllow() {
+	ctx := context.Background()
+
+	for _, algorithm := range []Algorithm{SlidingWindow, TokenBucket} {
+		s.Run(string(algorithm), func() {
+			limit := Limit{Algorithm: algorithm, Max: 10, Period: time.Minute}
+
+			var (
+				wg      sync.WaitGroup
+				allowed atomic.Int32
+			)
+
+			for range 50 {
+				wg.Go(func() {
+					res, err := s.store.Allow(ctx, "concurrent", limit)
+					if err == nil && res.Allowed {
+						allowed.Add(1)
+					}
+				})
+			}
+
+			wg.Wait()
+			s.Equal(int32(10), allowed.Load(), "Exactly max requests should be allowed")
+		})
+	}
+}
+
+// TestRedisStore runs the Redis rate limit store test suite.
+func TestRedisStore(t *testing.T) {
+	suite.Run(t, new(RedisStoreTestSuite))
+}
@@ -2,62 +2,90 @@ package result
 
 // i18n message keys for API responses.
 const (
-	OkMessage                                 = "ok"
-	ErrMessage                                = "error"
-	ErrMessageRecordNotFound                  = "record_not_found"
-	ErrMessageRecordAlreadyExists             = "record_already_exists"
-	ErrMessageForeignKeyViolation             = "foreign_key_violation"
-	ErrMessageUnknown                         = "unknown_error"
-	ErrMessageNotFound                        = "not_found"
-	ErrMessageTooManyRequests                 = "too_many_requests"
-	ErrMessageUnauthenticated                 = "unauthenticated"
-	ErrMessageTokenExpired                    = "token_expired"
-	ErrMessageTokenInvalid                    = "token_invalid"
-	ErrMessageTokenNotValidYet                = "token_not_valid_yet"
-	ErrMessageTokenInvalidIssuer              = "token_invalid_issuer"
-	ErrMessageTokenInvalidAudience            = "token_invalid_audience"
-	ErrMessageTokenMissingSubject             = "token_missing_subject"
-	ErrMessageTokenMissingTokenType           = "token_missing_token_type"
-	ErrMessageAppIDRequired                   = "app_id_required"
-	ErrMessageTimestampRequired               = "timestamp_required"
-	ErrMessageSignatureRequired               = "signature_required"
-	ErrMessageTimestampInvalid                = "timestamp_invalid"
-	ErrMessageSignatureExpired                = "signature_expired"
-	ErrMessageExternalAppNotFound             = "external_app_not_found"
-	ErrMessageExternalAppDisabled             = "external_app_disabled"
-	ErrMessageIPNotAllowed                    = "ip_not_allowed"
-	ErrMessageSignatureInvalid                = "signature_invalid"
-	ErrMessageAccessDenied                    = "access_denied"
-	ErrMessageUnsupportedMediaType            = "unsupported_media_type"
-	ErrMessageRequestTimeout                  = "request_timeout"
-	ErrMessageMonitorNotReady                 = "monitor_not_ready"
-	ErrMessageInvalidFileKey                  = "invalid_file_key"
-	ErrMessageFileNotFound                    = "file_not_found"
-	ErrMessageFailedToGetFile                 = "failed_to_get_file"
-	ErrMessageAPIRequestParamsInvalidJSON     = "api_request_params_invalid_json"
-	ErrMessageAPIRequestMetaInvalidJSON       = "api_request_meta_invalid_json"
-	ErrMessageDangerousSQL                    = "dangerous_sql"
-	ErrMessageExternalAppLoaderNotImplemented = "external_app_loader_not_implemented"
-	ErrMessageCredentialsFormatInvalid        = "credentials_format_invalid"
-	ErrMessageCredentialsFieldsRequired       = "credentials_fields_required"
-	ErrMessageSignatureDecodeFailed           = "signature_decode_failed"
-	ErrMessageNonceRequired                   = "nonce_required"
-	ErrMessageNonceInvalid                    = "nonce_invalid"
-	ErrMessageNonceAlreadyUsed                = "nonce_already_used"
-	ErrMessageAuthHeaderMissing               = "auth_header_missing"
-	ErrMessageAuthHeaderInvalid               = "auth_header_invalid"
-	ErrMessageUnsupportedAuthenticationType   = "unsupported_authentication_type"
-	ErrMessageUserLoaderNotImplemented        = "user_loader_not_implemented"
-	ErrMessageUserInfoLoaderNotImplemented    = "user_info_loader_not_implemented"
-	ErrMessageChallengeRequired               = "challenge_required"
-	ErrMessageChallengeTokenInvalid           = "challenge_token_invalid"
-	ErrMessageChallengeTokenExpired           = "challenge_token_expired"
-	ErrMessageChallengeTypeInvalid            = "challenge_type_invalid"
-	ErrMessageChallengeResolveFailed          = "challenge_resolve_failed"
-	ErrMessageOTPCodeRequired                 = "otp_code_required"
-	ErrMessageOTPCodeInvalid                  = "otp_code_invalid"
-	ErrMessageNewPasswordRequired             = "new_password_required"
-	ErrMessageDepartmentRequired              = "department_required"
+	OkMessage                                       = "ok"
+	ErrMessage                                      = "error"
+	ErrMessageRecordNotFound                        = "record_not_found"
+	ErrMessageRecordAlreadyExists                   = "record_already_exists"
+	ErrMessageForeignKeyViolation                   = "foreign_key_violation"
+	ErrMessageVersionConflict                       = "version_conflict"
+	ErrMessageUnknown                               = "unknown_error"
+	ErrMessageNotFound                              = "not_found"
+	ErrMessageTooManyRequests                       = "too_many_requests"
+	ErrMessageUnauthenticated                       = "unauthenticated"
+	ErrMessageTokenExpired                          = "token_expired"
+	ErrMessageTokenInvalid                          = "token_invalid"
+	ErrMessageTokenNotValidYet                      = "token_not_valid_yet"
+	ErrMessageTokenInvalidIssuer                    = "token_invalid_issuer"
+	ErrMessageTokenInvalidAudience                  = "token_invalid_audience"
+	ErrMessageTokenMissingSubject                   = "token_missing_subject"
+	ErrMessageTokenMissingTokenType                 = "token_missing_token_type"
+	ErrMessageAppIDRequired                         = "app_id_required"
+	ErrMessageTimestampRequired                     = "timestamp_required"
+	ErrMessageSignatureRequired                     = "signature_required"
+	ErrMessageTimestampInvalid                      = "timestamp_invalid"
+	ErrMessageSignatureExpired                      = "signature_expired"
+	ErrMessageExternalAppNotFound                   = "external_app_not_found"
+	ErrMessageExternalAppDisabled                   = "external_app_disabled"
+	ErrMessageIPNotAllowed                          = "ip_not_allowed"
+	ErrMessageSignatureInvalid                      = "signature_invalid"
+	ErrMessageAccessDenied                          = "access_denied"
+	ErrMessageUnsupportedMediaType                  = "unsupported_media_type"
+	ErrMessageRequestTimeout                        = "request_timeout"
+	ErrMessageIdempotencyKeyInProgress              = "idempotency_key_in_progress"
+	ErrMessageIdempotencyKeyReused                  = "idempotency_key_reused"
+	ErrMessageMonitorNotReady                       = "monitor_not_ready"
+	ErrMessageInvalidFileKey                        = "invalid_file_key"
+	ErrMessageFileNotFound                          = "file_not_found"
+	ErrMessageFailedToGetFile                       = "failed_to_get_file"
+	ErrMessageAPIRequestParamsInvalidJSON           = "api_request_params_invalid_json"
+	ErrMessageAPIRequestMetaInvalidJSON             = "api_request_meta_invalid_json"
+	ErrMessageDangerousSQL                          = "dangerous_sql"
+	ErrMessageExternalAppLoaderNotImplemented       = "external_app_loader_not_implemented"
+	ErrMessageCredentialsFormatInvalid              = "credentials_format_invalid"
+	ErrMessageCredentialsFieldsRequired             = "credentials_fields_required"
+	ErrMessageSignatureDecodeFailed                 = "signature_decode_failed"
+	ErrMessageNonceRequired                         = "nonce_required"
+	ErrMessageNonceInvalid                          = "nonce_invalid"
+	ErrMessageNonceAlreadyUsed                      = "nonce_already_used"
+	ErrMessageAuthHeaderMissing                     = "auth_header_missing"
+	ErrMessageAuthHeaderInvalid                     = "auth_header_invalid"
+	ErrMessageSessionRevoked                        = "session_revoked"
+	ErrMessageRefreshTokenReused                    = "refresh_token_reused"
+	ErrMessageAccountLocked                         = "account_locked"
+	ErrMessageIPBlocked                             = "ip_blocked"
+	ErrMessageSessionStoreNotImplemented            = "session_store_not_implemented"
+	ErrMessageAccountLockoutNotEnabled              = "account_lockout_not_enabled"
+	ErrMessageUnsupportedAuthenticationType         = "unsupported_authentication_type"
+	ErrMessageUserLoaderNotImplemented              = "user_loader_not_implemented"
+	ErrMessageUserInfoLoaderNotImplemented          = "user_info_loader_not_implemented"
+	ErrMessageChallengeRequired                     = "challenge_required"
+	ErrMessageChallengeTokenInvalid                 = "challenge_token_invalid"
+	ErrMessageChallengeTokenExpired                 = "challenge_token_expired"
+	ErrMessageChallengeTypeInvalid                  = "challenge_type_invalid"
+	ErrMessageChallengeResolveFailed                = "challenge_resolve_failed"
+	ErrMessageOTPCodeRequired                       = "otp_code_required"
+	ErrMessageOTPCodeInvalid                        = "otp_code_invalid"
+	ErrMessageNewPasswordRequired                   = "new_password_required"
+	ErrMessageDepartmentRequired                    = "department_required"
+	ErrMessageCaptchaInvalid                        = "captcha_invalid"
+	ErrMessageOIDCProviderNotFound                  = "oidc_provider_not_found"
+	ErrMessageOIDCFlowInvalid                       = "oidc_flow_invalid"
+	ErrMessageOIDCLoginFailed                       = "oidc_login_failed"
+	ErrMessageExternalIdentityNotLinked             = "external_identity_not_linked"
+	ErrMessageWebAuthnNotConfigured                 = "webauthn_not_configured"
+	ErrMessageWebAuthnCredentialStoreNotImplemented = "webauthn_credential_store_not_implemented"
+	ErrMessageWebAuthnCeremonyInvalid               = "webauthn_ceremony_invalid"
+	ErrMessageWebAuthnVerificationFailed            = "webauthn_verification_failed"
+	ErrMessageWebAuthnCredentialNotFound            = "webauthn_credential_not_found"
+	ErrMessageWebAuthnCredentialExists              = "webauthn_credential_exists"
+	ErrMessageAPIKeyStoreNotImplemented             = "api_key_store_not_implemented"
+	ErrMessageAPIKeyRequired                        = "api_key_required"
+	ErrMessageAPIKeyInvalid                         = "api_key_invalid"
+	ErrMessageAPIKeyExpired                         = "api_key_expired"
+	ErrMessageAPIKeyNotFound                        = "api_key_not_found"
+	ErrMessageAPIKeyScopeNotGranted                 = "api_key_scope_not_granted"
+	ErrMessagePasswordStoreNotImplemented           = "password_store_not_implemented"
+	ErrMessageCurrentPasswordInvalid                = "current_password_invalid"
 )
 
 // Response codes for API results.
@@ -92,6 +120,10 @@ const (
 	ErrCodeNonceAlreadyUsed              = 1023
 	ErrCodeAuthHeaderMissing             = 1024
 	ErrCodeAuthHeaderInvalid             = 1025
+	ErrCodeSessionRevoked                = 1026
+	ErrCodeRefreshTokenReused            = 1027
+	ErrCodeAccountLocked                 = 1028
+	ErrCodeIPBlocked                     = 1029
 
 	// Challenge errors (1030-1039).
 	ErrCodeChallengeRequired      = 1030
@@ -103,6 +135,30 @@ const (
 	ErrCodeOTPCodeInvalid         = 1036
 	ErrCodeNewPasswordRequired    = 1037
 	ErrCodeDepartmentRequired     = 1038
+	ErrCodeCaptchaInvalid         = 1039
+
+	// Single sign-on errors (1040-1049).
+	ErrCodeOIDCProviderNotFound      = 1040
+	ErrCodeOIDCFlowInvalid           = 1041
+	ErrCodeOIDCLoginFailed           = 1042
+	ErrCodeExternalIdentityNotLinked = 1043
+
+	// Passkey errors (1050-1059).
+	ErrCodeWebAuthnCeremonyInvalid    = 1050
+	ErrCodeWebAuthnVerificationFailed = 1051
+	ErrCodeWebAuthnCredentialNotFound = 1052
+	ErrCodeWebAuthnCredentialExists   = 1053
+
+	// API key errors (1060-1069).
+	ErrCodeAPIKeyRequired        = 1060
+	ErrCodeAPIKeyInvalid         = 1061
+	ErrCodeAPIKeyExpired         = 1062
+	ErrCodeAPIKeyNotFound        = 1063
+	ErrCodeAPIKeyScopeNotGranted = 1064
+
+	// Password policy errors (1070-1079).
+	ErrCodePasswordPolicyViolated = 1070
+	ErrCodeCurrentPasswordInvalid = 1071
 
 	// Authorization errors (1100-1199).
 	ErrCodeAccessDenied = 1100
@@ -114,9 +170,11 @@ const (
 	ErrCodeUnsupportedMediaType = 1300
 
 	// Request errors (1400-1499).
-	ErrCodeBadRequest      = 1400
-	ErrCodeTooManyRequests = 1401
-	ErrCodeRequestTimeout  = 1402
+	ErrCodeBadRequest               = 1400
+	ErrCodeTooManyRequests          = 1401
+	ErrCodeRequestTimeout           = 1402
+	ErrCodeIdempotencyKeyInProgress = 1403
+	ErrCodeIdempotencyKeyReused     = 1404
 
 	// Not implemented (1500-1599).
 	ErrCodeNotImplemented = 1500
@@ -132,6 +190,7 @@ const (
 	ErrCodeRecordNotFound      = 2001
 	ErrCodeRecordAlreadyExists = 2002
 	ErrCodeForeignKeyViolation = 2003
+	ErrCodeVersionConflict     = 2004
 	ErrCodeMonitorNotReady     = 2100
 	ErrCodeInvalidFileKey      = 2200
 	ErrCodeFileNotFound        = 2201
@@ -382,6 +382,25 @@ func TestPredefinedErrors(t *testing.T) {
 		{"ErrExternalAppNotFound", ErrExternalAppNotFound, ErrCodeExternalAppNotFound, fiber.StatusUnauthorized},
 		{"ErrExternalAppDisabled", ErrExternalAppDisabled, ErrCodeExternalAppDisabled, fiber.StatusUnauthorized},
 		{"ErrIPNotAllowed", ErrIPNotAllowed, ErrCodeIPNotAllowed, fiber.StatusUnauthorized},
+		{"ErrSessionRevoked", ErrSessionRevoked, ErrCodeSessionRevoked, fiber.StatusUnauthorized},
+		{"ErrRefreshTokenReused", ErrRefreshTokenReused, ErrCodeRefreshTokenReused, fiber.StatusUnauthorized},
+		{"ErrAccountLocked", ErrAccountLocked, ErrCodeAccountLocked, fiber.StatusUnauthorized},
+		{"ErrIPBlocked", ErrIPBlocked, ErrCodeIPBlocked, fiber.StatusUnauthorized},
+		{"ErrCaptchaInvalid", ErrCaptchaInvalid, ErrCodeCaptchaInvalid, fiber.StatusUnauthorized},
+		{"ErrOIDCProviderNotFound", ErrOIDCProviderNotFound, ErrCodeOIDCProviderNotFound, fiber.StatusBadRequest},
+		{"ErrOIDCFlowInvalid", ErrOIDCFlowInvalid, ErrCodeOIDCFlowInvalid, fiber.StatusUnauthorized},
+		{"ErrOIDCLoginFailed", ErrOIDCLoginFailed, ErrCodeOIDCLoginFailed, fiber.StatusUnauthorized},
+		{"ErrExternalIdentityNotLinked", ErrExternalIdentityNotLinked, ErrCodeExternalIdentityNotLinked, fiber.StatusUnauthorized},
+		{"ErrWebAuthnCeremonyInvalid", ErrWebAuthnCeremonyInvalid, ErrCodeWebAuthnCeremonyInvalid, fiber.StatusUnauthorized},
+		{"ErrWebAuthnVerificationFailed", ErrWebAuthnVerificationFailed, ErrCodeWebAuthnVerificationFailed, fiber.StatusUnauthorized},
+		{"ErrWebAuthnCredentialNotFound", ErrWebAuthnCredentialNotFound, ErrCodeWebAuthnCredentialNotFound, fiber.StatusBadRequest},
+		{"ErrWebAuthnCredentialExists", ErrWebAuthnCredentialExists, ErrCodeWebAuthnCredentialExists, fiber.StatusBadRequest},
+		{"ErrAPIKeyRequired", ErrAPIKeyRequired, ErrCodeAPIKeyRequired, fiber.StatusUnauthorized},
+		{"ErrAPIKeyInvalid", ErrAPIKeyInvalid, ErrCodeAPIKeyInvalid, fiber.StatusUnauthorized},
+		{"ErrAPIKeyExpired", ErrAPIKeyExpired, ErrCodeAPIKeyExpired, fiber.StatusUnauthorized},
+		{"ErrAPIKeyNotFound", ErrAPIKeyNotFound, ErrCodeAPIKeyNotFound, fiber.StatusBadRequest},
+		{"ErrAPIKeyScopeNotGranted", ErrAPIKeyScopeNotGranted, ErrCodeAPIKeyScopeNotGranted, fiber.StatusBadRequest},
+		{"ErrCurrentPasswordInvalid", ErrCurrentPasswordInvalid, ErrCodeCurrentPasswordInvalid, fiber.StatusBadRequest},
 		{"ErrUnauthenticated", ErrUnauthenticated, ErrCodeUnauthenticated, fiber.StatusUnauthorized},
 		{"ErrAccessDenied", ErrAccessDenied, ErrCodeAccessDenied, fiber.StatusForbidden},
 		{"ErrUnknown", ErrUnknown, ErrCodeUnknown, fiber.StatusInternalServerError},
@@ -122,6 +122,26 @@ var (
 		WithCode(ErrCodeAuthHeaderInvalid),
 		WithStatus(fiber.StatusUnauthorized),
 	)
+	ErrSessionRevoked = Err(
+		i18n.T(ErrMessageSessionRevoked),
+		WithCode(ErrCodeSessionRevoked),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrRefreshTokenReused = Err(
+		i18n.T(ErrMessageRefreshTokenReused),
+		WithCode(ErrCodeRefreshTokenReused),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrAccountLocked = Err(
+		i18n.T(ErrMessageAccountLocked),
+		WithCode(ErrCodeAccountLocked),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrIPBlocked = Err(
+		i18n.T(ErrMessageIPBlocked),
+		WithCode(ErrCodeIPBlocked),
+		WithStatus(fiber.StatusUnauthorized),
+	)
 )
 
 // Predefined authorization and request errors.
@@ -141,6 +161,16 @@ var (
 		WithCode(ErrCodeRequestTimeout),
 		WithStatus(fiber.StatusRequestTimeout),
 	)
+	ErrIdempotencyKeyInProgress = Err(
+		i18n.T(ErrMessageIdempotencyKeyInProgress),
+		WithCode(ErrCodeIdempotencyKeyInProgress),
+		WithStatus(fiber.StatusConflict),
+	)
+	ErrIdempotencyKeyReused = Err(
+		i18n.T(ErrMessageIdempotencyKeyReused),
+		WithCode(ErrCodeIdempotencyKeyReused),
+		WithStatus(fiber.StatusUnprocessableEntity),
+	)
 	ErrUnknown = Err(
 		i18n.T(ErrMessageUnknown),
 		WithCode(ErrCodeUnknown),
@@ -180,6 +210,85 @@ var (
 		WithCode(ErrCodeDepartmentRequired),
 		WithStatus(fiber.StatusBadRequest),
 	)
+	ErrCaptchaInvalid = Err(
+		i18n.T(ErrMessageCaptchaInvalid),
+		WithCode(ErrCodeCaptchaInvalid),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+)
+
+// Predefined single sign-on errors.
+var (
+	ErrOIDCProviderNotFound = Err(
+		i18n.T(ErrMessageOIDCProviderNotFound),
+		WithCode(ErrCodeOIDCProviderNotFound),
+		WithStatus(fiber.StatusBadRequest),
+	)
+	ErrOIDCFlowInvalid = Err(
+		i18n.T(ErrMessageOIDCFlowInvalid),
+		WithCode(ErrCodeOIDCFlowInvalid),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrOIDCLoginFailed = Err(
+		i18n.T(ErrMessageOIDCLoginFailed),
+		WithCode(ErrCodeOIDCLoginFailed),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrExternalIdentityNotLinked = Err(
+		i18n.T(ErrMessageExternalIdentityNotLinked),
+		WithCode(ErrCodeExternalIdentityNotLinked),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrWebAuthnCeremonyInvalid = Err(
+		i18n.T(ErrMessageWebAuthnCeremonyInvalid),
+		WithCode(ErrCodeWebAuthnCeremonyInvalid),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrWebAuthnVerificationFailed = Err(
+		i18n.T(ErrMessageWebAuthnVerificationFailed),
+		WithCode(ErrCodeWebAuthnVerificationFailed),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrWebAuthnCredentialNotFound = Err(
+		i18n.T(ErrMessageWebAuthnCredentialNotFound),
+		WithCode(ErrCodeWebAuthnCredentialNotFound),
+		WithStatus(fiber.StatusBadRequest),
+	)
+	ErrWebAuthnCredentialExists = Err(
+		i18n.T(ErrMessageWebAuthnCredentialExists),
+		WithCode(ErrCodeWebAuthnCredentialExists),
+		WithStatus(fiber.StatusBadRequest),
+	)
+	ErrAPIKeyRequired = Err(
+		i18n.T(ErrMessageAPIKeyRequired),
+		WithCode(ErrCodeAPIKeyRequired),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrAPIKeyInvalid = Err(
+		i18n.T(ErrMessageAPIKeyInvalid),
+		WithCode(ErrCodeAPIKeyInvalid),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrAPIKeyExpired = Err(
+		i18n.T(ErrMessageAPIKeyExpired),
+		WithCode(ErrCodeAPIKeyExpired),
+		WithStatus(fiber.StatusUnauthorized),
+	)
+	ErrAPIKeyNotFound = Err(
+		i18n.T(ErrMessageAPIKeyNotFound),
+		WithCode(ErrCodeAPIKeyNotFound),
+		WithStatus(fiber.StatusBadRequest),
+	)
+	ErrAPIKeyScopeNotGranted = Err(
+		i18n.T(ErrMessageAPIKeyScopeNotGranted),
+		WithCode(ErrCodeAPIKeyScopeNotGranted),
+		WithStatus(fiber.StatusBadRequest),
+	)
+	ErrCurrentPasswordInvalid = Err(
+		i18n.T(ErrMessageCurrentPasswordInvalid),
+		WithCode(ErrCodeCurrentPasswordInvalid),
+		WithStatus(fiber.StatusBadRequest),
+	)
 )
 
 // Predefined business errors (HTTP 200 with error code).
@@ -196,6 +305,10 @@ var (
 		i18n.T(ErrMessageForeignKeyViolation),
 		WithCode(ErrCodeForeignKeyViolation),
 	)
+	ErrVersionConflict = Err(
+		i18n.T(ErrMessageVersionConflict),
+		WithCode(ErrCodeVersionConflict),
+	)
 	ErrDangerousSQL = Err(
 		i18n.T(ErrMessageDangerousSQL),
 		WithCode(ErrCodeDangerousSQL),
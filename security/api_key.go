@@ -0,0 +1,243 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/id"
+	"github.com/coldsmirk/vef-framework-go/password"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// APIKeyPrefix starts every API key, so leaked keys are easy to recognize by secret scanners.
+// A key reads "<prefix>_<id>_<secret>"; only a hash of the secret is stored.
+const APIKeyPrefix = "vef"
+
+const (
+	// apiKeySecretLength is the number of random bytes of an API key secret.
+	apiKeySecretLength = 32
+	// apiKeyLastUsedPrecision bounds how often the last use of a key is written to the store.
+	apiKeyLastUsedPrecision = time.Minute
+)
+
+// APIKey is a long-lived credential a user or an external app authenticates API requests with.
+// A key grants no more than its scopes, and of those only the permissions its owner still holds.
+type APIKey struct {
+	// ID identifies the key and is part of the key itself.
+	ID string `json:"id"`
+	// OwnerType is the type of the principal the key acts as.
+	OwnerType PrincipalType `json:"ownerType"`
+	// OwnerID is the ID of the user or external app the key acts as.
+	OwnerID string `json:"ownerId"`
+	// Name is the label the key was created with.
+	Name string `json:"name"`
+	// SecretHash is the secret of the key encoded by a password.Encoder.
+	SecretHash string `json:"-"`
+	// Scopes are the permission tokens the key is limited to.
+	Scopes []string `json:"scopes"`
+	// IPWhitelist restricts the client IPs the key is accepted from, comma-separated IPs or CIDRs; empty allows all.
+	IPWhitelist string `json:"ipWhitelist,omitempty"`
+	// ExpiresAt is the time the key stops being accepted, nil for keys that never expire.
+	ExpiresAt *timex.DateTime `json:"expiresAt,omitempty"`
+	// CreatedAt is the time the key was created.
+	CreatedAt timex.DateTime `json:"createdAt"`
+	// LastUsedAt is the time the key last authenticated a request, accurate to a minute.
+	LastUsedAt *timex.DateTime `json:"lastUsedAt,omitempty"`
+}
+
+// IsExpired reports whether the key has expired at the given time.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(k.ExpiresAt.Unwrap())
+}
+
+// APIKeyStore persists API keys.
+type APIKeyStore interface {
+	// Save creates or replaces a key.
+	Save(ctx context.Context, key *APIKey) error
+	// Get retrieves a key by ID, returning nil when it does not exist.
+	Get(ctx context.Context, id string) (*APIKey, error)
+	// ListByOwner lists the keys of a user or an external app.
+	ListByOwner(ctx context.Context, ownerType PrincipalType, ownerID string) ([]*APIKey, error)
+	// Delete removes a key.
+	Delete(ctx context.Context, id string) error
+	// UpdateLastUsed records the last use of a key; updating a missing key is not an error.
+	UpdateLastUsed(ctx context.Context, id string, usedAt timex.DateTime) error
+}
+
+// APIKeySpec describes an API key to create.
+type APIKeySpec struct {
+	Name        string
+	Scopes      []string
+	IPWhitelist string
+	ExpiresAt   *timex.DateTime
+}
+
+// CreatedAPIKey is a newly created API key with its plaintext value, which is only available at creation.
+type CreatedAPIKey struct {
+	*APIKey
+
+	Key string `json:"key"`
+}
+
+// APIKeys creates, verifies and revokes API keys.
+type APIKeys struct {
+	store   APIKeyStore
+	encoder password.Encoder
+}
+
+// NewAPIKeys creates the API key service. API keys are disabled while store is nil.
+// Secrets are hashed with encoder, SHA-256 when nil. Unlike passwords they do not use the application's
+// password.Encoder: they carry 256 random bits, so a slow password hash would only slow down every request
+// without making keys harder to guess. Applications replace the encoder via vef.ProvideAPIKeyEncoder.
+func NewAPIKeys(store APIKeyStore, encoder password.Encoder) *APIKeys {
+	if encoder == nil {
+		encoder = password.NewSha256Encoder()
+	}
+
+	return &APIKeys{
+		store:   store,
+		encoder: encoder,
+	}
+}
+
+// Enabled reports whether an APIKeyStore was provided.
+func (k *APIKeys) Enabled() bool {
+	return k.store != nil
+}
+
+// Create creates an API key for the owner and returns it with its plaintext value.
+// The scopes are stored as given; callers check that the owner holds them.
+func (k *APIKeys) Create(ctx context.Context, ownerType PrincipalType, ownerID string, spec APIKeySpec) (*CreatedAPIKey, error) {
+	if err := k.checkEnabled(); err != nil {
+		return nil, err
+	}
+
+	secretBytes := make([]byte, apiKeySecretLength)
+	_, _ = rand.Read(secretBytes)
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	secretHash, err := k.encoder.Encode(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash api key secret: %w", err)
+	}
+
+	key := &APIKey{
+		ID:          id.Generate(),
+		OwnerType:   ownerType,
+		OwnerID:     ownerID,
+		Name:        spec.Name,
+		SecretHash:  secretHash,
+		Scopes:      slices.Compact(slices.Sorted(slices.Values(spec.Scopes))),
+		IPWhitelist: spec.IPWhitelist,
+		ExpiresAt:   spec.ExpiresAt,
+		CreatedAt:   timex.Now(),
+	}
+
+	if err := k.store.Save(ctx, key); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Created API key %q for %s %q", key.ID, ownerType, ownerID)
+
+	return &CreatedAPIKey{
+		APIKey: key,
+		Key:    strings.Join([]string{APIKeyPrefix, key.ID, secret}, "_"),
+	}, nil
+}
+
+// Verify checks the plaintext key presented from the client IP and returns the stored key.
+// Keys with an IP whitelist are rejected when the client IP is unknown, as it cannot be matched against it.
+func (k *APIKeys) Verify(ctx context.Context, plaintext, ip string) (*APIKey, error) {
+	if err := k.checkEnabled(); err != nil {
+		return nil, err
+	}
+
+	// The secret is base64url-encoded and may itself contain underscores, so it takes the rest of the key.
+	parts := strings.SplitN(plaintext, "_", 3)
+	if len(parts) != 3 || parts[0] != APIKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return nil, ErrAPIKeyMalformed
+	}
+
+	key, err := k.store.Get(ctx, parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	if !k.encoder.Matches(parts[2], key.SecretHash) {
+		return nil, ErrAPIKeySecretInvalid
+	}
+
+	now := time.Now()
+	if key.IsExpired(now) {
+		return nil, ErrAPIKeyExpired
+	}
+
+	if key.IPWhitelist != "" && (ip == "" || !NewIPWhitelistValidator(key.IPWhitelist).IsAllowed(ip)) {
+		return nil, fmt.Errorf("%w: %q", ErrAPIKeyIPNotAllowed, ip)
+	}
+
+	if key.LastUsedAt == nil || now.Sub(key.LastUsedAt.Unwrap()) >= apiKeyLastUsedPrecision {
+		usedAt := timex.Of(now)
+		if err := k.store.UpdateLastUsed(ctx, key.ID, usedAt); err != nil {
+			// Failing to track the last use must not fail the request it belongs to.
+			logger.Warnf("Failed to record last use of API key %q: %v", key.ID, err)
+		} else {
+			key.LastUsedAt = &usedAt
+		}
+	}
+
+	return key, nil
+}
+
+// List lists the keys of a user or an external app.
+func (k *APIKeys) List(ctx context.Context, ownerType PrincipalType, ownerID string) ([]*APIKey, error) {
+	if err := k.checkEnabled(); err != nil {
+		return nil, err
+	}
+
+	return k.store.ListByOwner(ctx, ownerType, ownerID)
+}
+
+// Revoke deletes a key of a user or an external app.
+func (k *APIKeys) Revoke(ctx context.Context, ownerType PrincipalType, ownerID, keyID string) error {
+	if err := k.checkEnabled(); err != nil {
+		return err
+	}
+
+	key, err := k.store.Get(ctx, keyID)
+	if err != nil {
+		return err
+	}
+
+	// Keys of other owners are reported as missing so their IDs cannot be probed.
+	if key == nil || key.OwnerType != ownerType || key.OwnerID != ownerID {
+		return result.ErrAPIKeyNotFound
+	}
+
+	if err := k.store.Delete(ctx, keyID); err != nil {
+		return err
+	}
+
+	logger.Infof("Revoked API key %q of %s %q", keyID, ownerType, ownerID)
+
+	return nil
+}
+
+func (k *APIKeys) checkEnabled() error {
+	if k.store == nil {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageAPIKeyStoreNotImplemented))
+	}
+
+	return nil
+}
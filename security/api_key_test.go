@@ -0,0 +1,202 @@
+package security
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/password"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+type APIKeysTestSuite struct {
+	suite.Suite
+
+	ctx     context.Context
+	store   APIKeyStore
+	apiKeys *APIKeys
+}
+
+func (s *APIKeysTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.store = NewMemoryAPIKeyStore()
+	s.apiKeys = NewAPIKeys(s.store, nil)
+}
+
+func (s *APIKeysTestSuite) create(spec APIKeySpec) *CreatedAPIKey {
+	s.T().Helper()
+
+	key, err := s.apiKeys.Create(s.ctx, PrincipalTypeUser, "user1", spec)
+	s.Require().NoError(err, "Should create API key")
+
+	return key
+}
+
+// TestCustomEncoder verifies secrets are hashed with the given encoder.
+func (s *APIKeysTestSuite) TestCustomEncoder() {
+	encoder := password.NewBcryptEncoder()
+	s.apiKeys = NewAPIKeys(s.store, encoder)
+
+	created := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}})
+	s.True(strings.HasPrefix(created.SecretHash, "$2"), "Should store a bcrypt hash")
+
+	_, err := s.apiKeys.Verify(s.ctx, created.Key, "")
+	s.NoError(err, "Should verify with the given encoder")
+}
+
+// TestCreate verifies the plaintext key format and the stored key.
+func (s *APIKeysTestSuite) TestCreate() {
+	key := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query", "sys:role:query", "sys:user:query"}})
+
+	s.True(strings.HasPrefix(key.Key, APIKeyPrefix+"_"+key.ID+"_"), "Should start the key with the prefix and ID")
+	s.Equal([]string{"sys:role:query", "sys:user:query"}, key.Scopes, "Should sort and deduplicate the scopes")
+	s.False(key.CreatedAt.Unwrap().IsZero(), "Should record the creation time")
+
+	stored, err := s.store.Get(s.ctx, key.ID)
+	s.Require().NoError(err, "Should get stored key")
+	s.Require().NotNil(stored, "Should store the key")
+	s.NotEmpty(stored.SecretHash, "Should store the secret hash")
+	s.NotContains(key.Key, stored.SecretHash, "Should not store the plaintext secret")
+	s.Equal(PrincipalTypeUser, stored.OwnerType, "Should store the owner type")
+	s.Equal("user1", stored.OwnerID, "Should store the owner ID")
+}
+
+// TestVerify verifies accepted and rejected keys.
+func (s *APIKeysTestSuite) TestVerify() {
+	s.Run("Valid", func() {
+		created := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}})
+
+		key, err := s.apiKeys.Verify(s.ctx, created.Key, "10.0.0.1")
+		s.Require().NoError(err, "Should accept the key")
+		s.Equal(created.ID, key.ID, "Should return the stored key")
+		s.NotNil(key.LastUsedAt, "Should record the last use")
+
+		stored, err := s.store.Get(s.ctx, created.ID)
+		s.Require().NoError(err, "Should get stored key")
+		s.NotNil(stored.LastUsedAt, "Should persist the last use")
+	})
+
+	s.Run("Malformed", func() {
+		for _, plaintext := range []string{"", "vef", "vef_id", "other_id_secret", "vef__secret", "vef_id_"} {
+			_, err := s.apiKeys.Verify(s.ctx, plaintext, "")
+			s.ErrorIs(err, ErrAPIKeyMalformed, "Should reject malformed key %q", plaintext)
+		}
+	})
+
+	s.Run("UnknownKey", func() {
+		_, err := s.apiKeys.Verify(s.ctx, "vef_missing_secret", "")
+		s.ErrorIs(err, ErrAPIKeyNotFound, "Should reject an unknown key")
+	})
+
+	s.Run("WrongSecret", func() {
+		created := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}})
+
+		_, err := s.apiKeys.Verify(s.ctx, APIKeyPrefix+"_"+created.ID+"_wrong", "")
+		s.ErrorIs(err, ErrAPIKeySecretInvalid, "Should reject a wrong secret")
+	})
+
+	s.Run("Expired", func() {
+		expiresAt := timex.Of(time.Now().Add(-time.Minute))
+		created := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}, ExpiresAt: &expiresAt})
+
+		_, err := s.apiKeys.Verify(s.ctx, created.Key, "")
+		s.ErrorIs(err, ErrAPIKeyExpired, "Should reject an expired key")
+	})
+
+	s.Run("NotExpired", func() {
+		expiresAt := timex.Of(time.Now().Add(time.Hour))
+		created := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}, ExpiresAt: &expiresAt})
+
+		_, err := s.apiKeys.Verify(s.ctx, created.Key, "")
+		s.NoError(err, "Should accept a key before its expiry")
+	})
+
+	s.Run("IPWhitelist", func() {
+		created := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}, IPWhitelist: "10.0.0.0/24"})
+
+		_, err := s.apiKeys.Verify(s.ctx, created.Key, "10.0.0.8")
+		s.NoError(err, "Should accept a whitelisted IP")
+
+		_, err = s.apiKeys.Verify(s.ctx, created.Key, "192.168.1.1")
+		s.ErrorIs(err, ErrAPIKeyIPNotAllowed, "Should reject an IP outside the whitelist")
+	})
+
+	s.Run("IPUnknown", func() {
+		created := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}, IPWhitelist: "10.0.0.0/24"})
+
+		_, err := s.apiKeys.Verify(s.ctx, created.Key, "")
+		s.ErrorIs(err, ErrAPIKeyIPNotAllowed, "Should reject a whitelisted key when the client IP is unknown")
+
+		unrestricted := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}})
+
+		_, err = s.apiKeys.Verify(s.ctx, unrestricted.Key, "")
+		s.NoError(err, "Should accept a key without a whitelist when the client IP is unknown")
+	})
+
+	s.Run("LastUsedThrottled", func() {
+		created := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}})
+		usedAt := timex.Of(time.Now().Add(-10 * time.Second))
+		s.Require().NoError(s.store.UpdateLastUsed(s.ctx, created.ID, usedAt), "Should record last use")
+
+		key, err := s.apiKeys.Verify(s.ctx, created.Key, "")
+		s.Require().NoError(err, "Should accept the key")
+		s.True(key.LastUsedAt.Unwrap().Equal(usedAt.Unwrap()), "Should not rewrite a last use within a minute")
+	})
+}
+
+// TestRevoke verifies that owners can only revoke their own keys.
+func (s *APIKeysTestSuite) TestRevoke() {
+	created := s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}})
+
+	err := s.apiKeys.Revoke(s.ctx, PrincipalTypeUser, "user2", created.ID)
+	s.ErrorIs(err, result.ErrAPIKeyNotFound, "Should hide keys of other owners")
+
+	err = s.apiKeys.Revoke(s.ctx, PrincipalTypeExternalApp, "user1", created.ID)
+	s.ErrorIs(err, result.ErrAPIKeyNotFound, "Should match the owner type")
+
+	s.Require().NoError(s.apiKeys.Revoke(s.ctx, PrincipalTypeUser, "user1", created.ID), "Should revoke own key")
+
+	_, err = s.apiKeys.Verify(s.ctx, created.Key, "")
+	s.ErrorIs(err, ErrAPIKeyNotFound, "Should reject a revoked key")
+
+	err = s.apiKeys.Revoke(s.ctx, PrincipalTypeUser, "user1", created.ID)
+	s.ErrorIs(err, result.ErrAPIKeyNotFound, "Should report a missing key")
+}
+
+// TestList verifies listing keys by owner.
+func (s *APIKeysTestSuite) TestList() {
+	s.create(APIKeySpec{Name: "ci", Scopes: []string{"sys:user:query"}})
+
+	_, err := s.apiKeys.Create(s.ctx, PrincipalTypeExternalApp, "user1", APIKeySpec{Name: "app", Scopes: []string{"sys:user:query"}})
+	s.Require().NoError(err, "Should create external app key")
+
+	keys, err := s.apiKeys.List(s.ctx, PrincipalTypeUser, "user1")
+	s.Require().NoError(err, "Should list keys")
+	s.Require().Len(keys, 1, "Should only list keys of the owner")
+	s.Equal("ci", keys[0].Name, "Should list the owner's key")
+}
+
+// TestDisabled verifies that every operation fails without a store.
+func (s *APIKeysTestSuite) TestDisabled() {
+	apiKeys := NewAPIKeys(nil, nil)
+	s.False(apiKeys.Enabled(), "Should be disabled without a store")
+
+	_, err := apiKeys.Create(s.ctx, PrincipalTypeUser, "user1", APIKeySpec{Name: "ci"})
+	s.Error(err, "Should fail to create keys")
+
+	_, err = apiKeys.Verify(s.ctx, "vef_id_secret", "")
+	s.Error(err, "Should fail to verify keys")
+
+	_, err = apiKeys.List(s.ctx, PrincipalTypeUser, "user1")
+	s.Error(err, "Should fail to list keys")
+
+	s.Error(apiKeys.Revoke(s.ctx, PrincipalTypeUser, "user1", "id"), "Should fail to revoke keys")
+}
+
+func TestAPIKeys(t *testing.T) {
+	suite.Run(t, new(APIKeysTestSuite))
+}
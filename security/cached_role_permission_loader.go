@@ -30,11 +30,42 @@ func PublishRolePermissionsChangedEvent(publisher event.Publisher, roles ...stri
 	})
 }
 
+// CachedLoaderOption configures the cache of a cached loader.
+type CachedLoaderOption func(*cachedLoaderOptions)
+
+type cachedLoaderOptions struct {
+	scope cache.ScopeFunc
+}
+
+// WithCacheScope isolates the cached entries per scope, e.g. per tenant with security.WithCacheScope(contextx.TenantID),
+// for loaders whose results differ between tenants.
+func WithCacheScope(scope cache.ScopeFunc) CachedLoaderOption {
+	return func(options *cachedLoaderOptions) {
+		options.scope = scope
+	}
+}
+
+// newLoaderCache creates the in-memory cache of a cached loader, scoped when WithCacheScope is given.
+func newLoaderCache[T any](opts []CachedLoaderOption) (cache.Cache[T], bool) {
+	var options cachedLoaderOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	c := cache.NewMemory[T]()
+	if options.scope == nil {
+		return c, false
+	}
+
+	return cache.NewScoped(c, options.scope), true
+}
+
 // CachedRolePermissionsLoader is a decorator that adds caching to a RolePermissionsLoader.
 // It uses the cache system and event bus for automatic cache invalidation.
 type CachedRolePermissionsLoader struct {
 	loader    RolePermissionsLoader
 	permCache cache.Cache[map[string]DataScope]
+	scoped    bool
 	logger    logx.Logger
 }
 
@@ -43,10 +74,13 @@ type CachedRolePermissionsLoader struct {
 func NewCachedRolePermissionsLoader(
 	loader RolePermissionsLoader,
 	eventBus event.Subscriber,
+	opts ...CachedLoaderOption,
 ) RolePermissionsLoader {
+	permCache, scoped := newLoaderCache[map[string]DataScope](opts)
 	cached := &CachedRolePermissionsLoader{
 		loader:    loader,
-		permCache: cache.NewMemory[map[string]DataScope](),
+		permCache: permCache,
+		scoped:    scoped,
 		logger:    ilogx.Named("security:cached_role_permissions_loader"),
 	}
 
@@ -64,8 +98,9 @@ func (c *CachedRolePermissionsLoader) handlePermissionsChanged(ctx context.Conte
 		return
 	}
 
-	// Empty roles means clear all cache
-	if len(changeEvent.Roles) == 0 {
+	// Empty roles means clear all cache. A scoped cache holds the roles of every scope, and since the event
+	// does not tell the scope, the whole cache is cleared as well.
+	if len(changeEvent.Roles) == 0 || c.scoped {
 		if err := c.permCache.Clear(ctx); err != nil {
 			c.logger.Errorf("Failed to clear all role permissions cache: %v", err)
 		} else {
@@ -195,6 +195,50 @@ func (s *CachedRolePermissionsLoaderTestSuite) TestInvalidatesAllRoles() {
 	mockLoader.AssertExpectations(s.T())
 }
 
+// TestScopedPerTenant tests that a scoped loader caches the permissions of equally named roles per tenant
+// and that invalidating a role reaches every tenant.
+func (s *CachedRolePermissionsLoaderTestSuite) TestScopedPerTenant() {
+	type tenantKey struct{}
+
+	var (
+		mockLoader = new(MockRolePermissionsLoader)
+		tenantA    = context.WithValue(s.ctx, tenantKey{}, "a")
+		tenantB    = context.WithValue(s.ctx, tenantKey{}, "b")
+		scope      = func(ctx context.Context) string {
+			tenantID, _ := ctx.Value(tenantKey{}).(string)
+
+			return tenantID
+		}
+	)
+
+	mockLoader.On("LoadPermissions", tenantA, "admin").
+		Return(map[string]DataScope{"a.read": NewAllDataScope()}, nil).
+		Twice()
+	mockLoader.On("LoadPermissions", tenantB, "admin").
+		Return(map[string]DataScope{"b.read": NewAllDataScope()}, nil).
+		Once()
+
+	cachedLoader := NewCachedRolePermissionsLoader(mockLoader, s.bus, WithCacheScope(scope))
+
+	for range 2 {
+		result, err := cachedLoader.LoadPermissions(tenantA, "admin")
+		s.Require().NoError(err, "Should load permissions of tenant a")
+		s.Contains(result, "a.read", "Should return the permissions of tenant a")
+
+		result, err = cachedLoader.LoadPermissions(tenantB, "admin")
+		s.Require().NoError(err, "Should load permissions of tenant b")
+		s.Contains(result, "b.read", "Should not return the permissions of another tenant")
+	}
+
+	PublishRolePermissionsChangedEvent(s.bus, "admin")
+	time.Sleep(10 * time.Millisecond)
+
+	_, err := cachedLoader.LoadPermissions(tenantA, "admin")
+	s.Require().NoError(err, "Should reload permissions of tenant a after invalidation")
+
+	mockLoader.AssertExpectations(s.T())
+}
+
 func (s *CachedRolePermissionsLoaderTestSuite) TestEmptyRole() {
 	mockLoader := new(MockRolePermissionsLoader)
 
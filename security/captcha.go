@@ -0,0 +1,80 @@
+package security
+
+import (
+	"context"
+	"math"
+
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+// ChallengeTypeCaptcha is the challenge type of CaptchaChallengeProvider.
+const ChallengeTypeCaptcha = "captcha"
+
+// CaptchaVerifier issues and verifies captchas, backed by the application's captcha service.
+type CaptchaVerifier interface {
+	// Issue creates a captcha for a login of username and returns the data presented to the user,
+	// such as an image or the site key of a hosted captcha.
+	Issue(ctx context.Context, username string) (any, error)
+	// Verify checks the user's answer to the captcha issued for a login of username.
+	Verify(ctx context.Context, username string, response any) (bool, error)
+}
+
+// CaptchaChallengeProvider requires a captcha on logins flagged by the account lockout policy.
+// The captcha is solved before the credentials are checked, so a flagged username cannot be used
+// to keep guessing passwords: a login without an answer is answered with the issued captcha,
+// and the login repeated with the answer is only authenticated once the answer is verified.
+// Register it via vef.ProvideChallengeProvider together with the application's CaptchaVerifier.
+// It implements the ChallengeProvider interface.
+type CaptchaChallengeProvider struct {
+	verifier CaptchaVerifier
+}
+
+// NewCaptchaChallengeProvider creates a captcha challenge provider; panics if verifier is nil.
+func NewCaptchaChallengeProvider(verifier CaptchaVerifier) *CaptchaChallengeProvider {
+	if verifier == nil {
+		panic("security: CaptchaVerifier is required")
+	}
+
+	return &CaptchaChallengeProvider{verifier: verifier}
+}
+
+func (*CaptchaChallengeProvider) Type() string { return ChallengeTypeCaptcha }
+func (*CaptchaChallengeProvider) Order() int   { return math.MinInt }
+
+// Evaluate never challenges an authenticated login, the captcha has been solved before authentication.
+func (*CaptchaChallengeProvider) Evaluate(context.Context, *Principal) (*LoginChallenge, error) {
+	return nil, nil
+}
+
+// Resolve rejects every response, as the captcha is never a pending challenge of a challenge token.
+func (*CaptchaChallengeProvider) Resolve(context.Context, *Principal, any) (*Principal, error) {
+	return nil, result.ErrChallengeTypeInvalid
+}
+
+// Issue creates the captcha a login of username must solve before it is authenticated.
+func (p *CaptchaChallengeProvider) Issue(ctx context.Context, username string) (*LoginChallenge, error) {
+	data, err := p.verifier.Issue(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginChallenge{
+		Type:     ChallengeTypeCaptcha,
+		Data:     data,
+		Required: true,
+	}, nil
+}
+
+// Verify checks the answer to the captcha issued for a login of username.
+func (p *CaptchaChallengeProvider) Verify(ctx context.Context, username string, response any) error {
+	valid, err := p.verifier.Verify(ctx, username, response)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return result.ErrCaptchaInvalid
+	}
+
+	return nil
+}
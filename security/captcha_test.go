@@ -0,0 +1,67 @@
+package security
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+type MockCaptchaVerifier struct {
+	IssueFn  func(ctx context.Context, username string) (any, error)
+	VerifyFn func(ctx context.Context, username string, response any) (bool, error)
+}
+
+func (m *MockCaptchaVerifier) Issue(ctx context.Context, username string) (any, error) {
+	return m.IssueFn(ctx, username)
+}
+
+func (m *MockCaptchaVerifier) Verify(ctx context.Context, username string, response any) (bool, error) {
+	return m.VerifyFn(ctx, username, response)
+}
+
+// TestCaptchaChallengeProvider tests that captchas are issued and verified before authentication.
+func TestCaptchaChallengeProvider(t *testing.T) {
+	provider := NewCaptchaChallengeProvider(&MockCaptchaVerifier{
+		IssueFn: func(context.Context, string) (any, error) {
+			return map[string]any{"image": "data:image/png;base64,..."}, nil
+		},
+		VerifyFn: func(_ context.Context, username string, response any) (bool, error) {
+			return username == "alice" && response == "x7k2", nil
+		},
+	})
+
+	t.Run("TypeAndOrder", func(t *testing.T) {
+		assert.Equal(t, ChallengeTypeCaptcha, provider.Type(), "Should report the captcha type")
+		assert.Less(t, provider.Order(), 0, "Should be evaluated before other challenges")
+	})
+
+	t.Run("NoChallengeAfterAuthentication", func(t *testing.T) {
+		challenge, err := provider.Evaluate(context.Background(), NewUser("user1", "Alice"))
+		require.NoError(t, err, "Should evaluate without error")
+		assert.Nil(t, challenge, "Should not challenge authenticated logins")
+
+		_, err = provider.Resolve(context.Background(), NewUser("user1", "Alice"), "x7k2")
+		assert.ErrorIs(t, err, result.ErrChallengeTypeInvalid, "Should not resolve as a pending challenge")
+	})
+
+	t.Run("Issue", func(t *testing.T) {
+		challenge, err := provider.Issue(context.Background(), "alice")
+		require.NoError(t, err, "Should issue without error")
+		assert.Equal(t, ChallengeTypeCaptcha, challenge.Type, "Challenge should have the captcha type")
+		assert.True(t, challenge.Required, "Captcha should be required")
+		assert.NotNil(t, challenge.Data, "Challenge should carry the issued captcha")
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		require.NoError(t, provider.Verify(context.Background(), "alice", "x7k2"), "Should accept the correct answer")
+		assert.ErrorIs(t, provider.Verify(context.Background(), "alice", "wrong"), result.ErrCaptchaInvalid, "Should reject a wrong answer")
+	})
+
+	t.Run("NilVerifierPanics", func(t *testing.T) {
+		assert.Panics(t, func() { NewCaptchaChallengeProvider(nil) }, "Should require a verifier")
+	})
+}
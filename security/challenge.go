@@ -12,6 +12,7 @@ type LoginChallenge struct {
 // LoginResult represents the response of a login attempt.
 // When a challenge is pending, Tokens is nil and ChallengeToken + Challenge are set.
 // When all challenges are resolved (or none were needed), Tokens is set.
+// A captcha demanded before authentication only sets Challenge; the login is then repeated with the answer.
 type LoginResult struct {
 	Tokens         *AuthTokens     `json:"tokens,omitempty"`
 	ChallengeToken string          `json:"challengeToken,omitempty"`
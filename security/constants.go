@@ -11,4 +11,6 @@ const (
 	TokenTypeAccess    = "access"
 	TokenTypeRefresh   = "refresh"
 	TokenTypeChallenge = "challenge"
+	TokenTypeOIDCFlow  = "oidc_flow"
+	TokenTypeWebAuthn  = "webauthn"
 )
@@ -56,6 +56,10 @@ func (*AllDataScope) Apply(*Principal, orm.SelectQuery) error {
 	return nil
 }
 
+func (*AllDataScope) ApplyConditions(*Principal, orm.ConditionBuilder) error {
+	return nil
+}
+
 // SelfDataScope restricts access to data created by the user themselves.
 // This is commonly used for personal data access where users can only see their own records.
 type SelfDataScope struct {
@@ -87,9 +91,22 @@ func (s *SelfDataScope) Supports(_ *Principal, table *orm.Table) bool {
 }
 
 func (s *SelfDataScope) Apply(principal *Principal, query orm.SelectQuery) error {
+	return applyConditions(s, principal, query)
+}
+
+func (s *SelfDataScope) ApplyConditions(principal *Principal, cb orm.ConditionBuilder) error {
+	cb.Equals(s.createdByColumn, principal.ID)
+
+	return nil
+}
+
+// applyConditions adds the conditions of scope to the WHERE clause of a SELECT query.
+func applyConditions(scope ConditionDataScope, principal *Principal, query orm.SelectQuery) error {
+	var err error
+
 	query.Where(func(cb orm.ConditionBuilder) {
-		cb.Equals(s.createdByColumn, principal.ID)
+		err = scope.ApplyConditions(principal, cb)
 	})
 
-	return nil
+	return err
 }
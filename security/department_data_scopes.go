@@ -0,0 +1,215 @@
+package security
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/coldsmirk/vef-framework-go/orm"
+)
+
+// ColumnDepartmentID is the default department column of tables restricted by the department data scopes.
+const ColumnDepartmentID = "department_id"
+
+// DepartmentDataScope restricts access to data of the principal's own department.
+type DepartmentDataScope struct {
+	// Database column name for the department, defaults to "department_id"
+	departmentColumn string
+}
+
+// NewDepartmentDataScope creates a new DepartmentDataScope instance.
+// The departmentColumn parameter specifies the database column name for the department.
+// If empty, it defaults to "department_id".
+func NewDepartmentDataScope(departmentColumn string) DataScope {
+	return &DepartmentDataScope{
+		departmentColumn: cmp.Or(departmentColumn, ColumnDepartmentID),
+	}
+}
+
+func (*DepartmentDataScope) Key() string {
+	return "department"
+}
+
+func (*DepartmentDataScope) Priority() int {
+	return PriorityDepartment
+}
+
+func (s *DepartmentDataScope) Supports(_ *Principal, table *orm.Table) bool {
+	return hasColumn(table, s.departmentColumn)
+}
+
+func (s *DepartmentDataScope) Apply(principal *Principal, query orm.SelectQuery) error {
+	return applyConditions(s, principal, query)
+}
+
+func (s *DepartmentDataScope) ApplyConditions(principal *Principal, cb orm.ConditionBuilder) error {
+	applyDepartmentCondition(cb, s.departmentColumn, departmentIDs(principal))
+
+	return nil
+}
+
+// DepartmentAndSubDataScope restricts access to data of the principal's department and all its sub-departments.
+// The subtree is loaded per request through a DepartmentHierarchyLoader when the scope is bound to the principal.
+type DepartmentAndSubDataScope struct {
+	// Database column name for the department, defaults to "department_id"
+	departmentColumn string
+	loader           DepartmentHierarchyLoader
+}
+
+// NewDepartmentAndSubDataScope creates a new DepartmentAndSubDataScope instance.
+// The departmentColumn parameter specifies the database column name for the department.
+// If empty, it defaults to "department_id".
+// Panics if loader is nil.
+func NewDepartmentAndSubDataScope(departmentColumn string, loader DepartmentHierarchyLoader) DataScope {
+	if loader == nil {
+		panic("security: DepartmentHierarchyLoader is required")
+	}
+
+	return &DepartmentAndSubDataScope{
+		departmentColumn: cmp.Or(departmentColumn, ColumnDepartmentID),
+		loader:           loader,
+	}
+}
+
+func (*DepartmentAndSubDataScope) Key() string {
+	return "department_and_sub"
+}
+
+func (*DepartmentAndSubDataScope) Priority() int {
+	return PriorityDepartmentAndSub
+}
+
+func (s *DepartmentAndSubDataScope) Supports(_ *Principal, table *orm.Table) bool {
+	return hasColumn(table, s.departmentColumn)
+}
+
+// Apply always fails, as the subtree is only known once the scope is bound to the principal.
+func (*DepartmentAndSubDataScope) Apply(*Principal, orm.SelectQuery) error {
+	return ErrDataScopeNotBound
+}
+
+// ApplyConditions always fails, as the subtree is only known once the scope is bound to the principal.
+func (*DepartmentAndSubDataScope) ApplyConditions(*Principal, orm.ConditionBuilder) error {
+	return ErrDataScopeNotBound
+}
+
+// Bind loads the department subtree of the principal.
+// A principal without a department is bound to a scope that matches no data.
+func (s *DepartmentAndSubDataScope) Bind(ctx context.Context, principal *Principal) (DataScope, error) {
+	var ids []string
+
+	if principal.DepartmentID != "" {
+		subtree, err := s.loader.LoadSubDepartmentIDs(ctx, principal.DepartmentID)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = subtree
+	}
+
+	return &boundDepartmentDataScope{
+		key:              s.Key(),
+		priority:         s.Priority(),
+		departmentColumn: s.departmentColumn,
+		departmentIDs:    ids,
+	}, nil
+}
+
+// CustomDepartmentDataScope restricts access to data of a fixed list of departments, typically configured per role.
+// Scopes returned by a RolePermissionsLoader are cached and invalidated with the role permissions.
+type CustomDepartmentDataScope struct {
+	// Database column name for the department, defaults to "department_id"
+	departmentColumn string
+	departmentIDs    []string
+}
+
+// NewCustomDepartmentDataScope creates a new CustomDepartmentDataScope instance granting the listed departments.
+// The departmentColumn parameter specifies the database column name for the department.
+// If empty, it defaults to "department_id".
+func NewCustomDepartmentDataScope(departmentColumn string, departmentIDs ...string) DataScope {
+	return &CustomDepartmentDataScope{
+		departmentColumn: cmp.Or(departmentColumn, ColumnDepartmentID),
+		departmentIDs:    slices.Clone(departmentIDs),
+	}
+}
+
+func (*CustomDepartmentDataScope) Key() string {
+	return "custom_department"
+}
+
+func (*CustomDepartmentDataScope) Priority() int {
+	return PriorityCustom
+}
+
+func (s *CustomDepartmentDataScope) Supports(_ *Principal, table *orm.Table) bool {
+	return hasColumn(table, s.departmentColumn)
+}
+
+func (s *CustomDepartmentDataScope) Apply(principal *Principal, query orm.SelectQuery) error {
+	return applyConditions(s, principal, query)
+}
+
+func (s *CustomDepartmentDataScope) ApplyConditions(_ *Principal, cb orm.ConditionBuilder) error {
+	applyDepartmentCondition(cb, s.departmentColumn, s.departmentIDs)
+
+	return nil
+}
+
+// boundDepartmentDataScope is a department scope resolved to the departments of one principal.
+type boundDepartmentDataScope struct {
+	key              string
+	priority         int
+	departmentColumn string
+	departmentIDs    []string
+}
+
+func (s *boundDepartmentDataScope) Key() string {
+	return s.key
+}
+
+func (s *boundDepartmentDataScope) Priority() int {
+	return s.priority
+}
+
+func (s *boundDepartmentDataScope) Supports(_ *Principal, table *orm.Table) bool {
+	return hasColumn(table, s.departmentColumn)
+}
+
+func (s *boundDepartmentDataScope) Apply(principal *Principal, query orm.SelectQuery) error {
+	return applyConditions(s, principal, query)
+}
+
+func (s *boundDepartmentDataScope) ApplyConditions(_ *Principal, cb orm.ConditionBuilder) error {
+	applyDepartmentCondition(cb, s.departmentColumn, s.departmentIDs)
+
+	return nil
+}
+
+func hasColumn(table *orm.Table, column string) bool {
+	field, _ := table.Field(column)
+
+	return field != nil
+}
+
+// departmentIDs returns the principal's department, or none when it has no department.
+func departmentIDs(principal *Principal) []string {
+	if principal == nil || principal.DepartmentID == "" {
+		return nil
+	}
+
+	return []string{principal.DepartmentID}
+}
+
+// applyDepartmentCondition restricts the column to the departments; no departments match no data.
+func applyDepartmentCondition(cb orm.ConditionBuilder, column string, departmentIDs []string) {
+	switch len(departmentIDs) {
+	case 0:
+		cb.Expr(func(eb orm.ExprBuilder) any {
+			return eb.Expr("1 = 0")
+		})
+	case 1:
+		cb.Equals(column, departmentIDs[0])
+	default:
+		cb.In(column, departmentIDs)
+	}
+}
@@ -0,0 +1,120 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDepartmentDataScope tests DepartmentDataScope Key, Priority and defaults.
+func TestDepartmentDataScope(t *testing.T) {
+	t.Run("KeyAndPriority", func(t *testing.T) {
+		scope := NewDepartmentDataScope("")
+		assert.Equal(t, "department", scope.Key(), "Should return 'department'")
+		assert.Equal(t, PriorityDepartment, scope.Priority(), "Should return PriorityDepartment")
+	})
+
+	t.Run("EmptyColumnUsesDefault", func(t *testing.T) {
+		scope := NewDepartmentDataScope("").(*DepartmentDataScope)
+		assert.Equal(t, "department_id", scope.departmentColumn, "Should default to 'department_id'")
+	})
+
+	t.Run("CustomColumn", func(t *testing.T) {
+		scope := NewDepartmentDataScope("dept_id").(*DepartmentDataScope)
+		assert.Equal(t, "dept_id", scope.departmentColumn, "Should use custom column")
+	})
+}
+
+// TestDepartmentAndSubDataScope tests DepartmentAndSubDataScope binding.
+func TestDepartmentAndSubDataScope(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("KeyAndPriority", func(t *testing.T) {
+		scope := NewDepartmentAndSubDataScope("", new(MockDepartmentHierarchyLoader))
+		assert.Equal(t, "department_and_sub", scope.Key(), "Should return 'department_and_sub'")
+		assert.Equal(t, PriorityDepartmentAndSub, scope.Priority(), "Should return PriorityDepartmentAndSub")
+	})
+
+	t.Run("NilLoaderPanics", func(t *testing.T) {
+		assert.Panics(t, func() { NewDepartmentAndSubDataScope("", nil) }, "Should panic without a loader")
+	})
+
+	t.Run("ApplyRequiresBinding", func(t *testing.T) {
+		scope := NewDepartmentAndSubDataScope("", new(MockDepartmentHierarchyLoader))
+		assert.ErrorIs(t, scope.Apply(NewUser("u1", "Alice"), nil), ErrDataScopeNotBound, "Should reject unbound use")
+	})
+
+	t.Run("BindLoadsSubtree", func(t *testing.T) {
+		loader := new(MockDepartmentHierarchyLoader)
+		loader.On("LoadSubDepartmentIDs", mock.Anything, "d1").Return([]string{"d1", "d2", "d3"}, nil).Once()
+
+		scope := NewDepartmentAndSubDataScope("dept_id", loader)
+		bindable, ok := scope.(BindableDataScope)
+		require.True(t, ok, "Should be bindable")
+
+		bound, err := bindable.Bind(ctx, NewUser("u1", "Alice").WithDepartmentID("d1"))
+		require.NoError(t, err, "Should bind")
+
+		boundScope, ok := bound.(*boundDepartmentDataScope)
+		require.True(t, ok, "Should return a bound department scope")
+		assert.Equal(t, scope.Key(), boundScope.Key(), "Should keep the key")
+		assert.Equal(t, scope.Priority(), boundScope.Priority(), "Should keep the priority")
+		assert.Equal(t, "dept_id", boundScope.departmentColumn, "Should keep the column")
+		assert.Equal(t, []string{"d1", "d2", "d3"}, boundScope.departmentIDs, "Should hold the subtree")
+		loader.AssertExpectations(t)
+	})
+
+	t.Run("BindWithoutDepartment", func(t *testing.T) {
+		loader := new(MockDepartmentHierarchyLoader)
+
+		bound, err := NewDepartmentAndSubDataScope("", loader).(BindableDataScope).Bind(ctx, NewUser("u1", "Alice"))
+		require.NoError(t, err, "Should bind")
+		assert.Empty(t, bound.(*boundDepartmentDataScope).departmentIDs, "Should match no department")
+		loader.AssertNotCalled(t, "LoadSubDepartmentIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("BindPropagatesLoaderError", func(t *testing.T) {
+		loaderErr := errors.New("db down")
+		loader := new(MockDepartmentHierarchyLoader)
+		loader.On("LoadSubDepartmentIDs", mock.Anything, "d1").Return(nil, loaderErr).Once()
+
+		_, err := NewDepartmentAndSubDataScope("", loader).(BindableDataScope).Bind(ctx, NewUser("u1", "Alice").WithDepartmentID("d1"))
+		assert.ErrorIs(t, err, loaderErr, "Should return the loader error")
+	})
+}
+
+// TestCustomDepartmentDataScope tests CustomDepartmentDataScope Key, Priority and defaults.
+func TestCustomDepartmentDataScope(t *testing.T) {
+	t.Run("KeyAndPriority", func(t *testing.T) {
+		scope := NewCustomDepartmentDataScope("", "d1")
+		assert.Equal(t, "custom_department", scope.Key(), "Should return 'custom_department'")
+		assert.Equal(t, PriorityCustom, scope.Priority(), "Should return PriorityCustom")
+	})
+
+	t.Run("CopiesDepartments", func(t *testing.T) {
+		ids := []string{"d1", "d2"}
+		scope := NewCustomDepartmentDataScope("", ids...).(*CustomDepartmentDataScope)
+		ids[0] = "changed"
+
+		assert.Equal(t, "department_id", scope.departmentColumn, "Should default to 'department_id'")
+		assert.Equal(t, []string{"d1", "d2"}, scope.departmentIDs, "Should not alias the caller's slice")
+	})
+}
+
+type MockDepartmentHierarchyLoader struct {
+	mock.Mock
+}
+
+func (m *MockDepartmentHierarchyLoader) LoadSubDepartmentIDs(ctx context.Context, departmentID string) ([]string, error) {
+	args := m.Called(ctx, departmentID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), args.Error(1)
+}
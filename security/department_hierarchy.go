@@ -0,0 +1,188 @@
+package security
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/coldsmirk/vef-framework-go/cache"
+	"github.com/coldsmirk/vef-framework-go/event"
+	ilogx "github.com/coldsmirk/vef-framework-go/internal/logx"
+	"github.com/coldsmirk/vef-framework-go/logx"
+	"github.com/coldsmirk/vef-framework-go/orm"
+)
+
+// eventTypeDepartmentHierarchyChanged is the event type for department hierarchy changes.
+// When this event is published, the entire department hierarchy cache will be cleared.
+const eventTypeDepartmentHierarchyChanged = "vef.security.department_hierarchy.changed"
+
+// DepartmentHierarchyLoader expands a department into its subtree for the department data scopes.
+type DepartmentHierarchyLoader interface {
+	// LoadSubDepartmentIDs returns the IDs of the department and all its descendants.
+	LoadSubDepartmentIDs(ctx context.Context, departmentID string) ([]string, error)
+}
+
+// ClosureTableConfig describes a closure table holding one row per ancestor-descendant pair of departments.
+type ClosureTableConfig struct {
+	Table            string // Closure table name, defaults to "sys_department_closure"
+	AncestorColumn   string // Ancestor department column, defaults to "ancestor_id"
+	DescendantColumn string // Descendant department column, defaults to "descendant_id"
+}
+
+// ClosureTableDepartmentHierarchyLoader loads department subtrees from a closure table.
+type ClosureTableDepartmentHierarchyLoader struct {
+	db     orm.DB
+	config ClosureTableConfig
+}
+
+// NewClosureTableDepartmentHierarchyLoader creates a department hierarchy loader backed by a closure table.
+// The department itself is part of its subtree whether or not the table stores self-referencing rows.
+func NewClosureTableDepartmentHierarchyLoader(db orm.DB, config ClosureTableConfig) DepartmentHierarchyLoader {
+	return &ClosureTableDepartmentHierarchyLoader{
+		db: db,
+		config: ClosureTableConfig{
+			Table:            cmp.Or(config.Table, "sys_department_closure"),
+			AncestorColumn:   cmp.Or(config.AncestorColumn, "ancestor_id"),
+			DescendantColumn: cmp.Or(config.DescendantColumn, "descendant_id"),
+		},
+	}
+}
+
+func (l *ClosureTableDepartmentHierarchyLoader) LoadSubDepartmentIDs(ctx context.Context, departmentID string) ([]string, error) {
+	var descendantIDs []string
+
+	if err := l.db.NewSelect().
+		Table(l.config.Table).
+		Select(l.config.DescendantColumn).
+		Where(func(cb orm.ConditionBuilder) {
+			cb.Equals(l.config.AncestorColumn, departmentID)
+		}).
+		Scan(ctx, &descendantIDs); err != nil {
+		return nil, fmt.Errorf("load sub-departments of %q: %w", departmentID, err)
+	}
+
+	if !slices.Contains(descendantIDs, departmentID) {
+		descendantIDs = append([]string{departmentID}, descendantIDs...)
+	}
+
+	return descendantIDs, nil
+}
+
+// PathConfig describes a department table storing the materialized path of each department, such as "1/5/9".
+type PathConfig struct {
+	Table      string // Department table name, defaults to "sys_department"
+	IDColumn   string // Department ID column, defaults to "id"
+	PathColumn string // Materialized path column, defaults to "path"
+	Separator  string // Path segment separator, defaults to "/"
+}
+
+// PathDepartmentHierarchyLoader loads department subtrees from materialized paths.
+// A descendant's path starts with the path of its ancestor followed by the separator.
+type PathDepartmentHierarchyLoader struct {
+	db     orm.DB
+	config PathConfig
+}
+
+// NewPathDepartmentHierarchyLoader creates a department hierarchy loader backed by materialized paths.
+func NewPathDepartmentHierarchyLoader(db orm.DB, config PathConfig) DepartmentHierarchyLoader {
+	return &PathDepartmentHierarchyLoader{
+		db: db,
+		config: PathConfig{
+			Table:      cmp.Or(config.Table, "sys_department"),
+			IDColumn:   cmp.Or(config.IDColumn, orm.ColumnID),
+			PathColumn: cmp.Or(config.PathColumn, "path"),
+			Separator:  cmp.Or(config.Separator, "/"),
+		},
+	}
+}
+
+func (l *PathDepartmentHierarchyLoader) LoadSubDepartmentIDs(ctx context.Context, departmentID string) ([]string, error) {
+	var paths []string
+
+	if err := l.db.NewSelect().
+		Table(l.config.Table).
+		Select(l.config.PathColumn).
+		Where(func(cb orm.ConditionBuilder) {
+			cb.Equals(l.config.IDColumn, departmentID)
+		}).
+		Limit(1).
+		Scan(ctx, &paths); err != nil {
+		return nil, fmt.Errorf("load path of department %q: %w", departmentID, err)
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrDepartmentNotFound, departmentID)
+	}
+
+	var ids []string
+
+	if err := l.db.NewSelect().
+		Table(l.config.Table).
+		Select(l.config.IDColumn).
+		Where(func(cb orm.ConditionBuilder) {
+			cb.Equals(l.config.PathColumn, paths[0]).
+				OrStartsWith(l.config.PathColumn, paths[0]+l.config.Separator)
+		}).
+		Scan(ctx, &ids); err != nil {
+		return nil, fmt.Errorf("load sub-departments of %q: %w", departmentID, err)
+	}
+
+	return ids, nil
+}
+
+// DepartmentHierarchyChangedEvent is published when departments are created, moved or deleted.
+type DepartmentHierarchyChangedEvent struct {
+	event.BaseEvent
+}
+
+// PublishDepartmentHierarchyChangedEvent publishes a department hierarchy changed event via the provided publisher.
+// Since moving a department changes the subtrees of all its former and new ancestors, subscribers reload every subtree.
+func PublishDepartmentHierarchyChangedEvent(publisher event.Publisher) {
+	publisher.Publish(&DepartmentHierarchyChangedEvent{
+		BaseEvent: event.NewBaseEvent(eventTypeDepartmentHierarchyChanged),
+	})
+}
+
+// CachedDepartmentHierarchyLoader is a decorator that adds caching to a DepartmentHierarchyLoader.
+// It uses the cache system and event bus for automatic cache invalidation.
+type CachedDepartmentHierarchyLoader struct {
+	loader       DepartmentHierarchyLoader
+	subtreeCache cache.Cache[[]string]
+	logger       logx.Logger
+}
+
+// NewCachedDepartmentHierarchyLoader creates a new cached department hierarchy loader.
+// It automatically subscribes to department hierarchy change events to invalidate cache.
+// In multi-tenant applications pass WithCacheScope(contextx.TenantID) so that subtrees are cached per tenant.
+func NewCachedDepartmentHierarchyLoader(
+	loader DepartmentHierarchyLoader,
+	eventBus event.Subscriber,
+	opts ...CachedLoaderOption,
+) DepartmentHierarchyLoader {
+	subtreeCache, _ := newLoaderCache[[]string](opts)
+	cached := &CachedDepartmentHierarchyLoader{
+		loader:       loader,
+		subtreeCache: subtreeCache,
+		logger:       ilogx.Named("security:cached_department_hierarchy_loader"),
+	}
+
+	// Subscribe to department hierarchy change events
+	eventBus.Subscribe(eventTypeDepartmentHierarchyChanged, cached.handleHierarchyChanged)
+
+	return cached
+}
+
+func (c *CachedDepartmentHierarchyLoader) handleHierarchyChanged(ctx context.Context, _ event.Event) {
+	if err := c.subtreeCache.Clear(ctx); err != nil {
+		c.logger.Errorf("Failed to clear department hierarchy cache: %v", err)
+	} else {
+		c.logger.Info("Cleared department hierarchy cache")
+	}
+}
+
+func (c *CachedDepartmentHierarchyLoader) LoadSubDepartmentIDs(ctx context.Context, departmentID string) ([]string, error) {
+	return c.subtreeCache.GetOrLoad(ctx, departmentID, func(ctx context.Context) ([]string, error) {
+		return c.loader.LoadSubDepartmentIDs(ctx, departmentID)
+	})
+}
@@ -0,0 +1,76 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/event"
+	ievent "github.com/coldsmirk/vef-framework-go/internal/event"
+)
+
+type CachedDepartmentHierarchyLoaderTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+	bus event.Bus
+}
+
+func (s *CachedDepartmentHierarchyLoaderTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	s.bus = ievent.NewMemoryBus([]event.Middleware{})
+	err := s.bus.(interface{ Start() error }).Start()
+	s.Require().NoError(err, "Should start event bus")
+}
+
+func (s *CachedDepartmentHierarchyLoaderTestSuite) TestCachesSubtrees() {
+	mockLoader := new(MockDepartmentHierarchyLoader)
+	mockLoader.On("LoadSubDepartmentIDs", mock.Anything, "d1").
+		Return([]string{"d1", "d2"}, nil).
+		Once()
+
+	cachedLoader := NewCachedDepartmentHierarchyLoader(mockLoader, s.bus)
+
+	ids, err := cachedLoader.LoadSubDepartmentIDs(s.ctx, "d1")
+	s.Require().NoError(err, "Should load subtree")
+	s.Equal([]string{"d1", "d2"}, ids, "Should return the subtree")
+
+	ids, err = cachedLoader.LoadSubDepartmentIDs(s.ctx, "d1")
+	s.Require().NoError(err, "Should load cached subtree")
+	s.Equal([]string{"d1", "d2"}, ids, "Should return the cached subtree")
+
+	mockLoader.AssertExpectations(s.T())
+}
+
+func (s *CachedDepartmentHierarchyLoaderTestSuite) TestInvalidatesOnHierarchyChange() {
+	mockLoader := new(MockDepartmentHierarchyLoader)
+	mockLoader.On("LoadSubDepartmentIDs", mock.Anything, "d1").
+		Return([]string{"d1"}, nil).
+		Once()
+	mockLoader.On("LoadSubDepartmentIDs", mock.Anything, "d1").
+		Return([]string{"d1", "d3"}, nil).
+		Once()
+
+	cachedLoader := NewCachedDepartmentHierarchyLoader(mockLoader, s.bus)
+
+	ids, err := cachedLoader.LoadSubDepartmentIDs(s.ctx, "d1")
+	s.Require().NoError(err, "Should load subtree")
+	s.Equal([]string{"d1"}, ids, "Should return the subtree")
+
+	PublishDepartmentHierarchyChangedEvent(s.bus)
+	time.Sleep(10 * time.Millisecond)
+
+	ids, err = cachedLoader.LoadSubDepartmentIDs(s.ctx, "d1")
+	s.Require().NoError(err, "Should reload subtree after invalidation")
+	s.Equal([]string{"d1", "d3"}, ids, "Should return the reloaded subtree")
+
+	mockLoader.AssertExpectations(s.T())
+}
+
+func TestCachedDepartmentHierarchyLoader(t *testing.T) {
+	suite.Run(t, new(CachedDepartmentHierarchyLoaderTestSuite))
+}
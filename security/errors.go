@@ -3,7 +3,13 @@ package security
 import "errors"
 
 var (
-	ErrDecodeJWTSecretFailed = errors.New("failed to decode jwt secret")
+	ErrDecodeJWTSecretFailed   = errors.New("failed to decode jwt secret")
+	ErrJWTAlgorithmUnsupported = errors.New("unsupported jwt signing algorithm")
+	ErrJWTKeyIDRequired        = errors.New("jwt key id is required")
+	ErrJWTKeyIDDuplicate       = errors.New("duplicate jwt key id")
+	ErrJWTKeyMaterialRequired  = errors.New("jwt key requires a private or public key")
+	ErrJWTKeyMaterialInvalid   = errors.New("invalid jwt key material")
+	ErrJWTSigningKeyMissing    = errors.New("no active jwt signing key")
 
 	ErrDecodeSignatureSecretFailed = errors.New("failed to decode signature secret")
 	ErrSignatureSecretRequired     = errors.New("signature secret is required")
@@ -19,4 +25,15 @@ var (
 
 	ErrQueryNotQueryBuilder = errors.New("query does not implement QueryBuilder interface")
 	ErrQueryModelNotSet     = errors.New("query must call Model() before applying data permission")
+	ErrDataScopeNotBound    = errors.New("data scope must be bound to the principal before it is applied")
+	ErrDepartmentNotFound   = errors.New("department not found")
+
+	ErrWebAuthnSignCountInvalid      = errors.New("webauthn signature counter did not increase, the authenticator may be cloned")
+	ErrWebAuthnCredentialUserInvalid = errors.New("webauthn credential belongs to another user")
+
+	ErrAPIKeyMalformed     = errors.New("malformed api key")
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrAPIKeySecretInvalid = errors.New("api key secret is invalid")
+	ErrAPIKeyExpired       = errors.New("api key has expired")
+	ErrAPIKeyIPNotAllowed  = errors.New("api key is not allowed from this ip")
 )
@@ -2,7 +2,6 @@ package security
 
 import (
 	"cmp"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"slices"
@@ -20,35 +19,96 @@ const (
 )
 
 var jwtParseOptions = []jwt.ParserOption{
-	jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}),
 	jwt.WithIssuer(JWTIssuer),
 	jwt.WithLeeway(10 * time.Second),
 	jwt.WithIssuedAt(),
 	jwt.WithExpirationRequired(),
 }
 
+// jwtHeaderKeyID is the token header identifying the signing key.
+const jwtHeaderKeyID = "kid"
+
 // JWT provides low-level JWT token operations.
 // It handles token generation, parsing, and validation without business logic.
 type JWT struct {
-	config *JWTConfig
-	secret []byte
+	config  *JWTConfig
+	keys    []*JWTKey
+	keyByID map[string]*JWTKey
+	methods []string
 }
 
 // NewJWT creates a new JWT instance with the given configuration.
 // Secret expects a hex-encoded string; invalid hex will cause an error.
 // Audience will be defaulted when empty.
 func NewJWT(config *JWTConfig) (*JWT, error) {
-	secret, err := hex.DecodeString(cmp.Or(config.Secret, DefaultJWTSecret))
-	if err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrDecodeJWTSecretFailed, err)
+	config.Audience = cmp.Or(config.Audience, DefaultJWTAudience)
+
+	j := &JWT{
+		config:  config,
+		keyByID: make(map[string]*JWTKey),
 	}
 
-	config.Audience = cmp.Or(config.Audience, DefaultJWTAudience)
+	// The secret key has no ID, so tokens signed by it carry no kid header, as before keys were introduced.
+	if len(config.Keys) == 0 || config.Secret != "" {
+		key, err := newSecretKey("", cmp.Or(config.Secret, DefaultJWTSecret))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(config.Keys) > 0 {
+			// Once keys are configured the secret only verifies previously issued tokens.
+			key.signKey = nil
+		}
+
+		if err := j.addKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, keyConfig := range config.Keys {
+		key, err := newJWTKey(keyConfig, config.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := j.addKey(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return j, nil
+}
+
+func (j *JWT) addKey(key *JWTKey) error {
+	if _, ok := j.keyByID[key.ID]; ok {
+		return fmt.Errorf("%w: %q", ErrJWTKeyIDDuplicate, key.ID)
+	}
+
+	j.keys = append(j.keys, key)
+	j.keyByID[key.ID] = key
+
+	if !slices.Contains(j.methods, key.Algorithm) {
+		j.methods = append(j.methods, key.Algorithm)
+	}
+
+	return nil
+}
+
+// signingKey returns the key that signs tokens at now: among the active keys, the most recently activated one.
+func (j *JWT) signingKey(now time.Time) (*JWTKey, error) {
+	var signing *JWTKey
+
+	for _, key := range j.keys {
+		if key.canSign(now) && (signing == nil || !key.ActivateAt.Before(signing.ActivateAt)) {
+			signing = key
+		}
+	}
+
+	if signing == nil {
+		return nil, ErrJWTSigningKeyMissing
+	}
 
-	return &JWT{
-		config: config,
-		secret: secret,
-	}, nil
+	return signing, nil
 }
 
 // Generate creates a JWT token with the given claims and expires.
@@ -63,23 +123,29 @@ func (j *JWT) Generate(claimsBuilder *JWTClaimsBuilder, expires, notBefore time.
 	claims[claimNotBefore] = now.Add(notBefore).Unix()
 	claims[claimExpiresAt] = now.Add(expires).Unix()
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	key, err := j.signingKey(now)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	if key.ID != "" {
+		token.Header[jwtHeaderKeyID] = key.ID
+	}
 
-	return token.SignedString(j.secret)
+	return token.SignedString(key.signKey)
 }
 
 // Parse parses and validates a JWT token.
 // It returns a read-only claims accessor which performs safe conversions and never panics.
 func (j *JWT) Parse(tokenString string) (*JWTClaimsAccessor, error) {
-	options := append(slices.Clone(jwtParseOptions), jwt.WithAudience(j.config.Audience))
-
-	token, err := jwt.NewParser(options...).
-		Parse(
-			tokenString,
-			func(_ *jwt.Token) (any, error) {
-				return j.secret, nil
-			},
-		)
+	options := append(
+		slices.Clone(jwtParseOptions),
+		jwt.WithValidMethods(j.methods),
+		jwt.WithAudience(j.config.Audience),
+	)
+
+	token, err := jwt.NewParser(options...).Parse(tokenString, j.verificationKey)
 	if err != nil {
 		return nil, mapJWTError(err)
 	}
@@ -92,6 +158,39 @@ func (j *JWT) Parse(tokenString string) (*JWTClaimsAccessor, error) {
 	return NewJWTClaimsAccessor(claims), nil
 }
 
+// verificationKey resolves the key a token was signed with from its kid header.
+// The token algorithm must match the key, and keys past their grace period are rejected.
+func (j *JWT) verificationKey(token *jwt.Token) (any, error) {
+	keyID, _ := token.Header[jwtHeaderKeyID].(string)
+
+	key, ok := j.keyByID[keyID]
+	if !ok || token.Method.Alg() != key.Algorithm || !key.canVerify(time.Now(), j.config.GracePeriod) {
+		return nil, jwt.ErrTokenUnverifiable
+	}
+
+	return key.verifyKey, nil
+}
+
+// JWKS returns the public keys that verify tokens, for publishing at /.well-known/jwks.json.
+// Keys are published before they activate so that verifiers can cache them ahead of a rotation,
+// and until their grace period ends; shared secrets are never published.
+func (j *JWT) JWKS() *JWKS {
+	now := time.Now()
+	jwks := &JWKS{Keys: make([]JWK, 0, len(j.keys))}
+
+	for _, key := range j.keys {
+		if !key.canVerify(now, j.config.GracePeriod) {
+			continue
+		}
+
+		if jwk, ok := key.jwk(); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+
+	return jwks
+}
+
 // mapJWTError maps JWT library errors to framework errors.
 func mapJWTError(err error) error {
 	switch {
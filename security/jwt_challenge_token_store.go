@@ -35,6 +35,8 @@ func (s *JWTChallengeTokenStore) Generate(principal *Principal, pending, resolve
 		WithID(id.GenerateUUID()).
 		WithSubject(fmt.Sprintf("%s@%s", principal.ID, principal.Name)).
 		WithRoles(principal.Roles).
+		WithTenantID(principal.TenantID).
+		WithDepartmentID(principal.DepartmentID).
 		WithDetails(principal.Details).
 		WithType(TokenTypeChallenge).
 		WithClaim(ClaimChallengePrincipalType, principal.Type).
@@ -80,6 +82,8 @@ func (s *JWTChallengeTokenStore) Parse(token string) (*ChallengeState, error) {
 		return nil, result.ErrTokenInvalid
 	}
 
+	principal.TenantID = claimsAccessor.TenantID()
+	principal.DepartmentID = claimsAccessor.DepartmentID()
 	principal.AttemptUnmarshalDetails(claimsAccessor.Details())
 
 	return &ChallengeState{
@@ -1,6 +1,8 @@
 package security
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/spf13/cast"
 )
@@ -8,22 +10,42 @@ import (
 // Custom and standard JWT claim keys.
 // Short keys are used for custom claims to keep token size small.
 const (
-	claimJWTID     = "jti" // JWT ID
-	claimSubject   = "sub" // Subject
-	claimIssuer    = "iss" // Issuer
-	claimAudience  = "aud" // Audience
-	claimIssuedAt  = "iat" // Issued At
-	claimNotBefore = "nbf" // Not Before
-	claimExpiresAt = "exp" // Expires At
-	claimType      = "typ" // Token Type
-	claimRoles     = "rls" // User Roles
-	claimDetails   = "det" // User Details
+	claimJWTID      = "jti" // JWT ID
+	claimSubject    = "sub" // Subject
+	claimIssuer     = "iss" // Issuer
+	claimAudience   = "aud" // Audience
+	claimIssuedAt   = "iat" // Issued At
+	claimNotBefore  = "nbf" // Not Before
+	claimExpiresAt  = "exp" // Expires At
+	claimType       = "typ" // Token Type
+	claimRoles      = "rls" // User Roles
+	claimDetails    = "det" // User Details
+	claimTenant     = "tid" // Tenant ID
+	claimDepartment = "dpt" // Department ID
+	claimSession    = "sid" // Session ID
 )
 
 // JWTConfig is the configuration for the JWT token.
+// Without Keys, tokens are signed with Secret using HS256. With Keys, tokens are signed by the active key
+// and carry its ID in the kid header; Secret then only verifies tokens issued before keys were configured.
 type JWTConfig struct {
-	Secret   string `config:"secret"`   // Secret key for JWT signing
-	Audience string `config:"audience"` // JWT audience
+	Secret      string         `config:"secret"`       // Secret key for JWT signing
+	Audience    string         `config:"audience"`     // JWT audience
+	Algorithm   string         `config:"algorithm"`    // Default signing algorithm of Keys
+	Keys        []JWTKeyConfig `config:"keys"`         // Signing keys, rotated by their activation and retirement times
+	GracePeriod time.Duration  `config:"grace_period"` // How long tokens signed by a retired key are still accepted
+}
+
+// JWTKeyConfig is the configuration of a JWT signing key.
+// Asymmetric keys are PEM encoded; a key with only PublicKey verifies tokens signed elsewhere.
+type JWTKeyConfig struct {
+	ID         string    `config:"id"`          // Key ID, sent as the kid header
+	Algorithm  string    `config:"algorithm"`   // HS256, RS256, ES256, EdDSA or SM2
+	Secret     string    `config:"secret"`      // Hex-encoded secret of HS256 keys
+	PrivateKey string    `config:"private_key"` // PEM-encoded private key of asymmetric keys
+	PublicKey  string    `config:"public_key"`  // PEM-encoded public key, derived from PrivateKey when empty
+	ActivateAt time.Time `config:"activate_at"` // Time the key starts signing; zero means immediately
+	RetireAt   time.Time `config:"retire_at"`   // Time the key stops signing; zero means never
 }
 
 // JWTClaimsBuilder helps build JWT claims for different token types.
@@ -77,6 +99,52 @@ func (b *JWTClaimsBuilder) Roles() ([]string, bool) {
 	return cast.ToStringSlice(roles), ok
 }
 
+// WithTenantID sets the tenant claim; an empty tenant is omitted to keep single-tenant tokens unchanged.
+func (b *JWTClaimsBuilder) WithTenantID(tenantID string) *JWTClaimsBuilder {
+	if tenantID != "" {
+		b.claims[claimTenant] = tenantID
+	}
+
+	return b
+}
+
+// TenantID returns the tenant claim.
+func (b *JWTClaimsBuilder) TenantID() (string, bool) {
+	tenantID, ok := b.claims[claimTenant]
+
+	return cast.ToString(tenantID), ok
+}
+
+// WithDepartmentID sets the department claim; an empty department is omitted.
+func (b *JWTClaimsBuilder) WithDepartmentID(departmentID string) *JWTClaimsBuilder {
+	if departmentID != "" {
+		b.claims[claimDepartment] = departmentID
+	}
+
+	return b
+}
+
+// DepartmentID returns the department claim.
+func (b *JWTClaimsBuilder) DepartmentID() (string, bool) {
+	departmentID, ok := b.claims[claimDepartment]
+
+	return cast.ToString(departmentID), ok
+}
+
+// WithSessionID sets the session claim binding the token to a server-side session.
+func (b *JWTClaimsBuilder) WithSessionID(sessionID string) *JWTClaimsBuilder {
+	b.claims[claimSession] = sessionID
+
+	return b
+}
+
+// SessionID returns the session claim.
+func (b *JWTClaimsBuilder) SessionID() (string, bool) {
+	sessionID, ok := b.claims[claimSession]
+
+	return cast.ToString(sessionID), ok
+}
+
 func (b *JWTClaimsBuilder) WithDetails(details any) *JWTClaimsBuilder {
 	b.claims[claimDetails] = details
 
@@ -150,6 +218,28 @@ func (a *JWTClaimsAccessor) Roles() []string {
 	return cast.ToStringSlice(a.claims[claimRoles])
 }
 
+// TenantID returns the tenant claim.
+// Returns empty string if the claim is missing or not a string.
+func (a *JWTClaimsAccessor) TenantID() string {
+	return cast.ToString(a.claims[claimTenant])
+}
+
+// DepartmentID returns the department claim.
+// Returns empty string if the claim is missing or not a string.
+func (a *JWTClaimsAccessor) DepartmentID() string {
+	return cast.ToString(a.claims[claimDepartment])
+}
+
+// SessionID returns the session claim.
+// Falls back to the JWT ID for tokens issued without a session claim, since a session is keyed by the JWT ID of its login.
+func (a *JWTClaimsAccessor) SessionID() string {
+	if sessionID := cast.ToString(a.claims[claimSession]); sessionID != "" {
+		return sessionID
+	}
+
+	return a.ID()
+}
+
 // Details returns the details claim.
 func (a *JWTClaimsAccessor) Details() any {
 	return a.claims[claimDetails]
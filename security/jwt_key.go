@@ -0,0 +1,400 @@
+package security
+
+import (
+	"cmp"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/tjfoc/gmsm/sm2"
+	"github.com/tjfoc/gmsm/x509"
+
+	"github.com/coldsmirk/vef-framework-go/cryptox"
+)
+
+// Supported JWT signing algorithms.
+const (
+	JWTAlgorithmHS256 = "HS256" // HMAC with SHA-256 using a shared secret
+	JWTAlgorithmRS256 = "RS256" // RSASSA-PKCS1-v1_5 with SHA-256
+	JWTAlgorithmES256 = "ES256" // ECDSA with P-256 and SHA-256
+	JWTAlgorithmEdDSA = "EdDSA" // Ed25519
+	JWTAlgorithmSM2   = "SM2"   // SM2 with SM3, signed by cryptox.SM2Cipher
+)
+
+// jwkUseSignature marks a published key as a signature verification key.
+const jwkUseSignature = "sig"
+
+func init() {
+	jwt.RegisterSigningMethod(JWTAlgorithmSM2, func() jwt.SigningMethod {
+		return signingMethodSM2{}
+	})
+}
+
+// JWTKey is a key that signs and verifies tokens.
+// A key signs new tokens from ActivateAt until RetireAt, and keeps verifying the tokens it signed
+// for the grace period after retirement, so keys can be rotated without invalidating issued tokens.
+type JWTKey struct {
+	// ID is the key identifier, sent as the kid header of the tokens the key signs.
+	ID string
+	// Algorithm is the signing algorithm of the key.
+	Algorithm string
+	// ActivateAt is the time the key starts signing tokens; zero means immediately.
+	ActivateAt time.Time
+	// RetireAt is the time the key stops signing tokens; zero means never.
+	RetireAt time.Time
+
+	method    jwt.SigningMethod
+	signKey   any
+	verifyKey any
+	publicKey crypto.PublicKey
+}
+
+// canSign reports whether the key signs new tokens at now.
+func (k *JWTKey) canSign(now time.Time) bool {
+	return k.signKey != nil &&
+		!now.Before(k.ActivateAt) &&
+		(k.RetireAt.IsZero() || now.Before(k.RetireAt))
+}
+
+// canVerify reports whether tokens signed by the key are still accepted at now.
+func (k *JWTKey) canVerify(now time.Time, gracePeriod time.Duration) bool {
+	return k.RetireAt.IsZero() || now.Before(k.RetireAt.Add(gracePeriod))
+}
+
+// newSecretKey creates an HS256 key from a hex-encoded secret.
+func newSecretKey(id, secretHex string) (*JWTKey, error) {
+	secret, err := hex.DecodeString(secretHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecodeJWTSecretFailed, err)
+	}
+
+	return &JWTKey{
+		ID:        id,
+		Algorithm: JWTAlgorithmHS256,
+		method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}, nil
+}
+
+// newJWTKey creates a key from its configuration, using defaultAlgorithm when the key sets none.
+func newJWTKey(config JWTKeyConfig, defaultAlgorithm string) (*JWTKey, error) {
+	if config.ID == "" {
+		return nil, ErrJWTKeyIDRequired
+	}
+
+	algorithm := cmp.Or(config.Algorithm, defaultAlgorithm, JWTAlgorithmHS256)
+
+	var (
+		key *JWTKey
+		err error
+	)
+
+	switch algorithm {
+	case JWTAlgorithmHS256:
+		key, err = newSecretKey(config.ID, config.Secret)
+	case JWTAlgorithmRS256:
+		key, err = newRSAKey(config)
+	case JWTAlgorithmES256:
+		key, err = newECDSAKey(config)
+	case JWTAlgorithmEdDSA:
+		key, err = newEd25519Key(config)
+	case JWTAlgorithmSM2:
+		key, err = newSM2Key(config)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrJWTAlgorithmUnsupported, algorithm)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid jwt key %q: %w", config.ID, err)
+	}
+
+	key.ID = config.ID
+	key.Algorithm = algorithm
+	key.ActivateAt = config.ActivateAt
+	key.RetireAt = config.RetireAt
+
+	return key, nil
+}
+
+func newRSAKey(config JWTKeyConfig) (*JWTKey, error) {
+	key := &JWTKey{method: jwt.SigningMethodRS256}
+
+	if config.PrivateKey != "" {
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(config.PrivateKey))
+		if err != nil {
+			return nil, err
+		}
+
+		key.signKey = privateKey
+		key.publicKey = &privateKey.PublicKey
+	}
+
+	if config.PublicKey != "" {
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(config.PublicKey))
+		if err != nil {
+			return nil, err
+		}
+
+		key.publicKey = publicKey
+	}
+
+	if key.publicKey == nil {
+		return nil, ErrJWTKeyMaterialRequired
+	}
+
+	key.verifyKey = key.publicKey
+
+	return key, nil
+}
+
+func newECDSAKey(config JWTKeyConfig) (*JWTKey, error) {
+	key := &JWTKey{method: jwt.SigningMethodES256}
+
+	if config.PrivateKey != "" {
+		privateKey, err := jwt.ParseECPrivateKeyFromPEM([]byte(config.PrivateKey))
+		if err != nil {
+			return nil, err
+		}
+
+		key.signKey = privateKey
+		key.publicKey = &privateKey.PublicKey
+	}
+
+	if config.PublicKey != "" {
+		publicKey, err := jwt.ParseECPublicKeyFromPEM([]byte(config.PublicKey))
+		if err != nil {
+			return nil, err
+		}
+
+		key.publicKey = publicKey
+	}
+
+	publicKey, ok := key.publicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, ErrJWTKeyMaterialRequired
+	}
+
+	if publicKey.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("%w: ES256 requires a P-256 key", ErrJWTKeyMaterialInvalid)
+	}
+
+	key.verifyKey = publicKey
+
+	return key, nil
+}
+
+func newEd25519Key(config JWTKeyConfig) (*JWTKey, error) {
+	key := &JWTKey{method: jwt.SigningMethodEdDSA}
+
+	if config.PrivateKey != "" {
+		parsed, err := jwt.ParseEdPrivateKeyFromPEM([]byte(config.PrivateKey))
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return nil, ErrJWTKeyMaterialInvalid
+		}
+
+		key.signKey = privateKey
+		key.publicKey = privateKey.Public()
+	}
+
+	if config.PublicKey != "" {
+		publicKey, err := jwt.ParseEdPublicKeyFromPEM([]byte(config.PublicKey))
+		if err != nil {
+			return nil, err
+		}
+
+		key.publicKey = publicKey
+	}
+
+	if key.publicKey == nil {
+		return nil, ErrJWTKeyMaterialRequired
+	}
+
+	key.verifyKey = key.publicKey
+
+	return key, nil
+}
+
+func newSM2Key(config JWTKeyConfig) (*JWTKey, error) {
+	var (
+		privateKey *sm2.PrivateKey
+		publicKey  *sm2.PublicKey
+	)
+
+	if config.PrivateKey != "" {
+		block, _ := pem.Decode([]byte(config.PrivateKey))
+		if block == nil {
+			return nil, cryptox.ErrFailedDecodePemBlock
+		}
+
+		parsed, err := x509.ParseSm2PrivateKey(block.Bytes)
+		if err != nil {
+			if parsed, err = x509.ParsePKCS8UnecryptedPrivateKey(block.Bytes); err != nil {
+				return nil, err
+			}
+		}
+
+		privateKey = parsed
+		publicKey = &parsed.PublicKey
+	}
+
+	if config.PublicKey != "" {
+		block, _ := pem.Decode([]byte(config.PublicKey))
+		if block == nil {
+			return nil, cryptox.ErrFailedDecodePemBlock
+		}
+
+		parsed, err := x509.ParseSm2PublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey = parsed
+	}
+
+	if publicKey == nil {
+		return nil, ErrJWTKeyMaterialRequired
+	}
+
+	key := &JWTKey{
+		method:    signingMethodSM2{},
+		publicKey: publicKey,
+	}
+
+	verifier, err := cryptox.NewSM2(nil, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key.verifyKey = verifier
+
+	if privateKey != nil {
+		if key.signKey, err = cryptox.NewSM2(privateKey, publicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set, as served at /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// jwk returns the public key in JWK format, or false for keys that must not be published.
+func (k *JWTKey) jwk() (JWK, bool) {
+	jwk := JWK{
+		KeyID:     k.ID,
+		Use:       jwkUseSignature,
+		Algorithm: k.Algorithm,
+	}
+
+	switch publicKey := k.publicKey.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = encodeJWKInt(publicKey.N)
+		jwk.E = encodeJWKInt(big.NewInt(int64(publicKey.E)))
+
+	case *ecdsa.PublicKey:
+		point, err := publicKey.ECDH()
+		if err != nil {
+			return jwk, false
+		}
+
+		coordinates := point.Bytes()[1:]
+		size := len(coordinates) / 2
+		jwk.KeyType = "EC"
+		jwk.Curve = publicKey.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(coordinates[:size])
+		jwk.Y = base64.RawURLEncoding.EncodeToString(coordinates[size:])
+
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+
+	case *sm2.PublicKey:
+		jwk.KeyType = "EC"
+		jwk.Curve = "SM2"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, 32)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, 32)))
+
+	default:
+		// Shared secrets are never published.
+		return jwk, false
+	}
+
+	return jwk, true
+}
+
+func encodeJWKInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+// signingMethodSM2 signs tokens with SM2, delegating to cryptox.SM2Cipher.
+// The signature is the DER-encoded SM2 signature over the SM3 digest of the signing string.
+type signingMethodSM2 struct{}
+
+func (signingMethodSM2) Alg() string {
+	return JWTAlgorithmSM2
+}
+
+func (signingMethodSM2) Sign(signingString string, key any) ([]byte, error) {
+	signer, ok := key.(cryptox.Signer)
+	if !ok {
+		return nil, jwt.ErrInvalidKeyType
+	}
+
+	signature, err := signer.Sign(signingString)
+	if err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(signature)
+}
+
+func (signingMethodSM2) Verify(signingString string, signature []byte, key any) error {
+	verifier, ok := key.(cryptox.Signer)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+
+	valid, err := verifier.Verify(signingString, base64.StdEncoding.EncodeToString(signature))
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return jwt.ErrSignatureInvalid
+	}
+
+	return nil
+}
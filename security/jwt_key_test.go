@@ -0,0 +1,349 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tjfoc/gmsm/sm2"
+	smx509 "github.com/tjfoc/gmsm/x509"
+
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+// encodePKCS8 PEM-encodes a private key and its public key.
+func encodePKCS8(t *testing.T, privateKey, publicKey any) (privatePEM, publicPEM string) {
+	t.Helper()
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err, "Should marshal private key")
+
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err, "Should marshal public key")
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+}
+
+// newTestKeyConfig generates a key pair for algorithm and returns its configuration.
+func newTestKeyConfig(t *testing.T, id, algorithm string) JWTKeyConfig {
+	t.Helper()
+
+	config := JWTKeyConfig{ID: id, Algorithm: algorithm}
+
+	switch algorithm {
+	case JWTAlgorithmHS256:
+		config.Secret = DefaultJWTSecret
+	case JWTAlgorithmRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err, "Should generate RSA key")
+
+		config.PrivateKey, config.PublicKey = encodePKCS8(t, key, &key.PublicKey)
+	case JWTAlgorithmES256:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err, "Should generate ECDSA key")
+
+		config.PrivateKey, config.PublicKey = encodePKCS8(t, key, &key.PublicKey)
+	case JWTAlgorithmEdDSA:
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err, "Should generate Ed25519 key")
+
+		config.PrivateKey, config.PublicKey = encodePKCS8(t, privateKey, publicKey)
+	case JWTAlgorithmSM2:
+		key, err := sm2.GenerateKey(rand.Reader)
+		require.NoError(t, err, "Should generate SM2 key")
+
+		privatePEM, err := smx509.WritePrivateKeyToPem(key, nil)
+		require.NoError(t, err, "Should encode SM2 private key")
+
+		publicPEM, err := smx509.WritePublicKeyToPem(&key.PublicKey)
+		require.NoError(t, err, "Should encode SM2 public key")
+
+		config.PrivateKey, config.PublicKey = string(privatePEM), string(publicPEM)
+	}
+
+	return config
+}
+
+// newKeyedJWT creates a JWT with the given keys.
+func newKeyedJWT(t *testing.T, gracePeriod time.Duration, keys ...JWTKeyConfig) *JWT {
+	t.Helper()
+
+	j, err := NewJWT(&JWTConfig{Audience: "test_app", Keys: keys, GracePeriod: gracePeriod})
+	require.NoError(t, err, "Should create JWT with keys")
+
+	return j
+}
+
+// tokenKeyID returns the kid header and algorithm of a token without verifying it.
+func tokenKeyID(t *testing.T, token string) (string, string) {
+	t.Helper()
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err, "Should decode token")
+
+	keyID, _ := parsed.Header[jwtHeaderKeyID].(string)
+
+	return keyID, parsed.Method.Alg()
+}
+
+// TestJWTAlgorithms tests signing and verification with every supported algorithm.
+func TestJWTAlgorithms(t *testing.T) {
+	algorithms := []string{JWTAlgorithmHS256, JWTAlgorithmRS256, JWTAlgorithmES256, JWTAlgorithmEdDSA, JWTAlgorithmSM2}
+
+	for _, algorithm := range algorithms {
+		t.Run(algorithm, func(t *testing.T) {
+			keyConfig := newTestKeyConfig(t, "key-1", algorithm)
+			j := newKeyedJWT(t, 0, keyConfig)
+
+			token, err := j.Generate(NewJWTClaimsBuilder().WithSubject("user1@Alice"), time.Hour, 0)
+			require.NoError(t, err, "Should sign token")
+
+			keyID, alg := tokenKeyID(t, token)
+			assert.Equal(t, "key-1", keyID, "Token should carry the key ID")
+			assert.Equal(t, algorithm, alg, "Token should use the key algorithm")
+
+			claims, err := j.Parse(token)
+			require.NoError(t, err, "Should verify token")
+			assert.Equal(t, "user1@Alice", claims.Subject(), "Should decode the subject")
+
+			t.Run("VerificationOnly", func(t *testing.T) {
+				if algorithm == JWTAlgorithmHS256 {
+					t.Skip("Shared secrets cannot be split into a verification-only key")
+				}
+
+				verifierConfig := keyConfig
+				verifierConfig.PrivateKey = ""
+				verifier := newKeyedJWT(t, 0, verifierConfig)
+
+				_, err := verifier.Parse(token)
+				require.NoError(t, err, "Public key should verify the token")
+
+				_, err = verifier.Generate(NewJWTClaimsBuilder(), time.Hour, 0)
+				assert.ErrorIs(t, err, ErrJWTSigningKeyMissing, "Public key should not sign tokens")
+			})
+		})
+	}
+}
+
+// TestJWTKeyValidation tests rejection of invalid key configurations.
+func TestJWTKeyValidation(t *testing.T) {
+	t.Run("MissingID", func(t *testing.T) {
+		_, err := NewJWT(&JWTConfig{Keys: []JWTKeyConfig{{Algorithm: JWTAlgorithmHS256, Secret: DefaultJWTSecret}}})
+		assert.ErrorIs(t, err, ErrJWTKeyIDRequired, "Keys should require an ID")
+	})
+
+	t.Run("DuplicateID", func(t *testing.T) {
+		key := newTestKeyConfig(t, "key-1", JWTAlgorithmHS256)
+		_, err := NewJWT(&JWTConfig{Keys: []JWTKeyConfig{key, key}})
+		assert.ErrorIs(t, err, ErrJWTKeyIDDuplicate, "Key IDs should be unique")
+	})
+
+	t.Run("UnsupportedAlgorithm", func(t *testing.T) {
+		_, err := NewJWT(&JWTConfig{Keys: []JWTKeyConfig{{ID: "key-1", Algorithm: "none"}}})
+		assert.ErrorIs(t, err, ErrJWTAlgorithmUnsupported, "Unknown algorithms should be rejected")
+	})
+
+	t.Run("MissingKeyMaterial", func(t *testing.T) {
+		_, err := NewJWT(&JWTConfig{Keys: []JWTKeyConfig{{ID: "key-1", Algorithm: JWTAlgorithmRS256}}})
+		assert.ErrorIs(t, err, ErrJWTKeyMaterialRequired, "Asymmetric keys should require key material")
+	})
+
+	t.Run("WrongCurve", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err, "Should generate ECDSA key")
+
+		privatePEM, _ := encodePKCS8(t, key, &key.PublicKey)
+		_, err = NewJWT(&JWTConfig{Keys: []JWTKeyConfig{{ID: "key-1", Algorithm: JWTAlgorithmES256, PrivateKey: privatePEM}}})
+		assert.ErrorIs(t, err, ErrJWTKeyMaterialInvalid, "ES256 should require a P-256 key")
+	})
+
+	t.Run("DefaultAlgorithm", func(t *testing.T) {
+		key := newTestKeyConfig(t, "key-1", JWTAlgorithmEdDSA)
+		key.Algorithm = ""
+
+		j, err := NewJWT(&JWTConfig{Algorithm: JWTAlgorithmEdDSA, Keys: []JWTKeyConfig{key}})
+		require.NoError(t, err, "Keys should use the configured default algorithm")
+		assert.Equal(t, JWTAlgorithmEdDSA, j.keyByID["key-1"].Algorithm, "Key should use the default algorithm")
+	})
+}
+
+// TestJWTKeyRotation tests scheduled key rotation and grace-period verification of retired keys.
+func TestJWTKeyRotation(t *testing.T) {
+	now := time.Now()
+
+	t.Run("SignsWithLatestActiveKey", func(t *testing.T) {
+		previous := newTestKeyConfig(t, "previous", JWTAlgorithmES256)
+		current := newTestKeyConfig(t, "current", JWTAlgorithmES256)
+		current.ActivateAt = now.Add(-time.Minute)
+		upcoming := newTestKeyConfig(t, "upcoming", JWTAlgorithmES256)
+		upcoming.ActivateAt = now.Add(time.Hour)
+
+		j := newKeyedJWT(t, 0, previous, current, upcoming)
+
+		token, err := j.Generate(NewJWTClaimsBuilder(), time.Hour, 0)
+		require.NoError(t, err, "Should sign token")
+
+		keyID, _ := tokenKeyID(t, token)
+		assert.Equal(t, "current", keyID, "Should sign with the most recently activated key")
+	})
+
+	t.Run("RetiredKeyWithinGracePeriod", func(t *testing.T) {
+		retiring := newTestKeyConfig(t, "retiring", JWTAlgorithmRS256)
+		signer := newKeyedJWT(t, 0, retiring)
+
+		token, err := signer.Generate(NewJWTClaimsBuilder(), time.Hour, 0)
+		require.NoError(t, err, "Should sign token")
+
+		retiring.RetireAt = now.Add(-time.Minute)
+		successor := newTestKeyConfig(t, "successor", JWTAlgorithmRS256)
+		j := newKeyedJWT(t, time.Hour, retiring, successor)
+
+		_, err = j.Parse(token)
+		require.NoError(t, err, "Retired key should verify tokens during the grace period")
+
+		rotated, err := j.Generate(NewJWTClaimsBuilder(), time.Hour, 0)
+		require.NoError(t, err, "Should sign token")
+
+		keyID, _ := tokenKeyID(t, rotated)
+		assert.Equal(t, "successor", keyID, "Retired key should no longer sign")
+
+		t.Run("AfterGracePeriod", func(t *testing.T) {
+			expired := newKeyedJWT(t, 30*time.Second, retiring, successor)
+
+			_, err := expired.Parse(token)
+			assert.ErrorIs(t, err, result.ErrTokenInvalid, "Retired key should be rejected after the grace period")
+		})
+	})
+
+	t.Run("UnknownKeyID", func(t *testing.T) {
+		signer := newKeyedJWT(t, 0, newTestKeyConfig(t, "other", JWTAlgorithmEdDSA))
+		token, err := signer.Generate(NewJWTClaimsBuilder(), time.Hour, 0)
+		require.NoError(t, err, "Should sign token")
+
+		j := newKeyedJWT(t, 0, newTestKeyConfig(t, "key-1", JWTAlgorithmEdDSA))
+		_, err = j.Parse(token)
+		assert.ErrorIs(t, err, result.ErrTokenInvalid, "Tokens of unknown keys should be rejected")
+	})
+
+	t.Run("LegacySecretTokens", func(t *testing.T) {
+		legacy, err := NewJWT(&JWTConfig{Secret: DefaultJWTSecret, Audience: "test_app"})
+		require.NoError(t, err, "Should create legacy JWT")
+
+		token, err := legacy.Generate(NewJWTClaimsBuilder(), time.Hour, 0)
+		require.NoError(t, err, "Should sign token")
+
+		keyID, _ := tokenKeyID(t, token)
+		assert.Empty(t, keyID, "Secret-signed tokens should carry no key ID")
+
+		j, err := NewJWT(&JWTConfig{
+			Secret:   DefaultJWTSecret,
+			Audience: "test_app",
+			Keys:     []JWTKeyConfig{newTestKeyConfig(t, "key-1", JWTAlgorithmRS256)},
+		})
+		require.NoError(t, err, "Should create keyed JWT")
+
+		_, err = j.Parse(token)
+		require.NoError(t, err, "Secret should still verify previously issued tokens")
+
+		rotated, err := j.Generate(NewJWTClaimsBuilder(), time.Hour, 0)
+		require.NoError(t, err, "Should sign token")
+
+		keyID, _ = tokenKeyID(t, rotated)
+		assert.Equal(t, "key-1", keyID, "Secret should no longer sign once keys are configured")
+	})
+
+	t.Run("AlgorithmMismatch", func(t *testing.T) {
+		key := newTestKeyConfig(t, "key-1", JWTAlgorithmRS256)
+		j := newKeyedJWT(t, 0, key, newTestKeyConfig(t, "hmac", JWTAlgorithmHS256))
+
+		// An HS256 token claiming the RSA key must not be verified with the RSA public key as HMAC secret.
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			claimIssuer:    JWTIssuer,
+			claimAudience:  "test_app",
+			claimIssuedAt:  now.Unix(),
+			claimExpiresAt: now.Add(time.Hour).Unix(),
+		})
+		forged.Header[jwtHeaderKeyID] = "key-1"
+
+		signed, err := forged.SignedString([]byte(key.PublicKey))
+		require.NoError(t, err, "Should sign forged token")
+
+		_, err = j.Parse(signed)
+		assert.ErrorIs(t, err, result.ErrTokenInvalid, "Algorithm must match the key")
+	})
+}
+
+// TestJWKS tests publishing of verification keys.
+func TestJWKS(t *testing.T) {
+	now := time.Now()
+
+	rsaKey := newTestKeyConfig(t, "rsa", JWTAlgorithmRS256)
+	ecKey := newTestKeyConfig(t, "ec", JWTAlgorithmES256)
+	ecKey.ActivateAt = now.Add(time.Hour)
+	edKey := newTestKeyConfig(t, "ed", JWTAlgorithmEdDSA)
+	sm2Key := newTestKeyConfig(t, "sm2", JWTAlgorithmSM2)
+	retired := newTestKeyConfig(t, "retired", JWTAlgorithmEdDSA)
+	retired.RetireAt = now.Add(-time.Hour)
+	hmacKey := newTestKeyConfig(t, "hmac", JWTAlgorithmHS256)
+
+	j := newKeyedJWT(t, time.Minute, rsaKey, ecKey, edKey, sm2Key, retired, hmacKey)
+
+	jwks := j.JWKS()
+	byID := make(map[string]JWK, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		byID[jwk.KeyID] = jwk
+	}
+
+	assert.Len(t, jwks.Keys, 4, "Should publish only live asymmetric keys")
+	assert.NotContains(t, byID, "hmac", "Shared secrets should never be published")
+	assert.NotContains(t, byID, "retired", "Keys past their grace period should not be published")
+
+	t.Run("RSA", func(t *testing.T) {
+		jwk := byID["rsa"]
+		assert.Equal(t, "RSA", jwk.KeyType, "Should publish an RSA key")
+		assert.Equal(t, "sig", jwk.Use, "Should be a signature key")
+		assert.Equal(t, "AQAB", jwk.E, "Should encode the public exponent")
+		assert.NotEmpty(t, jwk.N, "Should encode the modulus")
+		assert.False(t, strings.ContainsAny(jwk.N, "+/="), "Should use unpadded base64url")
+	})
+
+	t.Run("ECUpcoming", func(t *testing.T) {
+		jwk, ok := byID["ec"]
+		require.True(t, ok, "Upcoming keys should be published ahead of activation")
+		assert.Equal(t, "EC", jwk.KeyType, "Should publish an EC key")
+		assert.Equal(t, "P-256", jwk.Curve, "Should publish the curve")
+		assert.Len(t, jwk.X, 43, "X coordinate should be 32 bytes")
+		assert.Len(t, jwk.Y, 43, "Y coordinate should be 32 bytes")
+	})
+
+	t.Run("Ed25519", func(t *testing.T) {
+		jwk := byID["ed"]
+		assert.Equal(t, "OKP", jwk.KeyType, "Should publish an OKP key")
+		assert.Equal(t, "Ed25519", jwk.Curve, "Should publish the curve")
+		assert.Len(t, jwk.X, 43, "Public key should be 32 bytes")
+	})
+
+	t.Run("SM2", func(t *testing.T) {
+		jwk := byID["sm2"]
+		assert.Equal(t, "EC", jwk.KeyType, "Should publish an EC key")
+		assert.Equal(t, "SM2", jwk.Curve, "Should publish the SM2 curve")
+		assert.Equal(t, JWTAlgorithmSM2, jwk.Algorithm, "Should publish the algorithm")
+	})
+
+	t.Run("SecretOnly", func(t *testing.T) {
+		legacy, err := NewJWT(&JWTConfig{})
+		require.NoError(t, err, "Should create legacy JWT")
+		assert.Empty(t, legacy.JWKS().Keys, "Secret-only configuration should publish no keys")
+	})
+}
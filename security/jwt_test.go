@@ -40,7 +40,7 @@ func TestNewJWT(t *testing.T) {
 		jwt, err := NewJWT(config)
 		require.NoError(t, err, "Should not return error")
 		assert.NotNil(t, jwt, "Should not be nil")
-		assert.Equal(t, 32, len(jwt.secret), "Should equal expected value") // Default secret is 64 hex chars = 32 bytes
+		assert.Equal(t, 32, len(jwt.keyByID[""].signKey.([]byte)), "Should equal expected value") // Default secret is 64 hex chars = 32 bytes
 	})
 
 	t.Run("EmptyAudienceUsesDefault", func(t *testing.T) {
@@ -0,0 +1,32 @@
+package security
+
+import (
+	"time"
+
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// Lockout reasons recorded on login events.
+const (
+	// LockoutReasonAccountLocked marks logins rejected because the username is locked,
+	// and the failed login that locked it.
+	LockoutReasonAccountLocked = "account_locked"
+	// LockoutReasonIPBlocked marks logins rejected because the client IP is blocked,
+	// and the failed login that blocked it.
+	LockoutReasonIPBlocked = "ip_blocked"
+)
+
+// LoginAttempts is the failed login record of a username or client IP.
+type LoginAttempts struct {
+	// Failures is the number of failed logins within the current counting window.
+	Failures int `json:"failures"`
+	// Lockouts is the number of consecutive lockouts, which makes each lockout last longer than the previous one.
+	Lockouts int `json:"lockouts"`
+	// LockedUntil is the end of the current lockout, or nil when not locked.
+	LockedUntil *timex.DateTime `json:"lockedUntil"`
+}
+
+// IsLocked reports whether the record is locked at now.
+func (a *LoginAttempts) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(a.LockedUntil.Unwrap())
+}
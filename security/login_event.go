@@ -12,43 +12,49 @@ const eventTypeLogin = "vef.security.login"
 type LoginEvent struct {
 	event.BaseEvent
 
-	AuthType   string  `json:"authType"`
-	UserID     *string `json:"userId"` // Populated on success
-	Username   string  `json:"username"`
-	LoginIP    string  `json:"loginIp"`
-	UserAgent  string  `json:"userAgent"`
-	TraceID    string  `json:"traceId"`
-	IsOk       bool    `json:"isOk"`
-	FailReason string  `json:"failReason"` // Populated on failure
-	ErrorCode  int     `json:"errorCode"`
+	AuthType      string  `json:"authType"`
+	UserID        *string `json:"userId"` // Populated on success
+	Username      string  `json:"username"`
+	TenantID      string  `json:"tenantId"`
+	LoginIP       string  `json:"loginIp"`
+	UserAgent     string  `json:"userAgent"`
+	TraceID       string  `json:"traceId"`
+	IsOk          bool    `json:"isOk"`
+	FailReason    string  `json:"failReason"` // Populated on failure
+	ErrorCode     int     `json:"errorCode"`
+	LockoutReason string  `json:"lockoutReason,omitempty"` // Populated when a lockout rejected the login or was triggered by it
 }
 
 // LoginEventParams contains parameters for creating a LoginEvent.
 type LoginEventParams struct {
-	AuthType   string
-	UserID     *string
-	Username   string
-	LoginIP    string
-	UserAgent  string
-	TraceID    string
-	IsOk       bool
-	FailReason string
-	ErrorCode  int
+	AuthType      string
+	UserID        *string
+	Username      string
+	TenantID      string
+	LoginIP       string
+	UserAgent     string
+	TraceID       string
+	IsOk          bool
+	FailReason    string
+	ErrorCode     int
+	LockoutReason string
 }
 
 // NewLoginEvent creates a new login event with the given parameters.
 func NewLoginEvent(params LoginEventParams) *LoginEvent {
 	return &LoginEvent{
-		BaseEvent:  event.NewBaseEvent(eventTypeLogin),
-		AuthType:   params.AuthType,
-		UserID:     params.UserID,
-		Username:   params.Username,
-		LoginIP:    params.LoginIP,
-		UserAgent:  params.UserAgent,
-		TraceID:    params.TraceID,
-		IsOk:       params.IsOk,
-		FailReason: params.FailReason,
-		ErrorCode:  params.ErrorCode,
+		BaseEvent:     event.NewBaseEvent(eventTypeLogin),
+		AuthType:      params.AuthType,
+		UserID:        params.UserID,
+		Username:      params.Username,
+		TenantID:      params.TenantID,
+		LoginIP:       params.LoginIP,
+		UserAgent:     params.UserAgent,
+		TraceID:       params.TraceID,
+		IsOk:          params.IsOk,
+		FailReason:    params.FailReason,
+		ErrorCode:     params.ErrorCode,
+		LockoutReason: params.LockoutReason,
 	}
 }
 
@@ -56,6 +56,18 @@ func TestNewLoginEvent(t *testing.T) {
 		assert.Equal(t, 401, evt.ErrorCode, "Should preserve ErrorCode")
 	})
 
+	t.Run("LockedOutLogin", func(t *testing.T) {
+		evt := NewLoginEvent(LoginEventParams{
+			AuthType:      "password",
+			Username:      "bob",
+			IsOk:          false,
+			ErrorCode:     1028,
+			LockoutReason: LockoutReasonAccountLocked,
+		})
+
+		assert.Equal(t, LockoutReasonAccountLocked, evt.LockoutReason, "Should preserve LockoutReason")
+	})
+
 	t.Run("ImplementsEventInterface", func(t *testing.T) {
 		evt := NewLoginEvent(LoginEventParams{AuthType: "test"})
 
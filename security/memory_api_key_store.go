@@ -0,0 +1,87 @@
+package security
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// MemoryAPIKeyStore implements APIKeyStore in memory.
+// Keys are lost on restart, so it is only suitable for development and tests.
+type MemoryAPIKeyStore struct {
+	keys map[string]APIKey
+	mu   sync.RWMutex
+}
+
+// NewMemoryAPIKeyStore creates a new in-memory API key store.
+func NewMemoryAPIKeyStore() APIKeyStore {
+	return &MemoryAPIKeyStore{
+		keys: make(map[string]APIKey),
+	}
+}
+
+func (m *MemoryAPIKeyStore) Save(_ context.Context, key *APIKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys[key.ID] = *key
+
+	return nil
+}
+
+func (m *MemoryAPIKeyStore) Get(_ context.Context, id string) (*APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key, ok := m.keys[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &key, nil
+}
+
+func (m *MemoryAPIKeyStore) ListByOwner(_ context.Context, ownerType PrincipalType, ownerID string) ([]*APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var keys []*APIKey
+	for _, key := range m.keys {
+		if key.OwnerType == ownerType && key.OwnerID == ownerID {
+			keys = append(keys, &key)
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b *APIKey) int {
+		return cmp.Or(
+			a.CreatedAt.Unwrap().Compare(b.CreatedAt.Unwrap()),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+
+	return keys, nil
+}
+
+func (m *MemoryAPIKeyStore) Delete(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.keys, id)
+
+	return nil
+}
+
+func (m *MemoryAPIKeyStore) UpdateLastUsed(_ context.Context, id string, usedAt timex.DateTime) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if key, ok := m.keys[id]; ok {
+		key.LastUsedAt = &usedAt
+		m.keys[id] = key
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// TestMemoryAPIKeyStore tests API key storage, listing, last use tracking and removal.
+func TestMemoryAPIKeyStore(t *testing.T) {
+	ctx := context.Background()
+	now := timex.Now()
+
+	t.Run("SaveAndGet", func(t *testing.T) {
+		store := NewMemoryAPIKeyStore()
+		require.NoError(t, store.Save(ctx, &APIKey{ID: "k1", OwnerType: PrincipalTypeUser, OwnerID: "user1", Name: "ci"}), "Should save key")
+
+		key, err := store.Get(ctx, "k1")
+		require.NoError(t, err, "Should get key")
+		require.NotNil(t, key, "Should find the key")
+		assert.Equal(t, "user1", key.OwnerID, "Should return the saved key")
+
+		key.Name = "changed"
+		stored, err := store.Get(ctx, "k1")
+		require.NoError(t, err, "Should get key")
+		assert.Equal(t, "ci", stored.Name, "Should not share state with returned keys")
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		key, err := NewMemoryAPIKeyStore().Get(ctx, "missing")
+		require.NoError(t, err, "Should not fail for a missing key")
+		assert.Nil(t, key, "Should return nil for a missing key")
+	})
+
+	t.Run("ListByOwner", func(t *testing.T) {
+		store := NewMemoryAPIKeyStore()
+		require.NoError(t, store.Save(ctx, &APIKey{ID: "k2", OwnerType: PrincipalTypeUser, OwnerID: "user1", CreatedAt: now}), "Should save key")
+		require.NoError(t, store.Save(ctx, &APIKey{ID: "k1", OwnerType: PrincipalTypeUser, OwnerID: "user1", CreatedAt: now.Add(-time.Hour)}), "Should save key")
+		require.NoError(t, store.Save(ctx, &APIKey{ID: "k3", OwnerType: PrincipalTypeExternalApp, OwnerID: "user1", CreatedAt: now}), "Should save key")
+
+		keys, err := store.ListByOwner(ctx, PrincipalTypeUser, "user1")
+		require.NoError(t, err, "Should list keys")
+		require.Len(t, keys, 2, "Should list only the owner's keys")
+		assert.Equal(t, "k1", keys[0].ID, "Should list the oldest key first")
+		assert.Equal(t, "k2", keys[1].ID, "Should list the newest key last")
+	})
+
+	t.Run("UpdateLastUsed", func(t *testing.T) {
+		store := NewMemoryAPIKeyStore()
+		require.NoError(t, store.Save(ctx, &APIKey{ID: "k1"}), "Should save key")
+		require.NoError(t, store.UpdateLastUsed(ctx, "k1", now), "Should record last use")
+		require.NoError(t, store.UpdateLastUsed(ctx, "missing", now), "Should ignore a missing key")
+
+		key, err := store.Get(ctx, "k1")
+		require.NoError(t, err, "Should get key")
+		require.NotNil(t, key.LastUsedAt, "Should have recorded the last use")
+		assert.True(t, key.LastUsedAt.Unwrap().Equal(now.Unwrap()), "Should record the given time")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		store := NewMemoryAPIKeyStore()
+		require.NoError(t, store.Save(ctx, &APIKey{ID: "k1"}), "Should save key")
+		require.NoError(t, store.Delete(ctx, "k1"), "Should delete key")
+
+		key, err := store.Get(ctx, "k1")
+		require.NoError(t, err, "Should get key")
+		assert.Nil(t, key, "Should have deleted the key")
+	})
+}
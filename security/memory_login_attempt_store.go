@@ -0,0 +1,121 @@
+package security
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/coldsmirk/vef-framework-go/cache"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// loginCounter is a count that expires at a fixed time, regardless of later increments.
+type loginCounter struct {
+	Count     int
+	ExpiresAt time.Time
+}
+
+// MemoryLoginAttemptStore implements LoginAttemptStore using in-memory caches.
+// This implementation is suitable for development and single-instance deployments.
+// For distributed systems, use RedisLoginAttemptStore instead.
+type MemoryLoginAttemptStore struct {
+	failures cache.Cache[loginCounter]
+	lockouts cache.Cache[loginCounter]
+	locks    cache.Cache[time.Time]
+	mu       sync.Mutex
+}
+
+// NewMemoryLoginAttemptStore creates a new in-memory login attempt store.
+func NewMemoryLoginAttemptStore() LoginAttemptStore {
+	return &MemoryLoginAttemptStore{
+		failures: cache.NewMemory[loginCounter](),
+		lockouts: cache.NewMemory[loginCounter](),
+		locks:    cache.NewMemory[time.Time](),
+	}
+}
+
+func (m *MemoryLoginAttemptStore) Get(ctx context.Context, key string) (*LoginAttempts, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	attempts := new(LoginAttempts)
+
+	if failures, ok := m.failures.Get(ctx, key); ok {
+		attempts.Failures = failures.Count
+	}
+
+	if lockouts, ok := m.lockouts.Get(ctx, key); ok {
+		attempts.Lockouts = lockouts.Count
+	}
+
+	if until, ok := m.locks.Get(ctx, key); ok {
+		lockedUntil := timex.DateTime(until)
+		attempts.LockedUntil = &lockedUntil
+	}
+
+	return attempts, nil
+}
+
+func (m *MemoryLoginAttemptStore) AddFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.increment(ctx, m.failures, key, window, false)
+}
+
+func (m *MemoryLoginAttemptStore) AddLockout(ctx context.Context, key string, memory time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	lockouts, err := m.increment(ctx, m.lockouts, key, memory, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return lockouts, m.failures.Delete(ctx, key)
+}
+
+func (m *MemoryLoginAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return m.locks.Set(ctx, key, until, ttl)
+}
+
+func (m *MemoryLoginAttemptStore) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.failures.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	if err := m.lockouts.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	return m.locks.Delete(ctx, key)
+}
+
+// increment adds one to the counter of key. A new counter expires after ttl;
+// an existing one keeps its expiry unless extend is set.
+func (*MemoryLoginAttemptStore) increment(ctx context.Context, counters cache.Cache[loginCounter], key string, ttl time.Duration, extend bool) (int, error) {
+	now := time.Now()
+
+	counter, ok := counters.Get(ctx, key)
+	if !ok || !now.Before(counter.ExpiresAt) {
+		counter = loginCounter{ExpiresAt: now.Add(ttl)}
+	} else if extend {
+		counter.ExpiresAt = now.Add(ttl)
+	}
+
+	counter.Count++
+
+	if err := counters.Set(ctx, key, counter, counter.ExpiresAt.Sub(now)); err != nil {
+		return 0, err
+	}
+
+	return counter.Count, nil
+}
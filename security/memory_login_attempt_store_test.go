@@ -0,0 +1,99 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMemoryLoginAttemptStore tests failure counting, lockouts and resets.
+func TestMemoryLoginAttemptStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("GetMissing", func(t *testing.T) {
+		store := NewMemoryLoginAttemptStore()
+
+		attempts, err := store.Get(ctx, "user:alice")
+		require.NoError(t, err, "Should get attempts")
+		assert.Zero(t, attempts.Failures, "Unknown key should have no failures")
+		assert.Zero(t, attempts.Lockouts, "Unknown key should have no lockouts")
+		assert.False(t, attempts.IsLocked(time.Now()), "Unknown key should not be locked")
+	})
+
+	t.Run("AddFailure", func(t *testing.T) {
+		store := NewMemoryLoginAttemptStore()
+
+		for want := 1; want <= 3; want++ {
+			failures, err := store.AddFailure(ctx, "user:alice", time.Minute)
+			require.NoError(t, err, "Should add failure")
+			assert.Equal(t, want, failures, "Failures should be counted")
+		}
+
+		other, err := store.Get(ctx, "user:bob")
+		require.NoError(t, err, "Should get attempts")
+		assert.Zero(t, other.Failures, "Failures of other keys should be separate")
+	})
+
+	t.Run("WindowExpires", func(t *testing.T) {
+		store := NewMemoryLoginAttemptStore()
+
+		_, err := store.AddFailure(ctx, "user:alice", 50*time.Millisecond)
+		require.NoError(t, err, "Should add failure")
+
+		time.Sleep(100 * time.Millisecond)
+
+		failures, err := store.AddFailure(ctx, "user:alice", 50*time.Millisecond)
+		require.NoError(t, err, "Should add failure")
+		assert.Equal(t, 1, failures, "A failure after the window should open a new window")
+	})
+
+	t.Run("AddLockoutClearsFailures", func(t *testing.T) {
+		store := NewMemoryLoginAttemptStore()
+
+		_, err := store.AddFailure(ctx, "user:alice", time.Minute)
+		require.NoError(t, err, "Should add failure")
+
+		for want := 1; want <= 2; want++ {
+			lockouts, err := store.AddLockout(ctx, "user:alice", time.Hour)
+			require.NoError(t, err, "Should add lockout")
+			assert.Equal(t, want, lockouts, "Lockouts should be counted")
+		}
+
+		attempts, err := store.Get(ctx, "user:alice")
+		require.NoError(t, err, "Should get attempts")
+		assert.Zero(t, attempts.Failures, "Lockout should clear failures")
+		assert.Equal(t, 2, attempts.Lockouts, "Lockouts should be kept")
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		store := NewMemoryLoginAttemptStore()
+		until := time.Now().Add(time.Minute)
+
+		require.NoError(t, store.Lock(ctx, "ip:10.0.0.1", until), "Should lock key")
+
+		attempts, err := store.Get(ctx, "ip:10.0.0.1")
+		require.NoError(t, err, "Should get attempts")
+		require.NotNil(t, attempts.LockedUntil, "Locked key should report its lock end")
+		assert.True(t, attempts.IsLocked(time.Now()), "Key should be locked")
+		assert.False(t, attempts.IsLocked(until.Add(time.Second)), "Lock should end at its end time")
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		store := NewMemoryLoginAttemptStore()
+
+		_, err := store.AddFailure(ctx, "user:alice", time.Minute)
+		require.NoError(t, err, "Should add failure")
+		_, err = store.AddLockout(ctx, "user:alice", time.Hour)
+		require.NoError(t, err, "Should add lockout")
+		require.NoError(t, store.Lock(ctx, "user:alice", time.Now().Add(time.Minute)), "Should lock key")
+
+		require.NoError(t, store.Reset(ctx, "user:alice"), "Should reset key")
+
+		attempts, err := store.Get(ctx, "user:alice")
+		require.NoError(t, err, "Should get attempts")
+		assert.Equal(t, &LoginAttempts{}, attempts, "Reset should clear failures, lockouts and lock")
+	})
+}
@@ -0,0 +1,116 @@
+package security
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/coldsmirk/vef-framework-go/cache"
+)
+
+// MemorySessionStore implements SessionStore using an in-memory cache.
+// This implementation is suitable for development and single-instance deployments.
+// For distributed systems, use RedisSessionStore instead.
+type MemorySessionStore struct {
+	// mu serializes writes so that CompareAndSwap cannot interleave with another write of the session.
+	mu    sync.Mutex
+	cache cache.Cache[Session]
+}
+
+// NewMemorySessionStore creates a new in-memory session store.
+func NewMemorySessionStore() SessionStore {
+	return &MemorySessionStore{
+		cache: cache.NewMemory[Session](),
+	}
+}
+
+func (m *MemorySessionStore) Save(ctx context.Context, session *Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.save(ctx, session)
+}
+
+func (m *MemorySessionStore) save(ctx context.Context, session *Session) error {
+	ttl := time.Until(session.ExpiresAt.Unwrap())
+	if ttl <= 0 {
+		return m.cache.Delete(ctx, session.ID)
+	}
+
+	return m.cache.Set(ctx, session.ID, *session, ttl)
+}
+
+func (m *MemorySessionStore) CompareAndSwap(ctx context.Context, session *Session, oldRefreshTokenHash string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.cache.Get(ctx, session.ID)
+	if !ok || stored.RefreshTokenHash != oldRefreshTokenHash {
+		return false, nil
+	}
+
+	return true, m.save(ctx, session)
+}
+
+func (m *MemorySessionStore) Get(ctx context.Context, id string) (*Session, error) {
+	session, ok := m.cache.Get(ctx, id)
+	if !ok {
+		return nil, nil
+	}
+
+	return &session, nil
+}
+
+func (m *MemorySessionStore) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.cache.Delete(ctx, id)
+}
+
+func (m *MemorySessionStore) DeleteByUser(ctx context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sessions, err := m.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if err := m.cache.Delete(ctx, session.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *MemorySessionStore) ListByUser(ctx context.Context, userID string) ([]*Session, error) {
+	var sessions []*Session
+	if err := m.cache.ForEach(ctx, func(_ string, session Session) bool {
+		if session.UserID == userID {
+			sessions = append(sessions, &session)
+		}
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	sortSessions(sessions)
+
+	return sessions, nil
+}
+
+// sortSessions orders sessions by login time, oldest first.
+func sortSessions(sessions []*Session) {
+	slices.SortFunc(sessions, func(a, b *Session) int {
+		return cmp.Or(
+			a.CreatedAt.Unwrap().Compare(b.CreatedAt.Unwrap()),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+}
@@ -0,0 +1,125 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// newTestSession creates a session of userID that logged in at createdAt and expires in an hour.
+func newTestSession(id, userID string, createdAt timex.DateTime) *Session {
+	session := &Session{
+		ID:          id,
+		UserID:      userID,
+		IP:          "127.0.0.1",
+		UserAgent:   "test-agent",
+		CreatedAt:   createdAt,
+		RefreshedAt: createdAt,
+		ExpiresAt:   timex.Now().Add(time.Hour),
+	}
+	session.SetRefreshToken("refresh-" + id)
+
+	return session
+}
+
+// TestMemorySessionStore tests session storage, listing and revocation.
+func TestMemorySessionStore(t *testing.T) {
+	ctx := context.Background()
+	now := timex.Now()
+
+	t.Run("SaveAndGet", func(t *testing.T) {
+		store := NewMemorySessionStore()
+		require.NoError(t, store.Save(ctx, newTestSession("s1", "user1", now)), "Should save session")
+
+		session, err := store.Get(ctx, "s1")
+		require.NoError(t, err, "Should get session")
+		require.NotNil(t, session, "Session should exist")
+		assert.Equal(t, "user1", session.UserID, "User ID should be preserved")
+		assert.True(t, session.MatchesRefreshToken("refresh-s1"), "Refresh token fingerprint should be preserved")
+		assert.False(t, session.MatchesRefreshToken("refresh-other"), "Other refresh tokens should not match")
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		store := NewMemorySessionStore()
+
+		session, err := store.Get(ctx, "missing")
+		require.NoError(t, err, "Missing session should not be an error")
+		assert.Nil(t, session, "Missing session should be nil")
+	})
+
+	t.Run("SaveExpired", func(t *testing.T) {
+		store := NewMemorySessionStore()
+		session := newTestSession("s1", "user1", now)
+		require.NoError(t, store.Save(ctx, session), "Should save session")
+
+		session.ExpiresAt = now.Add(-time.Minute)
+		require.NoError(t, store.Save(ctx, session), "Saving an expired session should succeed")
+
+		got, err := store.Get(ctx, "s1")
+		require.NoError(t, err, "Should get session")
+		assert.Nil(t, got, "Expired session should be removed")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		store := NewMemorySessionStore()
+		require.NoError(t, store.Save(ctx, newTestSession("s1", "user1", now)), "Should save session")
+		require.NoError(t, store.Delete(ctx, "s1"), "Should delete session")
+
+		got, err := store.Get(ctx, "s1")
+		require.NoError(t, err, "Should get session")
+		assert.Nil(t, got, "Deleted session should not exist")
+	})
+
+	t.Run("CompareAndSwap", func(t *testing.T) {
+		store := NewMemorySessionStore()
+		session := newTestSession("s1", "user1", now)
+		require.NoError(t, store.Save(ctx, session), "Should save session")
+
+		exchanged := session.RefreshTokenHash
+		session.SetRefreshToken("rotated")
+
+		swapped, err := store.CompareAndSwap(ctx, session, exchanged)
+		require.NoError(t, err, "Should swap session")
+		assert.True(t, swapped, "Swap with the stored fingerprint should succeed")
+
+		swapped, err = store.CompareAndSwap(ctx, session, exchanged)
+		require.NoError(t, err, "Should compare session")
+		assert.False(t, swapped, "Swap with an exchanged fingerprint should fail")
+
+		got, err := store.Get(ctx, "s1")
+		require.NoError(t, err, "Should get session")
+		assert.True(t, got.MatchesRefreshToken("rotated"), "Rotated refresh token should be stored")
+
+		swapped, err = store.CompareAndSwap(ctx, newTestSession("missing", "user1", now), "")
+		require.NoError(t, err, "Should compare missing session")
+		assert.False(t, swapped, "Swap of a missing session should fail")
+	})
+
+	t.Run("ListAndDeleteByUser", func(t *testing.T) {
+		store := NewMemorySessionStore()
+		require.NoError(t, store.Save(ctx, newTestSession("s2", "user1", now)), "Should save session")
+		require.NoError(t, store.Save(ctx, newTestSession("s1", "user1", now.Add(-time.Minute))), "Should save session")
+		require.NoError(t, store.Save(ctx, newTestSession("s3", "user2", now)), "Should save session")
+
+		sessions, err := store.ListByUser(ctx, "user1")
+		require.NoError(t, err, "Should list sessions")
+		require.Len(t, sessions, 2, "Should list only the sessions of the user")
+		assert.Equal(t, "s1", sessions[0].ID, "Sessions should be ordered oldest first")
+		assert.Equal(t, "s2", sessions[1].ID, "Sessions should be ordered oldest first")
+
+		require.NoError(t, store.DeleteByUser(ctx, "user1"), "Should delete sessions of the user")
+
+		sessions, err = store.ListByUser(ctx, "user1")
+		require.NoError(t, err, "Should list sessions")
+		assert.Empty(t, sessions, "All sessions of the user should be deleted")
+
+		other, err := store.Get(ctx, "s3")
+		require.NoError(t, err, "Should get session")
+		assert.NotNil(t, other, "Sessions of other users should be kept")
+	})
+}
@@ -0,0 +1,73 @@
+package security
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemoryWebAuthnCredentialStore implements WebAuthnCredentialStore in memory.
+// Credentials are lost on restart, so it is only suitable for development and tests.
+type MemoryWebAuthnCredentialStore struct {
+	credentials map[string]WebAuthnCredential
+	mu          sync.RWMutex
+}
+
+// NewMemoryWebAuthnCredentialStore creates a new in-memory WebAuthn credential store.
+func NewMemoryWebAuthnCredentialStore() WebAuthnCredentialStore {
+	return &MemoryWebAuthnCredentialStore{
+		credentials: make(map[string]WebAuthnCredential),
+	}
+}
+
+func (m *MemoryWebAuthnCredentialStore) Save(_ context.Context, credential *WebAuthnCredential) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.credentials[credential.ID] = *credential
+
+	return nil
+}
+
+func (m *MemoryWebAuthnCredentialStore) Get(_ context.Context, id string) (*WebAuthnCredential, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	credential, ok := m.credentials[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &credential, nil
+}
+
+func (m *MemoryWebAuthnCredentialStore) ListByUser(_ context.Context, userID string) ([]*WebAuthnCredential, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var credentials []*WebAuthnCredential
+	for _, credential := range m.credentials {
+		if credential.UserID == userID {
+			credentials = append(credentials, &credential)
+		}
+	}
+
+	slices.SortFunc(credentials, func(a, b *WebAuthnCredential) int {
+		return cmp.Or(
+			a.CreatedAt.Unwrap().Compare(b.CreatedAt.Unwrap()),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+
+	return credentials, nil
+}
+
+func (m *MemoryWebAuthnCredentialStore) Delete(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.credentials, id)
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// TestMemoryWebAuthnCredentialStore tests credential storage, listing and removal.
+func TestMemoryWebAuthnCredentialStore(t *testing.T) {
+	ctx := context.Background()
+	now := timex.Now()
+
+	t.Run("SaveAndGet", func(t *testing.T) {
+		store := NewMemoryWebAuthnCredentialStore()
+		require.NoError(t, store.Save(ctx, &WebAuthnCredential{ID: "c1", UserID: "user1", SignCount: 1}), "Should save credential")
+
+		credential, err := store.Get(ctx, "c1")
+		require.NoError(t, err, "Should get credential")
+		require.NotNil(t, credential, "Should find the credential")
+		assert.Equal(t, "user1", credential.UserID, "Should return the saved credential")
+
+		credential.SignCount = 9
+		stored, err := store.Get(ctx, "c1")
+		require.NoError(t, err, "Should get credential")
+		assert.Equal(t, uint32(1), stored.SignCount, "Should not share state with returned credentials")
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		credential, err := NewMemoryWebAuthnCredentialStore().Get(ctx, "missing")
+		require.NoError(t, err, "Should not fail for a missing credential")
+		assert.Nil(t, credential, "Should return nil for a missing credential")
+	})
+
+	t.Run("ListByUser", func(t *testing.T) {
+		store := NewMemoryWebAuthnCredentialStore()
+		require.NoError(t, store.Save(ctx, &WebAuthnCredential{ID: "c2", UserID: "user1", CreatedAt: now}), "Should save credential")
+		require.NoError(t, store.Save(ctx, &WebAuthnCredential{ID: "c1", UserID: "user1", CreatedAt: now.Add(-time.Hour)}), "Should save credential")
+		require.NoError(t, store.Save(ctx, &WebAuthnCredential{ID: "c3", UserID: "user2", CreatedAt: now}), "Should save credential")
+
+		credentials, err := store.ListByUser(ctx, "user1")
+		require.NoError(t, err, "Should list credentials")
+		require.Len(t, credentials, 2, "Should list only the user's credentials")
+		assert.Equal(t, "c1", credentials[0].ID, "Should list the oldest credential first")
+		assert.Equal(t, "c2", credentials[1].ID, "Should list the newest credential last")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		store := NewMemoryWebAuthnCredentialStore()
+		require.NoError(t, store.Save(ctx, &WebAuthnCredential{ID: "c1", UserID: "user1"}), "Should save credential")
+		require.NoError(t, store.Delete(ctx, "c1"), "Should delete credential")
+
+		credential, err := store.Get(ctx, "c1")
+		require.NoError(t, err, "Should get credential")
+		assert.Nil(t, credential, "Should have deleted the credential")
+	})
+}
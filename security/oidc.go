@@ -0,0 +1,78 @@
+package security
+
+import (
+	"context"
+
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+// ExternalIdentity is a user identity asserted by an external identity provider after a single sign-on.
+type ExternalIdentity struct {
+	// Provider is the name of the configured provider that asserted the identity.
+	Provider string
+	// Subject is the provider's stable identifier of the user, unique within the provider.
+	Subject string
+	// Username is the value of the provider's configured username claim.
+	Username string
+	// Email is the user's email address, if released by the provider.
+	Email string
+	// EmailVerified reports whether the provider verified Email.
+	EmailVerified bool
+	// Name is the user's display name, if released by the provider.
+	Name string
+	// Claims holds all verified ID token and userinfo claims.
+	Claims map[string]any
+}
+
+// ExternalIdentityMapper resolves the local user behind an external identity.
+// Implementations may look up a linked account, or provision a new local user on first sign-in.
+type ExternalIdentityMapper interface {
+	// Map returns the local principal of the identity, or result.ErrExternalIdentityNotLinked
+	// when the identity belongs to no local user.
+	Map(ctx context.Context, identity *ExternalIdentity) (*Principal, error)
+}
+
+// OIDCCredentials are the callback parameters the provider redirected the user back with.
+type OIDCCredentials struct {
+	Code  string
+	State string
+}
+
+// OIDCAuthorization starts an OpenID Connect login.
+// The client redirects the user to AuthorizationURL and keeps FlowToken to complete the login on callback.
+type OIDCAuthorization struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+	FlowToken        string `json:"flowToken"`
+}
+
+// UserLoaderIdentityMapper links external identities to the local users with the same username.
+// It never provisions users; applications that do should provide their own ExternalIdentityMapper.
+type UserLoaderIdentityMapper struct {
+	loader UserLoader
+}
+
+// NewUserLoaderIdentityMapper creates an identity mapper that looks users up by username through loader.
+func NewUserLoaderIdentityMapper(loader UserLoader) ExternalIdentityMapper {
+	return &UserLoaderIdentityMapper{loader: loader}
+}
+
+func (m *UserLoaderIdentityMapper) Map(ctx context.Context, identity *ExternalIdentity) (*Principal, error) {
+	if identity.Username == "" {
+		return nil, result.ErrExternalIdentityNotLinked
+	}
+
+	principal, _, err := m.loader.LoadByUsername(ctx, identity.Username)
+	if err != nil {
+		if result.IsRecordNotFound(err) {
+			return nil, result.ErrExternalIdentityNotLinked
+		}
+
+		return nil, err
+	}
+
+	if principal == nil {
+		return nil, result.ErrExternalIdentityNotLinked
+	}
+
+	return principal, nil
+}
@@ -0,0 +1,33 @@
+package security
+
+import "slices"
+
+// commonPasswords are among the most frequent passwords of public breach corpora, lowercased.
+// They are rejected outright and priced as a pick from the dictionary by the entropy estimator,
+// together with the words of PasswordPolicyConfig.Dictionary.
+var commonPasswords = []string{
+	"123456", "password", "12345678", "qwerty", "123456789", "12345", "1234", "111111", "1234567", "dragon",
+	"123123", "baseball", "abc123", "football", "monkey", "letmein", "696969", "shadow", "master", "666666",
+	"qwertyuiop", "123321", "mustang", "1234567890", "michael", "654321", "superman", "1qaz2wsx", "7777777", "121212",
+	"000000", "qazwsx", "123qwe", "killer", "trustno1", "jordan", "jennifer", "zxcvbnm", "asdfgh", "hunter",
+	"buster", "soccer", "harley", "batman", "andrew", "tigger", "sunshine", "iloveyou", "2000", "charlie",
+	"robert", "thomas", "hockey", "ranger", "daniel", "starwars", "klaster", "112233", "george", "computer",
+	"michelle", "jessica", "pepper", "1111", "zxcvbn", "555555", "11111111", "131313", "freedom", "777777",
+	"pass", "maggie", "159753", "aaaaaa", "ginger", "princess", "joshua", "cheese", "amanda", "summer",
+	"love", "ashley", "nicole", "chelsea", "biteme", "matthew", "access", "yankees", "987654321", "dallas",
+	"austin", "thunder", "taylor", "matrix", "admin", "administrator", "welcome", "login", "passw0rd", "p@ssw0rd",
+	"changeme", "secret", "root", "guest", "default", "test", "qwerty123", "password1", "iloveyou1", "abcdef",
+	"abcd1234", "a123456", "woaini", "woaini1314", "5201314", "888888", "88888888", "147258369", "qq123456", "aa123456",
+}
+
+// newPasswordDictionary builds the lookup set of the common passwords and the extra words, lowercased.
+func newPasswordDictionary(words []string) map[string]struct{} {
+	dictionary := make(map[string]struct{}, len(commonPasswords)+len(words))
+	for _, word := range slices.Concat(commonPasswords, words) {
+		if inputs := normalizeUserInputs([]string{word}); len(inputs) > 0 {
+			dictionary[inputs[0]] = struct{}{}
+		}
+	}
+
+	return dictionary
+}
@@ -0,0 +1,436 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofiber/fiber/v3"
+
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/password"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// PasswordChangeReasonAdminReset is the password change reason of users whose password was set by an administrator.
+const PasswordChangeReasonAdminReset = "admin_reset"
+
+const (
+	// PasswordPolicyDefaultMinLength is the default minimum password length.
+	PasswordPolicyDefaultMinLength = 8
+	// PasswordPolicyDefaultMaxLength is the default maximum password length, bounding the cost of hashing.
+	PasswordPolicyDefaultMaxLength = 128
+
+	// maxUserInputDistance is the edit distance below which a password counts as a variant of a user input.
+	maxUserInputDistance = 2
+)
+
+// Password policy rules, which are also the i18n keys of their violation messages.
+const (
+	PasswordRuleMinLength   = "password_too_short"
+	PasswordRuleMaxLength   = "password_too_long"
+	PasswordRuleUpper       = "password_missing_upper"
+	PasswordRuleLower       = "password_missing_lower"
+	PasswordRuleDigit       = "password_missing_digit"
+	PasswordRuleSymbol      = "password_missing_symbol"
+	PasswordRuleCharClasses = "password_too_few_char_classes"
+	PasswordRuleUserInfo    = "password_similar_to_user_info"
+	PasswordRuleDictionary  = "password_in_dictionary"
+	PasswordRuleEntropy     = "password_too_weak"
+	PasswordRuleHistory     = "password_reused"
+)
+
+// PasswordPolicyConfig is the configuration of the password policy.
+type PasswordPolicyConfig struct {
+	MinLength      int           `config:"min_length"       json:"minLength"`      // Minimum length (default: 8)
+	MaxLength      int           `config:"max_length"       json:"maxLength"`      // Maximum length (default: 128)
+	RequireUpper   bool          `config:"require_upper"    json:"requireUpper"`   // Require an uppercase letter
+	RequireLower   bool          `config:"require_lower"    json:"requireLower"`   // Require a lowercase letter
+	RequireDigit   bool          `config:"require_digit"    json:"requireDigit"`   // Require a digit
+	RequireSymbol  bool          `config:"require_symbol"   json:"requireSymbol"`  // Require a symbol
+	MinCharClasses int           `config:"min_char_classes" json:"minCharClasses"` // Minimum distinct classes of uppercase, lowercase, digits and symbols
+	MinEntropy     float64       `config:"min_entropy"      json:"minEntropy"`     // Minimum estimated entropy in bits, 0 disables the check
+	Dictionary     []string      `config:"dictionary"       json:"-"`              // Words rejected in addition to the built-in common passwords
+	HistorySize    int           `config:"history_size"     json:"historySize"`    // Number of recent passwords, the current one included, that cannot be reused; the current password is always rejected
+	MaxAge         time.Duration `config:"max_age"          json:"maxAge"`         // Time after which a password must be changed, 0 never expires
+}
+
+// PasswordRecord is the stored password of a user with the state the policy needs.
+type PasswordRecord struct {
+	// EncodedPassword is the current password encoded by the password.Encoder.
+	EncodedPassword string
+	// ChangedAt is the time the password was last set.
+	ChangedAt timex.DateTime
+	// SetByAdmin reports whether the password was set by an administrator, which forces the user to change it.
+	SetByAdmin bool
+	// History holds the previous encoded passwords, newest first.
+	History []string
+}
+
+// PasswordStore loads and persists the passwords the password policy governs.
+// Implementations typically map it onto the user table and a password history table.
+// The password login checks the hash returned by UserLoader.LoadByUsername, so the store must read and write
+// that same hash: a password saved elsewhere is neither required to log in nor able to replace the old one.
+type PasswordStore interface {
+	// Load returns the password record of the user, or nil when the user has no password.
+	Load(ctx context.Context, userID string) (*PasswordRecord, error)
+	// Save replaces the password record of the user.
+	Save(ctx context.Context, userID string, record *PasswordRecord) error
+}
+
+// PasswordViolation is a password policy rule a password breaks.
+type PasswordViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PasswordEvaluation is the result of checking a password against the policy rules.
+type PasswordEvaluation struct {
+	Violations []PasswordViolation `json:"violations"`
+	// Entropy is the estimated entropy of the password in bits.
+	Entropy float64 `json:"entropy"`
+}
+
+// Valid reports whether the password satisfies every rule.
+func (e *PasswordEvaluation) Valid() bool {
+	return len(e.Violations) == 0
+}
+
+// Err returns the first violation as a result error, or nil when the password is valid.
+func (e *PasswordEvaluation) Err() error {
+	if e.Valid() {
+		return nil
+	}
+
+	return newPasswordPolicyError(e.Violations[0])
+}
+
+// PasswordPolicy enforces length, character class, user info similarity, dictionary, entropy,
+// history and maximum age rules on the passwords of users.
+// It implements PasswordChangeChecker and PasswordChanger, so the forced password change
+// challenge of NewPasswordPolicyChallengeProvider runs on it.
+type PasswordPolicy struct {
+	config     *PasswordPolicyConfig
+	encoder    password.Encoder
+	store      PasswordStore
+	dictionary map[string]struct{}
+}
+
+// NewPasswordPolicy creates a password policy that encodes passwords with encoder and persists them in store.
+// The store must share its storage with the UserLoader, see PasswordStore.
+// Only the rules checking the password itself are available while store is nil.
+func NewPasswordPolicy(config *PasswordPolicyConfig, encoder password.Encoder, store PasswordStore) *PasswordPolicy {
+	if config.MinLength <= 0 {
+		config.MinLength = PasswordPolicyDefaultMinLength
+	}
+
+	if config.MaxLength <= 0 {
+		config.MaxLength = PasswordPolicyDefaultMaxLength
+	}
+
+	config.MaxLength = max(config.MaxLength, config.MinLength)
+
+	return &PasswordPolicy{
+		config:     config,
+		encoder:    encoder,
+		store:      store,
+		dictionary: newPasswordDictionary(config.Dictionary),
+	}
+}
+
+// Config returns the configuration of the policy, e.g. to show the rules next to a password field.
+func (p *PasswordPolicy) Config() *PasswordPolicyConfig {
+	return p.config
+}
+
+// Evaluate checks the password against the rules that need no stored state.
+// The user inputs, such as the username and display name, must not be part of the password.
+func (p *PasswordPolicy) Evaluate(plaintext string, userInputs ...string) *PasswordEvaluation {
+	var (
+		evaluation = &PasswordEvaluation{Violations: make([]PasswordViolation, 0)}
+		length     = len([]rune(plaintext))
+	)
+
+	violate := func(rule string, data ...map[string]any) {
+		evaluation.Violations = append(evaluation.Violations, PasswordViolation{Rule: rule, Message: i18n.T(rule, data...)})
+	}
+
+	if length < p.config.MinLength {
+		violate(PasswordRuleMinLength, map[string]any{"min": p.config.MinLength})
+	}
+
+	if length > p.config.MaxLength {
+		violate(PasswordRuleMaxLength, map[string]any{"max": p.config.MaxLength})
+	}
+
+	upper, lower, digit, symbol := charClasses(plaintext)
+	if p.config.RequireUpper && !upper {
+		violate(PasswordRuleUpper)
+	}
+
+	if p.config.RequireLower && !lower {
+		violate(PasswordRuleLower)
+	}
+
+	if p.config.RequireDigit && !digit {
+		violate(PasswordRuleDigit)
+	}
+
+	if p.config.RequireSymbol && !symbol {
+		violate(PasswordRuleSymbol)
+	}
+
+	if classes := countTrue(upper, lower, digit, symbol); classes < p.config.MinCharClasses {
+		violate(PasswordRuleCharClasses, map[string]any{"min": p.config.MinCharClasses})
+	}
+
+	if isSimilarToUserInputs(plaintext, userInputs) {
+		violate(PasswordRuleUserInfo)
+	}
+
+	if p.isDictionaryWord(plaintext) {
+		violate(PasswordRuleDictionary)
+	}
+
+	evaluation.Entropy = EstimatePasswordEntropy(plaintext, p.dictionary, userInputs...)
+	if p.config.MinEntropy > 0 && evaluation.Entropy < p.config.MinEntropy {
+		violate(PasswordRuleEntropy)
+	}
+
+	return evaluation
+}
+
+// Check implements PasswordChangeChecker, requiring a change of passwords set by an administrator or older than MaxAge.
+func (p *PasswordPolicy) Check(ctx context.Context, principal *Principal) (*PasswordChangeChallengeData, error) {
+	if err := p.checkStore(); err != nil {
+		return nil, err
+	}
+
+	record, err := p.store.Load(ctx, principal.ID)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	if record.SetByAdmin {
+		return &PasswordChangeChallengeData{Reason: PasswordChangeReasonAdminReset}, nil
+	}
+
+	if p.isExpired(record) {
+		return &PasswordChangeChallengeData{
+			Reason: PasswordChangeReasonExpired,
+			Meta:   map[string]any{"changedAt": record.ChangedAt},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// ChangePassword implements PasswordChanger, validating the new password of the user and persisting it.
+func (p *PasswordPolicy) ChangePassword(ctx context.Context, principal *Principal, newPassword string) error {
+	return p.setPassword(ctx, principal.ID, newPassword, false, principal.ID, principal.Name)
+}
+
+// ChangeOwnPassword changes the password of the user after verifying the current password.
+func (p *PasswordPolicy) ChangeOwnPassword(ctx context.Context, principal *Principal, currentPassword, newPassword string) error {
+	if err := p.checkStore(); err != nil {
+		return err
+	}
+
+	record, err := p.store.Load(ctx, principal.ID)
+	if err != nil {
+		return err
+	}
+
+	if record == nil || !p.encoder.Matches(currentPassword, record.EncodedPassword) {
+		return result.ErrCurrentPasswordInvalid
+	}
+
+	return p.ChangePassword(ctx, principal, newPassword)
+}
+
+// ResetPassword sets the password of a user on behalf of an administrator.
+// The user has to change it at the next login.
+func (p *PasswordPolicy) ResetPassword(ctx context.Context, userID, newPassword string, userInputs ...string) error {
+	return p.setPassword(ctx, userID, newPassword, true, slices.Concat(userInputs, []string{userID})...)
+}
+
+// IsExpired reports whether the password of the user has outlived MaxAge.
+func (p *PasswordPolicy) IsExpired(ctx context.Context, userID string) (bool, error) {
+	if err := p.checkStore(); err != nil {
+		return false, err
+	}
+
+	record, err := p.store.Load(ctx, userID)
+	if err != nil || record == nil {
+		return false, err
+	}
+
+	return p.isExpired(record), nil
+}
+
+func (p *PasswordPolicy) setPassword(ctx context.Context, userID, newPassword string, setByAdmin bool, userInputs ...string) error {
+	if err := p.checkStore(); err != nil {
+		return err
+	}
+
+	if err := p.Evaluate(newPassword, userInputs...).Err(); err != nil {
+		return err
+	}
+
+	record, err := p.store.Load(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var history []string
+	if record != nil {
+		history = append([]string{record.EncodedPassword}, record.History...)
+	}
+
+	// The current password is rejected even without a history, otherwise a forced change could keep it.
+	reused := max(p.config.HistorySize, 1)
+	for _, previous := range history[:min(len(history), reused)] {
+		if p.encoder.Matches(newPassword, previous) {
+			return newPasswordPolicyError(PasswordViolation{
+				Rule:    PasswordRuleHistory,
+				Message: i18n.T(PasswordRuleHistory, map[string]any{"count": reused}),
+			})
+		}
+	}
+
+	history = history[:min(len(history), p.config.HistorySize)]
+
+	encoded, err := p.encoder.Encode(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to encode password: %w", err)
+	}
+
+	if err := p.store.Save(ctx, userID, &PasswordRecord{
+		EncodedPassword: encoded,
+		ChangedAt:       timex.Now(),
+		SetByAdmin:      setByAdmin,
+		History:         history,
+	}); err != nil {
+		return err
+	}
+
+	logger.Infof("Password of user %q changed (set by admin: %t)", userID, setByAdmin)
+
+	return nil
+}
+
+func (p *PasswordPolicy) isExpired(record *PasswordRecord) bool {
+	return p.config.MaxAge > 0 && time.Since(record.ChangedAt.Unwrap()) >= p.config.MaxAge
+}
+
+// isDictionaryWord reports whether the password is a dictionary word, ignoring case, leet substitutions
+// and the digits and symbols people append to satisfy character class rules, as in "Password1!".
+func (p *PasswordPolicy) isDictionaryWord(plaintext string) bool {
+	lower := strings.ToLower(plaintext)
+	trimmed := strings.TrimRightFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) })
+
+	for _, candidate := range []string{lower, trimmed} {
+		if len([]rune(candidate)) >= minPatternWordLength && isWord(candidate, p.dictionary, nil) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *PasswordPolicy) checkStore() error {
+	if p.store == nil {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessagePasswordStoreNotImplemented))
+	}
+
+	return nil
+}
+
+// NewPasswordPolicyChallengeProvider creates a forced password change challenge backed by the password policy,
+// asking users to change passwords that expired or were set by an administrator before they get their tokens.
+// Register it via vef.ProvideChallengeProvider.
+func NewPasswordPolicyChallengeProvider(policy *PasswordPolicy) ChallengeProvider {
+	return NewPasswordChangeChallengeProvider(policy, policy)
+}
+
+func newPasswordPolicyError(violation PasswordViolation) error {
+	return result.Err(
+		violation.Message,
+		result.WithCode(result.ErrCodePasswordPolicyViolated),
+		result.WithStatus(fiber.StatusBadRequest),
+	)
+}
+
+// isSimilarToUserInputs reports whether the password contains a user input, is contained in one,
+// or is a few edits away from one, ignoring case.
+func isSimilarToUserInputs(plaintext string, userInputs []string) bool {
+	lower := strings.ToLower(plaintext)
+
+	for _, input := range normalizeUserInputs(userInputs) {
+		if strings.Contains(lower, input) || strings.Contains(input, lower) ||
+			levenshtein(lower, input) <= maxUserInputDistance {
+			return true
+		}
+	}
+
+	return false
+}
+
+func charClasses(plaintext string) (upper, lower, digit, symbol bool) {
+	for _, r := range plaintext {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	return upper, lower, digit, symbol
+}
+
+func countTrue(values ...bool) int {
+	var count int
+	for _, value := range values {
+		if value {
+			count++
+		}
+	}
+
+	return count
+}
+
+// levenshtein returns the edit distance of two strings in runes.
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(target)]
+}
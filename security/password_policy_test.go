@@ -0,0 +1,288 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/password"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// testPasswordStore is an in-memory PasswordStore.
+type testPasswordStore struct {
+	records map[string]PasswordRecord
+}
+
+func (s *testPasswordStore) Load(_ context.Context, userID string) (*PasswordRecord, error) {
+	record, ok := s.records[userID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &record, nil
+}
+
+func (s *testPasswordStore) Save(_ context.Context, userID string, record *PasswordRecord) error {
+	s.records[userID] = *record
+
+	return nil
+}
+
+type PasswordPolicyTestSuite struct {
+	suite.Suite
+
+	ctx       context.Context
+	encoder   password.Encoder
+	store     *testPasswordStore
+	principal *Principal
+}
+
+func (s *PasswordPolicyTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.encoder = password.NewSha256Encoder()
+	s.store = &testPasswordStore{records: make(map[string]PasswordRecord)}
+	s.principal = NewUser("alice", "Alice Smith")
+}
+
+func (s *PasswordPolicyTestSuite) newPolicy(config PasswordPolicyConfig) *PasswordPolicy {
+	return NewPasswordPolicy(&config, s.encoder, s.store)
+}
+
+func (s *PasswordPolicyTestSuite) setPassword(userID, plaintext string, changedAt time.Time, setByAdmin bool) {
+	encoded, err := s.encoder.Encode(plaintext)
+	s.Require().NoError(err, "Should encode password")
+
+	s.store.records[userID] = PasswordRecord{EncodedPassword: encoded, ChangedAt: timex.Of(changedAt), SetByAdmin: setByAdmin}
+}
+
+func (s *PasswordPolicyTestSuite) rules(evaluation *PasswordEvaluation) []string {
+	rules := make([]string, 0, len(evaluation.Violations))
+	for _, violation := range evaluation.Violations {
+		s.NotEmpty(violation.Message, "Should describe violation %q", violation.Rule)
+		rules = append(rules, violation.Rule)
+	}
+
+	return rules
+}
+
+// TestDefaults verifies the default length limits.
+func (s *PasswordPolicyTestSuite) TestDefaults() {
+	policy := s.newPolicy(PasswordPolicyConfig{})
+
+	s.Equal(PasswordPolicyDefaultMinLength, policy.Config().MinLength, "Should default the minimum length")
+	s.Equal(PasswordPolicyDefaultMaxLength, policy.Config().MaxLength, "Should default the maximum length")
+}
+
+// TestEvaluate verifies each stateless rule.
+func (s *PasswordPolicyTestSuite) TestEvaluate() {
+	s.Run("Length", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{MinLength: 10, MaxLength: 12})
+
+		s.Contains(s.rules(policy.Evaluate("Xk9#mQ2v")), PasswordRuleMinLength, "Should reject short passwords")
+		s.Contains(s.rules(policy.Evaluate("Xk9#mQ2vLp7$Wz")), PasswordRuleMaxLength, "Should reject long passwords")
+		s.Empty(s.rules(policy.Evaluate("Xk9#mQ2vLp7")), "Should accept passwords within the limits")
+	})
+
+	s.Run("CharacterClasses", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true})
+
+		s.ElementsMatch(
+			[]string{PasswordRuleUpper, PasswordRuleDigit, PasswordRuleSymbol},
+			s.rules(policy.Evaluate("kvmqzxwt")),
+			"Should report every missing class",
+		)
+		s.Empty(s.rules(policy.Evaluate("kvM9#qzx")), "Should accept a password with every class")
+	})
+
+	s.Run("MinCharClasses", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{MinCharClasses: 3})
+
+		s.Contains(s.rules(policy.Evaluate("kvmqzx98")), PasswordRuleCharClasses, "Should reject two classes")
+		s.NotContains(s.rules(policy.Evaluate("kvmQzx98")), PasswordRuleCharClasses, "Should accept three classes")
+	})
+
+	s.Run("UserInfo", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{})
+
+		s.Contains(s.rules(policy.Evaluate("xAlice2024x", "alice")), PasswordRuleUserInfo, "Should reject a password containing the username")
+		s.Contains(s.rules(policy.Evaluate("alicesmyth", "Alice Smith", "alice")), PasswordRuleUserInfo, "Should reject a variant of the name")
+		s.NotContains(s.rules(policy.Evaluate("Xk9#mQ2vLp", "alice")), PasswordRuleUserInfo, "Should accept an unrelated password")
+	})
+
+	s.Run("Dictionary", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{Dictionary: []string{"Acmecorp"}})
+
+		for _, common := range []string{"password", "Password1!", "P@ssw0rd", "qwertyuiop", "acmecorp2024"} {
+			s.Contains(s.rules(policy.Evaluate(common)), PasswordRuleDictionary, "Should reject common password %q", common)
+		}
+
+		s.NotContains(s.rules(policy.Evaluate("Xk9#mQ2vLp")), PasswordRuleDictionary, "Should accept an uncommon password")
+	})
+
+	s.Run("Entropy", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{MinEntropy: 40})
+
+		evaluation := policy.Evaluate("abcdefgh12345678")
+		s.Contains(s.rules(evaluation), PasswordRuleEntropy, "Should reject a guessable password")
+		s.Less(evaluation.Entropy, 40.0, "Should report the estimated entropy")
+
+		s.NotContains(s.rules(policy.Evaluate("Xk9#mQ2vLp")), PasswordRuleEntropy, "Should accept a random password")
+	})
+
+	s.Run("Err", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{})
+
+		s.NoError(policy.Evaluate("Xk9#mQ2vLp").Err(), "Should not fail for a valid password")
+
+		var resultErr result.Error
+		s.Require().True(errors.As(policy.Evaluate("short").Err(), &resultErr), "Should fail with a result error")
+		s.Equal(result.ErrCodePasswordPolicyViolated, resultErr.Code, "Should use the policy violation code")
+	})
+}
+
+// TestChangePassword verifies persistence and the history rule.
+func (s *PasswordPolicyTestSuite) TestChangePassword() {
+	s.Run("Persists", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{})
+		s.setPassword("alice", "Old#Pass9x", time.Now().Add(-time.Hour), true)
+
+		s.Require().NoError(policy.ChangePassword(s.ctx, s.principal, "Xk9#mQ2vLp"), "Should change the password")
+
+		record := s.store.records["alice"]
+		s.True(s.encoder.Matches("Xk9#mQ2vLp", record.EncodedPassword), "Should store the encoded new password")
+		s.False(record.SetByAdmin, "Should clear the admin flag")
+		s.WithinDuration(time.Now(), record.ChangedAt.Unwrap(), time.Minute, "Should record the change time")
+		s.Empty(record.History, "Should keep no history without a history size")
+	})
+
+	s.Run("RejectsViolations", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{})
+
+		s.Error(policy.ChangePassword(s.ctx, s.principal, "alice123"), "Should reject a password breaking the rules")
+	})
+
+	s.Run("History", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{HistorySize: 2})
+		s.setPassword("alice", "First#Pass1", time.Now(), false)
+
+		s.Require().NoError(policy.ChangePassword(s.ctx, s.principal, "Second#Pass2"), "Should change the password")
+		s.Error(policy.ChangePassword(s.ctx, s.principal, "Second#Pass2"), "Should reject the current password")
+		s.Error(policy.ChangePassword(s.ctx, s.principal, "First#Pass1"), "Should reject a recent password")
+
+		s.Require().NoError(policy.ChangePassword(s.ctx, s.principal, "Third#Pass3"), "Should change the password")
+		s.Len(s.store.records["alice"].History, 2, "Should keep the history size")
+		s.NoError(policy.ChangePassword(s.ctx, s.principal, "First#Pass1"), "Should accept a password older than the history")
+	})
+
+	s.Run("CurrentPasswordWithoutHistory", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{})
+		s.setPassword("alice", "Old#Pass9x", time.Now(), true)
+
+		s.Error(policy.ChangePassword(s.ctx, s.principal, "Old#Pass9x"), "Should reject the current password without a history size")
+		s.True(s.store.records["alice"].SetByAdmin, "Should keep the forced change pending")
+	})
+
+	s.Run("WithoutStore", func() {
+		policy := NewPasswordPolicy(&PasswordPolicyConfig{}, s.encoder, nil)
+
+		s.Error(policy.ChangePassword(s.ctx, s.principal, "Xk9#mQ2vLp"), "Should fail without a store")
+	})
+}
+
+// TestChangeOwnPassword verifies the current password check.
+func (s *PasswordPolicyTestSuite) TestChangeOwnPassword() {
+	policy := s.newPolicy(PasswordPolicyConfig{})
+	s.setPassword("alice", "Old#Pass9x", time.Now(), false)
+
+	err := policy.ChangeOwnPassword(s.ctx, s.principal, "wrong", "Xk9#mQ2vLp")
+	s.ErrorIs(err, result.ErrCurrentPasswordInvalid, "Should reject a wrong current password")
+
+	s.NoError(policy.ChangeOwnPassword(s.ctx, s.principal, "Old#Pass9x", "Xk9#mQ2vLp"), "Should change with the current password")
+}
+
+// TestResetPassword verifies that reset passwords must be changed at the next login.
+func (s *PasswordPolicyTestSuite) TestResetPassword() {
+	policy := s.newPolicy(PasswordPolicyConfig{})
+
+	s.Error(policy.ResetPassword(s.ctx, "alice", "alice2024!"), "Should apply the rules with the user ID as user input")
+	s.Require().NoError(policy.ResetPassword(s.ctx, "alice", "Xk9#mQ2vLp"), "Should reset the password")
+	s.True(s.store.records["alice"].SetByAdmin, "Should mark the password as set by an admin")
+
+	data, err := policy.Check(s.ctx, s.principal)
+	s.Require().NoError(err, "Should check the password")
+	s.Require().NotNil(data, "Should require a change")
+	s.Equal(PasswordChangeReasonAdminReset, data.Reason, "Should report the admin reset")
+}
+
+// TestCheck verifies the forced change of expired passwords.
+func (s *PasswordPolicyTestSuite) TestCheck() {
+	s.Run("Expired", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{MaxAge: 24 * time.Hour})
+		s.setPassword("alice", "Xk9#mQ2vLp", time.Now().Add(-48*time.Hour), false)
+
+		data, err := policy.Check(s.ctx, s.principal)
+		s.Require().NoError(err, "Should check the password")
+		s.Require().NotNil(data, "Should require a change")
+		s.Equal(PasswordChangeReasonExpired, data.Reason, "Should report the expiry")
+
+		expired, err := policy.IsExpired(s.ctx, "alice")
+		s.Require().NoError(err, "Should check the expiry")
+		s.True(expired, "Should report the password as expired")
+	})
+
+	s.Run("Fresh", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{MaxAge: 24 * time.Hour})
+		s.setPassword("alice", "Xk9#mQ2vLp", time.Now().Add(-time.Hour), false)
+
+		data, err := policy.Check(s.ctx, s.principal)
+		s.Require().NoError(err, "Should check the password")
+		s.Nil(data, "Should not require a change")
+	})
+
+	s.Run("NoMaxAge", func() {
+		policy := s.newPolicy(PasswordPolicyConfig{})
+		s.setPassword("alice", "Xk9#mQ2vLp", time.Now().Add(-10*365*24*time.Hour), false)
+
+		data, err := policy.Check(s.ctx, s.principal)
+		s.Require().NoError(err, "Should check the password")
+		s.Nil(data, "Should never expire passwords without a maximum age")
+	})
+
+	s.Run("NoPassword", func() {
+		data, err := s.newPolicy(PasswordPolicyConfig{MaxAge: time.Hour}).Check(s.ctx, NewUser("bob", "Bob"))
+		s.Require().NoError(err, "Should check the password")
+		s.Nil(data, "Should not require a change without a password")
+	})
+}
+
+// TestChallengeProvider verifies the forced change challenge runs on the policy.
+func (s *PasswordPolicyTestSuite) TestChallengeProvider() {
+	policy := s.newPolicy(PasswordPolicyConfig{})
+	s.setPassword("alice", "Old#Pass9x", time.Now(), true)
+
+	provider := NewPasswordPolicyChallengeProvider(policy)
+	s.Equal(ChallengeTypePasswordChange, provider.Type(), "Should be a password change challenge")
+
+	challenge, err := provider.Evaluate(s.ctx, s.principal)
+	s.Require().NoError(err, "Should evaluate the challenge")
+	s.Require().NotNil(challenge, "Should challenge a user with an admin-set password")
+
+	_, err = provider.Resolve(s.ctx, s.principal, "password")
+	s.Error(err, "Should reject a new password breaking the rules")
+
+	_, err = provider.Resolve(s.ctx, s.principal, "Xk9#mQ2vLp")
+	s.Require().NoError(err, "Should resolve with a valid new password")
+
+	challenge, err = provider.Evaluate(s.ctx, s.principal)
+	s.Require().NoError(err, "Should evaluate the challenge")
+	s.Nil(challenge, "Should not challenge after the change")
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	suite.Run(t, new(PasswordPolicyTestSuite))
+}
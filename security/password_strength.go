@@ -0,0 +1,230 @@
+package security
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Minimum lengths of the patterns the entropy estimator recognizes.
+const (
+	minPatternWordLength     = 3
+	minPatternRepeatLength   = 3
+	minPatternSequenceLength = 3
+	minPatternKeyboardLength = 4
+)
+
+// Character class sizes used to price characters that are not part of a pattern.
+const (
+	charsetDigits  = 10
+	charsetLetters = 26
+	charsetSymbols = 33
+)
+
+// keyboardRows are the rows of a US keyboard, whose runs people type as if they were random.
+var keyboardRows = []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}
+
+// keyboardSize is the number of keys keyboard runs can start at.
+var keyboardSize = len(strings.Join(keyboardRows, ""))
+
+// leetReplacer undoes the common character substitutions of passwords like "p@ssw0rd".
+var leetReplacer = strings.NewReplacer(
+	"0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "8", "b", "@", "a", "$", "s", "!", "i",
+)
+
+// EstimatePasswordEntropy estimates the entropy of a password in bits, the log2 of the guesses an attacker needs.
+// Like zxcvbn, it prices the password as a sequence of patterns attackers try first: words of the dictionary
+// and the user inputs (also with leet substitutions), repeated characters, alphabetical or numerical sequences,
+// and keyboard runs. Characters outside any pattern are priced by the size of their character class.
+func EstimatePasswordEntropy(password string, dictionary map[string]struct{}, userInputs ...string) float64 {
+	var (
+		runes   = []rune(password)
+		lower   = make([]rune, len(runes))
+		inputs  = normalizeUserInputs(userInputs)
+		entropy float64
+	)
+
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	for i := 0; i < len(runes); {
+		length, bits := matchPattern(lower, i, dictionary, inputs)
+		if length == 0 {
+			length, bits = 1, math.Log2(float64(charsetSize(runes[i])))
+		} else if hasUpper(runes[i : i+length]) {
+			// Capitalized or mixed-case patterns take a few more guesses, but far fewer than random case.
+			bits++
+		}
+
+		entropy += bits
+		i += length
+	}
+
+	return entropy
+}
+
+// matchPattern finds the longest pattern starting at i and returns its length and entropy, or zero when none matches.
+func matchPattern(password []rune, i int, dictionary map[string]struct{}, inputs []string) (int, float64) {
+	var (
+		bestLength int
+		bestBits   float64
+	)
+
+	consider := func(length int, bits float64) {
+		// Longer patterns win; among equally long ones the cheapest is what an attacker would guess.
+		if length > bestLength || (length == bestLength && bits < bestBits) {
+			bestLength, bestBits = length, bits
+		}
+	}
+
+	if length := matchWord(password, i, dictionary, inputs); length > 0 {
+		// A word costs about as much as picking it from a large dictionary; user inputs are known to the attacker.
+		consider(length, math.Log2(float64(max(len(dictionary), 1)))+1)
+	}
+
+	if length := matchRepeat(password, i); length > 0 {
+		consider(length, math.Log2(float64(charsetSize(password[i])))+math.Log2(float64(length)))
+	}
+
+	if length := matchSequence(password, i); length > 0 {
+		consider(length, math.Log2(float64(charsetSize(password[i])))+math.Log2(float64(length))+1)
+	}
+
+	if length := matchKeyboard(password, i); length > 0 {
+		consider(length, math.Log2(float64(keyboardSize))+math.Log2(float64(length))+1)
+	}
+
+	return bestLength, bestBits
+}
+
+// matchWord returns the length of the longest dictionary word or user input starting at i, ignoring leet substitutions.
+func matchWord(password []rune, i int, dictionary map[string]struct{}, inputs []string) int {
+	for end := len(password); end-i >= minPatternWordLength; end-- {
+		if isWord(string(password[i:end]), dictionary, inputs) {
+			return end - i
+		}
+	}
+
+	return 0
+}
+
+// isWord reports whether the lowercase candidate, as typed or with leet substitutions undone,
+// is a dictionary word or a user input.
+func isWord(candidate string, dictionary map[string]struct{}, inputs []string) bool {
+	for _, variant := range []string{candidate, leetReplacer.Replace(candidate)} {
+		if _, ok := dictionary[variant]; ok || slices.Contains(inputs, variant) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchRepeat(password []rune, i int) int {
+	end := i + 1
+	for end < len(password) && password[end] == password[i] {
+		end++
+	}
+
+	return patternLength(end-i, minPatternRepeatLength)
+}
+
+func matchSequence(password []rune, i int) int {
+	if i+1 >= len(password) || !isSequenceChar(password[i]) {
+		return 0
+	}
+
+	step := password[i+1] - password[i]
+	if step != 1 && step != -1 {
+		return 0
+	}
+
+	end := i + 1
+	for end < len(password) && password[end]-password[end-1] == step && isSequenceChar(password[end]) &&
+		unicode.IsDigit(password[end]) == unicode.IsDigit(password[i]) {
+		end++
+	}
+
+	return patternLength(end-i, minPatternSequenceLength)
+}
+
+func matchKeyboard(password []rune, i int) int {
+	var longest int
+
+	for _, row := range keyboardRows {
+		for _, keys := range []string{row, reverse(row)} {
+			start := strings.IndexRune(keys, password[i])
+			if start < 0 {
+				continue
+			}
+
+			length := 1
+			for i+length < len(password) && start+length < len(keys) && rune(keys[start+length]) == password[i+length] {
+				length++
+			}
+
+			longest = max(longest, length)
+		}
+	}
+
+	return patternLength(longest, minPatternKeyboardLength)
+}
+
+func patternLength(length, minLength int) int {
+	if length < minLength {
+		return 0
+	}
+
+	return length
+}
+
+func isSequenceChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')
+}
+
+func charsetSize(r rune) int {
+	switch {
+	case unicode.IsDigit(r):
+		return charsetDigits
+	case unicode.IsLetter(r) && r < unicode.MaxASCII:
+		return charsetLetters
+	case r < unicode.MaxASCII:
+		return charsetSymbols
+	default:
+		// Characters outside ASCII come from far larger alphabets.
+		return 1 << 10
+	}
+}
+
+func hasUpper(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func reverse(value string) string {
+	runes := []rune(value)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+
+	return string(runes)
+}
+
+// normalizeUserInputs lowercases the user inputs and drops those too short to be recognized as words.
+func normalizeUserInputs(userInputs []string) []string {
+	inputs := make([]string, 0, len(userInputs))
+	for _, input := range userInputs {
+		if input = strings.ToLower(strings.TrimSpace(input)); len([]rune(input)) >= minPatternWordLength {
+			inputs = append(inputs, input)
+		}
+	}
+
+	return inputs
+}
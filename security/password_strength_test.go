@@ -0,0 +1,47 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEstimatePasswordEntropy tests that patterns are priced far below random characters.
+func TestEstimatePasswordEntropy(t *testing.T) {
+	dictionary := newPasswordDictionary(nil)
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Zero(t, EstimatePasswordEntropy("", dictionary), "Should estimate no entropy for an empty password")
+	})
+
+	t.Run("RandomCharacters", func(t *testing.T) {
+		entropy := EstimatePasswordEntropy("k9#Tq2!vZ", dictionary)
+		assert.Greater(t, entropy, 40.0, "Should price random characters by their character class")
+	})
+
+	t.Run("Patterns", func(t *testing.T) {
+		random := EstimatePasswordEntropy("k9#Tq2!vZw", dictionary)
+
+		for _, weak := range []string{"aaaaaaaaaa", "abcdefghij", "9876543210", "qwertyuiop", "password12", "P@ssw0rd12"} {
+			assert.Less(t, EstimatePasswordEntropy(weak, dictionary), random/2, "Should price %q as a pattern", weak)
+		}
+	})
+
+	t.Run("Capitalization", func(t *testing.T) {
+		assert.Greater(t,
+			EstimatePasswordEntropy("Password", dictionary),
+			EstimatePasswordEntropy("password", dictionary),
+			"Should add a little entropy for capitalized words",
+		)
+	})
+
+	t.Run("UserInputs", func(t *testing.T) {
+		without := EstimatePasswordEntropy("johnathan", dictionary)
+		with := EstimatePasswordEntropy("johnathan", dictionary, "Johnathan")
+		assert.Less(t, with, without, "Should price user inputs as known words")
+	})
+
+	t.Run("NonASCII", func(t *testing.T) {
+		assert.Greater(t, EstimatePasswordEntropy("密码安全", dictionary), 30.0, "Should price characters of large alphabets highly")
+	})
+}
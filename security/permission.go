@@ -34,6 +34,24 @@ type DataScope interface {
 	Apply(principal *Principal, query orm.SelectQuery) error
 }
 
+// ConditionDataScope is a DataScope whose restrictions are plain WHERE conditions,
+// so that it can also filter UPDATE and DELETE statements.
+// Scopes that do not implement it only restrict the records loaded before a write.
+type ConditionDataScope interface {
+	DataScope
+	// ApplyConditions adds the data scope restrictions to the WHERE conditions of a query.
+	ApplyConditions(principal *Principal, cb orm.ConditionBuilder) error
+}
+
+// BindableDataScope is a DataScope that needs per-request data before it can filter queries,
+// such as the department subtree of the principal.
+// The data permission middleware binds the resolved scope to the principal of each request.
+type BindableDataScope interface {
+	DataScope
+	// Bind returns the DataScope applied to the requests of the principal.
+	Bind(ctx context.Context, principal *Principal) (DataScope, error)
+}
+
 // DataPermissionResolver determines the applicable DataScope for a permission.
 // Used to translate permission tokens into concrete data filtering rules.
 type DataPermissionResolver interface {
@@ -46,4 +64,8 @@ type DataPermissionResolver interface {
 type DataPermissionApplier interface {
 	// Apply adds data permission filters to the query based on the current context.
 	Apply(query orm.SelectQuery) error
+	// ApplyUpdate adds data permission filters to an UPDATE query, so rows outside the scope are left untouched.
+	ApplyUpdate(query orm.UpdateQuery) error
+	// ApplyDelete adds data permission filters to a DELETE query, so rows outside the scope are left untouched.
+	ApplyDelete(query orm.DeleteQuery) error
 }
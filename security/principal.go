@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 
 	"github.com/coldsmirk/vef-framework-go/mapx"
 	"github.com/coldsmirk/vef-framework-go/orm"
@@ -62,6 +63,15 @@ type Principal struct {
 	Name string `json:"name"`
 	// Roles is the roles of the user.
 	Roles []string `json:"roles"`
+	// TenantID is the tenant the user belongs to, empty when tenancy is not used.
+	TenantID string `json:"tenantId,omitempty"`
+	// DepartmentID is the department the user acts for, used by the department data scopes.
+	DepartmentID string `json:"departmentId,omitempty"`
+	// SessionID is the server-side session the principal authenticated with, empty outside token authentication.
+	SessionID string `json:"sessionId,omitempty"`
+	// Scopes limits the permissions of the principal to the listed permission tokens, set when it authenticated
+	// with an API key. Nil places no limit beyond the roles, while an empty list grants nothing.
+	Scopes []string `json:"scopes,omitzero"`
 	// Details is the details of the user.
 	Details any `json:"details"`
 }
@@ -162,6 +172,25 @@ func (p *Principal) WithRoles(roles ...string) *Principal {
 	return p
 }
 
+// WithTenantID sets the tenant of the principal.
+func (p *Principal) WithTenantID(tenantID string) *Principal {
+	p.TenantID = tenantID
+
+	return p
+}
+
+// WithDepartmentID sets the department of the principal.
+func (p *Principal) WithDepartmentID(departmentID string) *Principal {
+	p.DepartmentID = departmentID
+
+	return p
+}
+
+// InScope reports whether the scopes of the principal cover the permission token.
+func (p *Principal) InScope(permToken string) bool {
+	return p.Scopes == nil || slices.Contains(p.Scopes, permToken)
+}
+
 // NewUser is the function to create a new user principal.
 func NewUser(id, name string, roles ...string) *Principal {
 	return &Principal{
@@ -77,6 +77,38 @@ func TestPrincipalWithRoles(t *testing.T) {
 	})
 }
 
+// TestPrincipalInScope tests the scope limits of principals.
+func TestPrincipalInScope(t *testing.T) {
+	t.Run("Unscoped", func(t *testing.T) {
+		assert.True(t, NewUser("user123", "Test User").InScope("sys:user:query"), "Should not limit principals without scopes")
+	})
+
+	t.Run("Scoped", func(t *testing.T) {
+		user := NewUser("user123", "Test User")
+		user.Scopes = []string{"sys:user:query"}
+		assert.True(t, user.InScope("sys:user:query"), "Should allow a listed permission token")
+		assert.False(t, user.InScope("sys:user:delete"), "Should refuse an unlisted permission token")
+	})
+
+	t.Run("EmptyScopes", func(t *testing.T) {
+		user := NewUser("user123", "Test User")
+		user.Scopes = []string{}
+		assert.False(t, user.InScope("sys:user:query"), "Should grant nothing with an empty scope list")
+	})
+
+	t.Run("EmptyScopesSurviveJSON", func(t *testing.T) {
+		user := NewUser("user123", "Test User")
+		user.Scopes = []string{}
+
+		data, err := json.Marshal(user)
+		require.NoError(t, err, "Should marshal principal")
+
+		var decoded Principal
+		require.NoError(t, json.Unmarshal(data, &decoded), "Should unmarshal principal")
+		assert.NotNil(t, decoded.Scopes, "Should keep an empty scope list distinct from no scopes")
+	})
+}
+
 // TestPrincipalSystem tests principal system functionality.
 func TestPrincipalSystem(t *testing.T) {
 	t.Run("SystemPrincipalHasCorrectValues", func(t *testing.T) {
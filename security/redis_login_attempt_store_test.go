@@ -0,0 +1,109 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+)
+
+type RedisLoginAttemptStoreTestSuite struct {
+	suite.Suite
+
+	container *testx.RedisContainer
+	client    *redis.Client
+	store     LoginAttemptStore
+}
+
+func (s *RedisLoginAttemptStoreTestSuite) SetupSuite() {
+	ctx := context.Background()
+	s.container = testx.NewRedisContainer(ctx, s.T())
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: fmt.Sprintf("%s:%d", s.container.Redis.Host, s.container.Redis.Port),
+		DB:   int(s.container.Redis.Database),
+	})
+
+	err := s.client.Ping(ctx).Err()
+	s.Require().NoError(err, "Should connect to Redis")
+
+	s.store = NewRedisLoginAttemptStore(s.client)
+}
+
+func (s *RedisLoginAttemptStoreTestSuite) TearDownSuite() {
+	if s.client != nil {
+		s.client.Close()
+	}
+}
+
+func (s *RedisLoginAttemptStoreTestSuite) SetupTest() {
+	s.client.FlushDB(context.Background())
+}
+
+// TestAddFailure tests that failures are counted in a window opened by the first failure.
+func (s *RedisLoginAttemptStoreTestSuite) TestAddFailure() {
+	ctx := context.Background()
+
+	for want := 1; want <= 3; want++ {
+		failures, err := s.store.AddFailure(ctx, "user:alice", time.Minute)
+		s.Require().NoError(err, "Should add failure")
+		s.Equal(want, failures, "Failures should be counted")
+	}
+
+	attempts, err := s.store.Get(ctx, "user:alice")
+	s.Require().NoError(err, "Should get attempts")
+	s.Equal(3, attempts.Failures, "Get should report the failures")
+
+	ttl := s.client.TTL(ctx, redisLoginFailuresPrefix+"user:alice").Val()
+	s.True(ttl > 0 && ttl <= time.Minute, "Failures should expire with the window")
+}
+
+// TestLockout tests that lockouts are counted, clear failures and lock until the given time.
+func (s *RedisLoginAttemptStoreTestSuite) TestLockout() {
+	ctx := context.Background()
+
+	_, err := s.store.AddFailure(ctx, "user:alice", time.Minute)
+	s.Require().NoError(err, "Should add failure")
+
+	lockouts, err := s.store.AddLockout(ctx, "user:alice", time.Hour)
+	s.Require().NoError(err, "Should add lockout")
+	s.Equal(1, lockouts, "First lockout should be counted")
+
+	until := time.Now().Add(time.Minute)
+	s.Require().NoError(s.store.Lock(ctx, "user:alice", until), "Should lock key")
+
+	attempts, err := s.store.Get(ctx, "user:alice")
+	s.Require().NoError(err, "Should get attempts")
+	s.Zero(attempts.Failures, "Lockout should clear failures")
+	s.Equal(1, attempts.Lockouts, "Lockouts should be kept")
+	s.Require().NotNil(attempts.LockedUntil, "Locked key should report its lock end")
+	s.Equal(until.UnixMilli(), attempts.LockedUntil.Unwrap().UnixMilli(), "Lock end should be preserved")
+	s.True(attempts.IsLocked(time.Now()), "Key should be locked")
+}
+
+// TestReset tests that reset clears every record of the key.
+func (s *RedisLoginAttemptStoreTestSuite) TestReset() {
+	ctx := context.Background()
+
+	_, err := s.store.AddFailure(ctx, "ip:10.0.0.1", time.Minute)
+	s.Require().NoError(err, "Should add failure")
+	_, err = s.store.AddLockout(ctx, "ip:10.0.0.1", time.Hour)
+	s.Require().NoError(err, "Should add lockout")
+	s.Require().NoError(s.store.Lock(ctx, "ip:10.0.0.1", time.Now().Add(time.Minute)), "Should lock key")
+
+	s.Require().NoError(s.store.Reset(ctx, "ip:10.0.0.1"), "Should reset key")
+
+	attempts, err := s.store.Get(ctx, "ip:10.0.0.1")
+	s.Require().NoError(err, "Should get attempts")
+	s.Equal(&LoginAttempts{}, attempts, "Reset should clear failures, lockouts and lock")
+}
+
+// TestRedisLoginAttemptStore runs the Redis login attempt store test suite.
+func TestRedisLoginAttemptStore(t *testing.T) {
+	suite.Run(t, new(RedisLoginAttemptStoreTestSuite))
+}
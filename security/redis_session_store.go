@@ -0,0 +1,212 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisSessionPrefix     = "vef:security:session:"
+	redisUserSessionPrefix = "vef:security:user_sessions:"
+)
+
+// compareAndSwapScript replaces a session only while its stored refresh token fingerprint is unchanged.
+//
+// KEYS[1]: session key; KEYS[2]: user index key;
+// ARGV[1]: expected fingerprint; ARGV[2]: new session data; ARGV[3]: TTL in milliseconds.
+// Returns: 1 when the session was replaced, otherwise 0.
+var compareAndSwapScript = redis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if not data or cjson.decode(data).rth ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+redis.call('PEXPIRE', KEYS[2], ARGV[3], 'GT')
+return 1
+`)
+
+// redisSession is the stored form of a Session, including the refresh token fingerprint hidden from JSON responses.
+type redisSession struct {
+	*Session
+
+	RefreshTokenHash string `json:"rth"`
+}
+
+// RedisSessionStore implements SessionStore using Redis for distributed deployments.
+// Each session is stored under its own key with a TTL; a per-user set indexes the session IDs of a user.
+type RedisSessionStore struct {
+	client *redis.Client
+}
+
+// NewRedisSessionStore creates a new Redis-backed session store.
+func NewRedisSessionStore(client *redis.Client) SessionStore {
+	return &RedisSessionStore{client: client}
+}
+
+func (*RedisSessionStore) sessionKey(id string) string {
+	return redisSessionPrefix + id
+}
+
+func (*RedisSessionStore) userKey(userID string) string {
+	return redisUserSessionPrefix + userID
+}
+
+func (s *RedisSessionStore) Save(ctx context.Context, session *Session) error {
+	ttl := time.Until(session.ExpiresAt.Unwrap())
+	if ttl <= 0 {
+		return s.Delete(ctx, session.ID)
+	}
+
+	data, err := json.Marshal(redisSession{Session: session, RefreshTokenHash: session.RefreshTokenHash})
+	if err != nil {
+		return err
+	}
+
+	userKey := s.userKey(session.UserID)
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, s.sessionKey(session.ID), data, ttl)
+	pipe.SAdd(ctx, userKey, session.ID)
+	// The index lives as long as the longest session of the user; stale members are pruned on listing.
+	pipe.ExpireGT(ctx, userKey, ttl)
+	pipe.ExpireNX(ctx, userKey, ttl)
+	_, err = pipe.Exec(ctx)
+
+	return err
+}
+
+func (s *RedisSessionStore) CompareAndSwap(ctx context.Context, session *Session, oldRefreshTokenHash string) (bool, error) {
+	ttl := time.Until(session.ExpiresAt.Unwrap())
+	if ttl <= 0 {
+		return false, nil
+	}
+
+	data, err := json.Marshal(redisSession{Session: session, RefreshTokenHash: session.RefreshTokenHash})
+	if err != nil {
+		return false, err
+	}
+
+	swapped, err := compareAndSwapScript.Run(
+		ctx,
+		s.client,
+		[]string{s.sessionKey(session.ID), s.userKey(session.UserID)},
+		oldRefreshTokenHash,
+		data,
+		ttl.Milliseconds(),
+	).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return swapped == 1, nil
+}
+
+func (s *RedisSessionStore) Get(ctx context.Context, id string) (*Session, error) {
+	data, err := s.client.Get(ctx, s.sessionKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return decodeRedisSession(data)
+}
+
+func (s *RedisSessionStore) Delete(ctx context.Context, id string) error {
+	session, err := s.Get(ctx, id)
+	if err != nil || session == nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, s.sessionKey(id))
+	pipe.SRem(ctx, s.userKey(session.UserID), id)
+	_, err = pipe.Exec(ctx)
+
+	return err
+}
+
+func (s *RedisSessionStore) DeleteByUser(ctx context.Context, userID string) error {
+	userKey := s.userKey(userID)
+
+	ids, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, s.sessionKey(id))
+	}
+
+	keys = append(keys, userKey)
+
+	return s.client.Del(ctx, keys...).Err()
+}
+
+func (s *RedisSessionStore) ListByUser(ctx context.Context, userID string) ([]*Session, error) {
+	userKey := s.userKey(userID)
+
+	ids, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = s.sessionKey(id)
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sessions []*Session
+		expired  []any
+	)
+
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+
+			continue
+		}
+
+		session, err := decodeRedisSession([]byte(data))
+		if err != nil {
+			return nil, err
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if len(expired) > 0 {
+		if err := s.client.SRem(ctx, userKey, expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sortSessions(sessions)
+
+	return sessions, nil
+}
+
+func decodeRedisSession(data []byte) (*Session, error) {
+	stored := redisSession{Session: new(Session)}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+
+	stored.Session.RefreshTokenHash = stored.RefreshTokenHash
+
+	return stored.Session, nil
+}
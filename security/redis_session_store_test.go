@@ -0,0 +1,146 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+type RedisSessionStoreTestSuite struct {
+	suite.Suite
+
+	container *testx.RedisContainer
+	client    *redis.Client
+	store     SessionStore
+}
+
+func (s *RedisSessionStoreTestSuite) SetupSuite() {
+	ctx := context.Background()
+	s.container = testx.NewRedisContainer(ctx, s.T())
+
+	s.client = redis.NewClient(&redis.Options{
+		Addr: fmt.Sprintf("%s:%d", s.container.Redis.Host, s.container.Redis.Port),
+		DB:   int(s.container.Redis.Database),
+	})
+
+	err := s.client.Ping(ctx).Err()
+	s.Require().NoError(err, "Should connect to Redis")
+
+	s.store = NewRedisSessionStore(s.client)
+}
+
+func (s *RedisSessionStoreTestSuite) TearDownSuite() {
+	if s.client != nil {
+		s.client.Close()
+	}
+}
+
+func (s *RedisSessionStoreTestSuite) SetupTest() {
+	s.client.FlushDB(context.Background())
+}
+
+// TestSaveAndGet tests that sessions round-trip including the refresh token fingerprint.
+func (s *RedisSessionStoreTestSuite) TestSaveAndGet() {
+	ctx := context.Background()
+	s.Require().NoError(s.store.Save(ctx, newTestSession("s1", "user1", timex.Now())), "Should save session")
+
+	session, err := s.store.Get(ctx, "s1")
+	s.Require().NoError(err, "Should get session")
+	s.Require().NotNil(session, "Session should exist")
+	s.Equal("user1", session.UserID, "User ID should be preserved")
+	s.True(session.MatchesRefreshToken("refresh-s1"), "Refresh token fingerprint should be persisted")
+
+	ttl := s.client.TTL(ctx, redisSessionPrefix+"s1").Val()
+	s.True(ttl > 0 && ttl <= time.Hour, "Session key should expire with the session")
+
+	s.Run("Missing", func() {
+		session, err := s.store.Get(ctx, "missing")
+		s.Require().NoError(err, "Missing session should not be an error")
+		s.Nil(session, "Missing session should be nil")
+	})
+}
+
+// TestDelete tests that deleting a session removes it from the user index.
+func (s *RedisSessionStoreTestSuite) TestDelete() {
+	ctx := context.Background()
+	s.Require().NoError(s.store.Save(ctx, newTestSession("s1", "user1", timex.Now())), "Should save session")
+	s.Require().NoError(s.store.Delete(ctx, "s1"), "Should delete session")
+
+	session, err := s.store.Get(ctx, "s1")
+	s.Require().NoError(err, "Should get session")
+	s.Nil(session, "Deleted session should not exist")
+	s.Zero(s.client.SCard(ctx, redisUserSessionPrefix+"user1").Val(), "Deleted session should leave the user index")
+
+	s.Run("Missing", func() {
+		s.NoError(s.store.Delete(ctx, "missing"), "Deleting a missing session should succeed")
+	})
+}
+
+// TestCompareAndSwap tests that a session is only replaced while its refresh token fingerprint is unchanged.
+func (s *RedisSessionStoreTestSuite) TestCompareAndSwap() {
+	ctx := context.Background()
+	session := newTestSession("s1", "user1", timex.Now())
+	s.Require().NoError(s.store.Save(ctx, session), "Should save session")
+
+	exchanged := session.RefreshTokenHash
+	session.SetRefreshToken("rotated")
+
+	swapped, err := s.store.CompareAndSwap(ctx, session, exchanged)
+	s.Require().NoError(err, "Should swap session")
+	s.True(swapped, "Swap with the stored fingerprint should succeed")
+
+	swapped, err = s.store.CompareAndSwap(ctx, session, exchanged)
+	s.Require().NoError(err, "Should compare session")
+	s.False(swapped, "Swap with an exchanged fingerprint should fail")
+
+	got, err := s.store.Get(ctx, "s1")
+	s.Require().NoError(err, "Should get session")
+	s.True(got.MatchesRefreshToken("rotated"), "Rotated refresh token should be stored")
+
+	s.Run("Missing", func() {
+		swapped, err := s.store.CompareAndSwap(ctx, newTestSession("missing", "user1", timex.Now()), "")
+		s.Require().NoError(err, "Should compare missing session")
+		s.False(swapped, "Swap of a missing session should fail")
+	})
+}
+
+// TestListAndDeleteByUser tests listing, stale index pruning and revoking all sessions of a user.
+func (s *RedisSessionStoreTestSuite) TestListAndDeleteByUser() {
+	ctx := context.Background()
+	now := timex.Now()
+	s.Require().NoError(s.store.Save(ctx, newTestSession("s2", "user1", now)), "Should save session")
+	s.Require().NoError(s.store.Save(ctx, newTestSession("s1", "user1", now.Add(-time.Minute))), "Should save session")
+	s.Require().NoError(s.store.Save(ctx, newTestSession("s3", "user2", now)), "Should save session")
+
+	// Simulate a session that expired while still referenced by the index.
+	s.Require().NoError(s.client.SAdd(ctx, redisUserSessionPrefix+"user1", "stale").Err(), "Should add stale member")
+
+	sessions, err := s.store.ListByUser(ctx, "user1")
+	s.Require().NoError(err, "Should list sessions")
+	s.Require().Len(sessions, 2, "Should list only live sessions of the user")
+	s.Equal("s1", sessions[0].ID, "Sessions should be ordered oldest first")
+	s.Equal("s2", sessions[1].ID, "Sessions should be ordered oldest first")
+	s.False(s.client.SIsMember(ctx, redisUserSessionPrefix+"user1", "stale").Val(), "Stale members should be pruned")
+
+	s.Require().NoError(s.store.DeleteByUser(ctx, "user1"), "Should delete sessions of the user")
+
+	sessions, err = s.store.ListByUser(ctx, "user1")
+	s.Require().NoError(err, "Should list sessions")
+	s.Empty(sessions, "All sessions of the user should be deleted")
+
+	other, err := s.store.Get(ctx, "s3")
+	s.Require().NoError(err, "Should get session")
+	s.NotNil(other, "Sessions of other users should be kept")
+}
+
+// TestRedisSessionStore runs the Redis session store test suite.
+func TestRedisSessionStore(t *testing.T) {
+	suite.Run(t, new(RedisSessionStoreTestSuite))
+}
@@ -33,6 +33,44 @@ func NewRequestScopedDataPermApplier(
 
 // Apply implements security.DataPermissionApplier.Apply.
 func (a *RequestScopedDataPermApplier) Apply(query orm.SelectQuery) error {
+	return a.apply(query, func() error {
+		return a.dataScope.Apply(a.principal, query)
+	})
+}
+
+// ApplyUpdate implements security.DataPermissionApplier.ApplyUpdate.
+func (a *RequestScopedDataPermApplier) ApplyUpdate(query orm.UpdateQuery) error {
+	return a.applyConditions(query, func(builder func(orm.ConditionBuilder)) { query.Where(builder) })
+}
+
+// ApplyDelete implements security.DataPermissionApplier.ApplyDelete.
+func (a *RequestScopedDataPermApplier) ApplyDelete(query orm.DeleteQuery) error {
+	return a.applyConditions(query, func(builder func(orm.ConditionBuilder)) { query.Where(builder) })
+}
+
+// applyConditions adds the conditions of a ConditionDataScope through where, the Where method of the query.
+// Other scopes are skipped, as they can only filter SELECT queries.
+func (a *RequestScopedDataPermApplier) applyConditions(query any, where func(func(orm.ConditionBuilder))) error {
+	scope, ok := a.dataScope.(ConditionDataScope)
+	if a.dataScope != nil && !ok {
+		a.logger.Debugf("Data scope %q does not support write statements, skipping data permission", a.dataScope.Key())
+
+		return nil
+	}
+
+	return a.apply(query, func() error {
+		var err error
+
+		where(func(cb orm.ConditionBuilder) {
+			err = scope.ApplyConditions(a.principal, cb)
+		})
+
+		return err
+	})
+}
+
+// apply runs apply when the data scope supports the table of the query.
+func (a *RequestScopedDataPermApplier) apply(query any, apply func() error) error {
 	if a.dataScope == nil {
 		a.logger.Debugf("No data scope configured, skipping data permission")
 
@@ -57,7 +95,7 @@ func (a *RequestScopedDataPermApplier) Apply(query orm.SelectQuery) error {
 		return nil
 	}
 
-	if err := a.dataScope.Apply(a.principal, query); err != nil {
+	if err := apply(); err != nil {
 		return fmt.Errorf("failed to apply data scope %q: %w", a.dataScope.Key(), err)
 	}
 
@@ -0,0 +1,90 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+
+	ilogx "github.com/coldsmirk/vef-framework-go/internal/logx"
+	iorm "github.com/coldsmirk/vef-framework-go/internal/orm"
+)
+
+type dataPermTestModel struct {
+	bun.BaseModel `bun:"table:data_perm_test_models,alias:dptm"`
+
+	ID        string `bun:"id,pk"`
+	Title     string `bun:"title"`
+	CreatedBy string `bun:"created_by"`
+}
+
+type noCreatorTestModel struct {
+	bun.BaseModel `bun:"table:no_creator_test_models,alias:nctm"`
+
+	ID string `bun:"id,pk"`
+}
+
+// selectOnlyDataScope hides ApplyConditions, like a DataScope that only filters SELECT queries.
+type selectOnlyDataScope struct {
+	DataScope
+}
+
+// TestRequestScopedDataPermApplier tests that data scopes are pushed into SELECT, UPDATE and DELETE statements.
+func TestRequestScopedDataPermApplier(t *testing.T) {
+	db := iorm.New(bun.NewDB(nil, sqlitedialect.New()))
+	principal := NewUser("u1", "Alice")
+	applier := NewRequestScopedDataPermApplier(principal, NewSelfDataScope(""), ilogx.Named("test"))
+
+	t.Run("Select", func(t *testing.T) {
+		query := db.NewSelect().Model((*dataPermTestModel)(nil))
+		require.NoError(t, applier.Apply(query), "Should apply to select")
+		assert.Contains(t, query.String(), `"created_by" = 'u1'`, "Should filter by creator")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		query := db.NewUpdate().Model(&dataPermTestModel{ID: "1"}).Set("title", "new").WherePK()
+		require.NoError(t, applier.ApplyUpdate(query), "Should apply to update")
+		assert.Contains(t, query.String(), `"created_by" = 'u1'`, "Should filter by creator")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		query := db.NewDelete().Model(&dataPermTestModel{ID: "1"}).WherePK()
+		require.NoError(t, applier.ApplyDelete(query), "Should apply to delete")
+		assert.Contains(t, query.String(), `"created_by" = 'u1'`, "Should filter by creator")
+	})
+
+	t.Run("UnsupportedTableSkipped", func(t *testing.T) {
+		query := db.NewDelete().Model(&noCreatorTestModel{ID: "1"}).WherePK()
+		require.NoError(t, applier.ApplyDelete(query), "Should skip tables the scope does not support")
+		assert.NotContains(t, query.String(), "created_by", "Should not filter")
+	})
+
+	t.Run("NoDataScope", func(t *testing.T) {
+		query := db.NewUpdate().Model(&dataPermTestModel{ID: "1"}).Set("title", "new").WherePK()
+		require.NoError(t, NewRequestScopedDataPermApplier(principal, nil, ilogx.Named("test")).ApplyUpdate(query), "Should skip without a scope")
+		assert.NotContains(t, query.String(), "created_by", "Should not filter")
+	})
+
+	t.Run("SelectOnlyScopeSkipped", func(t *testing.T) {
+		query := db.NewUpdate().Model(&dataPermTestModel{ID: "1"}).Set("title", "new").WherePK()
+		scope := selectOnlyDataScope{NewSelfDataScope("")}
+
+		require.NoError(t, NewRequestScopedDataPermApplier(principal, scope, ilogx.Named("test")).ApplyUpdate(query), "Should skip scopes without conditions")
+		assert.NotContains(t, query.String(), "created_by", "Should not filter")
+	})
+
+	t.Run("ModelRequired", func(t *testing.T) {
+		query := db.NewDelete().Table("data_perm_test_models")
+		assert.ErrorIs(t, applier.ApplyDelete(query), ErrQueryModelNotSet, "Should require a model")
+	})
+
+	t.Run("ScopeErrorPropagated", func(t *testing.T) {
+		scope := NewDepartmentAndSubDataScope("created_by", new(MockDepartmentHierarchyLoader))
+		query := db.NewUpdate().Model(&dataPermTestModel{ID: "1"}).Set("title", "new").WherePK()
+
+		err := NewRequestScopedDataPermApplier(principal, scope, ilogx.Named("test")).ApplyUpdate(query)
+		assert.ErrorIs(t, err, ErrDataScopeNotBound, "Should return the scope error")
+	})
+}
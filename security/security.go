@@ -80,6 +80,43 @@ type PasswordDecryptor interface {
 	Decrypt(encryptedPassword string) (string, error)
 }
 
+// SessionStore keeps the server-side sessions behind issued tokens.
+// A token is only accepted while its session exists, so deleting a session revokes its tokens immediately.
+// Implementations must be thread-safe for concurrent access.
+type SessionStore interface {
+	// Save creates or replaces the session; it expires on its own at ExpiresAt.
+	Save(ctx context.Context, session *Session) error
+	// CompareAndSwap atomically replaces the stored session only while its refresh token fingerprint still equals
+	// oldRefreshTokenHash, and reports whether it did. Refresh token rotation uses it so a token is exchanged once.
+	CompareAndSwap(ctx context.Context, session *Session, oldRefreshTokenHash string) (bool, error)
+	// Get returns the session with the given ID, or nil when it does not exist or has expired.
+	Get(ctx context.Context, id string) (*Session, error)
+	// Delete removes the session with the given ID; deleting a missing session is not an error.
+	Delete(ctx context.Context, id string) error
+	// DeleteByUser removes all sessions of the user.
+	DeleteByUser(ctx context.Context, userID string) error
+	// ListByUser returns the active sessions of the user, oldest first.
+	ListByUser(ctx context.Context, userID string) ([]*Session, error)
+}
+
+// LoginAttemptStore keeps the failed login counters behind account lockout.
+// Keys identify what is counted, such as a username or a client IP.
+// Implementations must be thread-safe for concurrent access.
+type LoginAttemptStore interface {
+	// Get returns the record of key; a key without failures or lockouts has a zero record.
+	Get(ctx context.Context, key string) (*LoginAttempts, error)
+	// AddFailure counts a failed login of key and returns the failures within the window,
+	// which opens at the first failure and is not extended by later ones.
+	AddFailure(ctx context.Context, key string, window time.Duration) (int, error)
+	// AddLockout counts a lockout of key, clears its failures, and returns the consecutive lockouts.
+	// Lockouts are forgotten once key has not been locked out again for memory.
+	AddLockout(ctx context.Context, key string, memory time.Duration) (int, error)
+	// Lock locks key until the given time.
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Reset clears the failures, lockouts and lock of key.
+	Reset(ctx context.Context, key string) error
+}
+
 // NonceStore manages nonce lifecycle for replay attack prevention.
 // Stores used nonces with TTL to detect and reject duplicate requests.
 // Implementations must be thread-safe for concurrent access.
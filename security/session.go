@@ -0,0 +1,39 @@
+package security
+
+import (
+	"github.com/coldsmirk/vef-framework-go/hashx"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+// Session is the server-side record of a login.
+// Every token issued for the login, including those issued by refreshing, carries its ID in the sid claim,
+// so revoking the session revokes every token of the login.
+type Session struct {
+	// ID is the session identifier.
+	ID string `json:"id"`
+	// UserID is the ID of the logged-in user.
+	UserID string `json:"userId"`
+	// IP is the client IP address of the login.
+	IP string `json:"ip,omitempty"`
+	// UserAgent is the client user agent of the login.
+	UserAgent string `json:"userAgent,omitempty"`
+	// CreatedAt is the time of the login.
+	CreatedAt timex.DateTime `json:"createdAt"`
+	// RefreshedAt is the time tokens were last issued for the session.
+	RefreshedAt timex.DateTime `json:"refreshedAt"`
+	// ExpiresAt is the time the latest refresh token expires, after which the session ends.
+	ExpiresAt timex.DateTime `json:"expiresAt"`
+	// RefreshTokenHash fingerprints the only refresh token that may still be exchanged.
+	// Presenting an older refresh token of the session indicates it was stolen.
+	RefreshTokenHash string `json:"-"`
+}
+
+// SetRefreshToken records token as the only refresh token of the session that may be exchanged.
+func (s *Session) SetRefreshToken(token string) {
+	s.RefreshTokenHash = hashx.SHA256(token)
+}
+
+// MatchesRefreshToken reports whether token is the latest refresh token issued for the session.
+func (s *Session) MatchesRefreshToken(token string) bool {
+	return s.RefreshTokenHash != "" && s.RefreshTokenHash == hashx.SHA256(token)
+}
@@ -0,0 +1,38 @@
+package security
+
+import (
+	"context"
+
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+// TenantResolver determines the tenant a request operates on.
+// The resolved tenant scopes the request database and the tenant-aware cache, storage and sequence keys.
+type TenantResolver interface {
+	// Resolve returns the tenant for the principal. requestedTenant is the value of the tenant
+	// request header and may be empty. An empty result is only accepted for anonymous principals,
+	// the request of any other principal is denied.
+	Resolve(ctx context.Context, principal *Principal, requestedTenant string) (string, error)
+}
+
+// DefaultTenantResolver uses the principal's own tenant. The requested tenant is client-supplied and
+// cannot be verified, so it is rejected for principals that do not belong to a tenant; resolvers that
+// can check tenant membership may honor it instead.
+type DefaultTenantResolver struct{}
+
+// NewDefaultTenantResolver creates a new default tenant resolver.
+func NewDefaultTenantResolver() TenantResolver {
+	return &DefaultTenantResolver{}
+}
+
+func (*DefaultTenantResolver) Resolve(_ context.Context, principal *Principal, requestedTenant string) (string, error) {
+	if principal != nil && principal.TenantID != "" {
+		return principal.TenantID, nil
+	}
+
+	if requestedTenant != "" {
+		return "", result.ErrAccessDenied
+	}
+
+	return "", nil
+}
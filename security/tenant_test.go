@@ -0,0 +1,40 @@
+package security
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+// TestDefaultTenantResolver tests tenant resolution from the principal and the requested tenant.
+func TestDefaultTenantResolver(t *testing.T) {
+	resolver := NewDefaultTenantResolver()
+
+	t.Run("PrincipalTenantWins", func(t *testing.T) {
+		principal := NewUser("u1", "Alice").WithTenantID("tenant-a")
+
+		tenantID, err := resolver.Resolve(context.Background(), principal, "tenant-b")
+		require.NoError(t, err, "Should resolve without error")
+		assert.Equal(t, "tenant-a", tenantID, "Should ignore the requested tenant when the principal has one")
+	})
+
+	t.Run("RequestedTenantForAnonymous", func(t *testing.T) {
+		_, err := resolver.Resolve(context.Background(), PrincipalAnonymous, "tenant-b")
+		require.ErrorIs(t, err, result.ErrAccessDenied, "Should reject a tenant chosen by the header")
+	})
+
+	t.Run("RequestedTenantForPrincipalWithoutTenant", func(t *testing.T) {
+		_, err := resolver.Resolve(context.Background(), NewUser("u1", "Alice"), "tenant-b")
+		require.ErrorIs(t, err, result.ErrAccessDenied, "Should reject a tenant chosen by the header")
+	})
+
+	t.Run("NilPrincipal", func(t *testing.T) {
+		tenantID, err := resolver.Resolve(context.Background(), nil, "")
+		require.NoError(t, err, "Should resolve without error")
+		assert.Empty(t, tenantID, "Should leave the request unscoped")
+	})
+}
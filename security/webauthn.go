@@ -0,0 +1,604 @@
+package security
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/spf13/cast"
+
+	"github.com/coldsmirk/vef-framework-go/i18n"
+	"github.com/coldsmirk/vef-framework-go/id"
+	"github.com/coldsmirk/vef-framework-go/result"
+	"github.com/coldsmirk/vef-framework-go/timex"
+)
+
+const (
+	// WebAuthnDefaultTimeout is the default time the user has to complete a WebAuthn ceremony.
+	WebAuthnDefaultTimeout = 5 * time.Minute
+	// WebAuthnDefaultCredentialName names credentials registered without a name.
+	WebAuthnDefaultCredentialName = "Passkey"
+
+	claimWebAuthnChallenge = "chl"
+	claimWebAuthnCeremony  = "crm"
+
+	webAuthnCeremonyRegistration = "registration"
+	webAuthnCeremonyAssertion    = "assertion"
+
+	// webAuthnNonceScope scopes consumed ceremony challenges in the NonceStore.
+	webAuthnNonceScope = "webauthn"
+)
+
+// webAuthnEncoding encodes credential IDs, which are stored in the unpadded base64url form clients use.
+var webAuthnEncoding = base64.RawURLEncoding
+
+// User verification requirements of WebAuthn ceremonies.
+const (
+	UserVerificationRequired    = "required"
+	UserVerificationPreferred   = "preferred"
+	UserVerificationDiscouraged = "discouraged"
+)
+
+// WebAuthnConfig is the configuration of the WebAuthn relying party.
+// Passkeys are disabled while RPID is empty.
+type WebAuthnConfig struct {
+	RPID             string        `config:"rp_id"`             // Relying party ID, the domain credentials are scoped to, e.g. "example.com"
+	RPName           string        `config:"rp_name"`           // Relying party name shown by authenticators (default: RPID)
+	Origins          []string      `config:"origins"`           // Origins ceremonies may run at (default: https://<RPID>)
+	Timeout          time.Duration `config:"timeout"`           // Time the user has to complete a ceremony (default: 5m)
+	UserVerification string        `config:"user_verification"` // required, preferred or discouraged (default: preferred)
+}
+
+// WebAuthnCredential is a public key credential (passkey) registered by a user.
+type WebAuthnCredential struct {
+	// ID is the base64url-encoded credential ID chosen by the authenticator.
+	ID string `json:"id"`
+	// UserID is the ID of the user the credential signs in.
+	UserID string `json:"userId"`
+	// Name is the label the user gave the credential.
+	Name string `json:"name"`
+	// PublicKey is the COSE-encoded credential public key.
+	PublicKey []byte `json:"publicKey"`
+	// Algorithm is the COSE algorithm of PublicKey.
+	Algorithm int `json:"algorithm"`
+	// SignCount is the signature counter last reported by the authenticator, used to detect cloned authenticators.
+	SignCount uint32 `json:"signCount"`
+	// BackupEligible reports whether the credential may be synced between devices, which must never change.
+	BackupEligible bool `json:"backupEligible"`
+	// Transports hint how the client can reach the authenticator, e.g. "internal" or "usb".
+	Transports []string `json:"transports,omitempty"`
+	// CreatedAt is the time the credential was registered.
+	CreatedAt timex.DateTime `json:"createdAt"`
+	// LastUsedAt is the time the credential last signed the user in.
+	LastUsedAt *timex.DateTime `json:"lastUsedAt,omitempty"`
+}
+
+// WebAuthnCredentialStore persists the WebAuthn credentials of users.
+type WebAuthnCredentialStore interface {
+	// Save creates or replaces a credential.
+	Save(ctx context.Context, credential *WebAuthnCredential) error
+	// Get retrieves a credential by ID, returning nil when it does not exist.
+	Get(ctx context.Context, id string) (*WebAuthnCredential, error)
+	// ListByUser lists the credentials of a user.
+	ListByUser(ctx context.Context, userID string) ([]*WebAuthnCredential, error)
+	// Delete removes a credential.
+	Delete(ctx context.Context, id string) error
+}
+
+// WebAuthnRegistrationOptions starts a credential registration.
+// The client creates a credential with PublicKey and finishes the registration with CeremonyToken.
+type WebAuthnRegistrationOptions struct {
+	CeremonyToken string                                       `json:"ceremonyToken"`
+	PublicKey     *protocol.PublicKeyCredentialCreationOptions `json:"publicKey"`
+}
+
+// WebAuthnAssertionOptions starts a credential assertion.
+// The client gets an assertion with PublicKey and finishes the login with CeremonyToken.
+type WebAuthnAssertionOptions struct {
+	CeremonyToken string                                      `json:"ceremonyToken"`
+	PublicKey     *protocol.PublicKeyCredentialRequestOptions `json:"publicKey"`
+}
+
+// WebAuthnAttestationData is the response of an authenticator to navigator.credentials.create.
+type WebAuthnAttestationData struct {
+	ClientDataJSON    string   `json:"clientDataJSON"`
+	AttestationObject string   `json:"attestationObject"`
+	Transports        []string `json:"transports"`
+}
+
+// WebAuthnRegistrationResponse is the credential created by navigator.credentials.create, serialized with toJSON.
+type WebAuthnRegistrationResponse struct {
+	ID       string                  `json:"id"`
+	RawID    string                  `json:"rawId"`
+	Type     string                  `json:"type"`
+	Response WebAuthnAttestationData `json:"response"`
+}
+
+// WebAuthnAssertionData is the response of an authenticator to navigator.credentials.get.
+type WebAuthnAssertionData struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle"`
+}
+
+// WebAuthnAssertionResponse is the credential returned by navigator.credentials.get, serialized with toJSON.
+type WebAuthnAssertionResponse struct {
+	ID       string                `json:"id"`
+	RawID    string                `json:"rawId"`
+	Type     string                `json:"type"`
+	Response WebAuthnAssertionData `json:"response"`
+}
+
+// WebAuthn is the WebAuthn relying party, running the registration and assertion ceremonies of passkeys
+// on top of go-webauthn, which verifies the responses of authenticators.
+// The challenge of a ceremony travels in a short-lived signed ceremony token held by the client,
+// and is consumed in the NonceStore when the ceremony finishes so every response is accepted at most once.
+type WebAuthn struct {
+	config       *WebAuthnConfig
+	jwt          *JWT
+	store        WebAuthnCredentialStore
+	nonceStore   NonceStore
+	relyingParty *webauthn.WebAuthn
+}
+
+// NewWebAuthn creates a WebAuthn relying party.
+// Without a WebAuthnCredentialStore every ceremony fails as not implemented;
+// without a NonceStore consumed challenges are tracked in memory.
+func NewWebAuthn(config *WebAuthnConfig, jwt *JWT, store WebAuthnCredentialStore, nonceStore NonceStore) (*WebAuthn, error) {
+	config.RPName = cmp.Or(config.RPName, config.RPID)
+	config.Timeout = cmp.Or(config.Timeout, WebAuthnDefaultTimeout)
+	config.UserVerification = cmp.Or(config.UserVerification, UserVerificationPreferred)
+
+	if len(config.Origins) == 0 && config.RPID != "" {
+		config.Origins = []string{"https://" + config.RPID}
+	}
+
+	if nonceStore == nil {
+		nonceStore = NewMemoryNonceStore()
+	}
+
+	w := &WebAuthn{
+		config:     config,
+		jwt:        jwt,
+		store:      store,
+		nonceStore: nonceStore,
+	}
+
+	if config.RPID == "" {
+		return w, nil
+	}
+
+	timeout := webauthn.TimeoutConfig{Timeout: config.Timeout, TimeoutUVD: config.Timeout}
+
+	relyingParty, err := webauthn.New(&webauthn.Config{
+		RPID:                  config.RPID,
+		RPDisplayName:         config.RPName,
+		RPOrigins:             config.Origins,
+		AttestationPreference: protocol.PreferNoAttestation,
+		// Discoverable credentials let the user sign in without entering a username first.
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementPreferred,
+			UserVerification: protocol.UserVerificationRequirement(config.UserVerification),
+		},
+		Timeouts: webauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid webauthn config: %w", err)
+	}
+
+	w.relyingParty = relyingParty
+
+	return w, nil
+}
+
+// BeginRegistration starts registering a new credential for the principal.
+func (w *WebAuthn) BeginRegistration(ctx context.Context, principal *Principal) (*WebAuthnRegistrationOptions, error) {
+	if err := w.checkEnabled(); err != nil {
+		return nil, err
+	}
+
+	credentials, err := w.store.ListByUser(ctx, principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user := newWebAuthnUser(principal.ID, principal.Name, credentials)
+
+	creation, session, err := w.relyingParty.BeginRegistration(user, webauthn.WithExclusions(webauthn.Credentials(user.WebAuthnCredentials()).CredentialDescriptors()))
+	if err != nil {
+		return nil, err
+	}
+
+	ceremonyToken, err := w.startCeremony(webAuthnCeremonyRegistration, principal.ID, session.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnRegistrationOptions{
+		CeremonyToken: ceremonyToken,
+		PublicKey:     &creation.Response,
+	}, nil
+}
+
+// FinishRegistration verifies the credential created for a registration started by BeginRegistration and saves it.
+func (w *WebAuthn) FinishRegistration(
+	ctx context.Context,
+	principal *Principal,
+	ceremonyToken, name string,
+	response *WebAuthnRegistrationResponse,
+) (*WebAuthnCredential, error) {
+	if err := w.checkEnabled(); err != nil {
+		return nil, err
+	}
+
+	challenge, err := w.finishCeremony(ctx, ceremonyToken, webAuthnCeremonyRegistration, principal.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := w.verifyRegistration(principal, challenge, response)
+	if err != nil {
+		logger.Warnf("WebAuthn registration of principal %q failed: %v", principal.ID, err)
+
+		return nil, result.ErrWebAuthnVerificationFailed
+	}
+
+	existing, err := w.store.Get(ctx, credential.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, result.ErrWebAuthnCredentialExists
+	}
+
+	credential.UserID = principal.ID
+	credential.Name = cmp.Or(name, WebAuthnDefaultCredentialName)
+	credential.Transports = response.Response.Transports
+	credential.CreatedAt = timex.Now()
+
+	if err := w.store.Save(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Registered WebAuthn credential %q for principal %q", credential.ID, principal.ID)
+
+	return credential, nil
+}
+
+// BeginAssertion starts an assertion.
+// With a principal, only its credentials are allowed, as a second factor; without one, the user picks any
+// discoverable credential and the assertion identifies them, for passwordless login.
+func (w *WebAuthn) BeginAssertion(ctx context.Context, principal *Principal) (*WebAuthnAssertionOptions, error) {
+	if err := w.checkEnabled(); err != nil {
+		return nil, err
+	}
+
+	var (
+		userID           string
+		allowCredentials = []protocol.CredentialDescriptor{}
+	)
+
+	if principal != nil {
+		credentials, err := w.store.ListByUser(ctx, principal.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		userID = principal.ID
+		allowCredentials = webauthn.Credentials(newWebAuthnUser(principal.ID, principal.Name, credentials).WebAuthnCredentials()).CredentialDescriptors()
+	}
+
+	assertion, session, err := w.relyingParty.BeginDiscoverableLogin(webauthn.WithAllowedCredentials(allowCredentials))
+	if err != nil {
+		return nil, err
+	}
+
+	ceremonyToken, err := w.startCeremony(webAuthnCeremonyAssertion, userID, session.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnAssertionOptions{
+		CeremonyToken: ceremonyToken,
+		PublicKey:     &assertion.Response,
+	}, nil
+}
+
+// FinishAssertion verifies the assertion of an assertion started by BeginAssertion for the same principal,
+// or for none in passwordless logins, and returns the credential that signed it.
+func (w *WebAuthn) FinishAssertion(
+	ctx context.Context,
+	principal *Principal,
+	ceremonyToken string,
+	response *WebAuthnAssertionResponse,
+) (*WebAuthnCredential, error) {
+	if err := w.checkEnabled(); err != nil {
+		return nil, err
+	}
+
+	var userID string
+	if principal != nil {
+		userID = principal.ID
+	}
+
+	challenge, err := w.finishCeremony(ctx, ceremonyToken, webAuthnCeremonyAssertion, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := parseWebAuthnResponse(response, protocol.ParseCredentialRequestResponseBytes)
+	if err != nil {
+		logger.Warnf("WebAuthn assertion with credential %q failed: %v", response.ID, err)
+
+		return nil, result.ErrWebAuthnVerificationFailed
+	}
+
+	credential, err := w.store.Get(ctx, webAuthnEncoding.EncodeToString(parsed.RawID))
+	if err != nil {
+		return nil, err
+	}
+
+	if credential == nil {
+		logger.Infof("WebAuthn assertion with unknown credential %q", response.ID)
+
+		return nil, result.ErrWebAuthnVerificationFailed
+	}
+
+	signCount, err := w.verifyAssertion(challenge, userID, credential, parsed)
+	if err != nil {
+		logger.Warnf("WebAuthn assertion with credential %q of user %q failed: %v", credential.ID, credential.UserID, err)
+
+		return nil, result.ErrWebAuthnVerificationFailed
+	}
+
+	now := timex.Now()
+	credential.SignCount = signCount
+	credential.LastUsedAt = &now
+
+	if err := w.store.Save(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+// Credentials lists the credentials of a user.
+func (w *WebAuthn) Credentials(ctx context.Context, userID string) ([]*WebAuthnCredential, error) {
+	if err := w.checkEnabled(); err != nil {
+		return nil, err
+	}
+
+	return w.store.ListByUser(ctx, userID)
+}
+
+// RemoveCredential removes a credential of a user.
+func (w *WebAuthn) RemoveCredential(ctx context.Context, userID, credentialID string) error {
+	if err := w.checkEnabled(); err != nil {
+		return err
+	}
+
+	credential, err := w.store.Get(ctx, credentialID)
+	if err != nil {
+		return err
+	}
+
+	// Credentials of other users are reported as missing so their IDs cannot be probed.
+	if credential == nil || credential.UserID != userID {
+		return result.ErrWebAuthnCredentialNotFound
+	}
+
+	if err := w.store.Delete(ctx, credentialID); err != nil {
+		return err
+	}
+
+	logger.Infof("Removed WebAuthn credential %q of user %q", credentialID, userID)
+
+	return nil
+}
+
+func (w *WebAuthn) checkEnabled() error {
+	if w.relyingParty == nil {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageWebAuthnNotConfigured))
+	}
+
+	if w.store == nil {
+		return result.ErrNotImplemented(i18n.T(result.ErrMessageWebAuthnCredentialStoreNotImplemented))
+	}
+
+	return nil
+}
+
+// startCeremony issues the ceremony token carrying the challenge of a ceremony.
+func (w *WebAuthn) startCeremony(ceremony, userID, challenge string) (string, error) {
+	claimsBuilder := NewJWTClaimsBuilder().
+		WithID(id.GenerateUUID()).
+		WithSubject(userID).
+		WithType(TokenTypeWebAuthn).
+		WithClaim(claimWebAuthnCeremony, ceremony).
+		WithClaim(claimWebAuthnChallenge, challenge)
+
+	return w.jwt.Generate(claimsBuilder, w.config.Timeout, 0)
+}
+
+// finishCeremony validates the ceremony token for the ceremony and user, consumes its challenge and returns it.
+func (w *WebAuthn) finishCeremony(ctx context.Context, ceremonyToken, ceremony, userID string) (string, error) {
+	claimsAccessor, err := w.jwt.Parse(ceremonyToken)
+	if err != nil ||
+		claimsAccessor.Type() != TokenTypeWebAuthn ||
+		cast.ToString(claimsAccessor.Claim(claimWebAuthnCeremony)) != ceremony ||
+		claimsAccessor.Subject() != userID {
+		return "", result.ErrWebAuthnCeremonyInvalid
+	}
+
+	challenge := cast.ToString(claimsAccessor.Claim(claimWebAuthnChallenge))
+
+	stored, err := w.nonceStore.StoreIfAbsent(ctx, webAuthnNonceScope, challenge, w.config.Timeout)
+	if err != nil {
+		return "", err
+	}
+
+	if !stored {
+		return "", result.ErrWebAuthnCeremonyInvalid
+	}
+
+	return challenge, nil
+}
+
+// session rebuilds the go-webauthn session of a ceremony from its consumed challenge.
+func (w *WebAuthn) session(challenge, userID string) webauthn.SessionData {
+	return webauthn.SessionData{
+		Challenge:        challenge,
+		RelyingPartyID:   w.config.RPID,
+		UserID:           []byte(userID),
+		UserVerification: protocol.UserVerificationRequirement(w.config.UserVerification),
+		CredParams:       webauthn.CredentialParametersDefault(),
+	}
+}
+
+// verifyRegistration verifies a registration response and returns the new credential.
+func (w *WebAuthn) verifyRegistration(
+	principal *Principal,
+	challenge string,
+	response *WebAuthnRegistrationResponse,
+) (*WebAuthnCredential, error) {
+	parsed, err := parseWebAuthnResponse(response, protocol.ParseCredentialCreationResponseBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := w.relyingParty.CreateCredential(
+		newWebAuthnUser(principal.ID, principal.Name, nil),
+		w.session(challenge, principal.ID),
+		parsed,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var key webauthncose.PublicKeyData
+	if err := webauthncbor.Unmarshal(credential.PublicKey, &key); err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnCredential{
+		ID:             webAuthnEncoding.EncodeToString(credential.ID),
+		PublicKey:      credential.PublicKey,
+		Algorithm:      int(key.Algorithm),
+		SignCount:      credential.Authenticator.SignCount,
+		BackupEligible: credential.Flags.BackupEligible,
+	}, nil
+}
+
+// verifyAssertion verifies an assertion response signed by credential and returns the new signature counter.
+func (w *WebAuthn) verifyAssertion(
+	challenge, userID string,
+	credential *WebAuthnCredential,
+	parsed *protocol.ParsedCredentialAssertionData,
+) (uint32, error) {
+	if userID != "" && credential.UserID != userID {
+		return 0, ErrWebAuthnCredentialUserInvalid
+	}
+
+	var (
+		user     = newWebAuthnUser(credential.UserID, "", []*WebAuthnCredential{credential})
+		verified *webauthn.Credential
+		err      error
+	)
+
+	if userID == "" {
+		// Passwordless logins learn the user from the credential, whose user handle must agree.
+		_, verified, err = w.relyingParty.ValidatePasskeyLogin(func(_, _ []byte) (webauthn.User, error) {
+			return user, nil
+		}, w.session(challenge, ""), parsed)
+	} else {
+		verified, err = w.relyingParty.ValidateLogin(user, w.session(challenge, userID), parsed)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if verified.Authenticator.CloneWarning {
+		return 0, ErrWebAuthnSignCountInvalid
+	}
+
+	return verified.Authenticator.SignCount, nil
+}
+
+// parseWebAuthnResponse parses a response of the client with the go-webauthn parser for its ceremony.
+func parseWebAuthnResponse[R, P any](response R, parse func([]byte) (P, error)) (P, error) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		var zero P
+
+		return zero, err
+	}
+
+	return parse(data)
+}
+
+// webAuthnUser adapts a user and their stored credentials to go-webauthn.
+type webAuthnUser struct {
+	id          string
+	name        string
+	credentials []webauthn.Credential
+}
+
+func newWebAuthnUser(id, name string, credentials []*WebAuthnCredential) *webAuthnUser {
+	user := &webAuthnUser{
+		id:          id,
+		name:        name,
+		credentials: make([]webauthn.Credential, 0, len(credentials)),
+	}
+
+	for _, credential := range credentials {
+		credentialID, err := webAuthnEncoding.DecodeString(credential.ID)
+		if err != nil {
+			logger.Warnf("Skipping WebAuthn credential %q with malformed ID: %v", credential.ID, err)
+
+			continue
+		}
+
+		transports := make([]protocol.AuthenticatorTransport, len(credential.Transports))
+		for i, transport := range credential.Transports {
+			transports[i] = protocol.AuthenticatorTransport(transport)
+		}
+
+		user.credentials = append(user.credentials, webauthn.Credential{
+			ID:        credentialID,
+			PublicKey: credential.PublicKey,
+			Transport: transports,
+			Flags:     webauthn.CredentialFlags{BackupEligible: credential.BackupEligible},
+			Authenticator: webauthn.Authenticator{
+				SignCount: credential.SignCount,
+			},
+		})
+	}
+
+	return user
+}
+
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return []byte(u.id)
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.name
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.name
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
@@ -0,0 +1,70 @@
+package security
+
+import (
+	"context"
+
+	"github.com/coldsmirk/vef-framework-go/mapx"
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+// ChallengeTypeWebAuthn is the challenge type identifier for passkey verification.
+const ChallengeTypeWebAuthn = "webauthn"
+
+// WebAuthnChallengeResponse is the response to a WebAuthn challenge:
+// the assertion the client got with the challenge's options, and the ceremony token it came with.
+type WebAuthnChallengeResponse struct {
+	CeremonyToken string                    `json:"ceremonyToken"`
+	Credential    WebAuthnAssertionResponse `json:"credential"`
+}
+
+// WebAuthnChallengeProvider asks users with registered passkeys to verify one as a second factor.
+// The challenge data is a *WebAuthnAssertionOptions and the response a WebAuthnChallengeResponse.
+// It implements the ChallengeProvider interface.
+type WebAuthnChallengeProvider struct {
+	webAuthn *WebAuthn
+}
+
+// NewWebAuthnChallengeProvider creates a passkey challenge provider.
+// Default type "webauthn", order 50, ahead of the one-time password providers it is stronger than.
+func NewWebAuthnChallengeProvider(webAuthn *WebAuthn) ChallengeProvider {
+	return &WebAuthnChallengeProvider{webAuthn: webAuthn}
+}
+
+func (*WebAuthnChallengeProvider) Type() string { return ChallengeTypeWebAuthn }
+func (*WebAuthnChallengeProvider) Order() int   { return 50 }
+
+func (p *WebAuthnChallengeProvider) Evaluate(ctx context.Context, principal *Principal) (*LoginChallenge, error) {
+	credentials, err := p.webAuthn.Credentials(ctx, principal.ID)
+	if err != nil || len(credentials) == 0 {
+		return nil, err
+	}
+
+	options, err := p.webAuthn.BeginAssertion(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginChallenge{
+		Type:     ChallengeTypeWebAuthn,
+		Data:     options,
+		Required: true,
+	}, nil
+}
+
+func (p *WebAuthnChallengeProvider) Resolve(ctx context.Context, principal *Principal, response any) (*Principal, error) {
+	values, ok := response.(map[string]any)
+	if !ok {
+		return nil, result.ErrWebAuthnCeremonyInvalid
+	}
+
+	challengeResponse, err := mapx.FromMap[WebAuthnChallengeResponse](values)
+	if err != nil || challengeResponse.CeremonyToken == "" {
+		return nil, result.ErrWebAuthnCeremonyInvalid
+	}
+
+	if _, err := p.webAuthn.FinishAssertion(ctx, principal, challengeResponse.CeremonyToken, &challengeResponse.Credential); err != nil {
+		return nil, err
+	}
+
+	return principal, nil
+}
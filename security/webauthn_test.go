@@ -0,0 +1,441 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/result"
+)
+
+const (
+	testWebAuthnRPID   = "example.com"
+	testWebAuthnOrigin = "https://example.com"
+)
+
+type WebAuthnTestSuite struct {
+	suite.Suite
+
+	ctx      context.Context
+	jwt      *JWT
+	store    WebAuthnCredentialStore
+	webAuthn *WebAuthn
+	alice    *Principal
+	bob      *Principal
+}
+
+func (s *WebAuthnTestSuite) SetupSuite() {
+	jwt, err := NewJWT(&JWTConfig{Secret: DefaultJWTSecret, Audience: DefaultJWTAudience})
+	s.Require().NoError(err, "Should create JWT instance without error")
+
+	s.ctx = context.Background()
+	s.jwt = jwt
+	s.alice = NewUser("alice", "Alice")
+	s.bob = NewUser("bob", "Bob")
+}
+
+func (s *WebAuthnTestSuite) SetupTest() {
+	s.store = NewMemoryWebAuthnCredentialStore()
+	s.webAuthn = s.newWebAuthn(&WebAuthnConfig{RPID: testWebAuthnRPID, RPName: "Example"})
+}
+
+func (s *WebAuthnTestSuite) newWebAuthn(config *WebAuthnConfig) *WebAuthn {
+	webAuthn, err := NewWebAuthn(config, s.jwt, s.store, nil)
+	s.Require().NoError(err, "Should create WebAuthn instance without error")
+
+	return webAuthn
+}
+
+// register registers the credential of authenticator for principal.
+func (s *WebAuthnTestSuite) register(principal *Principal, authenticator *testx.WebAuthnAuthenticator) *WebAuthnCredential {
+	options, err := s.webAuthn.BeginRegistration(s.ctx, principal)
+	s.Require().NoError(err, "Should begin registration")
+
+	credential, err := s.webAuthn.FinishRegistration(
+		s.ctx, principal, options.CeremonyToken, "",
+		registrationResponse(authenticator.Register(options.PublicKey.Challenge.String(), userHandle(options))),
+	)
+	s.Require().NoError(err, "Should finish registration")
+
+	return credential
+}
+
+// assert runs an assertion ceremony for principal, or a passwordless one when principal is nil.
+func (s *WebAuthnTestSuite) assert(principal *Principal, authenticator *testx.WebAuthnAuthenticator) (*WebAuthnCredential, error) {
+	options, err := s.webAuthn.BeginAssertion(s.ctx, principal)
+	s.Require().NoError(err, "Should begin assertion")
+
+	return s.webAuthn.FinishAssertion(s.ctx, principal, options.CeremonyToken, assertionResponse(authenticator.Assert(options.PublicKey.Challenge.String())))
+}
+
+// userHandle returns the user handle the registration options create the credential for.
+func userHandle(options *WebAuthnRegistrationOptions) string {
+	return options.PublicKey.User.ID.(protocol.URLEncodedBase64).String()
+}
+
+func registrationResponse(attestation testx.WebAuthnAttestation) *WebAuthnRegistrationResponse {
+	return &WebAuthnRegistrationResponse{
+		ID:    attestation.ID,
+		RawID: attestation.ID,
+		Type:  "public-key",
+		Response: WebAuthnAttestationData{
+			ClientDataJSON:    attestation.ClientDataJSON,
+			AttestationObject: attestation.AttestationObject,
+			Transports:        []string{"internal"},
+		},
+	}
+}
+
+func assertionResponse(assertion testx.WebAuthnAssertion) *WebAuthnAssertionResponse {
+	return &WebAuthnAssertionResponse{
+		ID:    assertion.ID,
+		RawID: assertion.ID,
+		Type:  "public-key",
+		Response: WebAuthnAssertionData{
+			ClientDataJSON:    assertion.ClientDataJSON,
+			AuthenticatorData: assertion.AuthenticatorData,
+			Signature:         assertion.Signature,
+			UserHandle:        assertion.UserHandle,
+		},
+	}
+}
+
+func (s *WebAuthnTestSuite) assertResultCode(err error, code int, msg string) {
+	resErr, ok := result.AsErr(err)
+	s.Require().True(ok, "Should return a result error: %v", err)
+	s.Equal(code, resErr.Code, msg)
+}
+
+// TestRegistration verifies the registration ceremony.
+func (s *WebAuthnTestSuite) TestRegistration() {
+	s.Run("BeginReturnsCreationOptions", func() {
+		options, err := s.webAuthn.BeginRegistration(s.ctx, s.alice)
+		s.Require().NoError(err, "Should begin registration")
+
+		s.NotEmpty(options.CeremonyToken, "Should return a ceremony token")
+		s.Equal(testWebAuthnRPID, options.PublicKey.RelyingParty.ID, "Should identify the relying party")
+		s.Equal("Example", options.PublicKey.RelyingParty.Name, "Should name the relying party")
+		s.Equal(webAuthnEncoding.EncodeToString([]byte("alice")), userHandle(options), "Should use the principal ID as user handle")
+		s.Contains(options.PublicKey.Parameters, protocol.CredentialParameter{
+			Type:      protocol.PublicKeyCredentialType,
+			Algorithm: webauthncose.AlgES256,
+		}, "Should accept ES256")
+		s.Equal(protocol.PreferNoAttestation, options.PublicKey.Attestation, "Should not request attestation")
+		s.Equal(int(WebAuthnDefaultTimeout.Milliseconds()), options.PublicKey.Timeout, "Should default the timeout")
+	})
+
+	s.Run("SavesCredential", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+
+		credential := s.register(s.alice, authenticator)
+
+		s.Equal(authenticator.CredentialID(), credential.ID, "Should use the authenticator's credential ID")
+		s.Equal("alice", credential.UserID, "Should bind the credential to the principal")
+		s.Equal(WebAuthnDefaultCredentialName, credential.Name, "Should default the credential name")
+		s.Equal(int(webauthncose.AlgES256), credential.Algorithm, "Should record the key algorithm")
+		s.Equal([]string{"internal"}, credential.Transports, "Should record the transports")
+
+		credentials, err := s.webAuthn.Credentials(s.ctx, "alice")
+		s.Require().NoError(err, "Should list credentials")
+		s.Len(credentials, 1, "Should have saved the credential")
+	})
+
+	s.Run("ExcludesRegisteredCredentials", func() {
+		options, err := s.webAuthn.BeginRegistration(s.ctx, s.alice)
+		s.Require().NoError(err, "Should begin registration")
+
+		s.Len(options.PublicKey.CredentialExcludeList, 1, "Should exclude the registered credential")
+	})
+
+	s.Run("Ed25519Credential", func() {
+		credential := s.register(s.bob, testx.NewEd25519WebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin))
+
+		s.Equal(int(webauthncose.AlgEdDSA), credential.Algorithm, "Should record the EdDSA algorithm")
+	})
+}
+
+// TestRegistrationRejected verifies registrations the relying party must reject.
+func (s *WebAuthnTestSuite) TestRegistrationRejected() {
+	finish := func(principal *Principal, authenticator *testx.WebAuthnAuthenticator, tamper func(options *WebAuthnRegistrationOptions)) error {
+		options, err := s.webAuthn.BeginRegistration(s.ctx, s.alice)
+		s.Require().NoError(err, "Should begin registration")
+
+		if tamper != nil {
+			tamper(options)
+		}
+
+		_, err = s.webAuthn.FinishRegistration(
+			s.ctx, principal, options.CeremonyToken, "",
+			registrationResponse(authenticator.Register(options.PublicKey.Challenge.String(), userHandle(options))),
+		)
+
+		return err
+	}
+
+	s.Run("OriginNotAllowed", func() {
+		err := finish(s.alice, testx.NewWebAuthnAuthenticator(testWebAuthnRPID, "https://evil.example"), nil)
+		s.assertResultCode(err, result.ErrCodeWebAuthnVerificationFailed, "Should reject a foreign origin")
+	})
+
+	s.Run("RPIDMismatch", func() {
+		err := finish(s.alice, testx.NewWebAuthnAuthenticator("evil.example", testWebAuthnOrigin), nil)
+		s.assertResultCode(err, result.ErrCodeWebAuthnVerificationFailed, "Should reject a credential scoped to another relying party")
+	})
+
+	s.Run("ChallengeMismatch", func() {
+		err := finish(s.alice, testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin), func(options *WebAuthnRegistrationOptions) {
+			options.PublicKey.Challenge = protocol.URLEncodedBase64("forged challenge")
+		})
+		s.assertResultCode(err, result.ErrCodeWebAuthnVerificationFailed, "Should reject a response to another challenge")
+	})
+
+	s.Run("CeremonyOfAnotherUser", func() {
+		err := finish(s.bob, testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin), nil)
+		s.assertResultCode(err, result.ErrCodeWebAuthnCeremonyInvalid, "Should reject a ceremony started by another user")
+	})
+
+	s.Run("CeremonyReplayed", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+
+		options, err := s.webAuthn.BeginRegistration(s.ctx, s.alice)
+		s.Require().NoError(err, "Should begin registration")
+
+		response := registrationResponse(authenticator.Register(options.PublicKey.Challenge.String(), userHandle(options)))
+		_, err = s.webAuthn.FinishRegistration(s.ctx, s.alice, options.CeremonyToken, "", response)
+		s.Require().NoError(err, "Should finish the first registration")
+
+		_, err = s.webAuthn.FinishRegistration(s.ctx, s.alice, options.CeremonyToken, "", response)
+		s.assertResultCode(err, result.ErrCodeWebAuthnCeremonyInvalid, "Should accept a ceremony only once")
+	})
+
+	s.Run("CredentialAlreadyRegistered", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.alice, authenticator)
+
+		err := finish(s.alice, authenticator, nil)
+		s.assertResultCode(err, result.ErrCodeWebAuthnCredentialExists, "Should reject a credential registered before")
+	})
+
+	s.Run("UserVerificationRequired", func() {
+		s.webAuthn = s.newWebAuthn(&WebAuthnConfig{RPID: testWebAuthnRPID, UserVerification: UserVerificationRequired})
+		defer s.SetupTest()
+
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		authenticator.UserVerified = false
+
+		err := finish(s.alice, authenticator, nil)
+		s.assertResultCode(err, result.ErrCodeWebAuthnVerificationFailed, "Should require user verification")
+	})
+}
+
+// TestAssertion verifies passwordless and second-factor assertions.
+func (s *WebAuthnTestSuite) TestAssertion() {
+	s.Run("Passwordless", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.alice, authenticator)
+
+		options, err := s.webAuthn.BeginAssertion(s.ctx, nil)
+		s.Require().NoError(err, "Should begin assertion")
+		s.Empty(options.PublicKey.AllowedCredentials, "Should let the user pick a discoverable credential")
+
+		credential, err := s.webAuthn.FinishAssertion(s.ctx, nil, options.CeremonyToken, assertionResponse(authenticator.Assert(options.PublicKey.Challenge.String())))
+		s.Require().NoError(err, "Should finish assertion")
+
+		s.Equal("alice", credential.UserID, "Should identify the user")
+		s.Equal(uint32(1), credential.SignCount, "Should record the signature counter")
+		s.NotNil(credential.LastUsedAt, "Should record the last use")
+
+		stored, err := s.store.Get(s.ctx, credential.ID)
+		s.Require().NoError(err, "Should get the credential")
+		s.Equal(uint32(1), stored.SignCount, "Should persist the signature counter")
+	})
+
+	s.Run("SecondFactor", func() {
+		authenticator := testx.NewEd25519WebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.bob, authenticator)
+
+		options, err := s.webAuthn.BeginAssertion(s.ctx, s.bob)
+		s.Require().NoError(err, "Should begin assertion")
+		s.Require().Len(options.PublicKey.AllowedCredentials, 1, "Should allow only the user's credentials")
+		s.Equal(authenticator.CredentialID(), options.PublicKey.AllowedCredentials[0].CredentialID.String(), "Should allow the registered credential")
+
+		_, err = s.webAuthn.FinishAssertion(s.ctx, s.bob, options.CeremonyToken, assertionResponse(authenticator.Assert(options.PublicKey.Challenge.String())))
+		s.NoError(err, "Should finish assertion")
+	})
+
+	s.Run("ZeroSignCountAccepted", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.alice, authenticator)
+
+		authenticator.NoCounter = true
+
+		for range 2 {
+			_, err := s.assert(nil, authenticator)
+			s.NoError(err, "Should accept authenticators without a signature counter")
+		}
+	})
+}
+
+// TestAssertionRejected verifies assertions the relying party must reject.
+func (s *WebAuthnTestSuite) TestAssertionRejected() {
+	s.Run("UnknownCredential", func() {
+		_, err := s.assert(nil, testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin))
+		s.assertResultCode(err, result.ErrCodeWebAuthnVerificationFailed, "Should reject an unregistered credential")
+	})
+
+	s.Run("CredentialOfAnotherUser", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.alice, authenticator)
+
+		_, err := s.assert(s.bob, authenticator)
+		s.assertResultCode(err, result.ErrCodeWebAuthnVerificationFailed, "Should reject a credential of another user")
+	})
+
+	s.Run("UserHandleMismatch", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.alice, authenticator)
+		authenticator.UserHandle = webAuthnEncoding.EncodeToString([]byte("bob"))
+
+		_, err := s.assert(nil, authenticator)
+		s.assertResultCode(err, result.ErrCodeWebAuthnVerificationFailed, "Should reject a user handle differing from the credential's user")
+	})
+
+	s.Run("SignatureInvalid", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.alice, authenticator)
+
+		options, err := s.webAuthn.BeginAssertion(s.ctx, nil)
+		s.Require().NoError(err, "Should begin assertion")
+
+		response := assertionResponse(authenticator.Assert(options.PublicKey.Challenge.String()))
+		response.Response.Signature = assertionResponse(authenticator.Assert(options.PublicKey.Challenge.String())).Response.Signature
+
+		_, err = s.webAuthn.FinishAssertion(s.ctx, nil, options.CeremonyToken, response)
+		s.assertResultCode(err, result.ErrCodeWebAuthnVerificationFailed, "Should reject a signature over other data")
+	})
+
+	s.Run("SignCountRegressed", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.alice, authenticator)
+
+		_, err := s.assert(nil, authenticator)
+		s.Require().NoError(err, "Should accept the first assertion")
+
+		authenticator.SignCount = 0
+		_, err = s.assert(nil, authenticator)
+		s.assertResultCode(err, result.ErrCodeWebAuthnVerificationFailed, "Should reject a counter that did not increase")
+	})
+
+	s.Run("RegistrationCeremonyToken", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.alice, authenticator)
+
+		options, err := s.webAuthn.BeginRegistration(s.ctx, s.alice)
+		s.Require().NoError(err, "Should begin registration")
+
+		_, err = s.webAuthn.FinishAssertion(s.ctx, s.alice, options.CeremonyToken, assertionResponse(authenticator.Assert(options.PublicKey.Challenge.String())))
+		s.assertResultCode(err, result.ErrCodeWebAuthnCeremonyInvalid, "Should reject the token of a registration ceremony")
+	})
+
+	s.Run("PasswordlessCeremonyAsSecondFactor", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.alice, authenticator)
+
+		options, err := s.webAuthn.BeginAssertion(s.ctx, nil)
+		s.Require().NoError(err, "Should begin assertion")
+
+		_, err = s.webAuthn.FinishAssertion(s.ctx, s.alice, options.CeremonyToken, assertionResponse(authenticator.Assert(options.PublicKey.Challenge.String())))
+		s.assertResultCode(err, result.ErrCodeWebAuthnCeremonyInvalid, "Should reject a ceremony started for another user")
+	})
+}
+
+// TestRemoveCredential verifies credential removal.
+func (s *WebAuthnTestSuite) TestRemoveCredential() {
+	credential := s.register(s.alice, testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin))
+
+	s.Run("CredentialOfAnotherUser", func() {
+		err := s.webAuthn.RemoveCredential(s.ctx, "bob", credential.ID)
+		s.assertResultCode(err, result.ErrCodeWebAuthnCredentialNotFound, "Should not remove a credential of another user")
+	})
+
+	s.Run("OwnCredential", func() {
+		s.Require().NoError(s.webAuthn.RemoveCredential(s.ctx, "alice", credential.ID), "Should remove the credential")
+
+		credentials, err := s.webAuthn.Credentials(s.ctx, "alice")
+		s.Require().NoError(err, "Should list credentials")
+		s.Empty(credentials, "Should have removed the credential")
+	})
+}
+
+// TestNotEnabled verifies that ceremonies fail until the relying party is configured.
+func (s *WebAuthnTestSuite) TestNotEnabled() {
+	s.Run("WithoutRPID", func() {
+		_, err := s.newWebAuthn(&WebAuthnConfig{}).BeginAssertion(s.ctx, nil)
+		s.assertResultCode(err, result.ErrCodeNotImplemented, "Should report passkeys as not configured")
+	})
+
+	s.Run("WithoutStore", func() {
+		webAuthn, err := NewWebAuthn(&WebAuthnConfig{RPID: testWebAuthnRPID}, s.jwt, nil, nil)
+		s.Require().NoError(err, "Should create WebAuthn instance without error")
+
+		_, err = webAuthn.BeginAssertion(s.ctx, nil)
+		s.assertResultCode(err, result.ErrCodeNotImplemented, "Should report the credential store as not implemented")
+	})
+
+	s.Run("DefaultsOrigin", func() {
+		webAuthn := s.newWebAuthn(&WebAuthnConfig{RPID: testWebAuthnRPID})
+		s.Equal([]string{testWebAuthnOrigin}, webAuthn.config.Origins, "Should default the origin to the RP ID over HTTPS")
+	})
+}
+
+// TestChallengeProvider verifies the passkey second-factor challenge.
+func (s *WebAuthnTestSuite) TestChallengeProvider() {
+	provider := NewWebAuthnChallengeProvider(s.webAuthn)
+
+	s.Run("SkipsUsersWithoutCredentials", func() {
+		challenge, err := provider.Evaluate(s.ctx, s.bob)
+		s.Require().NoError(err, "Should evaluate without error")
+		s.Nil(challenge, "Should not challenge users without passkeys")
+	})
+
+	s.Run("ResolvesAssertion", func() {
+		authenticator := testx.NewWebAuthnAuthenticator(testWebAuthnRPID, testWebAuthnOrigin)
+		s.register(s.alice, authenticator)
+
+		challenge, err := provider.Evaluate(s.ctx, s.alice)
+		s.Require().NoError(err, "Should evaluate without error")
+		s.Require().NotNil(challenge, "Should challenge users with passkeys")
+		s.Equal(ChallengeTypeWebAuthn, challenge.Type, "Should use the webauthn challenge type")
+
+		options, ok := challenge.Data.(*WebAuthnAssertionOptions)
+		s.Require().True(ok, "Should carry the assertion options")
+
+		// The response reaches Resolve as the JSON object the client posted.
+		body, err := json.Marshal(WebAuthnChallengeResponse{
+			CeremonyToken: options.CeremonyToken,
+			Credential:    *assertionResponse(authenticator.Assert(options.PublicKey.Challenge.String())),
+		})
+		s.Require().NoError(err, "Should encode the response")
+
+		var response map[string]any
+		s.Require().NoError(json.Unmarshal(body, &response), "Should decode the response")
+
+		principal, err := provider.Resolve(s.ctx, s.alice, response)
+		s.Require().NoError(err, "Should resolve the challenge")
+		s.Equal(s.alice, principal, "Should keep the principal")
+	})
+
+	s.Run("RejectsMalformedResponse", func() {
+		_, err := provider.Resolve(s.ctx, s.alice, "not an assertion")
+		s.assertResultCode(err, result.ErrCodeWebAuthnCeremonyInvalid, "Should reject a response that is not an object")
+	})
+}
+
+func TestWebAuthn(t *testing.T) {
+	suite.Run(t, new(WebAuthnTestSuite))
+}
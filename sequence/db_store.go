@@ -2,6 +2,7 @@ package sequence
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/samber/lo"
@@ -143,3 +144,58 @@ func (s *DBStore) Reserve(ctx context.Context, key string, count int, now timex.
 
 	return reservedRule, newValue, nil
 }
+
+func (s *DBStore) ReserveTenant(ctx context.Context, tenantID, key string, count int, now timex.DateTime) (*Rule, int, error) {
+	tenantKey := TenantKey(tenantID, key)
+
+	if err := s.seedTenantRule(ctx, key, tenantKey); err != nil {
+		return nil, 0, err
+	}
+
+	return s.Reserve(ctx, tenantKey, count, now)
+}
+
+// seedTenantRule copies the shared rule of key to tenantKey unless the tenant already has a rule.
+// The unique key column lets only one instance insert the copy; the others find it in place.
+func (s *DBStore) seedTenantRule(ctx context.Context, key, tenantKey string) error {
+	exists, err := s.db.NewSelect().
+		Model((*RuleModel)(nil)).
+		Where(func(cb orm.ConditionBuilder) {
+			cb.Equals("key", tenantKey)
+		}).
+		Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check sequence rule %q: %w", tenantKey, err)
+	}
+
+	if exists {
+		return nil
+	}
+
+	var shared RuleModel
+	if err := s.db.NewSelect().
+		Model(&shared).
+		Where(func(cb orm.ConditionBuilder) {
+			cb.Equals("key", key).
+				IsTrue("is_active")
+		}).
+		Scan(ctx); err != nil {
+		if result.IsRecordNotFound(err) {
+			return ErrRuleNotFound
+		}
+
+		return fmt.Errorf("failed to select sequence rule %q: %w", key, err)
+	}
+
+	seed := shared
+	seed.FullAuditedModel = orm.FullAuditedModel{}
+	seed.Key = tenantKey
+	seed.CurrentValue = seed.StartValue
+	seed.LastResetAt = nil
+
+	if _, err := s.db.NewInsert().Model(&seed).Exec(ctx); err != nil && !errors.Is(err, result.ErrRecordAlreadyExists) {
+		return fmt.Errorf("failed to create sequence rule %q: %w", tenantKey, err)
+	}
+
+	return nil
+}
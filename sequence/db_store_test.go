@@ -278,6 +278,42 @@ func TestDBStore(t *testing.T) {
 			assert.Equal(t, numGoroutines, model.CurrentValue, "Final counter should equal successful reserve count")
 		})
 
+		t.Run("ReserveTenant", func(t *testing.T) {
+			insertTestRule(t, env.Ctx, env.DB, &RuleModel{
+				Key:              "reserve-tenant",
+				Name:             "Reserve Tenant",
+				SeqLength:        4,
+				SeqStep:          1,
+				StartValue:       100,
+				OverflowStrategy: OverflowError,
+				ResetCycle:       ResetNone,
+				CurrentValue:     500,
+				IsActive:         true,
+			})
+			defer deleteTestRule(t, env.Ctx, env.DB, "reserve-tenant")
+			defer deleteTestRule(t, env.Ctx, env.DB, TenantKey("t1", "reserve-tenant"))
+			defer deleteTestRule(t, env.Ctx, env.DB, TenantKey("t2", "reserve-tenant"))
+
+			rule, newValue, err := store.ReserveTenant(env.Ctx, "t1", "reserve-tenant", 1, timex.Now())
+			require.NoError(t, err, "Should reserve from the tenant counter")
+			assert.Equal(t, 101, newValue, "Tenant counter should start from the start value")
+			assert.Equal(t, TenantKey("t1", "reserve-tenant"), rule.Key, "Tenant counter should be kept under the tenant key")
+
+			_, newValue, err = store.ReserveTenant(env.Ctx, "t2", "reserve-tenant", 1, timex.Now())
+			require.NoError(t, err, "Should reserve from the tenant counter")
+			assert.Equal(t, 101, newValue, "Each tenant should have its own counter")
+
+			_, newValue, err = store.ReserveTenant(env.Ctx, "t1", "reserve-tenant", 1, timex.Now())
+			require.NoError(t, err, "Should reserve from the tenant counter")
+			assert.Equal(t, 102, newValue, "Tenant counter should continue")
+
+			model := queryRuleModelByKey(t, env.Ctx, env.DB, "reserve-tenant")
+			assert.Equal(t, 500, model.CurrentValue, "Tenant reservations should not consume the shared counter")
+
+			_, _, err = store.ReserveTenant(env.Ctx, "t1", "reserve-tenant-missing", 1, timex.Now())
+			assert.ErrorIs(t, err, ErrRuleNotFound, "Missing shared rule should return ErrRuleNotFound")
+		})
+
 		t.Run("AutoMigrateIdempotent", func(t *testing.T) {
 			err := store.Init(env.Ctx)
 			assert.NoError(t, err, "Second Init should be idempotent")
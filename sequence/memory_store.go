@@ -34,9 +34,7 @@ func (s *MemoryStore) Register(rules ...*Rule) {
 }
 
 func (s *MemoryStore) Reserve(_ context.Context, key string, count int, now timex.DateTime) (*Rule, int, error) {
-	mu, _ := s.locks.GetOrCompute(key, func() *sync.Mutex { return new(sync.Mutex) })
-
-	mu.Lock()
+	mu := s.lock(key)
 	defer mu.Unlock()
 
 	rule, ok := s.rules.Get(key)
@@ -59,3 +57,39 @@ func (s *MemoryStore) Reserve(_ context.Context, key string, count int, now time
 
 	return rule.Clone(), rule.CurrentValue, nil
 }
+
+func (s *MemoryStore) ReserveTenant(ctx context.Context, tenantID, key string, count int, now timex.DateTime) (*Rule, int, error) {
+	tenantKey := TenantKey(tenantID, key)
+
+	if !s.rules.ContainsKey(tenantKey) {
+		shared, err := s.snapshot(key)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		s.rules.GetOrCompute(tenantKey, func() *Rule { return newTenantRule(shared, tenantKey) })
+	}
+
+	return s.Reserve(ctx, tenantKey, count, now)
+}
+
+// lock locks and returns the mutex serializing the counter of key.
+func (s *MemoryStore) lock(key string) *sync.Mutex {
+	mu, _ := s.locks.GetOrCompute(key, func() *sync.Mutex { return new(sync.Mutex) })
+	mu.Lock()
+
+	return mu
+}
+
+// snapshot returns a copy of the active rule of key taken while its counter is not being reserved.
+func (s *MemoryStore) snapshot(key string) (*Rule, error) {
+	mu := s.lock(key)
+	defer mu.Unlock()
+
+	rule, ok := s.rules.Get(key)
+	if !ok || !rule.IsActive {
+		return nil, ErrRuleNotFound
+	}
+
+	return rule.Clone(), nil
+}
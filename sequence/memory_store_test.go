@@ -155,6 +155,38 @@ func (s *MemoryStoreTestSuite) TestReserve() {
 	})
 }
 
+func (s *MemoryStoreTestSuite) TestReserveTenant() {
+	s.store.Register(&Rule{
+		Key:          "tenant",
+		SeqStep:      1,
+		StartValue:   100,
+		CurrentValue: 500,
+		IsActive:     true,
+	})
+
+	rule, newValue, err := s.store.ReserveTenant(s.ctx, "t1", "tenant", 1, timex.Now())
+	s.Require().NoError(err, "Should reserve from the tenant counter")
+	s.Equal(101, newValue, "Tenant counter should start from the start value")
+	s.Equal(TenantKey("t1", "tenant"), rule.Key, "Tenant counter should be kept under the tenant key")
+
+	_, newValue, err = s.store.ReserveTenant(s.ctx, "t2", "tenant", 1, timex.Now())
+	s.Require().NoError(err, "Should reserve from the tenant counter")
+	s.Equal(101, newValue, "Each tenant should have its own counter")
+
+	_, newValue, err = s.store.ReserveTenant(s.ctx, "t1", "tenant", 1, timex.Now())
+	s.Require().NoError(err, "Should reserve from the tenant counter")
+	s.Equal(102, newValue, "Tenant counter should continue")
+
+	_, newValue, err = s.store.Reserve(s.ctx, "tenant", 1, timex.Now())
+	s.Require().NoError(err, "Should reserve from the shared counter")
+	s.Equal(501, newValue, "Tenant reservations should not consume the shared counter")
+
+	s.Run("RuleNotFound", func() {
+		_, _, err := s.store.ReserveTenant(s.ctx, "t1", "missing", 1, timex.Now())
+		s.ErrorIs(err, ErrRuleNotFound, "Missing shared rule should return ErrRuleNotFound")
+	})
+}
+
 func (s *MemoryStoreTestSuite) TestConcurrentReserve() {
 	s.store.Register(&Rule{
 		Key:      "concurrent",
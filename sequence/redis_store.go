@@ -94,11 +94,68 @@ func (s *RedisStore) Reserve(ctx context.Context, key string, count int, now tim
 	}
 }
 
+func (s *RedisStore) ReserveTenant(ctx context.Context, tenantID, key string, count int, now timex.DateTime) (*Rule, int, error) {
+	tenantKey := TenantKey(tenantID, key)
+
+	if err := s.seedTenantRule(ctx, key, tenantKey); err != nil {
+		return nil, 0, err
+	}
+
+	return s.Reserve(ctx, tenantKey, count, now)
+}
+
+// seedTenantRule copies the shared rule of key to tenantKey unless the tenant already has a rule.
+// The copy is written under WATCH, so a node that lost the race leaves the winner's counter untouched.
+func (s *RedisStore) seedTenantRule(ctx context.Context, key, tenantKey string) error {
+	tKey := redisSequencePrefix + tenantKey
+
+	err := s.client.Watch(ctx, func(tx *redis.Tx) error {
+		exists, err := tx.Exists(ctx, tKey).Result()
+		if err != nil || exists > 0 {
+			return err
+		}
+
+		fields, err := tx.HGetAll(ctx, redisSequencePrefix+key).Result()
+		if err != nil {
+			return err
+		}
+
+		if len(fields) == 0 {
+			return ErrRuleNotFound
+		}
+
+		shared, err := parseRedisRule(fields)
+		if err != nil {
+			return fmt.Errorf("failed to parse sequence rule %q from redis: %w", key, err)
+		}
+
+		if !shared.IsActive {
+			return ErrRuleNotFound
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, tKey, redisRuleFields(newTenantRule(shared, tenantKey)))
+
+			return nil
+		})
+
+		return err
+	}, tKey)
+	if errors.Is(err, redis.TxFailedErr) {
+		return nil
+	}
+
+	return err
+}
+
 // RegisterRule stores a rule in Redis as a hash.
 // This is a helper for setting up rules in Redis.
 func (s *RedisStore) RegisterRule(ctx context.Context, rule *Rule) error {
-	rKey := redisSequencePrefix + rule.Key
+	return s.client.HSet(ctx, redisSequencePrefix+rule.Key, redisRuleFields(rule)).Err()
+}
 
+// redisRuleFields returns the hash fields a rule is stored as.
+func redisRuleFields(rule *Rule) map[string]any {
 	fields := map[string]any{
 		"key":               rule.Key,
 		"name":              rule.Name,
@@ -119,7 +176,7 @@ func (s *RedisStore) RegisterRule(ctx context.Context, rule *Rule) error {
 		fields["last_reset_at"] = rule.LastResetAt.String()
 	}
 
-	return s.client.HSet(ctx, rKey, fields).Err()
+	return fields
 }
 
 func parseRedisRule(fields map[string]string) (*Rule, error) {
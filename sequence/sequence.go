@@ -9,6 +9,24 @@ import (
 
 var logger = logx.Named("sequence")
 
+// TenantKey returns the rule key of a tenant-specific rule for key.
+// When a request is scoped to a tenant, generators reserve from the rule under this key, so each tenant keeps
+// its own counter. A tenant-specific rule may be registered up front; otherwise stores implementing TenantStore
+// create it as a copy of the shared rule on first use.
+func TenantKey(tenantID, key string) string {
+	return tenantID + ":" + key
+}
+
+// newTenantRule copies the shared rule to the tenant-specific key with a counter that starts over.
+func newTenantRule(shared *Rule, tenantKey string) *Rule {
+	rule := shared.Clone()
+	rule.Key = tenantKey
+	rule.CurrentValue = rule.StartValue
+	rule.LastResetAt = nil
+
+	return rule
+}
+
 // Generator provides serial number generation.
 type Generator interface {
 	// Generate generates a new serial number for the given rule key.
@@ -24,3 +42,12 @@ type Store interface {
 	// It returns the rule snapshot used for generation and the final counter value in the reserved batch.
 	Reserve(ctx context.Context, key string, count int, now timex.DateTime) (rule *Rule, newValue int, err error)
 }
+
+// TenantStore is implemented by stores that keep a separate counter per tenant.
+// Generators use it for tenant-scoped requests; stores without it share one counter across tenants
+// unless a tenant-specific rule is registered under TenantKey.
+type TenantStore interface {
+	// ReserveTenant reserves like Reserve from the rule under TenantKey(tenantID, key),
+	// creating it from the shared rule of key with a fresh counter when the tenant has none yet.
+	ReserveTenant(ctx context.Context, tenantID, key string, count int, now timex.DateTime) (rule *Rule, newValue int, err error)
+}
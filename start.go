@@ -1,19 +1,27 @@
 package vef
 
 import (
+	"context"
+
 	"go.uber.org/fx"
 
 	"github.com/coldsmirk/vef-framework-go/internal/app"
+	"github.com/coldsmirk/vef-framework-go/internal/monitor"
 )
 
 // startApp starts the application.
-// It registers the application stop hook with the fx lifecycle manager.
-func startApp(lc fx.Lifecycle, app *app.App) error {
+// It registers the application stop hook with the fx lifecycle manager, which flips the readiness
+// of the application before the server drains its connections.
+func startApp(lc fx.Lifecycle, app *app.App, health *monitor.HealthChecker) error {
 	if err := <-app.Start(); err != nil {
 		return err
 	}
 
-	lc.Append(fx.StopHook(app.Stop))
+	lc.Append(fx.StopHook(func(ctx context.Context) error {
+		health.ShutDown(ctx)
+
+		return app.Stop()
+	}))
 
 	return nil
 }
@@ -8,4 +8,8 @@ const (
 	// TempPrefix is the prefix for temporary object storage.
 	// Files uploaded to temp/ should be promoted to permanent storage after business logic commits.
 	TempPrefix = "temp/"
+
+	// TenantPrefix is the prefix of the storage area owned by each tenant (tenants/{tenantID}/).
+	// Temporary uploads of a tenant live under temp/tenants/{tenantID}/ so that promotion keeps the tenant.
+	TenantPrefix = "tenants/"
 )
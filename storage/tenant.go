@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"slices"
+	"strings"
+)
+
+// TenantKey places key in the storage area of tenantID, keeping a leading temp/ prefix in front:
+// "temp/2025/01/02/a.png" becomes "temp/tenants/{tenantID}/2025/01/02/a.png".
+// Keys already in the tenant's area and an empty tenantID leave the key unchanged.
+func TenantKey(tenantID, key string) string {
+	if tenantID == "" || IsTenantKey(tenantID, key) {
+		return key
+	}
+
+	if rest, ok := strings.CutPrefix(key, TempPrefix); ok {
+		return TempPrefix + tenantKeyPrefix(tenantID) + rest
+	}
+
+	return tenantKeyPrefix(tenantID) + key
+}
+
+// IsTenantKey reports whether key lies in the storage area of tenantID, either permanent or temporary.
+// Keys with ".." segments never match so that they cannot climb out of the area.
+func IsTenantKey(tenantID, key string) bool {
+	if tenantID == "" || slices.Contains(strings.Split(key, "/"), "..") {
+		return false
+	}
+
+	return strings.HasPrefix(strings.TrimPrefix(key, TempPrefix), tenantKeyPrefix(tenantID))
+}
+
+func tenantKeyPrefix(tenantID string) string {
+	return TenantPrefix + tenantID + "/"
+}
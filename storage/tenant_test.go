@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTenantKey tests placing keys in a tenant's storage area.
+func TestTenantKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID string
+		key      string
+		expected string
+	}{
+		{"TempKey", "t1", "temp/2025/01/02/a.png", "temp/tenants/t1/2025/01/02/a.png"},
+		{"PermanentKey", "t1", "2025/01/02/a.png", "tenants/t1/2025/01/02/a.png"},
+		{"AlreadyScopedTempKey", "t1", "temp/tenants/t1/a.png", "temp/tenants/t1/a.png"},
+		{"AlreadyScopedKey", "t1", "tenants/t1/a.png", "tenants/t1/a.png"},
+		{"OtherTenantKey", "t1", "tenants/t2/a.png", "tenants/t1/tenants/t2/a.png"},
+		{"NoTenant", "", "temp/a.png", "temp/a.png"},
+		{"EmptyKey", "t1", "", "tenants/t1/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, TenantKey(tt.tenantID, tt.key), "Should place the key in the tenant area")
+		})
+	}
+}
+
+// TestIsTenantKey tests tenant ownership checks for object keys.
+func TestIsTenantKey(t *testing.T) {
+	assert.True(t, IsTenantKey("t1", "tenants/t1/a.png"), "Permanent tenant key should belong to the tenant")
+	assert.True(t, IsTenantKey("t1", "temp/tenants/t1/a.png"), "Temporary tenant key should belong to the tenant")
+	assert.False(t, IsTenantKey("t1", "tenants/t10/a.png"), "Key of a tenant sharing the prefix should not match")
+	assert.False(t, IsTenantKey("t1", "tenants/t2/a.png"), "Key of another tenant should not match")
+	assert.False(t, IsTenantKey("t1", "a.png"), "Shared key should not belong to the tenant")
+	assert.False(t, IsTenantKey("t1", "tenants/t1/../t2/a.png"), "Key escaping the tenant area should not match")
+	assert.False(t, IsTenantKey("", "tenants/t1/a.png"), "Empty tenant should own no keys")
+}
@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/coldsmirk/vef-framework-go/version"
+)
+
+// InstrumentationName is the instrumentation scope of the framework spans.
+const InstrumentationName = "github.com/coldsmirk/vef-framework-go"
+
+// Attribute keys of the framework spans which have no OpenTelemetry semantic convention.
+const (
+	AttrAPIResource    = "vef.api.resource"
+	AttrAPIAction      = "vef.api.action"
+	AttrAPIVersion     = "vef.api.version"
+	AttrResultCode     = "vef.result.code"
+	AttrRequestID      = "vef.request_id"
+	AttrCacheBackend   = "vef.cache.backend"
+	AttrCacheNamespace = "vef.cache.namespace"
+	AttrCacheHit       = "vef.cache.hit"
+	AttrCQRSAction     = "vef.cqrs.action"
+	AttrCQRSKind       = "vef.cqrs.kind"
+	AttrStorageBackend = "vef.storage.backend"
+	AttrStorageKey     = "vef.storage.key"
+	AttrCronJob        = "vef.cron.job"
+)
+
+// Tracer returns the framework tracer of the global TracerProvider.
+// Applications enable tracing by providing a trace.TracerProvider, e.g. an OTLP exporting SDK provider,
+// to the container; without one, all spans are no-ops.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName, trace.WithInstrumentationVersion(version.VEFVersion))
+}
+
+// StartChild starts a span only if the context already carries a recording span, e.g. for queries and cache
+// operations that are only worth tracing as part of a traced request or job. Otherwise the span of the context
+// is returned as is, and ending it is a no-op since it is not recording.
+func StartChild(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	return Tracer().Start(ctx, spanName, opts...)
+}
+
+// RecordError marks the span as failed with the error, if any.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// End records the error, if any, and ends the span.
+func End(span trace.Span, err error) {
+	RecordError(span, err)
+	span.End()
+}
+
+// TraceID returns the trace ID of the span in the context, or an empty string if there is none.
+func TraceID(ctx context.Context) string {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		return spanContext.TraceID().String()
+	}
+
+	return ""
+}
+
+// SpanID returns the span ID of the span in the context, or an empty string if there is none.
+func SpanID(ctx context.Context) string {
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasSpanID() {
+		return spanContext.SpanID().String()
+	}
+
+	return ""
+}
+
+// Inject writes the trace context of ctx into the carrier, e.g. the metadata of an event,
+// using the global propagator (W3C traceparent by default).
+func Inject(ctx context.Context, carrier map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(carrier))
+}
+
+// Extract returns a copy of ctx carrying the remote trace context read from the carrier.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
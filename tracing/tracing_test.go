@@ -0,0 +1,59 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/coldsmirk/vef-framework-go/internal/testx"
+	"github.com/coldsmirk/vef-framework-go/tracing"
+)
+
+// TestStartChild tests that child spans are only started within a recording span.
+func TestStartChild(t *testing.T) {
+	recorder := testx.RecordSpans(t)
+
+	t.Run("WithoutParent", func(t *testing.T) {
+		ctx, span := tracing.StartChild(context.Background(), "orphan")
+		span.End()
+
+		assert.False(t, span.IsRecording(), "Should not record a span without a parent")
+		assert.Empty(t, tracing.TraceID(ctx), "Should have no trace ID without a parent")
+	})
+
+	t.Run("WithParent", func(t *testing.T) {
+		recorder.Reset()
+
+		ctx, parent := tracing.Tracer().Start(context.Background(), "parent")
+		_, child := tracing.StartChild(ctx, "child")
+		tracing.End(child, errors.New("boom"))
+		parent.End()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2, "Should record the parent and the child")
+		assert.Equal(t, "child", spans[0].Name(), "Should end the child first")
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID(), "Should start the child under the parent")
+		assert.Equal(t, codes.Error, spans[0].Status().Code, "Should mark the child as failed")
+		assert.Equal(t, parent.SpanContext().TraceID().String(), tracing.TraceID(ctx), "Should return the trace ID of the context")
+		assert.Equal(t, parent.SpanContext().SpanID().String(), tracing.SpanID(ctx), "Should return the span ID of the context")
+	})
+}
+
+// TestInjectExtract tests carrying the trace context through a string map.
+func TestInjectExtract(t *testing.T) {
+	testx.RecordSpans(t)
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "publisher")
+	defer span.End()
+
+	carrier := make(map[string]string)
+	tracing.Inject(ctx, carrier)
+	assert.Contains(t, carrier, "traceparent", "Should write the W3C traceparent")
+
+	extracted := tracing.Extract(context.Background(), carrier)
+	assert.Equal(t, tracing.TraceID(ctx), tracing.TraceID(extracted), "Should continue the trace of the carrier")
+}